@@ -0,0 +1,248 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Action is an action of a resource, standard (i.e. Manager.Reset) or OEM (i.e. DellManager.ResetToDefaults)
+type Action struct {
+	// Name is the name of the action, without the leading #
+	Name string
+	// Target is the URI the action is invoked with
+	Target string
+	// ActionInfoURI is the URI of the ActionInfo describing the parameters of the action, if the service provides one
+	ActionInfoURI string
+	// AllowableValues are the values of the parameters annotated on the action, for the services without ActionInfo
+	AllowableValues map[string][]string
+}
+
+// ActionInfo describes the parameters of an action. gofish does not support it, so it is decoded here.
+type ActionInfo struct {
+	ODataID    string `json:"@odata.id"`
+	ID         string `json:"Id"`
+	Parameters []ActionParameter
+}
+
+// ActionParameter is a parameter of an ActionInfo
+type ActionParameter struct {
+	Name string
+	// DataType is Boolean, Number, NumberArray, Object, ObjectArray, String or StringArray
+	DataType        string
+	Required        bool
+	AllowableValues []string
+	MinimumValue    *float64
+	MaximumValue    *float64
+}
+
+// ActionResponse is the response of an action
+type ActionResponse struct {
+	StatusCode int
+	// TaskURI is the task the service created to perform the action, if any
+	TaskURI string
+	// Body is the response of the action, for the actions returning data. Empty for the rest
+	Body map[string]interface{}
+}
+
+// GetActions retrieves the actions of the resource at uri, indexed by name, including the OEM ones of every vendor
+func GetActions(c redfishcommon.Client, uri string) (map[string]*Action, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var resource struct {
+		Actions map[string]json.RawMessage
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+		return nil, err
+	}
+	actions := make(map[string]*Action)
+	if err := collectActions(resource.Actions, actions); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// collectActions adds the actions of an Actions object to actions. Vendors list their OEM actions either directly
+// in the Oem object or under their own key (i.e. Oem.Hpe), so the Oem object is walked recursively.
+func collectActions(raw map[string]json.RawMessage, actions map[string]*Action) error {
+	for key, value := range raw {
+		if !strings.HasPrefix(key, "#") {
+			var nested map[string]json.RawMessage
+			if json.Unmarshal(value, &nested) == nil {
+				if err := collectActions(nested, actions); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		var properties map[string]interface{}
+		if err := json.Unmarshal(value, &properties); err != nil {
+			return fmt.Errorf("error decoding action %s: %s", key, err)
+		}
+		action := &Action{Name: strings.TrimPrefix(key, "#"), AllowableValues: make(map[string][]string)}
+		action.Target, _ = properties["target"].(string)
+		action.ActionInfoURI, _ = properties["@Redfish.ActionInfo"].(string)
+		for property, values := range properties {
+			if !strings.HasSuffix(property, "@Redfish.AllowableValues") {
+				continue
+			}
+			allowed := []string{}
+			if list, ok := values.([]interface{}); ok {
+				for _, v := range list {
+					allowed = append(allowed, fmt.Sprintf("%v", v))
+				}
+			}
+			action.AllowableValues[strings.TrimSuffix(property, "@Redfish.AllowableValues")] = allowed
+		}
+		actions[action.Name] = action
+	}
+	return nil
+}
+
+// GetActionInfo retrieves the ActionInfo at uri
+func GetActionInfo(c redfishcommon.Client, uri string) (*ActionInfo, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var info ActionInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	if info.ODataID == "" {
+		info.ODataID = uri
+	}
+	return &info, nil
+}
+
+// ValidateParameters checks parameters against the ActionInfo: every required parameter is set, there is no
+// unknown parameter, and the values match the type, the allowable values and the range of their parameter.
+func (info *ActionInfo) ValidateParameters(parameters map[string]interface{}) error {
+	known := make(map[string]bool)
+	errors := []string{}
+	for _, parameter := range info.Parameters {
+		known[parameter.Name] = true
+		value, ok := parameters[parameter.Name]
+		if !ok {
+			if parameter.Required {
+				errors = append(errors, fmt.Sprintf("parameter %s is required", parameter.Name))
+			}
+			continue
+		}
+		if err := parameter.validate(value); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	for name := range parameters {
+		if !known[name] {
+			errors = append(errors, fmt.Sprintf("unknown parameter %s", name))
+		}
+	}
+	if len(errors) > 0 {
+		sort.Strings(errors)
+		return fmt.Errorf("invalid parameters for %s: %s", info.ODataID, strings.Join(errors, ", "))
+	}
+	return nil
+}
+
+// validate checks value against the type, the allowable values and the range of the parameter
+func (p *ActionParameter) validate(value interface{}) error {
+	values := []interface{}{value}
+	if strings.HasSuffix(p.DataType, "Array") {
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("parameter %s must be an array", p.Name)
+		}
+		values = list
+	}
+	for _, v := range values {
+		switch strings.TrimSuffix(p.DataType, "Array") {
+		case "Boolean":
+			if _, ok := v.(bool); !ok {
+				return fmt.Errorf("parameter %s must be a boolean", p.Name)
+			}
+		case "Number":
+			number, ok := v.(float64)
+			if !ok {
+				return fmt.Errorf("parameter %s must be a number", p.Name)
+			}
+			if (p.MinimumValue != nil && number < *p.MinimumValue) || (p.MaximumValue != nil && number > *p.MaximumValue) {
+				return fmt.Errorf("parameter %s is out of range", p.Name)
+			}
+		case "String":
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf("parameter %s must be a string", p.Name)
+			}
+		case "Object":
+			if _, ok := v.(map[string]interface{}); !ok {
+				return fmt.Errorf("parameter %s must be an object", p.Name)
+			}
+		}
+		if len(p.AllowableValues) > 0 && !allowableValue(p.AllowableValues, v) {
+			return fmt.Errorf("parameter %s must be one of %s", p.Name, strings.Join(p.AllowableValues, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateAllowableValues checks parameters against the allowable values annotated on the action,
+// for the services not describing it with an ActionInfo
+func (a *Action) ValidateAllowableValues(parameters map[string]interface{}) error {
+	names := []string{}
+	for name := range a.AllowableValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := parameters[name]
+		if ok && !allowableValue(a.AllowableValues[name], value) {
+			return fmt.Errorf("parameter %s of %s must be one of %s", name, a.Name, strings.Join(a.AllowableValues[name], ", "))
+		}
+	}
+	return nil
+}
+
+// allowableValue reports if value, as a string, is one of allowed
+func allowableValue(allowed []string, value interface{}) bool {
+	for _, v := range allowed {
+		if v == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvokeAction sends parameters to the target of an action, checking the status code of the response
+func InvokeAction(c redfishcommon.Client, target string, parameters map[string]interface{}) (*ActionResponse, error) {
+	resp, err := c.Post(target, parameters)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("the action %s failed. Status code was %d", target, resp.StatusCode)
+	}
+	response := &ActionResponse{StatusCode: resp.StatusCode, Body: map[string]interface{}{}}
+	if resp.StatusCode == http.StatusAccepted {
+		response.TaskURI = resp.Header.Get("Location")
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Actions returning a task might still return the task, or any other body, which is kept as is
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &response.Body); err != nil {
+			return nil, fmt.Errorf("error decoding the response of %s: %s", target, err)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetActions(t *testing.T) {
+	body := `{"Actions":{"#Manager.Reset":{"target":"/redfish/v1/Managers/1/Actions/Manager.Reset",` +
+		`"ResetType@Redfish.AllowableValues":["GracefulRestart","ForceRestart"]},` +
+		`"Oem":{"Hpe":{"#HpeiLO.ClearRestApiState":{"target":"/redfish/v1/Managers/1/Actions/Oem/Hpe/HpeiLO.ClearRestApiState",` +
+		`"@Redfish.ActionInfo":"/redfish/v1/Managers/1/ClearRestApiStateActionInfo"}}}}}`
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	})
+	actions, err := GetActions(testClient, "/redfish/v1/Managers/1")
+	if err != nil {
+		t.Fatalf("GetActions failed %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %v", actions)
+	}
+	reset := actions["Manager.Reset"]
+	if reset == nil || reset.Target != "/redfish/v1/Managers/1/Actions/Manager.Reset" || len(reset.AllowableValues["ResetType"]) != 2 {
+		t.Errorf("unexpected action %+v", reset)
+	}
+	oem := actions["HpeiLO.ClearRestApiState"]
+	if oem == nil || oem.ActionInfoURI != "/redfish/v1/Managers/1/ClearRestApiStateActionInfo" {
+		t.Errorf("unexpected action %+v", oem)
+	}
+	if err := reset.ValidateAllowableValues(map[string]interface{}{"ResetType": "PowerCycle"}); err == nil {
+		t.Errorf("expected the reset type to be rejected")
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	minimum, maximum := 1.0, 10.0
+	info := &ActionInfo{ODataID: "/redfish/v1/Systems/1/ResetActionInfo", Parameters: []ActionParameter{
+		{Name: "ResetType", DataType: "String", Required: true, AllowableValues: []string{"On", "ForceOff"}},
+		{Name: "Delay", DataType: "Number", MinimumValue: &minimum, MaximumValue: &maximum},
+		{Name: "Targets", DataType: "StringArray"},
+	}}
+	cases := []struct {
+		noTest     int
+		parameters map[string]interface{}
+		shouldPass bool
+	}{
+		{1, map[string]interface{}{"ResetType": "On"}, true},
+		{2, map[string]interface{}{"ResetType": "On", "Delay": 5.0, "Targets": []interface{}{"a", "b"}}, true},
+		{3, map[string]interface{}{}, false},
+		{4, map[string]interface{}{"ResetType": "PowerCycle"}, false},
+		{5, map[string]interface{}{"ResetType": "On", "Delay": 50.0}, false},
+		{6, map[string]interface{}{"ResetType": "On", "Targets": "a"}, false},
+		{7, map[string]interface{}{"ResetType": "On", "Unknown": true}, false},
+	}
+	for _, v := range cases {
+		err := info.ValidateParameters(v.parameters)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// ChangeBiosPassword sends the Bios.ChangePassword action of a BIOS resource.
+// Unlike gofish, an empty oldPassword is accepted, as it is what BMCs expect when no password is set yet.
+// Parameters:
+//   - biosURI -> ODataID of the BIOS resource.
+//   - passwordName -> password to change (i.e. SysPassword or SetupPassword on Dell systems).
+func ChangeBiosPassword(c redfishcommon.Client, biosURI string, passwordName string, oldPassword string, newPassword string) error {
+	payload := map[string]interface{}{
+		"PasswordName": passwordName,
+		"OldPassword":  oldPassword,
+		"NewPassword":  newPassword,
+	}
+	resp, err := c.Post(biosURI+"/Actions/Bios.ChangePassword", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the password change was not accepted. Status code was %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestChangeBiosPassword(t *testing.T) {
+	cases := []struct {
+		noTest      int
+		oldPassword string
+		statusCode  int
+		shouldPass  bool
+	}{
+		{1, "", http.StatusOK, true},
+		{2, "current", http.StatusNoContent, true},
+		{3, "current", http.StatusBadRequest, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		err := ChangeBiosPassword(testClient, "/redfish/v1/Systems/System.Embedded.1/Bios", "SetupPassword", v.oldPassword, "new")
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].URL != "/redfish/v1/Systems/System.Embedded.1/Bios/Actions/Bios.ChangePassword" {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"time"
+)
+
+// SetupEnteredBootProgress is the boot progress of a system that has entered the BIOS setup
+const SetupEnteredBootProgress string = "SetupEntered"
+
+// postCompleteBootProgress are the boot progress states of a system that has finished its POST,
+// by when the BIOS has applied its pending settings
+var postCompleteBootProgress = []string{"SystemHardwareInitializationComplete", SetupEnteredBootProgress, "OSBootStarted", "OSRunning"}
+
+// BootProgress is the boot progress of a system. LastStateTime is empty on BMCs not reporting it.
+type BootProgress struct {
+	LastState     string
+	LastStateTime string
+}
+
+// GetBootProgress returns the last boot progress state of a system (i.e. SystemHardwareInitializationComplete,
+// SetupEntered or OSRunning). gofish does not expose it, so it is decoded here.
+// An empty state is returned when the system does not report its boot progress.
+func GetBootProgress(c redfishcommon.Client, systemURI string) (string, error) {
+	progress, err := GetSystemBootProgress(c, systemURI)
+	if err != nil {
+		return "", err
+	}
+	return progress.LastState, nil
+}
+
+// GetSystemBootProgress returns the boot progress of a system, with the time its last state was reached
+func GetSystemBootProgress(c redfishcommon.Client, systemURI string) (*BootProgress, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		BootProgress BootProgress
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	return &system.BootProgress, nil
+}
+
+// WaitForBootProgress waits until a system reports the given boot progress state.
+// It returns right away, with an empty state, when the system does not report its boot progress.
+// Errors while polling are ignored, as the BMC might not answer while the system resets.
+// It returns as soon as ctx is done, without waiting for the next attempt.
+// Parameters:
+//   - state -> boot progress state to wait for (i.e. SetupEnteredBootProgress).
+//   - timeBetweenAttempts -> time to wait between attempts. I.e. 30 means 30 seconds.
+//   - timeout -> maximun time to wait until the state is considered unreachable.
+//
+// Returns the last state reported by the system.
+func WaitForBootProgress(ctx context.Context, c redfishcommon.Client, systemURI string, state string, timeBetweenAttempts int, timeout int) (string, error) {
+	attemptTick := time.NewTicker(time.Duration(timeBetweenAttempts) * time.Second)
+	defer attemptTick.Stop()
+	timeoutTick := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer timeoutTick.Stop()
+	last := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("stopped waiting for the system to reach %s: %s", state, ctx.Err())
+		case <-attemptTick.C:
+			progress, err := GetBootProgress(c, systemURI)
+			if err != nil {
+				fmt.Printf("[DEBUG] - Error reading the boot progress, trying again: %s\n", err)
+				continue
+			}
+			if progress == "" || progress == state {
+				return progress, nil
+			}
+			last = progress
+		case <-timeoutTick.C:
+			return last, fmt.Errorf("timeout waiting for the system to reach %s, last state was %s", state, last)
+		}
+	}
+}
+
+// WaitForPOST waits until a system finishes the POST following a reset, which is when the BIOS applies its
+// pending settings. The POST is considered finished once the system reports a state past the hardware
+// initialization which is not the one read before the reset: either it reported an earlier state meanwhile,
+// or the time of its last state changed.
+// It returns right away, with an empty state, when the system does not report its boot progress.
+// Errors while polling are ignored, as the BMC might not answer while the system resets.
+// Parameters:
+//   - before -> boot progress read before the reset.
+//   - timeBetweenAttempts -> time to wait between attempts. I.e. 30 means 30 seconds.
+//   - timeout -> maximun time to wait until the POST is considered failed.
+//
+// Returns the last state reported by the system.
+func WaitForPOST(ctx context.Context, c redfishcommon.Client, systemURI string, before *BootProgress, timeBetweenAttempts int, timeout int) (string, error) {
+	attemptTick := time.NewTicker(time.Duration(timeBetweenAttempts) * time.Second)
+	defer attemptTick.Stop()
+	timeoutTick := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer timeoutTick.Stop()
+	restarted := !containsString(postCompleteBootProgress, before.LastState)
+	last := before.LastState
+	for {
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("stopped waiting for the system to finish its POST: %s", ctx.Err())
+		case <-attemptTick.C:
+			progress, err := GetSystemBootProgress(c, systemURI)
+			if err != nil {
+				fmt.Printf("[DEBUG] - Error reading the boot progress, trying again: %s\n", err)
+				continue
+			}
+			if progress.LastState == "" {
+				return "", nil
+			}
+			last = progress.LastState
+			if !containsString(postCompleteBootProgress, progress.LastState) {
+				restarted = true
+				continue
+			}
+			if restarted || (progress.LastStateTime != "" && progress.LastStateTime != before.LastStateTime) {
+				return last, nil
+			}
+		case <-timeoutTick.C:
+			return last, fmt.Errorf("timeout waiting for the system to finish its POST, last state was %s", last)
+		}
+	}
+}
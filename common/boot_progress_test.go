@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWaitForBootProgress(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		bodies     []string
+		expected   string
+		shouldPass bool
+	}{
+		{1, []string{`{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"SetupEntered"}}`}, SetupEnteredBootProgress, true},
+		{2, []string{`{"PowerState":"On"}`}, "", true},
+		{3, []string{`{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`}, "OSRunning", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.bodies {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		state, err := WaitForBootProgress(context.Background(), testClient, "/redfish/v1/Systems/System.Embedded.1", SetupEnteredBootProgress, 1, 3)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if state != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, state)
+		}
+	}
+}
+
+func TestWaitForPOST(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		before     BootProgress
+		bodies     []string
+		expected   string
+		shouldPass bool
+	}{
+		{1, BootProgress{LastState: "OSRunning"}, []string{`{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"MemoryInitializationStarted"}}`, `{"BootProgress":{"LastState":"OSBootStarted"}}`}, "OSBootStarted", true},
+		{2, BootProgress{LastState: "OSRunning", LastStateTime: "2026-10-16T10:00:00Z"}, []string{`{"BootProgress":{"LastState":"OSRunning","LastStateTime":"2026-10-16T10:06:00Z"}}`}, "OSRunning", true},
+		{3, BootProgress{LastState: "None"}, []string{`{"BootProgress":{"LastState":"OSRunning"}}`}, "OSRunning", true},
+		{4, BootProgress{}, []string{`{"PowerState":"On"}`}, "", true},
+		{5, BootProgress{LastState: "OSRunning"}, []string{`{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`, `{"BootProgress":{"LastState":"OSRunning"}}`}, "OSRunning", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.bodies {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		state, err := WaitForPOST(context.Background(), testClient, "/redfish/v1/Systems/System.Embedded.1", &v.before, 1, 3)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if state != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, state)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// Catalog represents a firmware repository catalog (i.e. Dell Catalog.xml).
+type Catalog struct {
+	XMLName      xml.Name           `xml:"Manifest"`
+	BaseLocation string             `xml:"baseLocation,attr"`
+	DateTime     string             `xml:"dateTime,attr"`
+	Version      string             `xml:"version,attr"`
+	Components   []CatalogComponent `xml:"SoftwareComponent"`
+}
+
+// CatalogComponent is an update package listed in a catalog.
+type CatalogComponent struct {
+	PackageID      string          `xml:"packageID,attr"`
+	ReleaseID      string          `xml:"releaseID,attr"`
+	Path           string          `xml:"path,attr"`
+	VendorVersion  string          `xml:"vendorVersion,attr"`
+	DellVersion    string          `xml:"dellVersion,attr"`
+	PackageType    string          `xml:"packageType,attr"`
+	RebootRequired bool            `xml:"rebootRequired,attr"`
+	Name           string          `xml:"Name>Display"`
+	ComponentType  catalogValue    `xml:"ComponentType"`
+	Category       catalogValue    `xml:"Category"`
+	Criticality    catalogValue    `xml:"Criticality"`
+	Devices        []CatalogDevice `xml:"SupportedDevices>Device"`
+	Brands         []catalogBrand  `xml:"SupportedSystems>Brand"`
+}
+
+// CatalogDevice is a device an update package applies to.
+type CatalogDevice struct {
+	ComponentID string           `xml:"componentID,attr"`
+	Name        string           `xml:"Display"`
+	PCIInfo     []catalogPCIInfo `xml:"PCIInfo"`
+}
+
+type catalogValue struct {
+	Value   string `xml:"value,attr"`
+	Display string `xml:"Display"`
+}
+
+type catalogBrand struct {
+	Prefix string         `xml:"prefix,attr"`
+	Name   string         `xml:"Display"`
+	Models []catalogModel `xml:"Model"`
+}
+
+type catalogModel struct {
+	SystemID string `xml:"systemID,attr"`
+	Name     string `xml:"Display"`
+}
+
+type catalogPCIInfo struct {
+	VendorID    string `xml:"vendorID,attr"`
+	DeviceID    string `xml:"deviceID,attr"`
+	SubVendorID string `xml:"subVendorID,attr"`
+	SubDeviceID string `xml:"subDeviceID,attr"`
+}
+
+// FetchCatalog downloads and parses a catalog from an http(s) URL.
+// Catalogs ending in .gz are decompressed on the fly. The download is aborted when ctx is done.
+func FetchCatalog(ctx context.Context, catalogURL string) (*Catalog, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, catalogURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading catalog %s, status code was %d", catalogURL, resp.StatusCode)
+	}
+	var body io.Reader = resp.Body
+	if strings.HasSuffix(catalogURL, ".gz") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	return ParseCatalog(body)
+}
+
+// ParseCatalog parses a catalog from its XML representation.
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var catalog Catalog
+	decoder := xml.NewDecoder(bytes.NewReader(decodeUTF16(raw)))
+	// Dell catalogs are shipped UTF-16 encoded and declare so. The content is
+	// converted to UTF-8 beforehand, so the declared charset can be ignored.
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("error parsing the catalog: %s", err)
+	}
+	return &catalog, nil
+}
+
+// decodeUTF16 converts UTF-16 content with a byte order mark to UTF-8.
+// Content without a UTF-16 byte order mark is returned as is.
+func decodeUTF16(raw []byte) []byte {
+	if len(raw) < 2 {
+		return raw
+	}
+	var order binary.ByteOrder
+	switch {
+	case raw[0] == 0xFF && raw[1] == 0xFE:
+		order = binary.LittleEndian
+	case raw[0] == 0xFE && raw[1] == 0xFF:
+		order = binary.BigEndian
+	default:
+		return raw
+	}
+	raw = raw[2:]
+	codes := make([]uint16, len(raw)/2)
+	for i := range codes {
+		codes[i] = order.Uint16(raw[2*i:])
+	}
+	return []byte(string(utf16.Decode(codes)))
+}
+
+// Version returns the version of the package, preferring the vendor version.
+func (c *CatalogComponent) Version() string {
+	if len(c.VendorVersion) > 0 {
+		return c.VendorVersion
+	}
+	return c.DellVersion
+}
+
+// SupportsModel checks if the package applies to the given system model (i.e. "PowerEdge R740").
+// Catalog models are listed without the brand, so either the full name or the brand prefixed one matches.
+func (c *CatalogComponent) SupportsModel(model string) bool {
+	model = strings.ToUpper(strings.TrimSpace(model))
+	for _, brand := range c.Brands {
+		for _, m := range brand.Models {
+			name := strings.ToUpper(strings.TrimSpace(m.Name))
+			if len(name) == 0 {
+				continue
+			}
+			if model == name || strings.HasSuffix(model, " "+name) || strings.EqualFold(m.SystemID, model) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SoftwareIDs returns the identifiers a firmware inventory entry can expose for this package.
+// Dell exposes the component ID for embedded devices and vendor/device/subvendor/subdevice for PCI devices.
+func (c *CatalogComponent) SoftwareIDs() []string {
+	ids := []string{}
+	for _, device := range c.Devices {
+		if len(device.ComponentID) > 0 {
+			ids = append(ids, device.ComponentID)
+		}
+		for _, pci := range device.PCIInfo {
+			ids = append(ids, strings.ToLower(fmt.Sprintf("%s-%s-%s-%s", pci.VendorID, pci.DeviceID, pci.SubVendorID, pci.SubDeviceID)))
+		}
+	}
+	return ids
+}
+
+// ComponentsForModel returns the packages in the catalog that apply to a system model.
+func (c *Catalog) ComponentsForModel(model string) []CatalogComponent {
+	components := []CatalogComponent{}
+	for _, component := range c.Components {
+		if component.SupportsModel(model) {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// PackageURI builds the URI the BMC needs to download a package from.
+// If baseURI is empty, the catalog base location is used.
+func (c *Catalog) PackageURI(baseURI string, component CatalogComponent) string {
+	if len(baseURI) == 0 {
+		baseURI = c.BaseLocation
+		if !strings.Contains(baseURI, "://") {
+			baseURI = "https://" + baseURI
+		}
+	}
+	return strings.TrimSuffix(baseURI, "/") + "/" + strings.TrimPrefix(component.Path, "/")
+}
+
+// ApplicableUpdate is a catalog package newer than the firmware installed on a component.
+type ApplicableUpdate struct {
+	Component CatalogComponent
+	Installed *FirmwareInventoryEntry
+}
+
+// ApplicableUpdates returns every installed inventory entry with a newer package in the catalog.
+// A package that applies to several identical devices is returned once per device.
+func (c *Catalog) ApplicableUpdates(model string, inventory []*FirmwareInventoryEntry) []ApplicableUpdate {
+	updates := []ApplicableUpdate{}
+	for _, component := range c.ComponentsForModel(model) {
+		ids := component.SoftwareIDs()
+		for _, entry := range inventory {
+			if !entry.Installed() || !ContainsFold(ids, entry.SoftwareID) {
+				continue
+			}
+			if CompareVersions(component.Version(), entry.Version) > 0 {
+				updates = append(updates, ApplicableUpdate{Component: component, Installed: entry})
+			}
+		}
+	}
+	return updates
+}
+
+// ResolveUpdates returns the packages from the catalog that are newer than the installed firmware.
+// Only packages that match an installed inventory entry are returned, one per package.
+func (c *Catalog) ResolveUpdates(model string, inventory []*FirmwareInventoryEntry) []CatalogComponent {
+	updates := []CatalogComponent{}
+	seen := make(map[string]bool)
+	for _, update := range c.ApplicableUpdates(model, inventory) {
+		if seen[update.Component.Path] {
+			continue
+		}
+		seen[update.Component.Path] = true
+		updates = append(updates, update.Component)
+	}
+	return updates
+}
+
+// AppliesTo reports if the component updates the firmware of an inventory entry.
+func (c *CatalogComponent) AppliesTo(entry *FirmwareInventoryEntry) bool {
+	return ContainsFold(c.SoftwareIDs(), entry.SoftwareID)
+}
+
+// CriticalityName returns the short criticality of the package (i.e. Urgent, Recommended, Optional).
+// Dell catalogs append an explanation to the name, separated by a dash.
+func (c *CatalogComponent) CriticalityName() string {
+	name := strings.TrimSpace(strings.SplitN(c.Criticality.Display, "-", 2)[0])
+	if len(name) == 0 {
+		return c.Criticality.Value
+	}
+	return name
+}
+
+// ContainsFold reports if list holds s, ignoring the case
+func ContainsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareVersions compares two firmware versions segment by segment.
+// Numeric segments are compared as numbers and the rest as strings.
+// Returns 1 if a is newer than b, -1 if b is newer than a and 0 if they are the same.
+func CompareVersions(a string, b string) int {
+	split := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	segmentsA := strings.FieldsFunc(a, split)
+	segmentsB := strings.FieldsFunc(b, split)
+	for i := 0; i < len(segmentsA) || i < len(segmentsB); i++ {
+		var segA, segB string
+		if i < len(segmentsA) {
+			segA = segmentsA[i]
+		}
+		if i < len(segmentsB) {
+			segB = segmentsB[i]
+		}
+		// Missing segments count as zero, so 1.0 and 1 are the same version
+		if len(segA) == 0 {
+			segA = "0"
+		}
+		if len(segB) == 0 {
+			segB = "0"
+		}
+		intA, errA := strconv.Atoi(segA)
+		intB, errB := strconv.Atoi(segB)
+		if errA == nil && errB == nil {
+			if intA != intB {
+				if intA > intB {
+					return 1
+				}
+				return -1
+			}
+			continue
+		}
+		if cmp := strings.Compare(strings.ToUpper(segA), strings.ToUpper(segB)); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
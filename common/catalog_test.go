@@ -0,0 +1,134 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+// Reduced Dell catalog with a BIOS package for R740/R640 and a NIC package for R740 only
+var catalogXML = `<?xml version="1.0" encoding="utf-16"?>
+<Manifest baseLocation="downloads.dell.com" dateTime="2020-08-20T12:00:00-05:00" version="20.08.00">
+  <SoftwareComponent packageID="8MGHN" path="FOLDER06512000M/1/BIOS_8MGHN_WN64_2.8.1.EXE" vendorVersion="2.8.1" dellVersion="2.8.1" rebootRequired="true">
+    <Name><Display lang="en"><![CDATA[Dell Server BIOS PowerEdge R740/R640 Version 2.8.1]]></Display></Name>
+    <ComponentType value="BIOS"><Display lang="en"><![CDATA[BIOS]]></Display></ComponentType>
+    <Criticality value="2"><Display lang="en"><![CDATA[Urgent]]></Display></Criticality>
+    <SupportedDevices><Device componentID="159" embedded="1"><Display lang="en"><![CDATA[BIOS]]></Display></Device></SupportedDevices>
+    <SupportedSystems><Brand key="3" prefix="PE"><Display lang="en"><![CDATA[PowerEdge]]></Display>
+      <Model systemID="0716"><Display lang="en"><![CDATA[R740]]></Display></Model>
+      <Model systemID="0715"><Display lang="en"><![CDATA[R640]]></Display></Model>
+    </Brand></SupportedSystems>
+  </SoftwareComponent>
+  <SoftwareComponent packageID="XG3DK" path="FOLDER06400000M/1/Network_Firmware_XG3DK_WN64_21.60.2.EXE" vendorVersion="21.60.2" dellVersion="21.60.2">
+    <Name><Display lang="en"><![CDATA[Broadcom NetXtreme Firmware]]></Display></Name>
+    <ComponentType value="FRMW"><Display lang="en"><![CDATA[Firmware]]></Display></ComponentType>
+    <SupportedDevices><Device componentID="104378"><Display lang="en"><![CDATA[Broadcom 57416]]></Display>
+      <PCIInfo deviceID="16D8" vendorID="14E4" subDeviceID="4080" subVendorID="1028" /></Device></SupportedDevices>
+    <SupportedSystems><Brand key="3" prefix="PE"><Display lang="en"><![CDATA[PowerEdge]]></Display>
+      <Model systemID="0716"><Display lang="en"><![CDATA[R740]]></Display></Model>
+    </Brand></SupportedSystems>
+  </SoftwareComponent>
+</Manifest>`
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		a        string
+		b        string
+		expected int
+	}{
+		{1, "2.8.1", "2.7.7", 1},
+		{2, "2.7.7", "2.8.1", -1},
+		{3, "2.10.0", "2.9.9", 1},
+		{4, "1.0", "1", 0},
+		{5, "A01", "A00", 1},
+		{6, "4.22.00.00", "4.22.00.00", 0},
+	}
+	for _, v := range cases {
+		if result := CompareVersions(v.a, v.b); result != v.expected {
+			t.Errorf("Test number %v failed. Expected %v, got %v", v.noTest, v.expected, result)
+		}
+	}
+}
+
+func TestResolveUpdates(t *testing.T) {
+	cases := []struct {
+		noTest    int
+		model     string
+		inventory []*FirmwareInventoryEntry
+		expected  []string
+	}{
+		{1, "PowerEdge R740", []*FirmwareInventoryEntry{
+			{ID: "Installed-159-2.7.7__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.7.7"},
+			{ID: "Installed-104378-21.40.2__NIC.Integrated.1-1-1", SoftwareID: "14e4-16d8-1028-4080", Version: "21.40.2"},
+		}, []string{"8MGHN", "XG3DK"}},
+		{2, "PowerEdge R640", []*FirmwareInventoryEntry{
+			{ID: "Installed-159-2.7.7__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.7.7"},
+			{ID: "Installed-104378-21.40.2__NIC.Integrated.1-1-1", SoftwareID: "14e4-16d8-1028-4080", Version: "21.40.2"},
+		}, []string{"8MGHN"}},
+		{3, "PowerEdge R740", []*FirmwareInventoryEntry{
+			{ID: "Installed-159-2.8.1__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.8.1"},
+			{ID: "Previous-159-2.7.7__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.7.7"},
+		}, []string{}},
+		{4, "PowerEdge R940", []*FirmwareInventoryEntry{
+			{ID: "Installed-159-2.7.7__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.7.7"},
+		}, []string{}},
+	}
+	for _, v := range cases {
+		catalog, err := ParseCatalog(strings.NewReader(catalogXML))
+		if err != nil {
+			t.Fatalf("Test number %v failed parsing the catalog: %v", v.noTest, err)
+		}
+		updates := catalog.ResolveUpdates(v.model, v.inventory)
+		if len(updates) != len(v.expected) {
+			t.Errorf("Test number %v failed. Expected %v updates, got %v", v.noTest, len(v.expected), len(updates))
+			continue
+		}
+		for i, update := range updates {
+			if update.PackageID != v.expected[i] {
+				t.Errorf("Test number %v failed. Expected package %v, got %v", v.noTest, v.expected[i], update.PackageID)
+			}
+		}
+	}
+}
+
+func TestPackageURI(t *testing.T) {
+	catalog, err := ParseCatalog(strings.NewReader(catalogXML))
+	if err != nil {
+		t.Fatalf("Error parsing the catalog: %v", err)
+	}
+	cases := []struct {
+		noTest   int
+		baseURI  string
+		expected string
+	}{
+		{1, "", "https://downloads.dell.com/FOLDER06512000M/1/BIOS_8MGHN_WN64_2.8.1.EXE"},
+		{2, "http://10.0.0.1/repo/", "http://10.0.0.1/repo/FOLDER06512000M/1/BIOS_8MGHN_WN64_2.8.1.EXE"},
+	}
+	for _, v := range cases {
+		if uri := catalog.PackageURI(v.baseURI, catalog.Components[0]); uri != v.expected {
+			t.Errorf("Test number %v failed. Expected %v, got %v", v.noTest, v.expected, uri)
+		}
+	}
+}
+
+func TestApplicableUpdates(t *testing.T) {
+	catalog, err := ParseCatalog(strings.NewReader(catalogXML))
+	if err != nil {
+		t.Fatalf("Error parsing the catalog: %v", err)
+	}
+	inventory := []*FirmwareInventoryEntry{
+		{ID: "Installed-104378-21.40.2__NIC.Integrated.1-1-1", SoftwareID: "14e4-16d8-1028-4080", Version: "21.40.2"},
+		{ID: "Installed-104378-21.40.2__NIC.Integrated.1-2-1", SoftwareID: "14e4-16d8-1028-4080", Version: "21.40.2"},
+		{ID: "Installed-159-2.7.7__BIOS.Setup.1-1", SoftwareID: "159", Version: "2.7.7"},
+	}
+	updates := catalog.ApplicableUpdates("PowerEdge R740", inventory)
+	if len(updates) != 3 {
+		t.Fatalf("Expected 3 applicable updates, got %v", len(updates))
+	}
+	if criticality := updates[0].Component.CriticalityName(); criticality != "Urgent" {
+		t.Errorf("Expected criticality Urgent, got %v", criticality)
+	}
+	if resolved := catalog.ResolveUpdates("PowerEdge R740", inventory); len(resolved) != 2 {
+		t.Errorf("Expected 2 packages to resolve, got %v", len(resolved))
+	}
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// Types of the certificates imported through ImportDellCertificate
+const (
+	// KMSCACertificateType is the CA certificate the iDRAC uses to validate the key management server
+	KMSCACertificateType string = "KMS_CA"
+	// SEKMSSLCertificateType is the certificate the iDRAC presents to the key management server, signed from its CSR
+	SEKMSSLCertificateType string = "SEKM_SSL_CERT"
+	// SyslogCACertificateType is the CA certificate the iDRAC uses to validate the remote syslog servers over TLS
+	SyslogCACertificateType string = "RSYSLOG_SERVER_CA"
+)
+
+// Certificate is a certificate of a certificate collection of the service (i.e. the CA certificates the LDAP client trusts)
+type Certificate struct {
+	ODataID           string `json:"@odata.id"`
+	ID                string `json:"Id"`
+	CertificateString string
+	CertificateType   string
+}
+
+// dellIdracCardServiceURI is the Dell OEM service holding the iDRAC certificate actions
+const dellIdracCardServiceURI string = "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService"
+
+// ImportDellCertificate uploads a PEM certificate to the iDRAC through DelliDRACCardService.ImportSSLCertificate.
+// Parameters:
+//   - certificateType -> KMSCACertificateType, SEKMSSLCertificateType or SyslogCACertificateType.
+//   - certificate -> certificate in PEM format.
+func ImportDellCertificate(c redfishcommon.Client, certificateType string, certificate string) error {
+	payload := map[string]interface{}{
+		"CertificateType":    certificateType,
+		"SSLCertificateFile": certificate,
+	}
+	resp, err := c.Post(dellIdracCardServiceURI+"/Actions/DelliDRACCardService.ImportSSLCertificate", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the %s certificate was not imported. Status code was %d", certificateType, resp.StatusCode)
+	}
+	return nil
+}
+
+// ImportCertificate uploads a PEM certificate to the certificate collection at collectionURI.
+// Returns the URI of the certificate created.
+func ImportCertificate(c redfishcommon.Client, collectionURI string, certificate string) (string, error) {
+	payload := map[string]interface{}{
+		"CertificateString": certificate,
+		"CertificateType":   "PEM",
+	}
+	resp, err := c.Post(collectionURI, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("the certificate was not imported to %s. Status code was %d", collectionURI, resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("the import of the certificate to %s did not return its location", collectionURI)
+	}
+	return location, nil
+}
+
+// GetCertificate retrieves the certificate at certificateURI
+func GetCertificate(c redfishcommon.Client, certificateURI string) (*Certificate, error) {
+	resp, err := c.Get(certificateURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var certificate Certificate
+	if err = json.NewDecoder(resp.Body).Decode(&certificate); err != nil {
+		return nil, err
+	}
+	if certificate.ODataID == "" {
+		certificate.ODataID = certificateURI
+	}
+	return &certificate, nil
+}
+
+// DeleteCertificate removes the certificate at certificateURI from its collection.
+// Certificates already gone are not considered errors.
+func DeleteCertificate(c redfishcommon.Client, certificateURI string) error {
+	resp, err := c.Delete(certificateURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the deletion of the certificate %s failed. Status code was %d", certificateURI, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestImportDellCertificate(t *testing.T) {
+	const certificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+	cases := []struct {
+		noTest          int
+		certificateType string
+		statusCode      int
+		shouldPass      bool
+	}{
+		{1, KMSCACertificateType, http.StatusOK, true},
+		{2, SEKMSSLCertificateType, http.StatusAccepted, true},
+		{3, KMSCACertificateType, http.StatusBadRequest, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		err := ImportDellCertificate(testClient, v.certificateType, certificate)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].URL != dellIdracCardServiceURI+"/Actions/DelliDRACCardService.ImportSSLCertificate" ||
+			!strings.Contains(calls[0].Payload, "CertificateType:"+v.certificateType) {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestImportCertificate(t *testing.T) {
+	const certificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+	const collectionURI = "/redfish/v1/AccountService/LDAP/Certificates"
+	cases := []struct {
+		noTest     int
+		statusCode int
+		location   string
+		shouldPass bool
+	}{
+		{1, http.StatusCreated, collectionURI + "/1", true},
+		{2, http.StatusCreated, "", false},
+		{3, http.StatusBadRequest, "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		header := http.Header{}
+		if v.location != "" {
+			header.Set("Location", v.location)
+		}
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		uri, err := ImportCertificate(testClient, collectionURI, certificate)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if v.shouldPass && uri != v.location {
+			t.Errorf("Test number %v returned %s instead of %s", v.noTest, uri, v.location)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].URL != collectionURI || !strings.Contains(calls[0].Payload, "CertificateType:PEM") {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestGetLinkURI(t *testing.T) {
+	const accountService = `{"@odata.id": "/redfish/v1/AccountService", "LDAP": {"ServiceEnabled": false, "Certificates": {"@odata.id": "/redfish/v1/AccountService/LDAP/Certificates"}}}`
+	cases := []struct {
+		noTest     int
+		path       []string
+		expected   string
+		shouldPass bool
+	}{
+		{1, []string{"LDAP", "Certificates"}, "/redfish/v1/AccountService/LDAP/Certificates", true},
+		{2, []string{"ActiveDirectory", "Certificates"}, "", false},
+		{3, []string{"LDAP", "ServiceEnabled", "Certificates"}, "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(accountService)),
+		})
+		uri, err := GetLinkURI(testClient, "/redfish/v1/AccountService", v.path...)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if uri != v.expected {
+			t.Errorf("Test number %v returned %s instead of %s", v.noTest, uri, v.expected)
+		}
+	}
+}
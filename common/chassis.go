@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"sort"
+	"strings"
+)
+
+// ChassisLinks are the relations of a chassis with other resources.
+// gofish does not expose the containment links, which are needed to tell apart the sleds of a modular chassis.
+type ChassisLinks struct {
+	// ContainedBy is the chassis holding this one (i.e. the enclosure of a sled), empty for top level chassis
+	ContainedBy     string
+	Contains        []string
+	ComputerSystems []string
+	ManagedBy       []string
+}
+
+// GetChassisLinks retrieves the links of a chassis
+func GetChassisLinks(c redfishcommon.Client, chassisURI string) (*ChassisLinks, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var chassis struct {
+		Links struct {
+			ContainedBy     redfishcommon.Link
+			Contains        redfishcommon.Links
+			ComputerSystems redfishcommon.Links
+			ManagedBy       redfishcommon.Links
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&chassis); err != nil {
+		return nil, err
+	}
+	return &ChassisLinks{
+		ContainedBy:     string(chassis.Links.ContainedBy),
+		Contains:        chassis.Links.Contains.ToStrings(),
+		ComputerSystems: chassis.Links.ComputerSystems.ToStrings(),
+		ManagedBy:       chassis.Links.ManagedBy.ToStrings(),
+	}, nil
+}
+
+// GetSystemChassisURI returns the URI of the chassis holding the system at systemURI,
+// the first one of its links (i.e. the sled, rather than the enclosure, on modular systems)
+func GetSystemChassisURI(c redfishcommon.Client, systemURI string) (string, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		Links struct {
+			Chassis redfishcommon.Links
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return "", err
+	}
+	if len(system.Links.Chassis) == 0 {
+		return "", fmt.Errorf("system %s is not linked to any chassis", systemURI)
+	}
+	return system.Links.Chassis.ToStrings()[0], nil
+}
+
+// GetChassisReseatTarget returns the target of the OEM virtual reseat action of the chassis at chassisURI
+// (i.e. the sleds of a Dell MX7000), which removes and restores the power of the sled as if it was pulled out.
+// There is no standard Redfish action for it, so any OEM action named VirtualReseat is used.
+// It returns an empty target for the chassis not supporting it.
+func GetChassisReseatTarget(c redfishcommon.Client, chassisURI string) (string, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var chassis struct {
+		Actions struct {
+			Oem map[string]struct {
+				Target string `json:"target"`
+			}
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&chassis); err != nil {
+		return "", err
+	}
+	names := []string{}
+	for name := range chassis.Actions.Oem {
+		if strings.HasSuffix(name, "VirtualReseat") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	sort.Strings(names)
+	return chassis.Actions.Oem[names[0]].Target, nil
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetChassisLinks(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected ChassisLinks
+	}{
+		{1, `{"Links":{"ContainedBy":{"@odata.id":"/redfish/v1/Chassis/Chassis.1"},"ComputerSystems":[{"@odata.id":"/redfish/v1/Systems/System.Embedded.1"}],"ManagedBy":[{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1"}]}}`,
+			ChassisLinks{ContainedBy: "/redfish/v1/Chassis/Chassis.1", ComputerSystems: []string{"/redfish/v1/Systems/System.Embedded.1"}, ManagedBy: []string{"/redfish/v1/Managers/iDRAC.Embedded.1"}}},
+		{2, `{"Links":{"Contains":[{"@odata.id":"/redfish/v1/Chassis/Sled.1"},{"@odata.id":"/redfish/v1/Chassis/Sled.2"}]}}`,
+			ChassisLinks{Contains: []string{"/redfish/v1/Chassis/Sled.1", "/redfish/v1/Chassis/Sled.2"}}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		links, err := GetChassisLinks(testClient, "/redfish/v1/Chassis/System.Embedded.1")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !reflect.DeepEqual(*links, v.expected) {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, *links)
+		}
+	}
+}
+
+func TestGetSystemChassisURI(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected string
+		err      bool
+	}{
+		{1, `{"Links":{"Chassis":[{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1"},{"@odata.id":"/redfish/v1/Chassis/Enclosure.Internal.0-1"}]}}`, "/redfish/v1/Chassis/System.Embedded.1", false},
+		{2, `{"Links":{}}`, "", true},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		uri, err := GetSystemChassisURI(testClient, "/redfish/v1/Systems/System.Embedded.1")
+		if (err != nil) != v.err || uri != v.expected {
+			t.Errorf("Test number %v: expected %s, got %s (%v)", v.noTest, v.expected, uri, err)
+		}
+	}
+}
+
+func TestGetChassisReseatTarget(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected string
+	}{
+		{1, `{"Actions":{"#Chassis.Reset":{"target":"/redfish/v1/Chassis/Sled.Slot.1/Actions/Chassis.Reset"},` +
+			`"Oem":{"#DellChassis.VirtualReseat":{"target":"/redfish/v1/Chassis/Sled.Slot.1/Actions/Oem/DellChassis.VirtualReseat"}}}}`,
+			"/redfish/v1/Chassis/Sled.Slot.1/Actions/Oem/DellChassis.VirtualReseat"},
+		{2, `{"Actions":{"#Chassis.Reset":{"target":"/redfish/v1/Chassis/1/Actions/Chassis.Reset"}}}`, ""},
+		{3, `{}`, ""},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		target, err := GetChassisReseatTarget(testClient, "/redfish/v1/Chassis/Sled.Slot.1")
+		if err != nil || target != v.expected {
+			t.Errorf("Test number %v: expected %s, got %s (%v)", v.noTest, v.expected, target, err)
+		}
+	}
+}
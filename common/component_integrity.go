@@ -0,0 +1,85 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// ComponentIntegrity is a member of the ComponentIntegrity collection of the service root, which reports the
+// attestation (i.e. SPDM) of a device of the server. gofish does not support it, so it is decoded here.
+type ComponentIntegrity struct {
+	ODataID                       string `json:"@odata.id"`
+	ID                            string `json:"Id"`
+	Name                          string
+	ComponentIntegrityType        string
+	ComponentIntegrityTypeVersion string
+	ComponentIntegrityEnabled     bool
+	TargetComponentURI            string
+	LastUpdated                   string
+	Status                        redfishcommon.Status
+	SPDM                          struct {
+		IdentityAuthentication struct {
+			// VerificationStatus is the result of the authentication of the device identity (Success or Failed)
+			VerificationStatus string
+		}
+		MeasurementSet struct {
+			Measurements []ComponentIntegrityMeasurement
+		}
+	}
+}
+
+// ComponentIntegrityMeasurement is a measurement (i.e. the digest of a firmware) reported by a device over SPDM
+type ComponentIntegrityMeasurement struct {
+	MeasurementIndex         int
+	MeasurementType          string
+	Measurement              string
+	LastUpdated              string
+	SecurityVersionNumber    string
+	MeasurementSpecification string
+}
+
+// GetComponentIntegrity retrieves every member of the ComponentIntegrity collection of the service root at serviceRootURI.
+// Services not supporting device attestation have none.
+func GetComponentIntegrity(c redfishcommon.Client, serviceRootURI string) ([]*ComponentIntegrity, error) {
+	components := []*ComponentIntegrity{}
+	resp, err := c.Get(serviceRootURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var root struct {
+		ComponentIntegrity redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return nil, err
+	}
+	if root.ComponentIntegrity == "" {
+		return components, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(root.ComponentIntegrity))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		componentResp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var component ComponentIntegrity
+		err = json.NewDecoder(componentResp.Body).Decode(&component)
+		componentResp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if component.ODataID == "" {
+			component.ODataID = link
+		}
+		components = append(components, &component)
+	}
+	return components, nil
+}
+
+// SetComponentIntegrityEnabled enables or disables the attestation of the component at uri
+func SetComponentIntegrityEnabled(c redfishcommon.Client, uri string, enabled bool) error {
+	return PatchResource(c, uri, map[string]interface{}{"ComponentIntegrityEnabled": enabled})
+}
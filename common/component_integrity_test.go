@@ -0,0 +1,54 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetComponentIntegrity(t *testing.T) {
+	const collectionURI = "/redfish/v1/ComponentIntegrity"
+	cases := []struct {
+		noTest       int
+		responses    []string
+		components   []string
+		measurements int
+	}{
+		{1, []string{
+			`{"Id":"RootService","ComponentIntegrity":{"@odata.id":"` + collectionURI + `"}}`,
+			`{"Members":[{"@odata.id":"` + collectionURI + `/NIC.Slot.1-1"}],"Members@odata.count":1}`,
+			`{"Id":"NIC.Slot.1-1","ComponentIntegrityType":"SPDM","ComponentIntegrityTypeVersion":"1.1.0","ComponentIntegrityEnabled":true,` +
+				`"TargetComponentURI":"/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters/NIC.Slot.1",` +
+				`"SPDM":{"IdentityAuthentication":{"VerificationStatus":"Success"},"MeasurementSet":{"Measurements":[` +
+				`{"MeasurementIndex":1,"MeasurementType":"MutableFirmware","Measurement":"3q2+7w=="}]}}}`,
+		}, []string{"NIC.Slot.1-1"}, 1},
+		{2, []string{`{"Id":"RootService"}`}, []string{}, 0},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		components, err := GetComponentIntegrity(testClient, "/redfish/v1/")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(components) != len(v.components) {
+			t.Errorf("Test number %v: expected %d components, got %d", v.noTest, len(v.components), len(components))
+			continue
+		}
+		for i, component := range components {
+			if component.ID != v.components[i] || component.ODataID != collectionURI+"/"+v.components[i] ||
+				len(component.SPDM.MeasurementSet.Measurements) != v.measurements || component.SPDM.IdentityAuthentication.VerificationStatus != "Success" {
+				t.Errorf("Test number %v: unexpected component %+v", v.noTest, component)
+			}
+		}
+	}
+}
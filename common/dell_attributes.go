@@ -0,0 +1,99 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+const (
+	// DellIdracAttributesURI holds the iDRAC attributes (i.e. Lockdown.1.SystemLockdown)
+	DellIdracAttributesURI string = "/redfish/v1/Managers/iDRAC.Embedded.1/Attributes"
+	// DellSystemAttributesURI holds the system attributes (i.e. LCD.1.Configuration)
+	DellSystemAttributesURI string = "/redfish/v1/Managers/System.Embedded.1/Attributes"
+	// DellLifecycleControllerAttributesURI holds the lifecycle controller attributes (i.e. LCAttributes.1.AutoUpdate)
+	DellLifecycleControllerAttributesURI string = "/redfish/v1/Managers/LifecycleController.Embedded.1/Attributes"
+	// SystemLockdownAttribute is the iDRAC attribute that blocks configuration and firmware changes
+	SystemLockdownAttribute string = "Lockdown.1.SystemLockdown"
+	// GroupManagerAttribute is the iDRAC attribute that enables Group Manager, which pushes the group settings to its members
+	GroupManagerAttribute string = "GroupManager.1.Status"
+)
+
+// GetDellAttributes retrieves the attributes of a Dell OEM attributes resource.
+// Values are converted to string, as that is how they are stored in the terraform state.
+func GetDellAttributes(c redfishcommon.Client, uri string) (map[string]string, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Attributes map[string]interface{}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]string)
+	for key, value := range result.Attributes {
+		if value == nil {
+			attributes[key] = ""
+		} else if strValue, ok := value.(string); ok {
+			attributes[key] = strValue
+		} else {
+			attributes[key] = fmt.Sprintf("%v", value)
+		}
+	}
+	return attributes, nil
+}
+
+// PatchDellAttributes updates the attributes of a Dell OEM attributes resource.
+// Only the attributes given are modified, the rest keep their current value.
+func PatchDellAttributes(c redfishcommon.Client, uri string, attributes map[string]interface{}) error {
+	payload := make(map[string]interface{})
+	payload["Attributes"] = attributes
+	resp, err := c.Patch(uri, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error updating the attributes of %s, status code was %d", uri, resp.StatusCode)
+	}
+	return nil
+}
+
+// SystemLockdownEnabled reports if iDRAC System Lockdown is enabled.
+// BMCs without the Dell iDRAC attributes are reported as not locked down.
+func SystemLockdownEnabled(c redfishcommon.Client) (bool, error) {
+	attributes, err := GetDellAttributes(c, DellIdracAttributesURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return attributes[SystemLockdownAttribute] == "Enabled", nil
+}
+
+// SetSystemLockdown enables or disables iDRAC System Lockdown.
+func SetSystemLockdown(c redfishcommon.Client, enabled bool) error {
+	value := "Disabled"
+	if enabled {
+		value = "Enabled"
+	}
+	return PatchDellAttributes(c, DellIdracAttributesURI, map[string]interface{}{SystemLockdownAttribute: value})
+}
+
+// GroupManagerEnabled reports if iDRAC Group Manager is enabled.
+// BMCs without the Dell iDRAC attributes or the Group Manager feature are reported as disabled.
+func GroupManagerEnabled(c redfishcommon.Client) (bool, error) {
+	attributes, err := GetDellAttributes(c, DellIdracAttributesURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return attributes[GroupManagerAttribute] == "Enabled", nil
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetDellAttributes(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	body := `{"Attributes":{"LCD.1.Configuration":"Service Tag","LCD.1.QualifierWatt":1,"LCD.1.UserDefinedString":null}}`
+	testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	})
+	attributes, err := GetDellAttributes(testClient, DellSystemAttributesURI)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"LCD.1.Configuration":     "Service Tag",
+		"LCD.1.QualifierWatt":     "1",
+		"LCD.1.UserDefinedString": "",
+	}
+	for key, value := range expected {
+		if attributes[key] != value {
+			t.Errorf("Attribute %v: expected %q, got %q", key, value, attributes[key])
+		}
+	}
+}
+
+func TestPatchDellAttributes(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		statusCode int
+		shouldPass bool
+	}{
+		{1, http.StatusOK, true},
+		{2, http.StatusAccepted, true},
+		{3, http.StatusCreated, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPatch] = append(testClient.CustomReturnForActions[http.MethodPatch], &http.Response{
+			StatusCode: v.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		err := PatchDellAttributes(testClient, DellSystemAttributesURI, map[string]interface{}{"LCD.1.Configuration": "User Defined"})
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || !strings.Contains(calls[0].Payload, "LCD.1.Configuration") {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestSystemLockdownEnabled(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected bool
+	}{
+		{1, `{"Attributes":{"Lockdown.1.SystemLockdown":"Enabled"}}`, true},
+		{2, `{"Attributes":{"Lockdown.1.SystemLockdown":"Disabled"}}`, false},
+		{3, `{"Attributes":{}}`, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		enabled, err := SystemLockdownEnabled(testClient)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if enabled != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, enabled)
+		}
+	}
+}
+
+func TestGroupManagerEnabled(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected bool
+	}{
+		{1, `{"Attributes":{"GroupManager.1.Status":"Enabled"}}`, true},
+		{2, `{"Attributes":{"GroupManager.1.Status":"Disabled"}}`, false},
+		{3, `{"Attributes":{}}`, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		enabled, err := GroupManagerEnabled(testClient)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if enabled != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, enabled)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Erratum describes firmware that is known to be bad, or too old, for a set of system models.
+type Erratum struct {
+	ID          string
+	Description string
+	// ModelRegex is matched against the system model. Empty matches every model
+	ModelRegex string
+	// ComponentRegex is matched against the firmware inventory name of the installed firmware
+	ComponentRegex string
+	// MinimumVersion is the oldest acceptable version. Empty means no minimum
+	MinimumVersion string
+	// BadVersions are versions known to be bad, regardless of MinimumVersion
+	BadVersions []string
+}
+
+// ErratumViolation is installed firmware matching an erratum
+type ErratumViolation struct {
+	Erratum   Erratum
+	Installed *FirmwareInventoryEntry
+}
+
+// DefaultErrata is the errata table bundled with the provider. It only holds the firmware
+// the provider itself is known not to work properly with; site specific errata are expected
+// to be added through the redfish_hardware_errata data source.
+var DefaultErrata = []Erratum{
+	{
+		ID:             "IDRAC9-REDFISH-MINIMUM",
+		Description:    "iDRAC9 firmware before 4.00.00.00 lacks Redfish features used by this provider, such as the SimpleUpdate targets and the System Lockdown attributes",
+		ModelRegex:     `^PowerEdge [A-Z]+[0-9][4-9][0-9]*[a-z]*$`,
+		ComponentRegex: `^Integrated Dell Remote Access Controller$`,
+		MinimumVersion: "4.00.00.00",
+	},
+	{
+		ID:             "IDRAC8-REDFISH-MINIMUM",
+		Description:    "iDRAC8 firmware before 2.70.70.70 has an incomplete Redfish implementation",
+		ModelRegex:     `^PowerEdge [A-Z]+[0-9]3[0-9]*[a-z]*$`,
+		ComponentRegex: `^Integrated Dell Remote Access Controller$`,
+		MinimumVersion: "2.70.70.70",
+	},
+	{
+		ID:             "POWEREDGE-14G-BIOS-MINIMUM",
+		Description:    "BIOS of the 14th generation PowerEdge servers before 2.2.11 does not support the apply times of the BIOS settings, which the provider stages the BIOS changes with",
+		ModelRegex:     `^PowerEdge [A-Z]+[0-9]4[0-9]*[a-z]*$`,
+		ComponentRegex: `^BIOS$`,
+		MinimumVersion: "2.2.11",
+	},
+	{
+		ID:             "POWEREDGE-14G-CPLD-MINIMUM",
+		Description:    "System CPLD firmware of the 14th generation PowerEdge servers before 1.0.6 can leave the system powered off after the power cycles the provider applies the pending changes with",
+		ModelRegex:     `^PowerEdge [A-Z]+[0-9]4[0-9]*[a-z]*$`,
+		ComponentRegex: `^System CPLD$`,
+		MinimumVersion: "1.0.6",
+	},
+}
+
+// CheckErrata returns the installed firmware of a system matching any of the errata.
+func CheckErrata(model string, inventory []*FirmwareInventoryEntry, errata []Erratum) ([]ErratumViolation, error) {
+	violations := []ErratumViolation{}
+	for _, erratum := range errata {
+		if len(erratum.ModelRegex) > 0 {
+			modelRegex, err := regexp.Compile(erratum.ModelRegex)
+			if err != nil {
+				return nil, fmt.Errorf("erratum %s has an invalid model regex: %s", erratum.ID, err)
+			}
+			if !modelRegex.MatchString(model) {
+				continue
+			}
+		}
+		componentRegex, err := regexp.Compile(erratum.ComponentRegex)
+		if err != nil {
+			return nil, fmt.Errorf("erratum %s has an invalid component regex: %s", erratum.ID, err)
+		}
+		for _, entry := range inventory {
+			if !entry.Installed() || !componentRegex.MatchString(entry.Name) {
+				continue
+			}
+			tooOld := len(erratum.MinimumVersion) > 0 && CompareVersions(entry.Version, erratum.MinimumVersion) < 0
+			if tooOld || ContainsFold(erratum.BadVersions, entry.Version) {
+				violations = append(violations, ErratumViolation{Erratum: erratum, Installed: entry})
+			}
+		}
+	}
+	return violations, nil
+}
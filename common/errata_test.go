@@ -0,0 +1,53 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestCheckErrata(t *testing.T) {
+	errata := append([]Erratum{
+		{
+			ID:             "BIOS-BAD",
+			ComponentRegex: "^BIOS$",
+			BadVersions:    []string{"2.8.1"},
+		},
+	}, DefaultErrata...)
+	cases := []struct {
+		noTest   int
+		model    string
+		idrac    string
+		bios     string
+		cpld     string
+		expected []string
+	}{
+		{1, "PowerEdge R740", "4.40.00.00", "2.8.2", "1.0.6", []string{}},
+		{2, "PowerEdge R740", "3.30.30.30", "2.8.2", "1.0.6", []string{"IDRAC9-REDFISH-MINIMUM"}},
+		{3, "PowerEdge R7525", "3.30.30.30", "2.8.1", "1.0.6", []string{"BIOS-BAD", "IDRAC9-REDFISH-MINIMUM"}},
+		{4, "PowerEdge R730", "2.63.60.61", "2.8.2", "1.0.6", []string{"IDRAC8-REDFISH-MINIMUM"}},
+		{5, "PowerEdge R730", "2.70.70.70", "2.8.2", "1.0.6", []string{}},
+		{6, "PowerEdge R740xd", "4.40.00.00", "2.1.10", "1.0.1", []string{"POWEREDGE-14G-BIOS-MINIMUM", "POWEREDGE-14G-CPLD-MINIMUM"}},
+		{7, "PowerEdge R7525", "4.40.00.00", "2.1.10", "1.0.1", []string{}},
+	}
+	for _, v := range cases {
+		inventory := []*FirmwareInventoryEntry{
+			{ID: "Installed-25227-" + v.idrac, Name: "Integrated Dell Remote Access Controller", Version: v.idrac},
+			{ID: "Installed-159-" + v.bios, Name: "BIOS", Version: v.bios},
+			{ID: "Previous-159-2.8.1", Name: "BIOS", Version: "2.8.1"},
+			{ID: "Installed-27763-" + v.cpld, Name: "System CPLD", Version: v.cpld},
+		}
+		violations, err := CheckErrata(v.model, inventory, errata)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(violations) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, violations)
+			continue
+		}
+		for i, id := range v.expected {
+			if violations[i].Erratum.ID != id {
+				t.Errorf("Test number %v: expected %v, got %v", v.noTest, id, violations[i].Erratum.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when a resource does not exist (404 Not Found)
+	ErrNotFound = errors.New("resource not found")
+	// ErrUnauthorized is returned when the service rejects the credentials (401 Unauthorized)
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrTaskFailed is returned when a task or job finishes unsuccessfully. The error is a *TaskFailedError
+	ErrTaskFailed = errors.New("task failed")
+)
+
+// HTTPError is an error response of the service. It unwraps to ErrNotFound and ErrUnauthorized,
+// so callers check errors.Is instead of the status code.
+type HTTPError struct {
+	StatusCode int
+	// Body is the body of the response, which holds the Redfish error message
+	Body string
+}
+
+// Error returns the status code and the body, in the same format as gofish errors
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the typed error of the status code, if any
+func (e *HTTPError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// WrapHTTPError returns the errors gofish creates for error responses (i.e. "404: {...}") as *HTTPError,
+// so they match the typed errors. Any other error is returned as is.
+func WrapHTTPError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		return err
+	}
+	parts := strings.SplitN(err.Error(), ": ", 2)
+	statusCode, convErr := strconv.Atoi(parts[0])
+	if convErr != nil || statusCode < 400 || statusCode > 599 {
+		return err
+	}
+	httpError = &HTTPError{StatusCode: statusCode}
+	if len(parts) > 1 {
+		httpError.Body = parts[1]
+	}
+	return httpError
+}
+
+// IsNotFound reports if err means the resource does not exist, either as a typed error or a gofish error response
+func IsNotFound(err error) bool {
+	return errors.Is(WrapHTTPError(err), ErrNotFound)
+}
+
+// TaskFailedError is a task or job that finished unsuccessfully, with the messages it reported.
+// It matches ErrTaskFailed.
+type TaskFailedError struct {
+	TaskURI string
+	State   string
+	// Messages are the messages of the task, oldest first. The last one usually holds the reason of the failure
+	Messages []string
+}
+
+// Error returns the state of the task and its messages
+func (e *TaskFailedError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("the job has finished unsucessfully with a %s state", e.State)
+	}
+	return fmt.Sprintf("the job has finished unsucessfully with a %s state: %s", e.State, strings.Join(e.Messages, "; "))
+}
+
+// Is reports if target is ErrTaskFailed
+func (e *TaskFailedError) Is(target error) bool {
+	return target == ErrTaskFailed
+}
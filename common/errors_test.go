@@ -0,0 +1,55 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrapHTTPError(t *testing.T) {
+	cases := []struct {
+		noTest       int
+		err          error
+		notFound     bool
+		unauthorized bool
+		statusCode   int
+	}{
+		{1, errors.New(`404: {"error":{"code":"Base.1.8.ResourceMissingAtURI"}}`), true, false, 404},
+		{2, errors.New(`401: `), false, true, 401},
+		{3, errors.New(`500: {"error":{"code":"Base.1.8.InternalError"}}`), false, false, 500},
+		{4, fmt.Errorf("error creating a Redfish session: %w", &HTTPError{StatusCode: 401}), false, true, 401},
+		{5, errors.New("dial tcp 10.0.0.1:443: connect: connection refused"), false, false, 0},
+		{6, errors.New("200: unexpected"), false, false, 0},
+		{7, nil, false, false, 0},
+	}
+	for _, v := range cases {
+		err := WrapHTTPError(v.err)
+		if errors.Is(err, ErrNotFound) != v.notFound || IsNotFound(v.err) != v.notFound {
+			t.Errorf("Test number %v: %v matches ErrNotFound: %v, expected %v", v.noTest, v.err, errors.Is(err, ErrNotFound), v.notFound)
+		}
+		if errors.Is(err, ErrUnauthorized) != v.unauthorized {
+			t.Errorf("Test number %v: %v matches ErrUnauthorized: %v, expected %v", v.noTest, v.err, errors.Is(err, ErrUnauthorized), v.unauthorized)
+		}
+		var httpError *HTTPError
+		if errors.As(err, &httpError) != (v.statusCode != 0) || (httpError != nil && httpError.StatusCode != v.statusCode) {
+			t.Errorf("Test number %v: unexpected HTTP error %v", v.noTest, httpError)
+		}
+		if v.err != nil && err.Error() != v.err.Error() {
+			t.Errorf("Test number %v: the message changed to %s", v.noTest, err)
+		}
+	}
+}
+
+func TestTaskFailedError(t *testing.T) {
+	err := fmt.Errorf("error waiting for job: %w", &TaskFailedError{TaskURI: "/redfish/v1/TaskService/Tasks/1", State: "Exception", Messages: []string{"Downloading", "The image is not valid"}})
+	if !errors.Is(err, ErrTaskFailed) || errors.Is(err, ErrNotFound) {
+		t.Errorf("%v does not match ErrTaskFailed only", err)
+	}
+	var failed *TaskFailedError
+	if !errors.As(err, &failed) || failed.TaskURI != "/redfish/v1/TaskService/Tasks/1" {
+		t.Errorf("%v is not a TaskFailedError", err)
+	}
+	if err.Error() != "error waiting for job: the job has finished unsucessfully with a Exception state: Downloading; The image is not valid" {
+		t.Errorf("unexpected message %s", err)
+	}
+}
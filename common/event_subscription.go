@@ -0,0 +1,157 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"net/http"
+	"strings"
+)
+
+// SubscriptionContextPrefix is the prefix of the Context of the subscriptions created by the provider
+const SubscriptionContextPrefix string = "terraform-redfish-"
+
+// MetricReportFormat is the EventFormatType of the subscriptions receiving telemetry metric reports
+const MetricReportFormat string = "MetricReport"
+
+// CreateMetricReportSubscription subscribes destination to the metric reports of the service, so
+// they are pushed to it (i.e. to an HTTP ingest endpoint) as they are generated.
+// gofish does not support EventFormatType, so the subscription is created here.
+// Parameters:
+//   - destination -> URL the reports are POSTed to.
+//   - context -> string sent with every report, to identify the subscription.
+//
+// Returns the URI of the subscription.
+func CreateMetricReportSubscription(c *gofish.APIClient, destination string, context string) (string, error) {
+	subscriptionsURI, err := eventSubscriptionsURI(c)
+	if err != nil {
+		return "", err
+	}
+	payload := map[string]interface{}{
+		"Destination":     destination,
+		"EventFormatType": MetricReportFormat,
+		"EventTypes":      []string{MetricReportFormat},
+		"Protocol":        "Redfish",
+		"Context":         context,
+	}
+	return createEventSubscription(c, subscriptionsURI, payload)
+}
+
+// CreateAlertSubscription subscribes destination to the alerts raised by originResources (i.e. a sensor
+// crossing one of its thresholds), so they are pushed to it as they happen.
+// Parameters:
+//   - destination -> URL the events are POSTed to.
+//   - context -> string sent with every event, to identify the subscription.
+//   - originResources -> URIs of the resources whose events are sent.
+//
+// Returns the URI of the subscription.
+func CreateAlertSubscription(c *gofish.APIClient, destination string, context string, originResources []string) (string, error) {
+	subscriptionsURI, err := eventSubscriptionsURI(c)
+	if err != nil {
+		return "", err
+	}
+	origins := []map[string]string{}
+	for _, uri := range originResources {
+		origins = append(origins, map[string]string{"@odata.id": uri})
+	}
+	payload := map[string]interface{}{
+		"Destination":     destination,
+		"EventFormatType": "Event",
+		"EventTypes":      []string{"Alert"},
+		"OriginResources": origins,
+		"Protocol":        "Redfish",
+		"Context":         context,
+	}
+	return createEventSubscription(c, subscriptionsURI, payload)
+}
+
+// createEventSubscription posts payload to the subscriptions collection and returns the URI of the new subscription
+func createEventSubscription(c redfishcommon.Client, subscriptionsURI string, payload map[string]interface{}) (string, error) {
+	destination := payload["Destination"]
+	resp, err := c.Post(subscriptionsURI, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the subscription of %s was not created. Status code was %d", destination, resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	if len(location) == 0 {
+		return "", fmt.Errorf("the service returned no location for the subscription of %s", destination)
+	}
+	// Some services return the absolute URL of the subscription
+	if i := strings.Index(location, "/redfish/"); i > 0 {
+		location = location[i:]
+	}
+	return location, nil
+}
+
+// GetEventSubscriptions retrieves every event subscription of the service, whoever created it
+func GetEventSubscriptions(c *gofish.APIClient) ([]*redfish.EventDestination, error) {
+	subscriptionsURI, err := eventSubscriptionsURI(c)
+	if err != nil {
+		return nil, err
+	}
+	return redfish.ListReferencedEventDestinations(c, subscriptionsURI)
+}
+
+// DeleteEventSubscription deletes an event subscription. Subscriptions already gone are not an error.
+func DeleteEventSubscription(c redfishcommon.Client, uri string) error {
+	exists, err := EventSubscriptionExists(c, uri)
+	if err != nil || !exists {
+		return err
+	}
+	resp, err := c.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error deleting the subscription %s, status code was %d", uri, resp.StatusCode)
+	}
+	return nil
+}
+
+// EventSubscriptionExists reports if an event subscription still exists. BMCs delete the subscriptions
+// whose destination keeps failing, so they must be checked before relying on them.
+func EventSubscriptionExists(c redfishcommon.Client, uri string) (bool, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+func eventSubscriptionsURI(c *gofish.APIClient) (string, error) {
+	resp, err := c.Get(c.Service.ODataID)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var root struct {
+		EventService redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return "", err
+	}
+	if len(root.EventService) == 0 {
+		return "", fmt.Errorf("the service does not support events")
+	}
+	eventService, err := GetResource(c, string(root.EventService))
+	if err != nil {
+		return "", err
+	}
+	subscriptions, _ := eventService["Subscriptions"].(map[string]interface{})
+	uri, _ := subscriptions["@odata.id"].(string)
+	if len(uri) == 0 {
+		return "", fmt.Errorf("the event service does not support subscriptions")
+	}
+	return uri, nil
+}
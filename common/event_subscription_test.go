@@ -0,0 +1,80 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDeleteEventSubscription(t *testing.T) {
+	const uri = "/redfish/v1/EventService/Subscriptions/1"
+	cases := []struct {
+		noTest     int
+		statusCode int
+		shouldPass bool
+	}{
+		{1, http.StatusOK, true},
+		{2, http.StatusNoContent, true},
+		{3, http.StatusBadRequest, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		})
+		testClient.CustomReturnForActions[http.MethodDelete] = append(testClient.CustomReturnForActions[http.MethodDelete], &http.Response{
+			StatusCode: v.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		err := DeleteEventSubscription(testClient, uri)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 2 || calls[1].Action != http.MethodDelete || calls[1].URL != uri {
+			t.Errorf("Test number %v sent unexpected requests %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestCreateEventSubscription(t *testing.T) {
+	const subscriptionsURI = "/redfish/v1/EventService/Subscriptions"
+	cases := []struct {
+		noTest     int
+		statusCode int
+		location   string
+		expected   string
+		shouldPass bool
+	}{
+		{1, http.StatusCreated, "/redfish/v1/EventService/Subscriptions/1", "/redfish/v1/EventService/Subscriptions/1", true},
+		{2, http.StatusCreated, "https://10.0.0.10/redfish/v1/EventService/Subscriptions/2", "/redfish/v1/EventService/Subscriptions/2", true},
+		{3, http.StatusCreated, "", "", false},
+		{4, http.StatusBadRequest, "", "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Header:     http.Header{"Location": []string{v.location}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		uri, err := createEventSubscription(testClient, subscriptionsURI, map[string]interface{}{"Destination": "https://alerts.example.com"})
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if uri != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, uri)
+		}
+	}
+}
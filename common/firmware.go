@@ -0,0 +1,555 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FirmwareInventoryEntry is a member of the UpdateService FirmwareInventory collection.
+type FirmwareInventoryEntry struct {
+	ODataID    string `json:"@odata.id"`
+	ID         string `json:"Id"`
+	Name       string
+	Version    string
+	SoftwareID string `json:"SoftwareId"`
+	Updateable bool
+	Status     redfishcommon.Status
+}
+
+// Installed reports if the entry is the firmware currently running on the component.
+// Dell also lists the previous (rollback) and available (staged) images in the inventory.
+func (f *FirmwareInventoryEntry) Installed() bool {
+	return !strings.HasPrefix(f.ID, "Previous-") && !strings.HasPrefix(f.ID, "Available-")
+}
+
+// Previous reports if the entry is the image the component was running before its last update,
+// which the BMC can reinstall to roll the component back.
+func (f *FirmwareInventoryEntry) Previous() bool {
+	return strings.HasPrefix(f.ID, "Previous-")
+}
+
+// componentKey identifies a component across updates. Dell inventory Ids embed the version,
+// so they change with every update, while the SoftwareId does not.
+func (f *FirmwareInventoryEntry) componentKey() string {
+	if f.SoftwareID != "" {
+		return f.SoftwareID
+	}
+	return f.Name
+}
+
+// firmwareInventoryWorkers bounds the requests run in parallel to fetch the firmware inventory entries,
+// as BMCs answer slowly (or not at all) when flooded with requests
+const firmwareInventoryWorkers = 8
+
+// GetFirmwareInventory retrieves every entry of the UpdateService FirmwareInventory collection.
+// When the BMC supports $expand, the entries are fetched with the collection in a single request.
+// Otherwise, or if the expanded collection cannot be used, they are fetched in parallel.
+func GetFirmwareInventory(c *gofish.APIClient) ([]*FirmwareInventoryEntry, error) {
+	updateService, err := c.Service.UpdateService()
+	if err != nil {
+		return nil, err
+	}
+	expand := c.Service.ProtocolFeaturesSupported.ExpandQuery
+	if expand.NoLinks || expand.ExpandAll {
+		inventory, err := getExpandedFirmwareInventory(c, updateService.FirmwareInventory)
+		if err == nil {
+			return inventory, nil
+		}
+		fmt.Printf("[DEBUG] - Error expanding the firmware inventory, fetching every entry: %s\n", err)
+	}
+	collection, err := redfishcommon.GetCollection(c, updateService.FirmwareInventory)
+	if err != nil {
+		return nil, err
+	}
+	return getFirmwareInventoryEntries(c, collection.ItemLinks)
+}
+
+// getExpandedFirmwareInventory retrieves the firmware inventory collection at uri with its members expanded.
+// Members the BMC returns as links only (i.e. it ignored $expand) are fetched one by one.
+func getExpandedFirmwareInventory(c redfishcommon.Client, uri string) ([]*FirmwareInventoryEntry, error) {
+	resp, err := c.Get(uri + "?$expand=.($levels=1)")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var collection struct {
+		Members []*FirmwareInventoryEntry
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&collection); err != nil {
+		return nil, err
+	}
+	links := []string{}
+	for _, entry := range collection.Members {
+		if entry.ID == "" {
+			links = append(links, entry.ODataID)
+		}
+	}
+	if len(links) == 0 {
+		return collection.Members, nil
+	}
+	entries, err := getFirmwareInventoryEntries(c, links)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range collection.Members {
+		if entry.ID == "" {
+			collection.Members[i], entries = entries[0], entries[1:]
+		}
+	}
+	return collection.Members, nil
+}
+
+// getFirmwareInventoryEntries retrieves the firmware inventory entries at links, in order,
+// running up to firmwareInventoryWorkers requests in parallel. It returns the first error found.
+func getFirmwareInventoryEntries(c redfishcommon.Client, links []string) ([]*FirmwareInventoryEntry, error) {
+	entries := make([]*FirmwareInventoryEntry, len(links))
+	errs := make([]error, len(links))
+	workers := make(chan struct{}, firmwareInventoryWorkers)
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, link string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			entries[i], errs[i] = getFirmwareInventoryEntry(c, link)
+		}(i, link)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func getFirmwareInventoryEntry(c redfishcommon.Client, uri string) (*FirmwareInventoryEntry, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var entry FirmwareInventoryEntry
+	if err = json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SimpleUpdate requests the BMC to download and apply a firmware image through UpdateService.SimpleUpdate.
+// Parameters:
+//   - imageURI -> URI the BMC will pull the image from.
+//   - transferProtocol -> protocol to use to pull the image. Empty means the BMC infers it from the URI.
+//   - targets -> URIs of the devices the image must be applied to. Empty means every device the image applies to.
+//
+// Returns the URI of the job created to apply the image.
+func SimpleUpdate(c *gofish.APIClient, imageURI string, transferProtocol string, targets []string) (jobURI string, err error) {
+	payload := make(map[string]interface{})
+	payload["ImageURI"] = imageURI
+	if len(transferProtocol) > 0 {
+		payload["TransferProtocol"] = transferProtocol
+	}
+	return simpleUpdate(c, payload, targets)
+}
+
+// SimpleUpdateFromShare applies a firmware image stored on a network share like SimpleUpdate,
+// sending the transfer protocol and the credentials of the share.
+// Parameters:
+//   - path -> path of the image in the share.
+//   - targets -> URIs of the devices the image must be applied to. Empty means every device the image applies to.
+func SimpleUpdateFromShare(c *gofish.APIClient, share *Share, path string, targets []string) (jobURI string, err error) {
+	payload := make(map[string]interface{})
+	payload["ImageURI"] = share.URI(path)
+	payload["TransferProtocol"] = share.TransferProtocol()
+	share.addCredentials(payload)
+	return simpleUpdate(c, payload, targets)
+}
+
+func simpleUpdate(c *gofish.APIClient, payload map[string]interface{}, targets []string) (jobURI string, err error) {
+	updateService, err := c.Service.UpdateService()
+	if err != nil {
+		return "", err
+	}
+	if len(updateService.UpdateServiceTarget) == 0 {
+		return "", fmt.Errorf("the update service does not support SimpleUpdate")
+	}
+	if len(targets) > 0 {
+		payload["Targets"] = targets
+	}
+	res, err := c.Post(updateService.UpdateServiceTarget, payload)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("the update request was not accepted. Status code was %d", res.StatusCode)
+	}
+	jobURI = res.Header.Get("Location")
+	if len(jobURI) == 0 {
+		return "", fmt.Errorf("there was some error when retreiving the jobID")
+	}
+	return jobURI, nil
+}
+
+// InstalledFirmwareVersions returns the versions of the installed firmware, indexed by inventory Id.
+func InstalledFirmwareVersions(c *gofish.APIClient) (map[string]string, error) {
+	inventory, err := GetFirmwareInventory(c)
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string)
+	for _, entry := range inventory {
+		if entry.Installed() {
+			versions[entry.ID] = entry.Version
+		}
+	}
+	return versions, nil
+}
+
+// ResolveFirmwareTargets maps update targets to the installed firmware inventory entries.
+// Targets are either URIs of firmware inventory entries or device ids of the vendor (i.e. Dell FQDDs),
+// which are matched by oem.
+func ResolveFirmwareTargets(oem OEMHandler, inventory []*FirmwareInventoryEntry, targets []string) ([]*FirmwareInventoryEntry, error) {
+	entries := []*FirmwareInventoryEntry{}
+	for _, target := range targets {
+		var found *FirmwareInventoryEntry
+		for _, entry := range inventory {
+			if !entry.Installed() {
+				continue
+			}
+			if oem.MatchFirmwareTarget(entry, target) {
+				found = entry
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("target %s not found in the firmware inventory", target)
+		}
+		entries = append(entries, found)
+	}
+	return entries, nil
+}
+
+// FirmwareTarget selects update targets by properties that, unlike the inventory names, do not change between
+// BMC firmware revisions and localizations. The first one set is used, in this order: FQDD, SoftwareID and Name.
+type FirmwareTarget struct {
+	// FQDD is the device id of the vendor (i.e. the Dell FQDD NIC.Integrated.1-1-1), matched by the OEM handler
+	FQDD string
+	// SoftwareID identifies the firmware of a device type (i.e. from its PCI device id), so it selects every identical device
+	SoftwareID string
+	// Name is the name of the firmware inventory entries, only meant for BMCs reporting no SoftwareId
+	Name string
+}
+
+// String returns the property of the target used to select the entries
+func (t FirmwareTarget) String() string {
+	switch {
+	case t.FQDD != "":
+		return "FQDD " + t.FQDD
+	case t.SoftwareID != "":
+		return "SoftwareId " + t.SoftwareID
+	default:
+		return "name " + t.Name
+	}
+}
+
+func (t FirmwareTarget) matches(oem OEMHandler, entry *FirmwareInventoryEntry) bool {
+	switch {
+	case t.FQDD != "":
+		return oem.MatchFirmwareTarget(entry, t.FQDD)
+	case t.SoftwareID != "":
+		return entry.SoftwareID == t.SoftwareID
+	default:
+		return entry.Name == t.Name
+	}
+}
+
+// SelectFirmwareTargets returns the installed firmware inventory entries selected by targets, in inventory order.
+// Every target must select at least one entry.
+func SelectFirmwareTargets(oem OEMHandler, inventory []*FirmwareInventoryEntry, targets []FirmwareTarget) ([]*FirmwareInventoryEntry, error) {
+	selected := make(map[*FirmwareInventoryEntry]bool)
+	for _, target := range targets {
+		if target.FQDD == "" && target.SoftwareID == "" && target.Name == "" {
+			return nil, fmt.Errorf("targets need a FQDD, a SoftwareId or a name")
+		}
+		found := false
+		for _, entry := range inventory {
+			if entry.Installed() && target.matches(oem, entry) {
+				selected[entry] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("target %s not found in the firmware inventory", target)
+		}
+	}
+	entries := []*FirmwareInventoryEntry{}
+	for _, entry := range inventory {
+		if selected[entry] {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// UpdatedFirmware compares two firmware inventory snapshots and returns the components whose
+// installed version changed, indexed by SoftwareId (or name, if the BMC does not report it).
+func UpdatedFirmware(before []*FirmwareInventoryEntry, after []*FirmwareInventoryEntry) map[string]string {
+	installedBefore := make(map[string]bool)
+	for _, entry := range before {
+		if entry.Installed() {
+			installedBefore[entry.componentKey()+"@"+entry.Version] = true
+		}
+	}
+	updated := make(map[string]string)
+	for _, entry := range after {
+		if entry.Installed() && !installedBefore[entry.componentKey()+"@"+entry.Version] {
+			updated[entry.componentKey()] = entry.Version
+		}
+	}
+	return updated
+}
+
+// FirmwareChange is a component whose installed firmware differs between two firmware inventory snapshots
+type FirmwareChange struct {
+	// Component is the SoftwareId (or name) of the component, as identified by UpdatedFirmware
+	Component string
+	Name      string
+	// PreviousVersion is empty for components not installed before (i.e. new hardware)
+	PreviousVersion string
+	NewVersion      string
+}
+
+// FirmwareChanges returns the components whose installed firmware changed from the before to the after
+// inventory snapshot, with their versions in both, sorted by name.
+func FirmwareChanges(before []*FirmwareInventoryEntry, after []*FirmwareInventoryEntry) []FirmwareChange {
+	changes := []FirmwareChange{}
+	for component, version := range UpdatedFirmware(before, after) {
+		change := FirmwareChange{
+			Component:  component,
+			NewVersion: version,
+		}
+		if installed := FindFirmware(after, component, true); len(installed) > 0 {
+			change.Name = installed[0].Name
+		}
+		if installed := FindFirmware(before, component, true); len(installed) > 0 {
+			change.PreviousVersion = installed[0].Version
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Component < changes[j].Component
+	})
+	return changes
+}
+
+// FindFirmware returns the entries of the inventory for a component, as identified by UpdatedFirmware.
+// Parameters:
+//   - component -> SoftwareId (or name) of the component.
+//   - installed -> true for the installed entries, false for the previous (rollback) entries.
+func FindFirmware(inventory []*FirmwareInventoryEntry, component string, installed bool) []*FirmwareInventoryEntry {
+	entries := []*FirmwareInventoryEntry{}
+	for _, entry := range inventory {
+		if entry.componentKey() != component {
+			continue
+		}
+		if (installed && entry.Installed()) || (!installed && entry.Previous()) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// UpdateISOStablePolls is the number of polls the installed firmware must stay unchanged, after a first change,
+// for the run of an update ISO to be considered complete
+const UpdateISOStablePolls = 5
+
+// updateISORun follows the run of a bootable update ISO from the boot progress and the firmware inventory
+// polled after the reset. The ISO updates the components one after the other, so the run is not over at the
+// first version change.
+type updateISORun struct {
+	// before are the installed firmware versions read before the reset
+	before map[string]string
+	// stablePolls is the number of unchanged polls after a first change completing the run
+	stablePolls int
+	// leftOS is set once the system reported a boot progress other than OSRunning, which means it has reset
+	leftOS  bool
+	changed bool
+	last    map[string]string
+	stable  int
+}
+
+// observe records a poll, returning true once the run is complete. The boot progress alone cannot tell:
+// many systems stop at OSBootStarted without an OS agent, while the OS of the ISO may report OSRunning
+// between two components. So the run is complete once the installed firmware has stayed unchanged for
+// stablePolls polls after a first change, and, for the systems reporting OSRunning, once they have reset.
+func (r *updateISORun) observe(progress string, versions map[string]string) bool {
+	if !sameFirmwareVersions(r.before, versions) {
+		if r.changed && sameFirmwareVersions(r.last, versions) {
+			r.stable++
+		} else {
+			r.stable = 0
+		}
+		r.changed = true
+		r.last = versions
+	}
+	if progress == "OSRunning" && !r.leftOS {
+		return false
+	}
+	if progress != "" && progress != "OSRunning" {
+		r.leftOS = true
+	}
+	return r.changed && r.stable >= r.stablePolls
+}
+
+// sameFirmwareVersions reports if two snapshots of the installed firmware versions are the same
+func sameFirmwareVersions(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, version := range a {
+		if other, ok := b[id]; !ok || other != version {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForUpdateISOCompletion waits until the run of a bootable update ISO is complete, so its media can be
+// ejected without interrupting the updates still to be applied. The run is complete when the installed
+// firmware has stayed unchanged for stablePolls polls after a first change, and the system has reset.
+// Errors while polling are ignored, as the BMC might not answer while the components are being flashed.
+// It returns as soon as ctx is done, without waiting for the next attempt.
+// Parameters:
+//   - before -> installed firmware versions read before the reset, as returned by InstalledFirmwareVersions.
+//   - stablePolls -> number of unchanged polls after a first change completing the run.
+//   - timeBetweenAttempts -> time to wait between attempts. I.e. 30 means 30 seconds.
+//   - timeout -> maximun time to wait until the update is considered failed.
+func WaitForUpdateISOCompletion(ctx context.Context, c *gofish.APIClient, systemURI string, before map[string]string, stablePolls int, timeBetweenAttempts int, timeout int) error {
+	attemptTick := time.NewTicker(time.Duration(timeBetweenAttempts) * time.Second)
+	defer attemptTick.Stop()
+	timeoutTick := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer timeoutTick.Stop()
+	run := &updateISORun{before: before, stablePolls: stablePolls}
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for the update ISO to finish: %s", ctx.Err())
+		case <-attemptTick.C:
+			progress, err := GetBootProgress(c, systemURI)
+			if err != nil {
+				fmt.Printf("[DEBUG] - Error reading the boot progress, trying again: %s\n", err)
+				continue
+			}
+			after, err := InstalledFirmwareVersions(c)
+			if err != nil {
+				fmt.Printf("[DEBUG] - Error reading the firmware inventory, trying again: %s\n", err)
+				continue
+			}
+			if run.observe(progress, after) {
+				return nil
+			}
+		case <-timeoutTick.C:
+			if run.changed {
+				return fmt.Errorf("timeout waiting for the update ISO to finish, some firmware was updated meanwhile")
+			}
+			return fmt.Errorf("timeout waiting for the update ISO to change the firmware inventory")
+		}
+	}
+}
+
+// FindFirmwareUpdateJob returns the unfinished firmware update job of tasks applying the package imageURI, nil when there is none.
+// It is used to adopt the job of a push whose apply was interrupted, instead of pushing the package again, so a job
+// is only adopted when it is known to apply this very package:
+//   - its payload references imageURI, for the BMCs reporting the request of their tasks.
+//   - one of its messages names the file of the package.
+//   - it is an iDRAC job updating exactly one of components (i.e. Firmware Update: BIOS) and one of its messages names version.
+//
+// Jobs of the same component without the version are not adopted, as they might apply another package (i.e. pushed by another workspace).
+func FindFirmwareUpdateJob(tasks []*Task, imageURI string, version string, components []string) *Task {
+	image := path.Base(imageURI)
+	if imageURI == "" || image == "." || image == "/" {
+		image = ""
+	}
+	for _, task := range tasks {
+		if task.Finished() {
+			continue
+		}
+		if task.PackageURI != "" {
+			if imageURI != "" && task.PackageURI == imageURI {
+				return task
+			}
+			continue
+		}
+		if image != "" && task.mentions(image) {
+			return task
+		}
+		if version == "" || !task.mentions(version) {
+			continue
+		}
+		if component := task.updatedComponent(); component != "" {
+			for _, target := range components {
+				if strings.EqualFold(target, component) {
+					return task
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// updatedComponent returns the component an iDRAC firmware update job updates, from its name (i.e. BIOS for Firmware Update: BIOS).
+// It is empty for the other tasks.
+func (t *Task) updatedComponent() string {
+	if t.Type != "FirmwareUpdate" {
+		return ""
+	}
+	parts := strings.SplitN(t.Name, ":", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// mentions reports if one of the messages of the task holds token as a whole word, ignoring case.
+// A version or file name that is only part of a longer one (i.e. 2.1 in 2.10) is not a mention.
+func (t *Task) mentions(token string) bool {
+	token = strings.ToLower(token)
+	for _, message := range t.Messages {
+		text := strings.ToLower(message.Message)
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], token)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(token)
+			// A dot right after the token ends the sentence, not the token
+			if end < len(text) && text[end] == '.' && (end+1 == len(text) || !isTokenByte(text[end+1])) {
+				end++
+			}
+			if (start == 0 || !isTokenByte(text[start-1])) && (end == len(text) || !isTokenByte(text[end])) {
+				return true
+			}
+			offset = start + 1
+		}
+	}
+	return false
+}
+
+// isTokenByte reports if b can be part of a file name or version
+func isTokenByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '.' || b == '_' || b == '-'
+}
@@ -0,0 +1,320 @@
+package common
+
+import (
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/mock"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUpdatedFirmware(t *testing.T) {
+	before := []*FirmwareInventoryEntry{
+		{ID: "Installed-159-2.7.7", Name: "BIOS", Version: "2.7.7", SoftwareID: "159"},
+		{ID: "Installed-25227-4.40.00.00", Name: "iDRAC", Version: "4.40.00.00", SoftwareID: "25227"},
+		{ID: "Previous-159-2.6.4", Name: "BIOS", Version: "2.6.4", SoftwareID: "159"},
+	}
+	after := []*FirmwareInventoryEntry{
+		{ID: "Installed-159-2.8.2", Name: "BIOS", Version: "2.8.2", SoftwareID: "159"},
+		{ID: "Installed-25227-4.40.00.00", Name: "iDRAC", Version: "4.40.00.00", SoftwareID: "25227"},
+		{ID: "Installed-0-1.0", Name: "NoSoftwareId", Version: "1.0"},
+		{ID: "Previous-159-2.7.7", Name: "BIOS", Version: "2.7.7", SoftwareID: "159"},
+	}
+	updated := UpdatedFirmware(before, after)
+	expected := map[string]string{"159": "2.8.2", "NoSoftwareId": "1.0"}
+	if len(updated) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, updated)
+	}
+	for component, version := range expected {
+		if updated[component] != version {
+			t.Errorf("Component %v: expected %v, got %v", component, version, updated[component])
+		}
+	}
+
+	changes := FirmwareChanges(before, after)
+	expectedChanges := []FirmwareChange{
+		{Component: "159", Name: "BIOS", PreviousVersion: "2.7.7", NewVersion: "2.8.2"},
+		{Component: "NoSoftwareId", Name: "NoSoftwareId", NewVersion: "1.0"},
+	}
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("Expected changes %v, got %v", expectedChanges, changes)
+	}
+
+	cases := []struct {
+		noTest    int
+		component string
+		installed bool
+		expected  string
+	}{
+		{1, "159", true, "2.8.2"},
+		{2, "159", false, "2.7.7"},
+		{3, "25227", false, ""},
+	}
+	for _, v := range cases {
+		entries := FindFirmware(after, v.component, v.installed)
+		if v.expected == "" {
+			if len(entries) != 0 {
+				t.Errorf("Test number %v: expected no entries, got %v", v.noTest, entries)
+			}
+			continue
+		}
+		if len(entries) != 1 || entries[0].Version != v.expected {
+			t.Errorf("Test number %v: expected version %v, got %v", v.noTest, v.expected, entries)
+		}
+	}
+}
+
+func TestSelectFirmwareTargets(t *testing.T) {
+	inventory := []*FirmwareInventoryEntry{
+		{ID: "Installed-101548-22.00.6__NIC.Integrated.1-1-1", Name: "Broadcom Gigabit Ethernet BCM5720", SoftwareID: "101548"},
+		{ID: "Installed-101548-22.00.6__NIC.Integrated.1-2-1", Name: "Broadcom Gigabit Ethernet BCM5720", SoftwareID: "101548"},
+		{ID: "Previous-101548-21.80.9__NIC.Integrated.1-2-1", Name: "Broadcom Gigabit Ethernet BCM5720", SoftwareID: "101548"},
+		{ID: "Installed-159-2.8.2", Name: "BIOS", SoftwareID: "159"},
+	}
+	cases := []struct {
+		noTest     int
+		targets    []FirmwareTarget
+		expected   []string
+		shouldPass bool
+	}{
+		{1, []FirmwareTarget{{SoftwareID: "101548"}}, []string{"Installed-101548-22.00.6__NIC.Integrated.1-1-1", "Installed-101548-22.00.6__NIC.Integrated.1-2-1"}, true},
+		// The FQDD takes precedence over the other properties
+		{2, []FirmwareTarget{{FQDD: "NIC.Integrated.1-2-1", SoftwareID: "159"}}, []string{"Installed-101548-22.00.6__NIC.Integrated.1-2-1"}, true},
+		{3, []FirmwareTarget{{Name: "BIOS"}, {FQDD: "NIC.Integrated.1-1-1"}}, []string{"Installed-101548-22.00.6__NIC.Integrated.1-1-1", "Installed-159-2.8.2"}, true},
+		{4, []FirmwareTarget{{SoftwareID: "999999", Name: "BIOS"}}, nil, false},
+		{5, []FirmwareTarget{{}}, nil, false},
+	}
+	for _, v := range cases {
+		entries, err := SelectFirmwareTargets(dellOEM{}, inventory, v.targets)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		ids := []string{}
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		if !reflect.DeepEqual(ids, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, ids)
+		}
+	}
+}
+
+func TestResolveFirmwareTargets(t *testing.T) {
+	inventory := []*FirmwareInventoryEntry{
+		{ODataID: "/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.00.6__NIC.Integrated.1-1-1", ID: "Installed-101548-22.00.6__NIC.Integrated.1-1-1"},
+		{ODataID: "/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.00.6__NIC.Integrated.1-2-1", ID: "Installed-101548-22.00.6__NIC.Integrated.1-2-1"},
+		{ODataID: "/redfish/v1/UpdateService/FirmwareInventory/Previous-101548-21.80.9__NIC.Integrated.1-2-1", ID: "Previous-101548-21.80.9__NIC.Integrated.1-2-1"},
+	}
+	cases := []struct {
+		noTest     int
+		targets    []string
+		expected   []string
+		shouldPass bool
+	}{
+		{1, []string{"NIC.Integrated.1-2-1"}, []string{"Installed-101548-22.00.6__NIC.Integrated.1-2-1"}, true},
+		{2, []string{"/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.00.6__NIC.Integrated.1-1-1"}, []string{"Installed-101548-22.00.6__NIC.Integrated.1-1-1"}, true},
+		{3, []string{"NIC.Integrated.1-3-1"}, nil, false},
+	}
+	for _, v := range cases {
+		entries, err := ResolveFirmwareTargets(dellOEM{}, inventory, v.targets)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if len(entries) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, entries)
+			continue
+		}
+		for i, id := range v.expected {
+			if entries[i].ID != id {
+				t.Errorf("Test number %v: expected %v, got %v", v.noTest, id, entries[i].ID)
+			}
+		}
+	}
+}
+
+func TestGetFirmwareInventory(t *testing.T) {
+	const inventoryURI = "/redfish/v1/UpdateService/FirmwareInventory"
+	cases := []struct {
+		noTest int
+		vendor string
+		expand bool
+	}{
+		{1, mock.DellVendor, true},
+		{2, mock.DellVendor, false},
+		{3, mock.HPEVendor, false},
+	}
+	for _, v := range cases {
+		server, err := mock.NewServer(v.vendor)
+		if err != nil {
+			t.Fatalf("error starting the server %s", err)
+		}
+		// More entries than workers, so some requests wait for a free worker
+		collection := server.Resource(inventoryURI)
+		members := []interface{}{}
+		for i := 0; i < 3*firmwareInventoryWorkers; i++ {
+			uri := fmt.Sprintf("%s/Installed-%d-1.0", inventoryURI, i)
+			server.SetResource(uri, map[string]interface{}{"@odata.id": uri, "Id": fmt.Sprintf("Installed-%d-1.0", i), "Version": "1.0"})
+			members = append(members, map[string]interface{}{"@odata.id": uri})
+		}
+		collection["Members"] = members
+		collection["Members@odata.count"] = len(members)
+		server.SetResource(inventoryURI, collection)
+
+		c, err := gofish.Connect(gofish.ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+		if err != nil {
+			t.Fatalf("Test number %v: error connecting to the server %s", v.noTest, err)
+		}
+		c.Service.ProtocolFeaturesSupported.ExpandQuery.NoLinks = v.expand
+		inventory, err := GetFirmwareInventory(c)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		} else if len(inventory) != len(members) {
+			t.Errorf("Test number %v returned %d entries, expected %d", v.noTest, len(inventory), len(members))
+		} else {
+			for i, entry := range inventory {
+				if entry.ID != fmt.Sprintf("Installed-%d-1.0", i) {
+					t.Errorf("Test number %v returned %s at position %d", v.noTest, entry.ID, i)
+				}
+			}
+		}
+		server.Close()
+	}
+}
+
+func TestGetExpandedFirmwareInventory(t *testing.T) {
+	const inventoryURI = "/redfish/v1/UpdateService/FirmwareInventory"
+	// The second member is not expanded, so it is fetched on its own
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet],
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`{"Members":[{"@odata.id":"` + inventoryURI + `/1","Id":"1","Version":"2.7.7"},` +
+				`{"@odata.id":"` + inventoryURI + `/2"},{"@odata.id":"` + inventoryURI + `/3","Id":"3","Version":"1.0"}]}`)),
+		},
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"@odata.id":"` + inventoryURI + `/2","Id":"2","Version":"4.40.00.00"}`)),
+		},
+	)
+	inventory, err := getExpandedFirmwareInventory(testClient, inventoryURI)
+	if err != nil {
+		t.Fatalf("Error expanding the firmware inventory: %s", err)
+	}
+	if len(inventory) != 3 || inventory[0].ID != "1" || inventory[1].Version != "4.40.00.00" || inventory[2].ID != "3" {
+		t.Errorf("Unexpected inventory %v", inventory)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[0].URL != inventoryURI+"?$expand=.($levels=1)" || calls[1].URL != inventoryURI+"/2" {
+		t.Errorf("Unexpected requests %v", calls)
+	}
+}
+
+func TestFindFirmwareUpdateJob(t *testing.T) {
+	tasks := []*Task{
+		{ODataID: "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1", Name: "Firmware Update: BIOS", State: "Completed", Type: "FirmwareUpdate",
+			Messages: []TaskMessage{{Message: "Package BIOS_2.10.2.EXE applied."}}},
+		{ODataID: "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_2", Name: "Configure: BIOS.Setup.1-1", State: "Scheduled", Type: "BIOSConfiguration"},
+		{ODataID: "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_3", Name: "Firmware Update: BIOS", State: "Downloading", Type: "FirmwareUpdate",
+			Messages: []TaskMessage{{Message: "Downloading version 2.10.2."}}},
+		{ODataID: "/redfish/v1/TaskService/Tasks/4", Name: "Update Task", State: "Running",
+			Messages: []TaskMessage{{Message: "Downloading NIC_Broadcom_22.31.6.EXE"}}},
+		{ODataID: "/redfish/v1/TaskService/Tasks/5", Name: "Update Task", State: "Running", PackageURI: "http://repo/ilo5_278.bin"},
+		{ODataID: "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_6", Name: "Firmware Update: Integrated Dell Remote Access Controller", State: "Scheduled", Type: "FirmwareUpdate"},
+	}
+	cases := []struct {
+		noTest     int
+		imageURI   string
+		version    string
+		components []string
+		expected   string
+	}{
+		// Same component and version
+		{1, "http://repo/BIOS_2.10.2.EXE", "2.10.2", []string{"BIOS"}, "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_3"},
+		// Same file name
+		{2, "http://repo/firmware/nic_broadcom_22.31.6.exe", "", nil, "/redfish/v1/TaskService/Tasks/4"},
+		// Same component without any version
+		{3, "http://repo/iDRAC_5.10.EXE", "", []string{"Integrated Dell Remote Access Controller"}, ""},
+		{4, "", "", []string{""}, ""},
+		// Same component, other version
+		{5, "http://repo/BIOS_2.1.EXE", "2.1", []string{"BIOS"}, ""},
+		// Component only part of the one of the job
+		{6, "http://repo/BIOS_2.10.2.EXE", "2.10.2", []string{"BIOS Setup"}, ""},
+		// Package reference of the payload
+		{7, "http://repo/ilo5_278.bin", "", nil, "/redfish/v1/TaskService/Tasks/5"},
+		{8, "http://mirror/ilo5_278.bin", "", nil, ""},
+		// The name of a TaskService task is not enough
+		{9, "http://repo/update.bin", "", []string{"Update Task"}, ""},
+	}
+	for _, v := range cases {
+		job := FindFirmwareUpdateJob(tasks, v.imageURI, v.version, v.components)
+		if v.expected == "" {
+			if job != nil {
+				t.Errorf("Test number %v: expected no job, got %v", v.noTest, job.ODataID)
+			}
+			continue
+		}
+		if job == nil || job.ODataID != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, job)
+		}
+	}
+}
+
+func TestUpdateISORun(t *testing.T) {
+	before := map[string]string{"Installed-BIOS": "2.1.0", "Installed-NIC": "22.00.6"}
+	bios := map[string]string{"Installed-BIOS": "2.2.0", "Installed-NIC": "22.00.6"}
+	all := map[string]string{"Installed-BIOS": "2.2.0", "Installed-NIC": "22.31.6"}
+	type poll struct {
+		progress string
+		versions map[string]string
+	}
+	cases := []struct {
+		noTest   int
+		polls    []poll
+		expected int
+	}{
+		// Complete once back to the OS and the inventory is stable, not at the first change
+		{1, []poll{{"OSRunning", before}, {"OSBootStarted", before}, {"OSBootStarted", bios}, {"MemoryInitializationStarted", bios}, {"OSBootStarted", all}, {"OSRunning", all}, {"OSRunning", all}}, 7},
+		// The OS of the ISO reporting OSRunning between two components
+		{2, []poll{{"OSBootStarted", before}, {"OSRunning", bios}, {"OSRunning", all}, {"OSRunning", all}, {"OSRunning", all}}, 5},
+		// Systems stopping at OSBootStarted without an OS agent
+		{3, []poll{{"OSRunning", before}, {"OSBootStarted", before}, {"OSBootStarted", bios}, {"OSBootStarted", all}, {"OSBootStarted", all}, {"OSBootStarted", all}}, 6},
+		// Without boot progress
+		{4, []poll{{"", before}, {"", bios}, {"", bios}, {"", all}, {"", all}, {"", all}}, 6},
+		// Never complete without any change
+		{5, []poll{{"OSBootStarted", before}, {"OSRunning", before}, {"OSRunning", before}, {"OSRunning", before}}, 0},
+		// Never complete while the system has not reset
+		{6, []poll{{"OSRunning", bios}, {"OSRunning", all}, {"OSRunning", all}, {"OSRunning", all}}, 0},
+	}
+	for _, v := range cases {
+		run := &updateISORun{before: before, stablePolls: 2}
+		completed := 0
+		for i, p := range v.polls {
+			if run.observe(p.progress, p.versions) {
+				completed = i + 1
+				break
+			}
+		}
+		if completed != v.expected {
+			t.Errorf("Test number %v: expected completion at poll %v, got %v", v.noTest, v.expected, completed)
+		}
+	}
+}
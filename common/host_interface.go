@@ -0,0 +1,86 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// HostInterface is the in-band interface (i.e. KCS or USB NIC) the host OS uses to talk to the BMC.
+// gofish does not expose CredentialBootstrapping, so it is decoded here.
+type HostInterface struct {
+	ODataID           string `json:"@odata.id"`
+	ID                string `json:"Id"`
+	Name              string
+	HostInterfaceType string
+	InterfaceEnabled  bool
+	// CredentialBootstrapping is nil when the service does not support it
+	CredentialBootstrapping *CredentialBootstrapping
+}
+
+// CredentialBootstrapping lets the host OS create its own BMC account through the host interface
+type CredentialBootstrapping struct {
+	Enabled          bool
+	EnableAfterReset bool
+	RoleID           string `json:"RoleId"`
+}
+
+// GetHostInterfaces returns the host interfaces of a manager.
+// Managers without a HostInterfaces collection return an empty list.
+func GetHostInterfaces(c redfishcommon.Client, managerURI string) ([]*HostInterface, error) {
+	resp, err := c.Get(managerURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var manager struct {
+		HostInterfaces redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&manager); err != nil {
+		return nil, err
+	}
+	hostInterfaces := []*HostInterface{}
+	if len(manager.HostInterfaces) == 0 {
+		return hostInterfaces, nil
+	}
+	links, err := redfishcommon.GetCollection(c, string(manager.HostInterfaces))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links.ItemLinks {
+		hostInterface, err := GetHostInterface(c, link)
+		if err != nil {
+			return nil, err
+		}
+		hostInterfaces = append(hostInterfaces, hostInterface)
+	}
+	return hostInterfaces, nil
+}
+
+// GetHostInterface retrieves a host interface
+func GetHostInterface(c redfishcommon.Client, uri string) (*HostInterface, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var hostInterface HostInterface
+	if err = json.NewDecoder(resp.Body).Decode(&hostInterface); err != nil {
+		return nil, err
+	}
+	return &hostInterface, nil
+}
+
+// PatchHostInterface updates the given properties of a host interface
+func PatchHostInterface(c redfishcommon.Client, uri string, payload map[string]interface{}) error {
+	resp, err := c.Patch(uri, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error updating host interface %s, status code was %d", uri, resp.StatusCode)
+	}
+	return nil
+}
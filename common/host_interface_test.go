@@ -0,0 +1,31 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHostInterfaceCredentialBootstrapping(t *testing.T) {
+	cases := []struct {
+		noTest    int
+		body      string
+		supported bool
+		expected  CredentialBootstrapping
+	}{
+		{1, `{"Id":"Host.1","InterfaceEnabled":true,"CredentialBootstrapping":{"Enabled":true,"EnableAfterReset":false,"RoleId":"Operator"}}`, true, CredentialBootstrapping{Enabled: true, RoleID: "Operator"}},
+		{2, `{"Id":"Host.1","InterfaceEnabled":false}`, false, CredentialBootstrapping{}},
+	}
+	for _, v := range cases {
+		var hostInterface HostInterface
+		if err := json.Unmarshal([]byte(v.body), &hostInterface); err != nil {
+			t.Fatalf("Test number %v failed %v", v.noTest, err)
+		}
+		if (hostInterface.CredentialBootstrapping != nil) != v.supported {
+			t.Errorf("Test number %v: expected supported=%v", v.noTest, v.supported)
+			continue
+		}
+		if v.supported && *hostInterface.CredentialBootstrapping != v.expected {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, *hostInterface.CredentialBootstrapping)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// hpeUpdateTaskQueueURI is the iLO installation queue, holding the updates scheduled by the iLO repository
+const hpeUpdateTaskQueueURI string = "/redfish/v1/UpdateService/UpdateTaskQueue"
+
+// hpeUpdateTaskStates maps the states of the iLO installation queue to the TaskService ones
+var hpeUpdateTaskStates = map[string]string{
+	"Pending":    "Pending",
+	"InProgress": "Running",
+	"Complete":   "Completed",
+	"Exception":  "Exception",
+	"Expired":    "Cancelled",
+	"Canceled":   "Cancelled",
+}
+
+// GetHpeUpdateTasks retrieves the tasks of the iLO installation queue as Tasks, so they are handled like the TaskService ones.
+// iLOs without an installation queue (i.e. iLO 4) have no tasks.
+func GetHpeUpdateTasks(c redfishcommon.Client) ([]*Task, error) {
+	collection, err := redfishcommon.GetCollection(c, hpeUpdateTaskQueueURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return []*Task{}, nil
+		}
+		return nil, err
+	}
+	tasks := []*Task{}
+	for _, link := range collection.ItemLinks {
+		task, err := getHpeUpdateTask(c, link)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// getHpeUpdateTask retrieves a task of the iLO installation queue
+func getHpeUpdateTask(c redfishcommon.Client, uri string) (*Task, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, WrapHTTPError(err)
+	}
+	defer resp.Body.Close()
+	var raw struct {
+		ID       string `json:"Id"`
+		Name     string
+		Filename string
+		State    string
+		Result   struct {
+			MessageID string `json:"MessageId"`
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	task := &Task{
+		ODataID:  uri,
+		ID:       raw.ID,
+		Name:     raw.Name,
+		State:    hpeUpdateTaskStates[raw.State],
+		Messages: []TaskMessage{},
+	}
+	if task.State == "" {
+		task.State = raw.State
+	}
+	// The file is reported as a message, so the task can be matched with the package it installs
+	if raw.Filename != "" {
+		task.Messages = append(task.Messages, TaskMessage{Message: "Installing " + raw.Filename})
+	}
+	if raw.Result.MessageID != "" {
+		task.Messages = append(task.Messages, TaskMessage{MessageID: raw.Result.MessageID})
+	}
+	return task, nil
+}
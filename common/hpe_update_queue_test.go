@@ -0,0 +1,41 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetHpeUpdateTasks(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	for _, body := range []string{
+		`{"Members":[{"@odata.id":"/redfish/v1/UpdateService/UpdateTaskQueue/1"},{"@odata.id":"/redfish/v1/UpdateService/UpdateTaskQueue/2"}],"Members@odata.count":2}`,
+		`{"Id":"1","Name":"Update System ROM","Filename":"U32_2.68_07_14_2022.fwpkg","State":"Complete","Result":{"MessageId":"Success"}}`,
+		`{"Id":"2","Name":"Update iLO 5","Filename":"ilo5_278.fwpkg","State":"InProgress"}`,
+	} {
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		})
+	}
+	tasks, err := GetHpeUpdateTasks(testClient)
+	if err != nil {
+		t.Fatalf("Error getting the installation queue: %s", err)
+	}
+	expected := []*Task{
+		{ODataID: "/redfish/v1/UpdateService/UpdateTaskQueue/1", ID: "1", Name: "Update System ROM", State: "Completed",
+			Messages: []TaskMessage{{Message: "Installing U32_2.68_07_14_2022.fwpkg"}, {MessageID: "Success"}}},
+		{ODataID: "/redfish/v1/UpdateService/UpdateTaskQueue/2", ID: "2", Name: "Update iLO 5", State: "Running",
+			Messages: []TaskMessage{{Message: "Installing ilo5_278.fwpkg"}}},
+	}
+	if !reflect.DeepEqual(tasks, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, tasks)
+	}
+	if tasks[0].Finished() != true || tasks[1].Finished() != false {
+		t.Errorf("Expected only the first task to be finished")
+	}
+}
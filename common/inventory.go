@@ -0,0 +1,229 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+)
+
+// Inventory categories of the components
+const (
+	InventoryProcessor         string = "Processor"
+	InventoryMemory            string = "Memory"
+	InventoryStorageController string = "StorageController"
+	InventoryDrive             string = "Drive"
+	InventoryNetworkAdapter    string = "NetworkAdapter"
+	InventoryFirmware          string = "Firmware"
+)
+
+// Inventory is the hardware and firmware inventory of a server, normalized so it can be fed to a CMDB.
+type Inventory struct {
+	System     InventorySystem      `json:"system"`
+	Components []InventoryComponent `json:"components"`
+}
+
+// InventorySystem describes the server itself
+type InventorySystem struct {
+	ID             string  `json:"id"`
+	Manufacturer   string  `json:"manufacturer"`
+	Model          string  `json:"model"`
+	SerialNumber   string  `json:"serial_number"`
+	SKU            string  `json:"sku"`
+	AssetTag       string  `json:"asset_tag"`
+	UUID           string  `json:"uuid"`
+	HostName       string  `json:"host_name"`
+	BIOSVersion    string  `json:"bios_version"`
+	PowerState     string  `json:"power_state"`
+	Health         string  `json:"health"`
+	ProcessorCount int     `json:"processor_count"`
+	ProcessorModel string  `json:"processor_model"`
+	MemoryGiB      float32 `json:"memory_gib"`
+}
+
+// InventoryComponent is a hardware component or firmware of the server.
+// Capacity is in MiB for memory and bytes for drives, and 0 for the rest of the categories.
+type InventoryComponent struct {
+	Category     string `json:"category"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	SerialNumber string `json:"serial_number"`
+	PartNumber   string `json:"part_number"`
+	Version      string `json:"version"`
+	Capacity     int64  `json:"capacity"`
+	Health       string `json:"health"`
+}
+
+// GetInventory collects the hardware and firmware inventory of the computer system systemID (the first one when empty).
+func GetInventory(c *gofish.APIClient, systemID string) (*Inventory, error) {
+	system, err := GetSystem(c, systemID)
+	if err != nil {
+		return nil, err
+	}
+	inventory := &Inventory{
+		System: InventorySystem{
+			ID:             system.ID,
+			Manufacturer:   system.Manufacturer,
+			Model:          system.Model,
+			SerialNumber:   system.SerialNumber,
+			SKU:            system.SKU,
+			AssetTag:       system.AssetTag,
+			UUID:           system.UUID,
+			HostName:       system.HostName,
+			BIOSVersion:    system.BIOSVersion,
+			PowerState:     string(system.PowerState),
+			Health:         string(system.Status.Health),
+			ProcessorCount: system.ProcessorSummary.Count,
+			ProcessorModel: system.ProcessorSummary.Model,
+			MemoryGiB:      system.MemorySummary.TotalSystemMemoryGiB,
+		},
+		Components: []InventoryComponent{},
+	}
+
+	processors, err := system.Processors()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching processors: %s", err)
+	}
+	for _, p := range processors {
+		inventory.Components = append(inventory.Components, InventoryComponent{
+			Category:     InventoryProcessor,
+			ID:           p.ID,
+			Name:         p.Name,
+			Manufacturer: p.Manufacturer,
+			Model:        p.Model,
+			Health:       string(p.Status.Health),
+		})
+	}
+
+	memory, err := system.Memory()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching memory: %s", err)
+	}
+	for _, m := range memory {
+		inventory.Components = append(inventory.Components, InventoryComponent{
+			Category:     InventoryMemory,
+			ID:           m.ID,
+			Name:         m.Name,
+			Manufacturer: m.Manufacturer,
+			SerialNumber: m.SerialNumber,
+			PartNumber:   m.PartNumber,
+			Capacity:     int64(m.CapacityMiB),
+			Health:       string(m.Status.Health),
+		})
+	}
+
+	storage, err := system.Storage()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching storage: %s", err)
+	}
+	for _, s := range storage {
+		for _, controller := range s.StorageControllers {
+			inventory.Components = append(inventory.Components, InventoryComponent{
+				Category:     InventoryStorageController,
+				ID:           s.ID,
+				Name:         controller.Name,
+				Manufacturer: controller.Manufacturer,
+				Model:        controller.Model,
+				SerialNumber: controller.SerialNumber,
+				PartNumber:   controller.PartNumber,
+				Version:      controller.FirmwareVersion,
+				Health:       string(controller.Status.Health),
+			})
+		}
+		drives, err := s.Drives()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching drives of %s: %s", s.ID, err)
+		}
+		for _, drive := range drives {
+			inventory.Components = append(inventory.Components, InventoryComponent{
+				Category:     InventoryDrive,
+				ID:           drive.ID,
+				Name:         drive.Name,
+				Manufacturer: drive.Manufacturer,
+				Model:        drive.Model,
+				SerialNumber: drive.SerialNumber,
+				PartNumber:   drive.PartNumber,
+				Version:      drive.Revision,
+				Capacity:     drive.CapacityBytes,
+				Health:       string(drive.Status.Health),
+			})
+		}
+	}
+
+	chassis, err := c.Service.Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching chassis: %s", err)
+	}
+	for _, ch := range chassis {
+		adapters, err := ch.NetworkAdapters()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching network adapters of %s: %s", ch.ID, err)
+		}
+		for _, adapter := range adapters {
+			version := ""
+			if len(adapter.Controllers) > 0 {
+				version = adapter.Controllers[0].FirmwarePackageVersion
+			}
+			inventory.Components = append(inventory.Components, InventoryComponent{
+				Category:     InventoryNetworkAdapter,
+				ID:           adapter.ID,
+				Name:         adapter.Name,
+				Manufacturer: adapter.Manufacturer,
+				Model:        adapter.Model,
+				SerialNumber: adapter.SerialNumber,
+				PartNumber:   adapter.PartNumber,
+				Version:      version,
+				Health:       string(adapter.Status.Health),
+			})
+		}
+	}
+
+	firmware, err := GetFirmwareInventory(c)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching firmware inventory: %s", err)
+	}
+	for _, entry := range firmware {
+		if !entry.Installed() {
+			continue
+		}
+		inventory.Components = append(inventory.Components, InventoryComponent{
+			Category: InventoryFirmware,
+			ID:       entry.ID,
+			Name:     entry.Name,
+			Version:  entry.Version,
+			Health:   string(entry.Status.Health),
+		})
+	}
+
+	return inventory, nil
+}
+
+// CSV renders the components of the inventory as CSV, one row per component.
+// The system serial number is repeated on every row, so the rows of several servers can be concatenated.
+func (inv *Inventory) CSV() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"system_serial_number", "system_model", "category", "id", "name", "manufacturer", "model", "serial_number", "part_number", "version", "capacity", "health"}}
+	for _, c := range inv.Components {
+		rows = append(rows, []string{
+			inv.System.SerialNumber,
+			inv.System.Model,
+			c.Category,
+			c.ID,
+			c.Name,
+			c.Manufacturer,
+			c.Model,
+			c.SerialNumber,
+			c.PartNumber,
+			c.Version,
+			fmt.Sprintf("%d", c.Capacity),
+			c.Health,
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
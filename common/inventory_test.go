@@ -0,0 +1,38 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInventoryCSV(t *testing.T) {
+	inventory := &Inventory{
+		System: InventorySystem{SerialNumber: "ABC1234", Model: "PowerEdge R740"},
+		Components: []InventoryComponent{
+			{Category: InventoryMemory, ID: "DIMM.Socket.A1", Name: "DIMM A1", Capacity: 16384, Health: "OK"},
+			{Category: InventoryFirmware, ID: "Installed-159-2.8.2", Name: "BIOS, \"production\"", Version: "2.8.2"},
+		},
+	}
+	out, err := inventory.CSV()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	cases := []struct {
+		noTest   int
+		line     int
+		expected string
+	}{
+		{1, 0, "system_serial_number,system_model,category,id,name,manufacturer,model,serial_number,part_number,version,capacity,health"},
+		{2, 1, "ABC1234,PowerEdge R740,Memory,DIMM.Socket.A1,DIMM A1,,,,,,16384,OK"},
+		{3, 2, `ABC1234,PowerEdge R740,Firmware,Installed-159-2.8.2,"BIOS, ""production""",,,,,2.8.2,0,`},
+	}
+	if len(lines) != len(cases) {
+		t.Fatalf("Expected %v lines, got %v", len(cases), len(lines))
+	}
+	for _, v := range cases {
+		if lines[v.line] != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, lines[v.line])
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -12,33 +17,137 @@ const (
 	TimeBetweenAttempts int = 10
 	// Timeout will be used to consider a job task failed (variable is in seconds)
 	Timeout int = 300
+	// dellJobsURI is the job queue of the iDRAC
+	dellJobsURI string = "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs"
 )
 
+// Dell job types that can be checked against the job queue
+const (
+	BiosConfigurationJobType   string = "BIOSConfiguration"
+	RAIDConfigurationJobType   string = "RAIDConfiguration"
+	ImportConfigurationJobType string = "ImportConfiguration"
+)
+
+// DellJob is a job from the iDRAC job queue
+type DellJob struct {
+	ODataID         string `json:"@odata.id"`
+	ID              string `json:"Id"`
+	Name            string
+	JobState        string
+	JobType         string
+	Message         string
+	PercentComplete int
+	StartTime       string
+	CompletionTime  string
+}
+
+// Pending reports if the job has not reached a final state yet
+func (j *DellJob) Pending() bool {
+	switch j.JobState {
+	case "Completed", "CompletedWithErrors", "Failed":
+		return false
+	}
+	return true
+}
+
+// Task returns the job as a Task, so it is handled like the TaskService tasks
+func (j *DellJob) Task() *Task {
+	task := &Task{
+		ODataID:         j.ODataID,
+		ID:              j.ID,
+		Name:            j.Name,
+		Type:            j.JobType,
+		State:           j.JobState,
+		PercentComplete: j.PercentComplete,
+		StartTime:       j.StartTime,
+		EndTime:         j.CompletionTime,
+		Messages:        []TaskMessage{},
+	}
+	if j.Message != "" {
+		task.Messages = append(task.Messages, TaskMessage{Message: j.Message})
+	}
+	return task
+}
+
+// Running reports if the job is being executed, so it cannot be deleted
+func (j *DellJob) Running() bool {
+	return j.JobState == "Running"
+}
+
+// JobProgress is the progress of a job, as reported by the BMC
+type JobProgress struct {
+	State           string
+	PercentComplete int
+	// Message is the last message of the job, which holds the reason of the failure of failed jobs
+	Message string
+}
+
+// JobProgressFunc receives the progress of a job every time it is polled
+type JobProgressFunc func(progress JobProgress)
+
+// GetJobProgress retrieves the progress of a redfish task.
+// gofish does not decode the messages of the tasks, so the task is decoded here.
+func GetJobProgress(c redfishcommon.Client, jobURI string) (*JobProgress, error) {
+	resp, err := c.Get(jobURI)
+	if err != nil {
+		return nil, WrapHTTPError(err)
+	}
+	defer resp.Body.Close()
+	var task struct {
+		TaskState       string
+		PercentComplete int
+		Messages        []struct {
+			Message string
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, err
+	}
+	progress := &JobProgress{State: task.TaskState, PercentComplete: task.PercentComplete}
+	if len(task.Messages) > 0 {
+		progress.Message = task.Messages[len(task.Messages)-1].Message
+	}
+	return progress, nil
+}
+
 // WaitForJobToFinish waits for a redfish job to finish.
+// It returns as soon as ctx is done, without waiting for the next attempt.
 // Parameters:
 // 	- jobURI -> URI for the job to check.
 // 	- timeBetweenAttempts -> time to wait between attempts. I.e. 30 means 30 seconds.
 //	- timeout -> maximun time to wait until job is considered failed.
-func WaitForJobToFinish(c *gofish.APIClient, jobURI string, timeBetweenAttempts int, timeout int) error {
+func WaitForJobToFinish(ctx context.Context, c *gofish.APIClient, jobURI string, timeBetweenAttempts int, timeout int) error {
+	return WaitForJobToFinishWithProgress(ctx, c, jobURI, timeBetweenAttempts, timeout, nil)
+}
+
+// WaitForJobToFinishWithProgress waits for a redfish job to finish like WaitForJobToFinish,
+// passing the progress of the job to report after every attempt. report can be nil.
+// Failed jobs return a *TaskFailedError with the messages of the job.
+func WaitForJobToFinishWithProgress(ctx context.Context, c *gofish.APIClient, jobURI string, timeBetweenAttempts int, timeout int, report JobProgressFunc) error {
 	// Create tickers
 	attemptTick := time.NewTicker(time.Duration(timeBetweenAttempts) * time.Second)
+	defer attemptTick.Stop()
 	timeoutTick := time.NewTicker(time.Duration(timeout) * time.Second)
+	defer timeoutTick.Stop()
 	for {
 		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for the job to finish: %s", ctx.Err())
 		case <-attemptTick.C:
-			job, err := redfish.GetTask(c, jobURI)
+			job, err := GetJobProgress(c, jobURI)
 			if err != nil {
 				return err
 			}
-			fmt.Printf("[DEBUG] - Attempting one more time... Job state is %s\n", job.TaskState)
+			fmt.Printf("[DEBUG] - Attempting one more time... Job state is %s\n", job.State)
+			if report != nil {
+				report(*job)
+			}
 			//Check if job has finished
-			switch status := job.TaskState; status {
+			switch status := redfish.TaskState(job.State); status {
 			case redfish.CompletedTaskState:
 				return nil
-			case redfish.KilledTaskState:
-				return fmt.Errorf("the job has finished unsucessfully with a %s state", job.TaskState)
-			case redfish.ExceptionTaskState:
-				return fmt.Errorf("the job has finished unsucessfully with a %s state", job.TaskState)
+			case redfish.KilledTaskState, redfish.ExceptionTaskState:
+				return taskFailed(c, jobURI, job)
 			}
 		case <-timeoutTick.C:
 			fmt.Printf("[DEBUG] - Error. Timeout reached\n")
@@ -47,13 +156,29 @@ func WaitForJobToFinish(c *gofish.APIClient, jobURI string, timeBetweenAttempts
 	}
 }
 
+// taskFailed returns the error of a failed job, with every message of the task.
+// If the task cannot be read again, the last message seen while polling is used.
+func taskFailed(c redfishcommon.Client, jobURI string, job *JobProgress) error {
+	failed := &TaskFailedError{TaskURI: jobURI, State: job.State, Messages: []string{}}
+	if task, err := GetTask(c, jobURI); err == nil {
+		for _, message := range task.Messages {
+			if len(message.Message) > 0 {
+				failed.Messages = append(failed.Messages, message.Message)
+			}
+		}
+	}
+	if len(failed.Messages) == 0 && len(job.Message) > 0 {
+		failed.Messages = append(failed.Messages, job.Message)
+	}
+	return failed
+}
+
 // DeleteDellJob is intended to delete a task schedules in a Dell system.
 // This function is only a workaround until HTTP DELETE is supported under each task o taskmonitor
 //		Parameters:
 //		- taskID: Id of the tasks to delete
 func DeleteDellJob(c *gofish.APIClient, taskID string) error {
-	url := "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/"
-	resp, err := c.Delete(fmt.Sprintf("%s%s", url, taskID))
+	resp, err := c.Delete(fmt.Sprintf("%s/%s", dellJobsURI, taskID))
 	if err != nil {
 		return err
 	}
@@ -63,3 +188,91 @@ func DeleteDellJob(c *gofish.APIClient, taskID string) error {
 	}
 	return nil
 }
+
+// CreateDellConfigJob schedules a configuration job in the iDRAC job queue to apply the pending
+// settings of a resource (i.e. /redfish/v1/Systems/System.Embedded.1/Bios/Settings) on the next reboot.
+// Returns the URI of the job. If the BMC does not implement the Dell job queue, an empty URI is returned,
+// as the settings are expected to be applied on the next reboot without a job.
+func CreateDellConfigJob(c redfishcommon.Client, targetSettingsURI string) (string, error) {
+	payload := map[string]interface{}{
+		"TargetSettingsURI": targetSettingsURI,
+	}
+	resp, err := c.Post(dellJobsURI, payload)
+	if err != nil {
+		if IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("the configuration job was not created. Status code was %d", resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// GetDellJobs retrieves the jobs from the iDRAC job queue.
+// If the BMC does not implement the Dell job queue, an empty list is returned.
+func GetDellJobs(c *gofish.APIClient) ([]*DellJob, error) {
+	collection, err := redfishcommon.GetCollection(c, dellJobsURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return []*DellJob{}, nil
+		}
+		return nil, err
+	}
+	jobs := []*DellJob{}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var job DellJob
+		err = json.NewDecoder(resp.Body).Decode(&job)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+// CheckJobQueue verifies there are no pending jobs of the given types in the iDRAC job queue.
+// This avoids the "job already in progress" failures when submitting configuration jobs.
+//		Parameters:
+//		- jobTypes: Dell job types that conflict with the job to submit (i.e. BIOSConfiguration)
+//		- clearStale: delete the conflicting jobs that are not running instead of failing
+func CheckJobQueue(c *gofish.APIClient, jobTypes []string, clearStale bool) error {
+	jobs, err := GetDellJobs(c)
+	if err != nil {
+		return fmt.Errorf("error retrieving the job queue: %s", err)
+	}
+	conflicting := []string{}
+	for _, job := range jobs {
+		if !job.Pending() || !containsString(jobTypes, job.JobType) {
+			continue
+		}
+		if clearStale && !job.Running() {
+			fmt.Printf("[DEBUG] - Deleting stale job %s (%s, %s)\n", job.ID, job.JobType, job.JobState)
+			if err := DeleteDellJob(c, job.ID); err != nil {
+				return fmt.Errorf("error deleting stale job %s: %s", job.ID, err)
+			}
+			continue
+		}
+		conflicting = append(conflicting, fmt.Sprintf("%s (%s, %s)", job.ID, job.JobType, job.JobState))
+	}
+	if len(conflicting) > 0 {
+		return fmt.Errorf("there are conflicting jobs pending in the job queue: %s", strings.Join(conflicting, ", "))
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
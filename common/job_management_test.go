@@ -0,0 +1,88 @@
+package common
+
+import (
+	"errors"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetJobProgress(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected JobProgress
+	}{
+		{1, `{"TaskState":"Running","PercentComplete":40,"Messages":[{"Message":"Downloading"},{"Message":"Installing"}]}`, JobProgress{State: "Running", PercentComplete: 40, Message: "Installing"}},
+		{2, `{"TaskState":"Exception","PercentComplete":100,"Messages":[{"Message":"Unable to apply the package: the image is not valid"}]}`, JobProgress{State: "Exception", PercentComplete: 100, Message: "Unable to apply the package: the image is not valid"}},
+		{3, `{"TaskState":"New","Messages":[]}`, JobProgress{State: "New"}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		progress, err := GetJobProgress(testClient, "/redfish/v1/TaskService/Tasks/JID_000000000001")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if *progress != v.expected {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, *progress)
+		}
+	}
+}
+
+func TestTaskFailed(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		task     string
+		status   int
+		messages []string
+	}{
+		{1, `{"TaskState":"Exception","Messages":[{"Message":"Downloading"},{"Message":""},{"Message":"The image is not valid"}]}`, http.StatusOK, []string{"Downloading", "The image is not valid"}},
+		{2, `{"JobState":"Failed","Message":"Unable to apply the settings"}`, http.StatusOK, []string{"Unable to apply the settings"}},
+		{3, `{"TaskState":"Exception","Messages":[]}`, http.StatusOK, []string{"Last message polled"}},
+		{4, ``, http.StatusNotFound, []string{"Last message polled"}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: v.status,
+			Body:       ioutil.NopCloser(strings.NewReader(v.task)),
+		})
+		err := taskFailed(testClient, "/redfish/v1/TaskService/Tasks/1", &JobProgress{State: "Exception", Message: "Last message polled"})
+		var failed *TaskFailedError
+		if !errors.As(err, &failed) || !errors.Is(err, ErrTaskFailed) {
+			t.Errorf("Test number %v: %v is not a TaskFailedError", v.noTest, err)
+			continue
+		}
+		if failed.TaskURI != "/redfish/v1/TaskService/Tasks/1" || strings.Join(failed.Messages, "|") != strings.Join(v.messages, "|") {
+			t.Errorf("Test number %v: unexpected error %+v", v.noTest, failed)
+		}
+	}
+}
+
+func TestDellJobTask(t *testing.T) {
+	job := &DellJob{
+		ODataID:   "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_000000000001",
+		ID:        "JID_000000000001",
+		Name:      "Configure: BIOS.Setup.1-1",
+		JobState:  "Scheduled",
+		JobType:   "BIOSConfiguration",
+		Message:   "Task successfully scheduled.",
+		StartTime: "TIME_NOW",
+	}
+	task := job.Task()
+	if task.ODataID != job.ODataID || task.Type != "BIOSConfiguration" || task.State != "Scheduled" || task.Finished() {
+		t.Errorf("unexpected task %+v", task)
+	}
+	if len(task.Messages) != 1 || task.Messages[0].Message != "Task successfully scheduled." {
+		t.Errorf("unexpected messages %+v", task.Messages)
+	}
+}
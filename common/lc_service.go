@@ -0,0 +1,88 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// DellLCServiceURI is the Dell OEM service of the Lifecycle Controller, which backs up and restores the server profile
+const DellLCServiceURI string = "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellLCService"
+
+// BackupImage saves the server profile (iDRAC, BIOS, RAID and NIC configurations and the firmware images) to a
+// file of a network share. passphrase encrypts it and is needed to restore it. Empty leaves it unencrypted.
+// Returns the URI of the job tracking the backup.
+func BackupImage(c redfishcommon.Client, share *Share, imageName string, passphrase string) (string, error) {
+	return postLCServiceImageAction(c, "BackupImage", share, imageName, passphrase)
+}
+
+// RestoreImage restores a server profile saved by BackupImage. The host is rebooted to apply it.
+// Returns the URI of the job tracking the restore.
+func RestoreImage(c redfishcommon.Client, share *Share, imageName string, passphrase string) (string, error) {
+	return postLCServiceImageAction(c, "RestoreImage", share, imageName, passphrase)
+}
+
+func postLCServiceImageAction(c redfishcommon.Client, action string, share *Share, imageName string, passphrase string) (string, error) {
+	payload := share.Parameters()
+	payload["ImageName"] = imageName
+	if passphrase != "" {
+		payload["Passphrase"] = passphrase
+	}
+	resp, err := c.Post(fmt.Sprintf("%s/Actions/DellLCService.%s", DellLCServiceURI, action), payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("the %s action failed. Status code was %d", action, resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// SystemErase erases the given components of the server with the Lifecycle Controller (i.e. BIOS, IDRAC,
+// LCData or CryptographicErasePD). The iDRAC and the host are reset to complete it.
+// Returns the URI of the job tracking the erase.
+func SystemErase(c redfishcommon.Client, components []string) (string, error) {
+	resp, err := c.Post(DellLCServiceURI+"/Actions/DellLCService.SystemErase", map[string]interface{}{"Component": components})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("the SystemErase action failed. Status code was %d", resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// SecureEraseDrive erases a drive with the DMTF SecureErase action. gofish does not expose the action, so
+// its target is read from the drive. Returns the URI of the task tracking the erase, empty when it is synchronous.
+func SecureEraseDrive(c redfishcommon.Client, driveURI string) (string, error) {
+	resp, err := c.Get(driveURI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var drive struct {
+		Actions struct {
+			SecureErase struct {
+				Target string
+			} `json:"#Drive.SecureErase"`
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&drive); err != nil {
+		return "", err
+	}
+	if drive.Actions.SecureErase.Target == "" {
+		return "", fmt.Errorf("drive %s does not support SecureErase", driveURI)
+	}
+	resp, err = c.Post(drive.Actions.SecureErase.Target, map[string]interface{}{})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("the SecureErase action of %s failed. Status code was %d", driveURI, resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBackupRestoreImage(t *testing.T) {
+	share := &Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "backups", Username: "user", Password: "password"}
+	cases := []struct {
+		noTest     int
+		action     func(redfishcommon.Client, *Share, string, string) (string, error)
+		actionName string
+		passphrase string
+		statusCode int
+		shouldPass bool
+	}{
+		{1, BackupImage, "BackupImage", "secret", http.StatusAccepted, true},
+		{2, BackupImage, "BackupImage", "", http.StatusBadRequest, false},
+		{3, RestoreImage, "RestoreImage", "secret", http.StatusAccepted, true},
+		{4, RestoreImage, "RestoreImage", "", http.StatusInternalServerError, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Header:     http.Header{"Location": []string{"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		jobURI, err := v.action(testClient, share, "r740-known-good", v.passphrase)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if v.shouldPass && jobURI != "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1" {
+			t.Errorf("Test number %v returned the job %s", v.noTest, jobURI)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].URL != DellLCServiceURI+"/Actions/DellLCService."+v.actionName ||
+			!strings.Contains(calls[0].Payload, "ImageName:r740-known-good") || !strings.Contains(calls[0].Payload, "ShareName:backups") {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+		if strings.Contains(calls[0].Payload, "Passphrase") != (v.passphrase != "") {
+			t.Errorf("Test number %v sent an unexpected passphrase %v", v.noTest, calls[0].Payload)
+		}
+	}
+}
+
+func TestSystemErase(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+		StatusCode: http.StatusAccepted,
+		Header:     http.Header{"Location": []string{"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1"}},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	})
+	jobURI, err := SystemErase(testClient, []string{"BIOS", "LCData"})
+	if err != nil {
+		t.Fatalf("Error erasing the system: %s", err)
+	}
+	if jobURI != "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1" {
+		t.Errorf("Unexpected job %s", jobURI)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != DellLCServiceURI+"/Actions/DellLCService.SystemErase" || !strings.Contains(calls[0].Payload, "Component:[BIOS LCData]") {
+		t.Errorf("Unexpected request %v", calls)
+	}
+}
+
+func TestSecureEraseDrive(t *testing.T) {
+	const driveURI = "/redfish/v1/Systems/1/Storage/DE00A000/Drives/0"
+	cases := []struct {
+		noTest     int
+		drive      string
+		shouldPass bool
+	}{
+		{1, `{"Actions":{"#Drive.SecureErase":{"target":"` + driveURI + `/Actions/Drive.SecureErase"}}}`, true},
+		{2, `{"Actions":{}}`, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.drive)),
+		})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     http.Header{"Location": []string{"/redfish/v1/TaskService/Tasks/1"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		taskURI, err := SecureEraseDrive(testClient, driveURI)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		calls := testClient.CapturedCalls()
+		if taskURI != "/redfish/v1/TaskService/Tasks/1" || calls[len(calls)-1].URL != driveURI+"/Actions/Drive.SecureErase" {
+			t.Errorf("Test number %v: unexpected task %s or requests %v", v.noTest, taskURI, calls)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// License is a member of the Licenses collection of the LicenseService of the service root.
+// gofish does not support it, so it is decoded here.
+type License struct {
+	ODataID            string `json:"@odata.id"`
+	ID                 string `json:"Id"`
+	Name               string
+	Description        string
+	EntitlementID      string `json:"EntitlementId"`
+	LicenseType        string
+	LicenseOrigin      string
+	AuthorizationScope string
+	InstallDate        string
+	ExpirationDate     string
+	Removable          bool
+	Status             redfishcommon.Status
+}
+
+// GetLicenses retrieves every license installed on the service whose root is at serviceRootURI.
+// Services without a LicenseService have none.
+func GetLicenses(c redfishcommon.Client, serviceRootURI string) ([]*License, error) {
+	licenses := []*License{}
+	root, err := GetResource(c, serviceRootURI)
+	if err != nil {
+		return nil, err
+	}
+	service, _ := root["LicenseService"].(map[string]interface{})
+	serviceURI, _ := service["@odata.id"].(string)
+	if serviceURI == "" {
+		return licenses, nil
+	}
+	collectionURI, err := GetLinkURI(c, serviceURI, "Licenses")
+	if err != nil {
+		return nil, err
+	}
+	collection, err := redfishcommon.GetCollection(c, collectionURI)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var license License
+		err = json.NewDecoder(resp.Body).Decode(&license)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if license.ODataID == "" {
+			license.ODataID = link
+		}
+		licenses = append(licenses, &license)
+	}
+	return licenses, nil
+}
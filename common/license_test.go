@@ -0,0 +1,51 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetLicenses(t *testing.T) {
+	const collectionURI = "/redfish/v1/LicenseService/Licenses"
+	cases := []struct {
+		noTest    int
+		responses []string
+		licenses  []string
+	}{
+		{1, []string{
+			`{"Id":"RootService","LicenseService":{"@odata.id":"/redfish/v1/LicenseService"}}`,
+			`{"Id":"LicenseService","Licenses":{"@odata.id":"` + collectionURI + `"}}`,
+			`{"Members":[{"@odata.id":"` + collectionURI + `/FD00000011111111"}],"Members@odata.count":1}`,
+			`{"Id":"FD00000011111111","EntitlementId":"ABCDE12345","Description":"iDRAC9 Enterprise License","LicenseType":"Production",` +
+				`"ExpirationDate":"2027-01-01T00:00:00Z","Status":{"Health":"OK","State":"Enabled"}}`,
+		}, []string{"FD00000011111111"}},
+		{2, []string{`{"Id":"RootService"}`}, []string{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		licenses, err := GetLicenses(testClient, "/redfish/v1/")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(licenses) != len(v.licenses) {
+			t.Errorf("Test number %v: expected %d licenses, got %d", v.noTest, len(v.licenses), len(licenses))
+			continue
+		}
+		for i, license := range licenses {
+			if license.ID != v.licenses[i] || license.ODataID != collectionURI+"/"+v.licenses[i] || license.EntitlementID != "ABCDE12345" {
+				t.Errorf("Test number %v: unexpected license %+v", v.noTest, license)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"sort"
+	"strings"
+)
+
+// oemResetToDefaultsActions are the OEM actions resetting the manager to its defaults, for the BMCs predating
+// Manager.ResetToDefaults, mapped to the ResetType of the vendor for each standard reset type they support
+var oemResetToDefaultsActions = map[string]map[string]string{
+	"DellManager.ResetToDefaults": {
+		"ResetAll":                "All",
+		"PreserveNetworkAndUsers": "Default",
+	},
+	"HpeiLO.ResetToFactoryDefaults": {
+		"ResetAll": "Default",
+	},
+}
+
+// ResetManagerToDefaults resets the manager at managerURI to its defaults through Manager.ResetToDefaults or,
+// for the BMCs without it, the OEM action of the vendor.
+// Parameters:
+//   - resetType -> ResetAll, PreserveNetworkAndUsers or PreserveNetwork (the ResetToDefaultsType of gofish).
+//
+// Returns the target of the action used.
+func ResetManagerToDefaults(c redfishcommon.Client, managerURI string, resetType string) (string, error) {
+	actions, err := GetActions(c, managerURI)
+	if err != nil {
+		return "", err
+	}
+
+	target, vendorResetType := "", resetType
+	if action, ok := actions["Manager.ResetToDefaults"]; ok {
+		target = action.Target
+	} else {
+		for name, resetTypes := range oemResetToDefaultsActions {
+			action, ok := actions[name]
+			if !ok {
+				continue
+			}
+			if resetTypes[resetType] == "" {
+				supported := []string{}
+				for supportedType := range resetTypes {
+					supported = append(supported, supportedType)
+				}
+				sort.Strings(supported)
+				return "", fmt.Errorf("the manager does not support the %s reset to defaults, only %s", resetType, strings.Join(supported, ", "))
+			}
+			target, vendorResetType = action.Target, resetTypes[resetType]
+		}
+	}
+	if target == "" {
+		return "", fmt.Errorf("the manager %s does not support resetting to defaults", managerURI)
+	}
+
+	_, err = InvokeAction(c, target, map[string]interface{}{"ResetType": vendorResetType})
+	return target, err
+}
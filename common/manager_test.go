@@ -0,0 +1,56 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResetManagerToDefaults(t *testing.T) {
+	const managerURI = "/redfish/v1/Managers/1"
+	cases := []struct {
+		noTest     int
+		body       string
+		resetType  string
+		target     string
+		shouldPass bool
+	}{
+		{1, `{"Actions":{"#Manager.ResetToDefaults":{"target":"` + managerURI + `/Actions/Manager.ResetToDefaults"}}}`,
+			"PreserveNetwork", managerURI + "/Actions/Manager.ResetToDefaults", true},
+		{2, `{"Actions":{"Oem":{"#DellManager.ResetToDefaults":{"target":"` + managerURI + `/Actions/Oem/DellManager.ResetToDefaults"}}}}`,
+			"PreserveNetworkAndUsers", managerURI + "/Actions/Oem/DellManager.ResetToDefaults", true},
+		{3, `{"Actions":{"Oem":{"Hpe":{"#HpeiLO.ResetToFactoryDefaults":{"target":"` + managerURI + `/Actions/Oem/Hpe/HpeiLO.ResetToFactoryDefaults"}}}}}`,
+			"ResetAll", managerURI + "/Actions/Oem/Hpe/HpeiLO.ResetToFactoryDefaults", true},
+		{4, `{"Actions":{"Oem":{"Hpe":{"#HpeiLO.ResetToFactoryDefaults":{"target":"` + managerURI + `/Actions/Oem/Hpe/HpeiLO.ResetToFactoryDefaults"}}}}}`,
+			"PreserveNetwork", "", false},
+		{5, `{"Actions":{"#Manager.Reset":{"target":"` + managerURI + `/Actions/Manager.Reset"}}}`, "ResetAll", "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		target, err := ResetManagerToDefaults(testClient, managerURI, v.resetType)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if target != v.target {
+			t.Errorf("Test number %v: expected target %s, got %s", v.noTest, v.target, target)
+		}
+	}
+}
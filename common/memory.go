@@ -0,0 +1,148 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// PersistentMemoryAddressRangeType is the address range type of the memory chunks provisioned as persistent memory (App Direct)
+const PersistentMemoryAddressRangeType string = "PMEM"
+
+// MemoryDomain is a memory domain of a system, in which the persistent memory regions (memory chunks) are created.
+// gofish does not expose the memory chunks of the domains, so they are decoded here.
+type MemoryDomain struct {
+	ODataID                   string `json:"@odata.id"`
+	ID                        string `json:"Id"`
+	AllowsMemoryChunkCreation bool
+	// InterleavableMemorySets are the sets of memory modules a memory chunk can be interleaved across
+	InterleavableMemorySets []struct {
+		MemorySet []redfishcommon.Link
+	}
+	MemoryChunks redfishcommon.Link
+}
+
+// MemoryChunk is a region of a memory domain (i.e. a persistent memory goal)
+type MemoryChunk struct {
+	ODataID            string `json:"@odata.id"`
+	ID                 string `json:"Id"`
+	MemoryChunkSizeMiB int
+	AddressRangeType   string
+}
+
+// GetMemoryDomains retrieves the memory domains of the system at systemURI.
+// Systems without memory domains (i.e. without persistent memory) return an empty list.
+func GetMemoryDomains(c redfishcommon.Client, systemURI string) ([]*MemoryDomain, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		MemoryDomains redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	domains := []*MemoryDomain{}
+	if system.MemoryDomains == "" {
+		return domains, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(system.MemoryDomains))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var domain MemoryDomain
+		err = json.NewDecoder(resp.Body).Decode(&domain)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if domain.ODataID == "" {
+			domain.ODataID = link
+		}
+		domains = append(domains, &domain)
+	}
+	return domains, nil
+}
+
+// GetMemoryChunks retrieves the memory chunks of a memory domain
+func GetMemoryChunks(c redfishcommon.Client, domain *MemoryDomain) ([]*MemoryChunk, error) {
+	chunks := []*MemoryChunk{}
+	if domain.MemoryChunks == "" {
+		return chunks, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(domain.MemoryChunks))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var chunk MemoryChunk
+		err = json.NewDecoder(resp.Body).Decode(&chunk)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if chunk.ODataID == "" {
+			chunk.ODataID = link
+		}
+		chunks = append(chunks, &chunk)
+	}
+	return chunks, nil
+}
+
+// CreatePersistentMemoryChunk creates a persistent memory goal in a memory domain, interleaved across its
+// first interleavable memory set. The goal is provisioned by the BIOS on the next reboot.
+// A sizeMiB of 0 lets the BMC use the whole capacity of the set. Returns the URI of the memory chunk.
+func CreatePersistentMemoryChunk(c redfishcommon.Client, domain *MemoryDomain, sizeMiB int) (string, error) {
+	if !domain.AllowsMemoryChunkCreation || domain.MemoryChunks == "" {
+		return "", fmt.Errorf("memory domain %s does not allow creating memory chunks", domain.ID)
+	}
+	payload := map[string]interface{}{"AddressRangeType": PersistentMemoryAddressRangeType}
+	if sizeMiB > 0 {
+		payload["MemoryChunkSizeMiB"] = sizeMiB
+	}
+	if len(domain.InterleavableMemorySets) > 0 {
+		memory := []map[string]interface{}{}
+		for _, module := range domain.InterleavableMemorySets[0].MemorySet {
+			memory = append(memory, map[string]interface{}{"Memory": map[string]string{"@odata.id": string(module)}})
+		}
+		payload["InterleaveSets"] = memory
+	}
+	resp, err := c.Post(string(domain.MemoryChunks), payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("the creation of the memory chunk in %s failed. Status code was %d", domain.ID, resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// DeleteMemoryChunk deletes the memory chunk at chunkURI, along with the data it holds.
+// Chunks already gone are not considered errors.
+func DeleteMemoryChunk(c redfishcommon.Client, chunkURI string) error {
+	resp, err := c.Delete(chunkURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the deletion of the memory chunk %s failed. Status code was %d", chunkURI, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetMemoryDomains(t *testing.T) {
+	const systemURI = "/redfish/v1/Systems/System.Embedded.1"
+	cases := []struct {
+		noTest    int
+		responses []string
+		domains   []string
+	}{
+		{1, []string{
+			`{"Id":"System.Embedded.1","MemoryDomains":{"@odata.id":"` + systemURI + `/MemoryDomains"}}`,
+			`{"Members":[{"@odata.id":"` + systemURI + `/MemoryDomains/PMem.Socket.1"}],"Members@odata.count":1}`,
+			`{"@odata.id":"` + systemURI + `/MemoryDomains/PMem.Socket.1","Id":"PMem.Socket.1","AllowsMemoryChunkCreation":true,` +
+				`"InterleavableMemorySets":[{"MemorySet":[{"@odata.id":"` + systemURI + `/Memory/DIMM.Socket.A7"},{"@odata.id":"` + systemURI + `/Memory/DIMM.Socket.A8"}]}],` +
+				`"MemoryChunks":{"@odata.id":"` + systemURI + `/MemoryDomains/PMem.Socket.1/MemoryChunks"}}`,
+		}, []string{"PMem.Socket.1"}},
+		{2, []string{`{"Id":"System.Embedded.1"}`}, []string{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		testClient.CustomReturnForActions[http.MethodPost] = []interface{}{&http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Location": []string{systemURI + "/MemoryDomains/PMem.Socket.1/MemoryChunks/1"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}}
+		domains, err := GetMemoryDomains(testClient, systemURI)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(domains) != len(v.domains) {
+			t.Errorf("Test number %v: expected %d domains, got %d", v.noTest, len(v.domains), len(domains))
+			continue
+		}
+		for i, domain := range domains {
+			if domain.ID != v.domains[i] {
+				t.Errorf("Test number %v: expected domain %s, got %s", v.noTest, v.domains[i], domain.ID)
+			}
+		}
+		if len(domains) == 0 {
+			continue
+		}
+		location, err := CreatePersistentMemoryChunk(testClient, domains[0], 0)
+		if err != nil || location != systemURI+"/MemoryDomains/PMem.Socket.1/MemoryChunks/1" {
+			t.Errorf("Test number %v: unexpected memory chunk %s (%v)", v.noTest, location, err)
+			continue
+		}
+		calls := testClient.CapturedCalls()
+		post := calls[len(calls)-1]
+		if post.URL != systemURI+"/MemoryDomains/PMem.Socket.1/MemoryChunks" || !strings.Contains(post.Payload, "AddressRangeType:PMEM") ||
+			!strings.Contains(post.Payload, "DIMM.Socket.A8") || strings.Contains(post.Payload, "MemoryChunkSizeMiB") {
+			t.Errorf("Test number %v: unexpected request %+v", v.noTest, post)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// NetworkDeviceFunction is a function of a network adapter, such as a NIC partition (NPAR).
+// gofish does not decode the VLAN, the boot settings, the WWNs nor the settings object of the functions, so they are decoded here.
+type NetworkDeviceFunction struct {
+	ODataID        string `json:"@odata.id"`
+	ID             string `json:"Id"`
+	Name           string
+	NetDevFuncType string
+	Ethernet       struct {
+		MACAddress string
+		// PermanentMACAddress is the MAC address burned into the function, MACAddress differs when it is overridden
+		PermanentMACAddress string
+		VLAN                struct {
+			VLANEnable bool
+			VLANID     int `json:"VLANId"`
+		}
+	}
+	// FibreChannel is the identity of the FC and FCoE functions. WWNSource is ConfiguredLocally when the WWNs are overridden
+	FibreChannel struct {
+		WWNN          string
+		WWPN          string
+		PermanentWWNN string
+		PermanentWWPN string
+		WWNSource     string
+	}
+	// BootMode is the protocol the function boots the system with (i.e. PXE or iSCSI), Disabled when it does not
+	BootMode string
+	// ISCSIBoot is the iSCSI boot configuration of the function, including whether it takes it from DHCP
+	ISCSIBoot struct {
+		IPMaskDNSViaDHCP  bool
+		TargetInfoViaDHCP bool
+		PrimaryDNS        string
+		SecondaryDNS      string
+		InitiatorName     string
+	} `json:"iSCSIBoot"`
+	Settings struct {
+		SettingsObject redfishcommon.Link
+	} `json:"@Redfish.Settings"`
+}
+
+// SettingsURI returns where the changes of the function are sent: its settings object, applied on the next
+// reboot, when the BMC has one, or the function itself otherwise.
+func (f *NetworkDeviceFunction) SettingsURI() string {
+	if f.Settings.SettingsObject != "" {
+		return string(f.Settings.SettingsObject)
+	}
+	return f.ODataID
+}
+
+// DellNetworkAttributesURI returns the URI of the Dell attributes of the function (i.e. NicPartitioning and VLanId).
+// Their settings object is at the same URI followed by /Settings.
+func (f *NetworkDeviceFunction) DellNetworkAttributesURI() string {
+	return f.ODataID + "/Oem/Dell/DellNetworkAttributes/" + f.ID
+}
+
+// GetNetworkDeviceFunctions retrieves the functions of the network adapter at adapterURI
+func GetNetworkDeviceFunctions(c redfishcommon.Client, adapterURI string) ([]*NetworkDeviceFunction, error) {
+	resp, err := c.Get(adapterURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var adapter struct {
+		NetworkDeviceFunctions redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&adapter); err != nil {
+		return nil, err
+	}
+	functions := []*NetworkDeviceFunction{}
+	if adapter.NetworkDeviceFunctions == "" {
+		return functions, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(adapter.NetworkDeviceFunctions))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		function, err := GetNetworkDeviceFunction(c, link)
+		if err != nil {
+			return nil, err
+		}
+		functions = append(functions, function)
+	}
+	return functions, nil
+}
+
+// GetNetworkDeviceFunction retrieves the network device function at uri
+func GetNetworkDeviceFunction(c redfishcommon.Client, uri string) (*NetworkDeviceFunction, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var function NetworkDeviceFunction
+	if err = json.NewDecoder(resp.Body).Decode(&function); err != nil {
+		return nil, err
+	}
+	if function.ODataID == "" {
+		function.ODataID = uri
+	}
+	return &function, nil
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetNetworkDeviceFunctions(t *testing.T) {
+	const adapterURI = "/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters/NIC.Integrated.1"
+	cases := []struct {
+		noTest    int
+		responses []string
+		settings  []string
+	}{
+		{1, []string{
+			`{"Id":"NIC.Integrated.1","NetworkDeviceFunctions":{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions"}}`,
+			`{"Members":[{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions/NIC.Integrated.1-1-1"},{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions/NIC.Integrated.1-1-2"}],"Members@odata.count":2}`,
+			`{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions/NIC.Integrated.1-1-1","Id":"NIC.Integrated.1-1-1","Ethernet":{"VLAN":{"VLANEnable":true,"VLANId":100}},` +
+				`"BootMode":"iSCSI","iSCSIBoot":{"IPMaskDNSViaDHCP":true,"PrimaryDNS":"10.0.0.53"},` +
+				`"@Redfish.Settings":{"SettingsObject":{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions/NIC.Integrated.1-1-1/Settings"}}}`,
+			`{"@odata.id":"` + adapterURI + `/NetworkDeviceFunctions/NIC.Integrated.1-1-2","Id":"NIC.Integrated.1-1-2"}`,
+		}, []string{adapterURI + "/NetworkDeviceFunctions/NIC.Integrated.1-1-1/Settings", adapterURI + "/NetworkDeviceFunctions/NIC.Integrated.1-1-2"}},
+		{2, []string{`{"Id":"NIC.Integrated.1"}`}, []string{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		functions, err := GetNetworkDeviceFunctions(testClient, adapterURI)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(functions) != len(v.settings) {
+			t.Errorf("Test number %v: expected %d functions, got %d", v.noTest, len(v.settings), len(functions))
+			continue
+		}
+		for i, function := range functions {
+			if function.SettingsURI() != v.settings[i] {
+				t.Errorf("Test number %v: function %s has settings %s, expected %s", v.noTest, function.ID, function.SettingsURI(), v.settings[i])
+			}
+		}
+		if len(functions) > 0 && (!functions[0].Ethernet.VLAN.VLANEnable || functions[0].Ethernet.VLAN.VLANID != 100 ||
+			functions[0].BootMode != "iSCSI" || !functions[0].ISCSIBoot.IPMaskDNSViaDHCP || functions[0].ISCSIBoot.PrimaryDNS != "10.0.0.53" ||
+			functions[0].DellNetworkAttributesURI() != adapterURI+"/NetworkDeviceFunctions/NIC.Integrated.1-1-1/Oem/Dell/DellNetworkAttributes/NIC.Integrated.1-1-1") {
+			t.Errorf("Test number %v: unexpected function %+v", v.noTest, functions[0])
+		}
+	}
+}
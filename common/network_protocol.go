@@ -0,0 +1,57 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// ManagerNetworkProtocol holds the network services of a manager, which gofish does not expose
+type ManagerNetworkProtocol struct {
+	ODataID  string `json:"@odata.id"`
+	HostName string
+	FQDN     string
+	NTP      struct {
+		ProtocolEnabled bool
+		NTPServers      []string
+	}
+	HTTP   NetworkProtocolSettings
+	HTTPS  NetworkProtocolSettings
+	SSH    NetworkProtocolSettings
+	Telnet NetworkProtocolSettings
+	IPMI   NetworkProtocolSettings
+}
+
+// NetworkProtocolSettings are the settings of a network service of a manager
+type NetworkProtocolSettings struct {
+	ProtocolEnabled bool
+	Port            int
+}
+
+// GetManagerNetworkProtocol retrieves the network services of a manager
+func GetManagerNetworkProtocol(c redfishcommon.Client, managerURI string) (*ManagerNetworkProtocol, error) {
+	resp, err := c.Get(managerURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var manager struct {
+		NetworkProtocol redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&manager); err != nil {
+		return nil, err
+	}
+	if len(manager.NetworkProtocol) == 0 {
+		return nil, fmt.Errorf("manager %s has no network protocol resource", managerURI)
+	}
+	resp, err = c.Get(string(manager.NetworkProtocol))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var protocol ManagerNetworkProtocol
+	if err = json.NewDecoder(resp.Body).Decode(&protocol); err != nil {
+		return nil, err
+	}
+	return &protocol, nil
+}
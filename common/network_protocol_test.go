@@ -0,0 +1,35 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestManagerNetworkProtocolDecode(t *testing.T) {
+	body := `{"@odata.id":"/redfish/v1/Managers/iDRAC.Embedded.1/NetworkProtocol","HostName":"idrac-web01",
+		"HTTP":{"ProtocolEnabled":true,"Port":80},"HTTPS":{"ProtocolEnabled":true,"Port":443},
+		"IPMI":{"ProtocolEnabled":false,"Port":623},"NTP":{"ProtocolEnabled":true,"NTPServers":["pool.ntp.org",""]}}`
+	var protocol ManagerNetworkProtocol
+	if err := json.Unmarshal([]byte(body), &protocol); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cases := []struct {
+		noTest   int
+		name     string
+		settings NetworkProtocolSettings
+		expected NetworkProtocolSettings
+	}{
+		{1, "HTTP", protocol.HTTP, NetworkProtocolSettings{ProtocolEnabled: true, Port: 80}},
+		{2, "HTTPS", protocol.HTTPS, NetworkProtocolSettings{ProtocolEnabled: true, Port: 443}},
+		{3, "IPMI", protocol.IPMI, NetworkProtocolSettings{ProtocolEnabled: false, Port: 623}},
+		{4, "Telnet", protocol.Telnet, NetworkProtocolSettings{}},
+	}
+	for _, v := range cases {
+		if v.settings != v.expected {
+			t.Errorf("Test number %v: expected %s %+v, got %+v", v.noTest, v.name, v.expected, v.settings)
+		}
+	}
+	if protocol.HostName != "idrac-web01" || len(protocol.NTP.NTPServers) != 2 {
+		t.Errorf("Unexpected host name or NTP servers: %+v", protocol)
+	}
+}
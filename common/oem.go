@@ -0,0 +1,260 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// OEMHandler implements the parts of the Redfish workflows that differ between vendors (job queues,
+// configuration jobs, firmware inventory naming), so resources do not need to know the vendor of the BMC.
+// A vendor is supported by adding its implementation to oemHandlers.
+type OEMHandler interface {
+	// Vendor is the name of the vendor, as accepted by the vendor_override provider setting
+	Vendor() string
+	// CreateConfigJob schedules a job applying the pending settings at settingsURI (i.e. the BIOS Settings object).
+	// Returns an empty URI when the vendor applies the settings on the next reboot without a job.
+	CreateConfigJob(c redfishcommon.Client, settingsURI string) (string, error)
+	// ClearPendingSettings discards the changes pending at settingsURI, so fresh ones can be applied
+	ClearPendingSettings(c redfishcommon.Client, settingsURI string) error
+	// CheckJobQueue verifies there are no pending jobs of the given types, deleting those not running when clearStale is set.
+	// Vendors without a job queue never report conflicts.
+	CheckJobQueue(c *gofish.APIClient, jobTypes []string, clearStale bool) error
+	// DeleteJob removes the job (or task) at jobURI
+	DeleteJob(c *gofish.APIClient, jobURI string) error
+	// ActiveJobs describes the jobs (or tasks) that have not finished yet, including the ones scheduled for the next reboot
+	ActiveJobs(c *gofish.APIClient) ([]string, error)
+	// Jobs retrieves every job (or task) known to the BMC, finished or not
+	Jobs(c *gofish.APIClient) ([]*Task, error)
+	// MatchFirmwareTarget reports if target (an inventory URI or a vendor device id) refers to the firmware inventory entry
+	MatchFirmwareTarget(entry *FirmwareInventoryEntry, target string) bool
+}
+
+// oemHandlers are the supported vendors, indexed by the lowercase name used in vendor_override
+var oemHandlers = map[string]OEMHandler{
+	"dell": dellOEM{},
+	"hpe":  hpeOEM{standardOEM{vendor: "hpe"}},
+}
+
+// oemKeys are the keys vendors use in the Oem object of the service root, for BMCs not reporting Vendor
+var oemKeys = map[string]string{
+	"dell": "dell",
+	"hpe":  "hpe",
+	"hp":   "hpe",
+}
+
+// GenericOEM is the handler for the vendors without an implementation (i.e. Lenovo or Supermicro), which only uses standard Redfish
+var GenericOEM OEMHandler = standardOEM{vendor: "generic"}
+
+// OEMVendors returns the names of the supported vendors
+func OEMVendors() []string {
+	vendors := []string{}
+	for vendor := range oemHandlers {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+// GetOEMHandler returns the handler of a vendor by name (i.e. dell or hpe)
+func GetOEMHandler(vendor string) (OEMHandler, error) {
+	handler, ok := oemHandlers[strings.ToLower(vendor)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vendor %s. Supported vendors are %s", vendor, strings.Join(OEMVendors(), ", "))
+	}
+	return handler, nil
+}
+
+// DetectOEM returns the handler for the vendor of the BMC, identified by the Vendor property of the
+// service root or, for BMCs predating it, by its Oem object. Unknown vendors get GenericOEM.
+func DetectOEM(c *gofish.APIClient) (OEMHandler, error) {
+	if handler, ok := oemHandlers[oemKeys[strings.ToLower(c.Service.Vendor)]]; ok {
+		return handler, nil
+	}
+	resp, err := c.Get(c.Service.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var root struct {
+		Oem map[string]json.RawMessage
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return nil, err
+	}
+	for key := range root.Oem {
+		if handler, ok := oemHandlers[oemKeys[strings.ToLower(key)]]; ok {
+			return handler, nil
+		}
+	}
+	return GenericOEM, nil
+}
+
+// standardOEM relies on standard Redfish only: settings are applied on the next reboot and jobs are Tasks
+type standardOEM struct {
+	vendor string
+}
+
+// Vendor implements OEMHandler
+func (o standardOEM) Vendor() string {
+	return o.vendor
+}
+
+// CreateConfigJob implements OEMHandler
+func (o standardOEM) CreateConfigJob(c redfishcommon.Client, settingsURI string) (string, error) {
+	return "", nil
+}
+
+// ClearPendingSettings implements OEMHandler
+func (o standardOEM) ClearPendingSettings(c redfishcommon.Client, settingsURI string) error {
+	return DeleteSettings(c, settingsURI)
+}
+
+// CheckJobQueue implements OEMHandler
+func (o standardOEM) CheckJobQueue(c *gofish.APIClient, jobTypes []string, clearStale bool) error {
+	return nil
+}
+
+// DeleteJob implements OEMHandler
+func (o standardOEM) DeleteJob(c *gofish.APIClient, jobURI string) error {
+	resp, err := c.Delete(jobURI)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("Error when deleting the task, Delete status code was %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ActiveJobs implements OEMHandler
+func (o standardOEM) ActiveJobs(c *gofish.APIClient) ([]string, error) {
+	tasks, err := GetActiveTasks(c)
+	if err != nil {
+		return nil, err
+	}
+	active := []string{}
+	for _, task := range tasks {
+		active = append(active, fmt.Sprintf("%s (%s, %s)", task.ID, task.Name, task.State))
+	}
+	return active, nil
+}
+
+// Jobs implements OEMHandler
+func (o standardOEM) Jobs(c *gofish.APIClient) ([]*Task, error) {
+	return GetTasks(c)
+}
+
+// MatchFirmwareTarget implements OEMHandler
+func (o standardOEM) MatchFirmwareTarget(entry *FirmwareInventoryEntry, target string) bool {
+	return entry.ODataID == target || entry.ID == target
+}
+
+// dellOEM uses the iDRAC job queue and the FQDDs of the devices
+type dellOEM struct{}
+
+// Vendor implements OEMHandler
+func (o dellOEM) Vendor() string {
+	return "dell"
+}
+
+// CreateConfigJob implements OEMHandler
+func (o dellOEM) CreateConfigJob(c redfishcommon.Client, settingsURI string) (string, error) {
+	return CreateDellConfigJob(c, settingsURI)
+}
+
+// ClearPendingSettings implements OEMHandler
+func (o dellOEM) ClearPendingSettings(c redfishcommon.Client, settingsURI string) error {
+	return ClearDellPendingSettings(c, settingsURI)
+}
+
+// CheckJobQueue implements OEMHandler
+func (o dellOEM) CheckJobQueue(c *gofish.APIClient, jobTypes []string, clearStale bool) error {
+	return CheckJobQueue(c, jobTypes, clearStale)
+}
+
+// DeleteJob implements OEMHandler. The iDRAC only deletes jobs through its job queue, whatever URI the job was reported with.
+func (o dellOEM) DeleteJob(c *gofish.APIClient, jobURI string) error {
+	return DeleteDellJob(c, jobURI[strings.LastIndex(jobURI, "/")+1:])
+}
+
+// ActiveJobs implements OEMHandler. The iDRAC job queue holds the jobs scheduled for the next reboot too,
+// which the TaskService does not report.
+func (o dellOEM) ActiveJobs(c *gofish.APIClient) ([]string, error) {
+	jobs, err := GetDellJobs(c)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the job queue: %s", err)
+	}
+	active := []string{}
+	for _, job := range jobs {
+		if job.Pending() {
+			active = append(active, fmt.Sprintf("%s (%s, %s)", job.ID, job.JobType, job.JobState))
+		}
+	}
+	return active, nil
+}
+
+// Jobs implements OEMHandler. The iDRAC job queue holds the jobs scheduled for the next reboot too,
+// and their types, which the TaskService does not report.
+func (o dellOEM) Jobs(c *gofish.APIClient) ([]*Task, error) {
+	jobs, err := GetDellJobs(c)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the job queue: %s", err)
+	}
+	tasks := []*Task{}
+	for _, job := range jobs {
+		tasks = append(tasks, job.Task())
+	}
+	return tasks, nil
+}
+
+// MatchFirmwareTarget implements OEMHandler. Targets can be Dell FQDDs (i.e. NIC.Integrated.1-1-1),
+// which Dell appends to the inventory Ids (i.e. Installed-XXXX-22.00.6__NIC.Integrated.1-1-1).
+func (o dellOEM) MatchFirmwareTarget(entry *FirmwareInventoryEntry, target string) bool {
+	return entry.ODataID == target || strings.HasSuffix(entry.ID, "__"+target)
+}
+
+// hpeOEM adds the iLO installation queue to the TaskService tasks and matches the firmware by name,
+// as iLO numbers its inventory entries. Settings are applied on the next reboot without a job, as in standard Redfish.
+type hpeOEM struct {
+	standardOEM
+}
+
+// ActiveJobs implements OEMHandler. The updates waiting in the iLO installation queue are active too.
+func (o hpeOEM) ActiveJobs(c *gofish.APIClient) ([]string, error) {
+	tasks, err := o.Jobs(c)
+	if err != nil {
+		return nil, err
+	}
+	active := []string{}
+	for _, task := range tasks {
+		if !task.Finished() {
+			active = append(active, fmt.Sprintf("%s (%s, %s)", task.ID, task.Name, task.State))
+		}
+	}
+	return active, nil
+}
+
+// Jobs implements OEMHandler
+func (o hpeOEM) Jobs(c *gofish.APIClient) ([]*Task, error) {
+	tasks, err := GetTasks(c)
+	if err != nil {
+		return nil, err
+	}
+	queued, err := GetHpeUpdateTasks(c)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the installation queue: %s", err)
+	}
+	return append(tasks, queued...), nil
+}
+
+// MatchFirmwareTarget implements OEMHandler. Targets can be the names of the devices (i.e. System ROM),
+// as the inventory Ids of iLO are indexes that change between iLO versions.
+func (o hpeOEM) MatchFirmwareTarget(entry *FirmwareInventoryEntry, target string) bool {
+	return o.standardOEM.MatchFirmwareTarget(entry, target) || strings.EqualFold(entry.Name, target)
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestGetOEMHandler(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		expected   string
+		shouldPass bool
+	}{
+		{1, "dell", "dell", true},
+		{2, "HPE", "hpe", true},
+		{3, "acme", "", false},
+		{4, "lenovo", "", false},
+	}
+	for _, v := range cases {
+		handler, err := GetOEMHandler(v.vendor)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if handler.Vendor() != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, handler.Vendor())
+		}
+	}
+}
+
+func TestMatchFirmwareTarget(t *testing.T) {
+	entry := &FirmwareInventoryEntry{ODataID: "/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.00.6__NIC.Integrated.1-1-1", ID: "Installed-101548-22.00.6__NIC.Integrated.1-1-1", Name: "Broadcom BCM57414 NIC"}
+	cases := []struct {
+		noTest   int
+		oem      OEMHandler
+		target   string
+		expected bool
+	}{
+		{1, dellOEM{}, "NIC.Integrated.1-1-1", true},
+		{2, dellOEM{}, entry.ODataID, true},
+		{3, GenericOEM, "NIC.Integrated.1-1-1", false},
+		{4, GenericOEM, entry.ID, true},
+		{5, hpeOEM{standardOEM{vendor: "hpe"}}, "broadcom bcm57414 nic", true},
+		{6, GenericOEM, "Broadcom BCM57414 NIC", false},
+	}
+	for _, v := range cases {
+		if matched := v.oem.MatchFirmwareTarget(entry, v.target); matched != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, matched)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+)
+
+// DellOSDeploymentServiceURI is the Dell OEM service exposing ISO images and driver packs to the host for OS installations
+const DellOSDeploymentServiceURI string = "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellOSDeploymentService"
+
+// Attach statuses reported by the OS deployment service
+const (
+	AttachedStatus    string = "Attached"
+	NotAttachedStatus string = "NotAttached"
+)
+
+// OSDeploymentAttachStatus tells what the OS deployment service exposes to the host
+type OSDeploymentAttachStatus struct {
+	ISOAttachStatus     string
+	DriversAttachStatus string
+}
+
+// DriverPackInfo describes the driver pack embedded in the Lifecycle Controller
+type DriverPackInfo struct {
+	// OSList are the operating systems the driver pack has drivers for
+	OSList  []string
+	Version string
+}
+
+// BootToNetworkISO exposes an ISO image of a network share to the host and boots it from it once.
+// Only NFS and CIFS shares are supported by the service.
+// Parameters:
+//   - imageName -> path of the ISO image in the share.
+//   - exposeDuration -> how long the image stays attached (i.e. PT18H). Empty keeps the default of the service.
+//
+// Returns the URI of the job tracking the boot.
+func BootToNetworkISO(c redfishcommon.Client, share *Share, imageName string, exposeDuration string) (string, error) {
+	if share.ShareType != NFSShareType && share.ShareType != CIFSShareType {
+		return "", fmt.Errorf("network ISO images can only be booted from NFS or CIFS shares")
+	}
+	payload := share.Parameters()
+	payload["ImageName"] = imageName
+	if exposeDuration != "" {
+		payload["ExposeDuration"] = exposeDuration
+	}
+	resp, err := postOSDeploymentAction(c, "BootToNetworkISO", payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return resp.Header.Get("Location"), nil
+}
+
+// DetachISOImage detaches the ISO image exposed by BootToNetworkISO
+func DetachISOImage(c redfishcommon.Client) error {
+	resp, err := postOSDeploymentAction(c, "DetachISOImage", map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// UnpackAndAttachDrivers exposes to the host, as a USB device, the drivers of the embedded driver pack for osName.
+// Returns the URI of the job unpacking them.
+func UnpackAndAttachDrivers(c redfishcommon.Client, osName string, exposeDuration string) (string, error) {
+	payload := map[string]interface{}{
+		"OSName": osName,
+	}
+	if exposeDuration != "" {
+		payload["ExposeDuration"] = exposeDuration
+	}
+	resp, err := postOSDeploymentAction(c, "UnpackAndAttach", payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return resp.Header.Get("Location"), nil
+}
+
+// DetachDrivers detaches the drivers exposed by UnpackAndAttachDrivers
+func DetachDrivers(c redfishcommon.Client) error {
+	resp, err := postOSDeploymentAction(c, "DetachDrivers", map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// GetOSDeploymentAttachStatus returns whether an ISO image and drivers are exposed to the host
+func GetOSDeploymentAttachStatus(c redfishcommon.Client) (*OSDeploymentAttachStatus, error) {
+	resp, err := postOSDeploymentAction(c, "GetAttachStatus", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var status OSDeploymentAttachStatus
+	if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetDriverPackInfo returns the operating systems the embedded driver pack has drivers for
+func GetDriverPackInfo(c redfishcommon.Client) (*DriverPackInfo, error) {
+	resp, err := postOSDeploymentAction(c, "GetDriverPackInfo", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var info DriverPackInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func postOSDeploymentAction(c redfishcommon.Client, action string, payload map[string]interface{}) (*http.Response, error) {
+	resp, err := c.Post(fmt.Sprintf("%s/Actions/DellOSDeploymentService.%s", DellOSDeploymentServiceURI, action), payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		resp.Body.Close()
+		return nil, fmt.Errorf("the %s action failed. Status code was %d", action, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBootToNetworkISO(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		share      *Share
+		statusCode int
+		shouldPass bool
+	}{
+		{1, &Share{IPAddress: "10.0.0.1", ShareType: NFSShareType, ShareName: "/isos"}, http.StatusAccepted, true},
+		{2, &Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "isos", Username: "user", Password: "password"}, http.StatusBadRequest, false},
+		{3, &Share{IPAddress: "10.0.0.1", ShareType: HTTPShareType, ShareName: "isos"}, http.StatusAccepted, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Header:     http.Header{"Location": []string{"/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		jobURI, err := BootToNetworkISO(testClient, v.share, "esxi.iso", "PT2H")
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if v.shouldPass && jobURI != "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_1" {
+			t.Errorf("Test number %v returned the job %s", v.noTest, jobURI)
+		}
+		calls := testClient.CapturedCalls()
+		if v.share.ShareType == HTTPShareType {
+			if len(calls) != 0 {
+				t.Errorf("Test number %v sent requests for an unsupported share %v", v.noTest, calls)
+			}
+			continue
+		}
+		if len(calls) != 1 || calls[0].URL != DellOSDeploymentServiceURI+"/Actions/DellOSDeploymentService.BootToNetworkISO" ||
+			!strings.Contains(calls[0].Payload, "ImageName:esxi.iso") || !strings.Contains(calls[0].Payload, "ExposeDuration:PT2H") {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestGetOSDeploymentAttachStatus(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		body       string
+		expected   OSDeploymentAttachStatus
+		shouldPass bool
+	}{
+		{1, `{"ISOAttachStatus":"Attached","DriversAttachStatus":"NotAttached"}`, OSDeploymentAttachStatus{AttachedStatus, NotAttachedStatus}, true},
+		{2, `not json`, OSDeploymentAttachStatus{}, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		status, err := GetOSDeploymentAttachStatus(testClient)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if err == nil && *status != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, *status)
+		}
+	}
+}
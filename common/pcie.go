@@ -0,0 +1,234 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"strconv"
+)
+
+// PCIeDevice is a PCIe device of a computer system (i.e. a GPU or a NIC).
+// gofish does not expose the slot nor the PCIe functions of the devices, so they are decoded here.
+type PCIeDevice struct {
+	ODataID         string `json:"@odata.id"`
+	ID              string `json:"Id"`
+	Name            string
+	Manufacturer    string
+	Model           string
+	FirmwareVersion string
+	SerialNumber    string
+	PartNumber      string
+	DeviceType      string
+	PCIeInterface   struct {
+		LanesInUse  int
+		MaxLanes    int
+		PCIeType    string
+		MaxPCIeType string
+	}
+	Slot struct {
+		Location struct {
+			PartLocation struct {
+				ServiceLabel         string
+				LocationOrdinalValue *int
+			}
+		}
+	}
+	Status redfishcommon.Status
+	// PCIeFunctions is the collection of functions of the services implementing PCIeDevice v1.5 or newer
+	PCIeFunctions redfishcommon.Link
+	Links         struct {
+		PCIeFunctions redfishcommon.Links
+	}
+	// Functions are the PCIe functions the device exposes
+	Functions []*PCIeFunction `json:"-"`
+}
+
+// PCIeFunction is a function of a PCIe device, identified by its PCI ids
+type PCIeFunction struct {
+	ODataID           string `json:"@odata.id"`
+	ID                string `json:"Id"`
+	Name              string
+	FunctionID        int `json:"FunctionId"`
+	FunctionType      string
+	DeviceClass       string
+	ClassCode         string
+	DeviceID          string `json:"DeviceId"`
+	VendorID          string `json:"VendorId"`
+	SubsystemID       string `json:"SubsystemId"`
+	SubsystemVendorID string `json:"SubsystemVendorId"`
+}
+
+// SlotLabel returns the label of the slot the device is plugged in (i.e. "PCIe Slot 3").
+// Services not reporting it return the slot number, or an empty string for embedded devices.
+func (p *PCIeDevice) SlotLabel() string {
+	location := p.Slot.Location.PartLocation
+	if location.ServiceLabel != "" {
+		return location.ServiceLabel
+	}
+	if location.LocationOrdinalValue != nil {
+		return strconv.Itoa(*location.LocationOrdinalValue)
+	}
+	return ""
+}
+
+// GetPCIeDevices returns the PCIe devices of a computer system, with their functions.
+// Systems without PCIeDevices return an empty list.
+func GetPCIeDevices(c redfishcommon.Client, systemURI string) ([]*PCIeDevice, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		PCIeDevices redfishcommon.Links
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	devices := []*PCIeDevice{}
+	for _, link := range system.PCIeDevices.ToStrings() {
+		device, err := GetPCIeDevice(c, link)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// GetPCIeDevice retrieves a PCIe device and its functions
+func GetPCIeDevice(c redfishcommon.Client, uri string) (*PCIeDevice, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var device PCIeDevice
+	if err = json.NewDecoder(resp.Body).Decode(&device); err != nil {
+		return nil, err
+	}
+	functionLinks := device.Links.PCIeFunctions.ToStrings()
+	if len(functionLinks) == 0 && len(device.PCIeFunctions) > 0 {
+		collection, err := redfishcommon.GetCollection(c, string(device.PCIeFunctions))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching the PCIe functions of %s: %s", uri, err)
+		}
+		functionLinks = collection.ItemLinks
+	}
+	device.Functions = []*PCIeFunction{}
+	for _, link := range functionLinks {
+		function, err := getPCIeFunction(c, link)
+		if err != nil {
+			return nil, err
+		}
+		device.Functions = append(device.Functions, function)
+	}
+	return &device, nil
+}
+
+func getPCIeFunction(c redfishcommon.Client, uri string) (*PCIeFunction, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var function PCIeFunction
+	if err = json.NewDecoder(resp.Body).Decode(&function); err != nil {
+		return nil, err
+	}
+	return &function, nil
+}
+
+// PCIeSlot is a PCIe slot of a chassis, as reported by its PCIeSlots resource
+type PCIeSlot struct {
+	// Number is the number of the slot, as used in the BIOS slot attributes (i.e. Slot3 on Dell)
+	Number   int
+	Label    string
+	Lanes    int
+	PCIeType string
+	SlotType string
+	State    string
+}
+
+// GetPCIeSlots returns the PCIe slots of the chassis of a computer system.
+// Services without PCIeSlots (i.e. older iDRAC and iLO firmware) return an empty list.
+func GetPCIeSlots(c redfishcommon.Client, systemURI string) ([]*PCIeSlot, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		Links struct {
+			Chassis redfishcommon.Links
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	slots := []*PCIeSlot{}
+	for _, chassisURI := range system.Links.Chassis.ToStrings() {
+		chassisSlots, err := getChassisPCIeSlots(c, chassisURI)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching the PCIe slots of %s: %s", chassisURI, err)
+		}
+		slots = append(slots, chassisSlots...)
+	}
+	return slots, nil
+}
+
+func getChassisPCIeSlots(c redfishcommon.Client, chassisURI string) ([]*PCIeSlot, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var chassis struct {
+		PCIeSlots redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&chassis); err != nil {
+		return nil, err
+	}
+	slots := []*PCIeSlot{}
+	if chassis.PCIeSlots == "" {
+		return slots, nil
+	}
+	resp, err = c.Get(string(chassis.PCIeSlots))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var pcieSlots struct {
+		Slots []struct {
+			Lanes    int
+			PCIeType string
+			SlotType string
+			Location struct {
+				PartLocation struct {
+					ServiceLabel         string
+					LocationOrdinalValue *int
+				}
+			}
+			Status redfishcommon.Status
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&pcieSlots); err != nil {
+		return nil, err
+	}
+	for i, raw := range pcieSlots.Slots {
+		slot := &PCIeSlot{
+			// Services not reporting the slot numbers list the slots in order
+			Number:   i + 1,
+			Label:    raw.Location.PartLocation.ServiceLabel,
+			Lanes:    raw.Lanes,
+			PCIeType: raw.PCIeType,
+			SlotType: raw.SlotType,
+			State:    string(raw.Status.State),
+		}
+		if raw.Location.PartLocation.LocationOrdinalValue != nil {
+			slot.Number = *raw.Location.PartLocation.LocationOrdinalValue
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
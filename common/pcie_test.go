@@ -0,0 +1,122 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPCIeDeviceSlotLabel(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected string
+	}{
+		{1, `{"Id":"3-0","Slot":{"Location":{"PartLocation":{"ServiceLabel":"PCIe Slot 3","LocationOrdinalValue":3}}}}`, "PCIe Slot 3"},
+		{2, `{"Id":"3-0","Slot":{"Location":{"PartLocation":{"LocationOrdinalValue":0}}}}`, "0"},
+		{3, `{"Id":"0-31"}`, ""},
+	}
+	for _, v := range cases {
+		var device PCIeDevice
+		if err := json.Unmarshal([]byte(v.body), &device); err != nil {
+			t.Fatalf("Test number %v failed %v", v.noTest, err)
+		}
+		if label := device.SlotLabel(); label != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, label)
+		}
+	}
+}
+
+func TestGetPCIeDevice(t *testing.T) {
+	const deviceURI = "/redfish/v1/Systems/System.Embedded.1/PCIeDevices/59-0"
+	cases := []struct {
+		noTest    int
+		responses []string
+		functions []string
+	}{
+		{1, []string{
+			`{"Id":"59-0","Links":{"PCIeFunctions":[{"@odata.id":"` + deviceURI + `/PCIeFunctions/59-0-0"}]}}`,
+			`{"Id":"59-0-0","FunctionId":0,"DeviceClass":"DisplayController","VendorId":"0x10de"}`,
+		}, []string{"DisplayController"}},
+		{2, []string{
+			`{"Id":"59-0","PCIeFunctions":{"@odata.id":"` + deviceURI + `/PCIeFunctions"}}`,
+			`{"Members":[{"@odata.id":"` + deviceURI + `/PCIeFunctions/0"},{"@odata.id":"` + deviceURI + `/PCIeFunctions/1"}],"Members@odata.count":2}`,
+			`{"Id":"0","FunctionId":0,"DeviceClass":"NetworkController"}`,
+			`{"Id":"1","FunctionId":1,"DeviceClass":"NetworkController"}`,
+		}, []string{"NetworkController", "NetworkController"}},
+		{3, []string{`{"Id":"0-31"}`}, []string{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		device, err := GetPCIeDevice(testClient, deviceURI)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(device.Functions) != len(v.functions) {
+			t.Errorf("Test number %v: expected %v functions, got %v", v.noTest, len(v.functions), len(device.Functions))
+			continue
+		}
+		for i, function := range device.Functions {
+			if function.DeviceClass != v.functions[i] {
+				t.Errorf("Test number %v: expected function %v to be %s, got %s", v.noTest, i, v.functions[i], function.DeviceClass)
+			}
+		}
+	}
+}
+
+func TestGetPCIeSlots(t *testing.T) {
+	const systemURI = "/redfish/v1/Systems/System.Embedded.1"
+	cases := []struct {
+		noTest    int
+		responses []string
+		expected  []PCIeSlot
+	}{
+		{1, []string{
+			`{"Links":{"Chassis":[{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1"}]}}`,
+			`{"PCIeSlots":{"@odata.id":"/redfish/v1/Chassis/System.Embedded.1/PCIeSlots"}}`,
+			`{"Slots":[{"Lanes":16,"PCIeType":"Gen4","SlotType":"FullLength","Location":{"PartLocation":{"ServiceLabel":"Slot 2","LocationOrdinalValue":2}},"Status":{"State":"Enabled"}},{"Lanes":8,"PCIeType":"Gen3","Status":{"State":"Absent"}}]}`,
+		}, []PCIeSlot{
+			{Number: 2, Label: "Slot 2", Lanes: 16, PCIeType: "Gen4", SlotType: "FullLength", State: "Enabled"},
+			{Number: 2, Lanes: 8, PCIeType: "Gen3", State: "Absent"},
+		}},
+		{2, []string{
+			`{"Links":{"Chassis":[{"@odata.id":"/redfish/v1/Chassis/1"}]}}`,
+			`{"Id":"1"}`,
+		}, []PCIeSlot{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		slots, err := GetPCIeSlots(testClient, systemURI)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(slots) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v slots, got %v", v.noTest, len(v.expected), len(slots))
+			continue
+		}
+		for i, slot := range slots {
+			if *slot != v.expected[i] {
+				t.Errorf("Test number %v: expected slot %+v, got %+v", v.noTest, v.expected[i], *slot)
+			}
+		}
+	}
+}
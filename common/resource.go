@@ -0,0 +1,72 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"strings"
+)
+
+// GetResource retrieves the raw properties of a resource, for the resources gofish does not model
+func GetResource(c redfishcommon.Client, uri string) (map[string]interface{}, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var resource map[string]interface{}
+	if err = json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// PatchResource updates the given properties of a resource, checking the status code of the response
+func PatchResource(c redfishcommon.Client, uri string, payload map[string]interface{}) error {
+	_, err := PatchResourceWithJob(c, uri, payload)
+	return err
+}
+
+// PatchResourceWithJob updates the given properties of a resource like PatchResource.
+// Returns the URI of the task the service created to apply the changes, if any.
+func PatchResourceWithJob(c redfishcommon.Client, uri string, payload map[string]interface{}) (string, error) {
+	resp, err := c.Patch(uri, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("error updating %s, status code was %d", uri, resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return resp.Header.Get("Location"), nil
+	}
+	return "", nil
+}
+
+// GetLinkURI follows path, the names of the nested properties (i.e. "LDAP", "Certificates"), from the resource at uri
+// and returns the URI of the link it ends with. Fails when the resource does not have the link.
+func GetLinkURI(c redfishcommon.Client, uri string, path ...string) (string, error) {
+	resource, err := GetResource(c, uri)
+	if err != nil {
+		return "", err
+	}
+	property := interface{}(resource)
+	for _, name := range path {
+		object, ok := property.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("%s has no %s link", uri, strings.Join(path, "."))
+		}
+		property = object[name]
+	}
+	link, ok := property.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("%s has no %s link", uri, strings.Join(path, "."))
+	}
+	linkURI, ok := link["@odata.id"].(string)
+	if !ok || linkURI == "" {
+		return "", fmt.Errorf("%s has no %s link", uri, strings.Join(path, "."))
+	}
+	return linkURI, nil
+}
@@ -0,0 +1,158 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// SecureBoot is the UEFI Secure Boot resource of a system.
+// gofish does not expose the Secure Boot databases, so it is decoded here.
+type SecureBoot struct {
+	ODataID               string `json:"@odata.id"`
+	SecureBootEnable      bool
+	SecureBootCurrentBoot string
+	SecureBootMode        string
+	SecureBootDatabases   redfishcommon.Link
+}
+
+// SecureBootDatabase is a UEFI Secure Boot database (i.e. db, dbx, KEK or PK) with the keys it holds
+type SecureBootDatabase struct {
+	ODataID    string `json:"@odata.id"`
+	ID         string `json:"Id"`
+	DatabaseID string `json:"DatabaseId"`
+	// Certificates and Signatures are the keys of the database, filled by GetSecureBootDatabases
+	Certificates []*Certificate         `json:"-"`
+	Signatures   []*SecureBootSignature `json:"-"`
+}
+
+// SecureBootSignature is a signature (hash) of a Secure Boot database
+type SecureBootSignature struct {
+	ODataID               string `json:"@odata.id"`
+	ID                    string `json:"Id"`
+	SignatureString       string
+	SignatureType         string
+	SignatureTypeRegistry string
+	UefiSignatureOwner    string
+}
+
+// GetSecureBoot retrieves the Secure Boot resource of the system at systemURI
+func GetSecureBoot(c redfishcommon.Client, systemURI string) (*SecureBoot, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		SecureBoot redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	if system.SecureBoot == "" {
+		return nil, fmt.Errorf("system %s does not support Secure Boot", systemURI)
+	}
+	secureBootResp, err := c.Get(string(system.SecureBoot))
+	if err != nil {
+		return nil, err
+	}
+	defer secureBootResp.Body.Close()
+	var secureBoot SecureBoot
+	if err = json.NewDecoder(secureBootResp.Body).Decode(&secureBoot); err != nil {
+		return nil, err
+	}
+	if secureBoot.ODataID == "" {
+		secureBoot.ODataID = string(system.SecureBoot)
+	}
+	return &secureBoot, nil
+}
+
+// GetSecureBootDatabases retrieves the databases of secureBoot along with their certificates and signatures.
+// BMCs not exposing the databases return an empty list.
+func GetSecureBootDatabases(c redfishcommon.Client, secureBoot *SecureBoot) ([]*SecureBootDatabase, error) {
+	databases := []*SecureBootDatabase{}
+	if secureBoot.SecureBootDatabases == "" {
+		return databases, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(secureBoot.SecureBootDatabases))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var database struct {
+			SecureBootDatabase
+			Certificates redfishcommon.Link
+			Signatures   redfishcommon.Link
+		}
+		err = json.NewDecoder(resp.Body).Decode(&database)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if database.ODataID == "" {
+			database.ODataID = link
+		}
+		result := database.SecureBootDatabase
+		if result.Certificates, err = getSecureBootCertificates(c, string(database.Certificates)); err != nil {
+			return nil, err
+		}
+		if result.Signatures, err = getSecureBootSignatures(c, string(database.Signatures)); err != nil {
+			return nil, err
+		}
+		databases = append(databases, &result)
+	}
+	return databases, nil
+}
+
+// getSecureBootCertificates retrieves the certificates of the collection at uri. A missing collection has none
+func getSecureBootCertificates(c redfishcommon.Client, uri string) ([]*Certificate, error) {
+	certificates := []*Certificate{}
+	if uri == "" {
+		return certificates, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, uri)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		certificate, err := GetCertificate(c, link)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, nil
+}
+
+// getSecureBootSignatures retrieves the signatures of the collection at uri. A missing collection has none
+func getSecureBootSignatures(c redfishcommon.Client, uri string) ([]*SecureBootSignature, error) {
+	signatures := []*SecureBootSignature{}
+	if uri == "" {
+		return signatures, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, uri)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		resp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var signature SecureBootSignature
+		err = json.NewDecoder(resp.Body).Decode(&signature)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if signature.ODataID == "" {
+			signature.ODataID = link
+		}
+		signatures = append(signatures, &signature)
+	}
+	return signatures, nil
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSecureBootDatabases(t *testing.T) {
+	const systemURI = "/redfish/v1/Systems/System.Embedded.1"
+	const databasesURI = systemURI + "/SecureBoot/SecureBootDatabases"
+	cases := []struct {
+		noTest       int
+		responses    []string
+		databases    []string
+		certificates int
+		signatures   int
+		shouldPass   bool
+	}{
+		{1, []string{
+			`{"Id":"System.Embedded.1","SecureBoot":{"@odata.id":"` + systemURI + `/SecureBoot"}}`,
+			`{"@odata.id":"` + systemURI + `/SecureBoot","SecureBootEnable":true,"SecureBootCurrentBoot":"Enabled","SecureBootMode":"DeployedMode",` +
+				`"SecureBootDatabases":{"@odata.id":"` + databasesURI + `"}}`,
+			`{"Members":[{"@odata.id":"` + databasesURI + `/db"}],"Members@odata.count":1}`,
+			`{"@odata.id":"` + databasesURI + `/db","Id":"db","DatabaseId":"db","Certificates":{"@odata.id":"` + databasesURI + `/db/Certificates"},` +
+				`"Signatures":{"@odata.id":"` + databasesURI + `/db/Signatures"}}`,
+			`{"Members":[{"@odata.id":"` + databasesURI + `/db/Certificates/1"}],"Members@odata.count":1}`,
+			`{"Id":"1","CertificateString":"-----BEGIN CERTIFICATE-----","CertificateType":"PEM"}`,
+			`{"Members":[{"@odata.id":"` + databasesURI + `/db/Signatures/1"}],"Members@odata.count":1}`,
+			`{"Id":"1","SignatureString":"3q2+7w==","SignatureType":"EFI_CERT_SHA256_GUID","SignatureTypeRegistry":"UEFI"}`,
+		}, []string{"db"}, 1, 1, true},
+		{2, []string{
+			`{"Id":"1","SecureBoot":{"@odata.id":"/redfish/v1/Systems/1/SecureBoot"}}`,
+			`{"@odata.id":"/redfish/v1/Systems/1/SecureBoot","SecureBootEnable":false,"SecureBootMode":"SetupMode"}`,
+		}, []string{}, 0, 0, true},
+		{3, []string{`{"Id":"1"}`}, nil, 0, 0, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		secureBoot, err := GetSecureBoot(testClient, systemURI)
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		databases, err := GetSecureBootDatabases(testClient, secureBoot)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(databases) != len(v.databases) {
+			t.Errorf("Test number %v: expected %d databases, got %d", v.noTest, len(v.databases), len(databases))
+			continue
+		}
+		for i, database := range databases {
+			if database.ID != v.databases[i] || len(database.Certificates) != v.certificates || len(database.Signatures) != v.signatures {
+				t.Errorf("Test number %v: unexpected database %+v", v.noTest, database)
+			}
+		}
+		if len(databases) > 0 && (databases[0].Signatures[0].SignatureType != "EFI_CERT_SHA256_GUID" ||
+			databases[0].Certificates[0].ODataID != databasesURI+"/db/Certificates/1") {
+			t.Errorf("Test number %v: unexpected keys %+v %+v", v.noTest, databases[0].Certificates[0], databases[0].Signatures[0])
+		}
+	}
+}
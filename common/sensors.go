@@ -0,0 +1,126 @@
+package common
+
+import (
+	"encoding/json"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// Sensor is a member of the Sensors collection of a chassis.
+type Sensor struct {
+	ODataID         string `json:"@odata.id"`
+	ID              string `json:"Id"`
+	Name            string
+	ReadingType     string
+	Reading         *float64
+	ReadingUnits    string
+	PhysicalContext string
+	Status          redfishcommon.Status
+	Thresholds      SensorThresholds
+	// ChassisID is the Id of the chassis the sensor belongs to. It is not part of the sensor payload.
+	ChassisID string `json:"-"`
+}
+
+// SensorThresholds holds the thresholds of a sensor. Thresholds not defined by the BMC are nil.
+type SensorThresholds struct {
+	LowerCaution  *SensorThreshold
+	LowerCritical *SensorThreshold
+	LowerFatal    *SensorThreshold
+	UpperCaution  *SensorThreshold
+	UpperCritical *SensorThreshold
+	UpperFatal    *SensorThreshold
+}
+
+// SensorThreshold is a single threshold of a sensor
+type SensorThreshold struct {
+	Reading *float64
+}
+
+// Readings returns the defined thresholds indexed by name (i.e. upper_critical).
+func (t SensorThresholds) Readings() map[string]float64 {
+	readings := make(map[string]float64)
+	for name, threshold := range map[string]*SensorThreshold{
+		"lower_caution":  t.LowerCaution,
+		"lower_critical": t.LowerCritical,
+		"lower_fatal":    t.LowerFatal,
+		"upper_caution":  t.UpperCaution,
+		"upper_critical": t.UpperCritical,
+		"upper_fatal":    t.UpperFatal,
+	} {
+		if threshold != nil && threshold.Reading != nil {
+			readings[name] = *threshold.Reading
+		}
+	}
+	return readings
+}
+
+// GetSensors retrieves the sensors of every chassis.
+// Chassis without a Sensors collection (i.e. BMCs implementing only Thermal and Power) are skipped.
+func GetSensors(c *gofish.APIClient) ([]*Sensor, error) {
+	chassis, err := c.Service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+	sensors := []*Sensor{}
+	for _, ch := range chassis {
+		chassisSensors, err := GetChassisSensors(c, ch.ODataID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sensor := range chassisSensors {
+			sensor.ChassisID = ch.ID
+		}
+		sensors = append(sensors, chassisSensors...)
+	}
+	return sensors, nil
+}
+
+// GetChassisSensors retrieves the sensors of the chassis at chassisURI, none if it has no Sensors collection
+func GetChassisSensors(c redfishcommon.Client, chassisURI string) ([]*Sensor, error) {
+	sensors := []*Sensor{}
+	sensorsURI, err := getSensorsURI(c, chassisURI)
+	if err != nil || sensorsURI == "" {
+		return sensors, err
+	}
+	collection, err := redfishcommon.GetCollection(c, sensorsURI)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		sensor, err := getSensor(c, link)
+		if err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, sensor)
+	}
+	return sensors, nil
+}
+
+// getSensorsURI returns the URI of the Sensors collection of a chassis, which gofish does not expose
+func getSensorsURI(c redfishcommon.Client, chassisURI string) (string, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Sensors redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return string(result.Sensors), nil
+}
+
+func getSensor(c redfishcommon.Client, uri string) (*Sensor, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var sensor Sensor
+	if err = json.NewDecoder(resp.Body).Decode(&sensor); err != nil {
+		return nil, err
+	}
+	return &sensor, nil
+}
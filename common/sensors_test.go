@@ -0,0 +1,33 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSensorThresholdsReadings(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected map[string]float64
+	}{
+		{1, `{"Thresholds":{"UpperCaution":{"Reading":42},"UpperCritical":{"Reading":47.5}}}`, map[string]float64{"upper_caution": 42, "upper_critical": 47.5}},
+		{2, `{"Thresholds":{"LowerCritical":{"Reading":null}}}`, map[string]float64{}},
+		{3, `{}`, map[string]float64{}},
+	}
+	for _, v := range cases {
+		var sensor Sensor
+		if err := json.Unmarshal([]byte(v.body), &sensor); err != nil {
+			t.Fatalf("Test number %v failed %v", v.noTest, err)
+		}
+		readings := sensor.Thresholds.Readings()
+		if len(readings) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, readings)
+		}
+		for name, value := range v.expected {
+			if readings[name] != value {
+				t.Errorf("Test number %v: expected %v=%v, got %v", v.noTest, name, value, readings[name])
+			}
+		}
+	}
+}
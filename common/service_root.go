@@ -0,0 +1,72 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"strconv"
+	"strings"
+)
+
+// ServiceRoot is the service root of a BMC, with the query parameters it supports.
+// gofish does not expose ProtocolFeaturesSupported, so the service root is decoded here.
+type ServiceRoot struct {
+	RedfishVersion            string
+	Vendor                    string
+	Product                   string
+	UUID                      string
+	ProtocolFeaturesSupported struct {
+		ExpandQuery struct {
+			ExpandAll bool
+			Levels    bool
+			Links     bool
+			NoLinks   bool
+			MaxLevels int
+		}
+		FilterQuery     bool
+		SelectQuery     bool
+		OnlyMemberQuery bool
+		ExcerptQuery    bool
+	}
+}
+
+// GetServiceRoot retrieves the service root at uri (i.e. /redfish/v1/)
+func GetServiceRoot(c redfishcommon.Client, uri string) (*ServiceRoot, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var root ServiceRoot
+	if err = json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// CompareRedfishVersions compares two Redfish versions (i.e. 1.11.0), returning a negative number when a is older
+// than b, 0 when they are the same and a positive number when a is newer. Missing components count as 0.
+func CompareRedfishVersions(a string, b string) (int, error) {
+	aParts := strings.Split(strings.TrimSpace(a), ".")
+	bParts := strings.Split(strings.TrimSpace(b), ".")
+	for len(aParts) < len(bParts) {
+		aParts = append(aParts, "0")
+	}
+	for len(bParts) < len(aParts) {
+		bParts = append(bParts, "0")
+	}
+	for i := range aParts {
+		aValue, err := strconv.Atoi(aParts[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid Redfish version %q", a)
+		}
+		bValue, err := strconv.Atoi(bParts[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid Redfish version %q", b)
+		}
+		if aValue != bValue {
+			return aValue - bValue, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetServiceRoot(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"RedfishVersion":"1.11.0","Vendor":"Dell","UUID":"4c4c4544","ProtocolFeaturesSupported":{"ExpandQuery":{"Levels":true,"MaxLevels":1},"SelectQuery":true}}`)),
+	})
+	root, err := GetServiceRoot(testClient, "/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Error getting the service root: %s", err)
+	}
+	if root.RedfishVersion != "1.11.0" || root.Vendor != "Dell" || root.UUID != "4c4c4544" {
+		t.Errorf("Unexpected service root %+v", root)
+	}
+	features := root.ProtocolFeaturesSupported
+	if !features.ExpandQuery.Levels || features.ExpandQuery.MaxLevels != 1 || !features.SelectQuery || features.FilterQuery {
+		t.Errorf("Unexpected protocol features %+v", features)
+	}
+}
+
+func TestCompareRedfishVersions(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		a          string
+		b          string
+		expected   int
+		shouldPass bool
+	}{
+		{1, "1.11.0", "1.6.0", 1, true},
+		{2, "1.6.0", "1.11", -1, true},
+		{3, "1.11", "1.11.0", 0, true},
+		{4, "1.x", "1.0", 0, false},
+	}
+	for _, v := range cases {
+		result, err := CompareRedfishVersions(v.a, v.b)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if (result > 0) != (v.expected > 0) || (result < 0) != (v.expected < 0) {
+			t.Errorf("Test number %v: comparing %s and %s expected %v, got %v", v.noTest, v.a, v.b, v.expected, result)
+		}
+	}
+}
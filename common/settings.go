@@ -0,0 +1,62 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"strings"
+)
+
+// SettingsObjectURI returns the URI of the settings object holding the pending changes of a resource,
+// as advertised in its @Redfish.Settings annotation. URIs of settings objects are returned as they are.
+func SettingsObjectURI(c redfishcommon.Client, uri string) (string, error) {
+	if strings.HasSuffix(strings.TrimSuffix(uri, "/"), "/Settings") {
+		return strings.TrimSuffix(uri, "/"), nil
+	}
+	resp, err := c.Get(uri)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var resource struct {
+		Settings struct {
+			SettingsObject redfishcommon.Link
+		} `json:"@Redfish.Settings"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+		return "", err
+	}
+	if resource.Settings.SettingsObject == "" {
+		return "", fmt.Errorf("%s has no settings object", uri)
+	}
+	return string(resource.Settings.SettingsObject), nil
+}
+
+// ClearDellPendingSettings discards the pending changes of a settings object through the Dell
+// DellManager.ClearPending action. It fails if a configuration job is already scheduled for them.
+func ClearDellPendingSettings(c redfishcommon.Client, settingsURI string) error {
+	resp, err := c.Post(settingsURI+"/Actions/Oem/DellManager.ClearPending", map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the pending settings were not cleared. Status code was %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DeleteSettings discards the pending changes of a settings object by deleting it,
+// which services without a dedicated action accept to revert the settings to the current values.
+func DeleteSettings(c redfishcommon.Client, settingsURI string) error {
+	resp, err := c.Delete(settingsURI)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the pending settings were not cleared. Status code was %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSettingsObjectURI(t *testing.T) {
+	const biosURI = "/redfish/v1/Systems/System.Embedded.1/Bios"
+	cases := []struct {
+		noTest     int
+		uri        string
+		body       string
+		expected   string
+		shouldPass bool
+	}{
+		{1, biosURI + "/Settings/", "", biosURI + "/Settings", true},
+		{2, biosURI, `{"@Redfish.Settings":{"SettingsObject":{"@odata.id":"` + biosURI + `/Settings"}}}`, biosURI + "/Settings", true},
+		{3, "/redfish/v1/Managers/iDRAC.Embedded.1/Attributes", `{"Attributes":{}}`, "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		settingsURI, err := SettingsObjectURI(testClient, v.uri)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if settingsURI != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, settingsURI)
+		}
+	}
+}
+
+func TestClearPendingSettings(t *testing.T) {
+	const settingsURI = "/redfish/v1/Systems/System.Embedded.1/Bios/Settings"
+	cases := []struct {
+		noTest     int
+		oem        OEMHandler
+		method     string
+		url        string
+		statusCode int
+		shouldPass bool
+	}{
+		{1, dellOEM{}, "POST", settingsURI + "/Actions/Oem/DellManager.ClearPending", http.StatusOK, true},
+		{2, dellOEM{}, "POST", settingsURI + "/Actions/Oem/DellManager.ClearPending", http.StatusBadRequest, false},
+		{3, GenericOEM, "DELETE", settingsURI, http.StatusNoContent, true},
+		{4, GenericOEM, "DELETE", settingsURI, http.StatusMethodNotAllowed, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[v.method] = append(testClient.CustomReturnForActions[v.method], &http.Response{
+			StatusCode: v.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		err := v.oem.ClearPendingSettings(testClient, settingsURI)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].Action != v.method || calls[0].URL != v.url {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
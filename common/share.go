@@ -0,0 +1,116 @@
+package common
+
+import (
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"strings"
+)
+
+// Network share types
+const (
+	NFSShareType   string = "NFS"
+	CIFSShareType  string = "CIFS"
+	HTTPShareType  string = "HTTP"
+	HTTPSShareType string = "HTTPS"
+)
+
+// Share is a network share the BMC pulls files from (i.e. update packages, ISO images or configuration profiles)
+type Share struct {
+	IPAddress  string
+	ShareType  string
+	ShareName  string
+	Username   string
+	Password   string
+	IgnoreCert bool
+}
+
+// Validate checks the settings of the share are consistent, as the BMCs only report a generic error when they are not
+func (s *Share) Validate() error {
+	switch s.ShareType {
+	case NFSShareType, HTTPShareType, HTTPSShareType:
+	case CIFSShareType:
+		if s.Username == "" || s.Password == "" {
+			return fmt.Errorf("CIFS shares need a username and a password")
+		}
+	default:
+		return fmt.Errorf("unsupported share type %s", s.ShareType)
+	}
+	if (s.Username == "") != (s.Password == "") {
+		return fmt.Errorf("username and password must be set together")
+	}
+	if s.IgnoreCert && s.ShareType != HTTPSShareType {
+		return fmt.Errorf("ignore_cert only applies to HTTPS shares")
+	}
+	return nil
+}
+
+// URI returns the URI of a file of the share (i.e. nfs://10.0.0.1/share/BIOS.EXE).
+// The credentials are not included, they are sent apart.
+func (s *Share) URI(path string) string {
+	uri := fmt.Sprintf("%s://%s/%s", strings.ToLower(s.ShareType), s.IPAddress, strings.Trim(s.ShareName, "/"))
+	if path = strings.TrimPrefix(path, "/"); path != "" {
+		uri += "/" + path
+	}
+	return uri
+}
+
+// TransferProtocol returns the Redfish transfer protocol of the share, as used by SimpleUpdate and InsertMedia
+func (s *Share) TransferProtocol() string {
+	return s.ShareType
+}
+
+// Parameters returns the share as the ShareParameters of the Dell OEM actions (i.e. ImportSystemConfiguration)
+func (s *Share) Parameters() map[string]interface{} {
+	parameters := map[string]interface{}{
+		"IPAddress": s.IPAddress,
+		"ShareType": s.ShareType,
+		"ShareName": s.ShareName,
+	}
+	if s.Username != "" {
+		parameters["UserName"] = s.Username
+		parameters["Password"] = s.Password
+	}
+	if s.ShareType == HTTPSShareType {
+		parameters["IgnoreCertificateWarning"] = "Disabled"
+		if s.IgnoreCert {
+			parameters["IgnoreCertificateWarning"] = "Enabled"
+		}
+	}
+	return parameters
+}
+
+// addCredentials adds the credentials of the share, if any, to the payload of a standard Redfish action
+func (s *Share) addCredentials(payload map[string]interface{}) {
+	if s == nil || s.Username == "" {
+		return
+	}
+	payload["Username"] = s.Username
+	payload["Password"] = s.Password
+}
+
+// InsertVirtualMedia mounts an image on a virtual media through the VirtualMedia.InsertMedia action.
+// Unlike gofish, it sends the transfer protocol and the credentials of share, which can be nil for public images.
+func InsertVirtualMedia(c redfishcommon.Client, virtualMediaURI string, image string, share *Share) error {
+	payload := map[string]interface{}{
+		"Image":          image,
+		"Inserted":       true,
+		"WriteProtected": true,
+	}
+	if share != nil {
+		payload["TransferProtocolType"] = share.TransferProtocol()
+		if share.Username != "" {
+			payload["UserName"] = share.Username
+			payload["Password"] = share.Password
+		}
+	}
+	resp, err := c.Post(virtualMediaURI+"/Actions/VirtualMedia.InsertMedia", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the image was not mounted. Status code was %d", resp.StatusCode)
+	}
+	return nil
+}
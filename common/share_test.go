@@ -0,0 +1,100 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestShareValidate(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		share      Share
+		shouldPass bool
+	}{
+		{1, Share{IPAddress: "10.0.0.1", ShareType: NFSShareType, ShareName: "/repo"}, true},
+		{2, Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "repo"}, false},
+		{3, Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "repo", Username: "admin", Password: "secret"}, true},
+		{4, Share{IPAddress: "10.0.0.1", ShareType: HTTPShareType, ShareName: "repo", Username: "admin"}, false},
+		{5, Share{IPAddress: "10.0.0.1", ShareType: HTTPSShareType, ShareName: "repo", IgnoreCert: true}, true},
+		{6, Share{IPAddress: "10.0.0.1", ShareType: NFSShareType, ShareName: "repo", IgnoreCert: true}, false},
+		{7, Share{IPAddress: "10.0.0.1", ShareType: "FTP", ShareName: "repo"}, false},
+	}
+	for _, v := range cases {
+		err := v.share.Validate()
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
+
+func TestShareURI(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		share    Share
+		path     string
+		expected string
+	}{
+		{1, Share{IPAddress: "10.0.0.1", ShareType: NFSShareType, ShareName: "/repo/"}, "BIOS.EXE", "nfs://10.0.0.1/repo/BIOS.EXE"},
+		{2, Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "repo"}, "/FOLDER1/NIC.EXE", "cifs://10.0.0.1/repo/FOLDER1/NIC.EXE"},
+		{3, Share{IPAddress: "10.0.0.1", ShareType: HTTPSShareType, ShareName: "repo"}, "", "https://10.0.0.1/repo"},
+	}
+	for _, v := range cases {
+		if uri := v.share.URI(v.path); uri != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, uri)
+		}
+	}
+}
+
+func TestShareParameters(t *testing.T) {
+	share := Share{IPAddress: "10.0.0.1", ShareType: HTTPSShareType, ShareName: "repo", Username: "admin", Password: "secret", IgnoreCert: true}
+	parameters := share.Parameters()
+	expected := map[string]interface{}{
+		"IPAddress":                "10.0.0.1",
+		"ShareType":                HTTPSShareType,
+		"ShareName":                "repo",
+		"UserName":                 "admin",
+		"Password":                 "secret",
+		"IgnoreCertificateWarning": "Enabled",
+	}
+	if len(parameters) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, parameters)
+	}
+	for key, value := range expected {
+		if parameters[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, parameters[key])
+		}
+	}
+	nfs := Share{IPAddress: "10.0.0.1", ShareType: NFSShareType, ShareName: "/repo"}
+	if _, ok := nfs.Parameters()["UserName"]; ok {
+		t.Errorf("shares without credentials must not send a user name")
+	}
+}
+
+func TestInsertVirtualMedia(t *testing.T) {
+	const virtualMediaURI = "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD"
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	})
+	share := &Share{IPAddress: "10.0.0.1", ShareType: CIFSShareType, ShareName: "repo", Username: "admin", Password: "secret"}
+	if err := InsertVirtualMedia(testClient, virtualMediaURI, share.URI("update.iso"), share); err != nil {
+		t.Fatalf("InsertVirtualMedia failed %v", err)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != virtualMediaURI+"/Actions/VirtualMedia.InsertMedia" {
+		t.Fatalf("unexpected requests %v", calls)
+	}
+	for _, expected := range []string{"Image:cifs://10.0.0.1/repo/update.iso", "TransferProtocolType:CIFS", "UserName:admin", "Password:secret"} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("expected %s in the payload %s", expected, calls[0].Payload)
+		}
+	}
+}
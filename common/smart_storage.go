@@ -0,0 +1,94 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// SmartStorageConfig is the configuration of an HPE Smart Array controller (HpeSmartStorageConfig).
+// Its changes are made to the settings object and applied on the next reboot.
+type SmartStorageConfig struct {
+	ODataID string `json:"@odata.id"`
+	// Location identifies the controller (i.e. Slot 0 for the embedded one)
+	Location                            string
+	ReadCachePercent                    *int
+	WriteCacheBypassThresholdKiB        *int
+	WriteCacheWithoutBackupPowerEnabled *bool
+	DriveWriteCache                     string
+}
+
+// SmartStorageBattery is the HPE Smart Storage battery backing the write cache of the Smart Array controllers of a chassis
+type SmartStorageBattery struct {
+	Index              int
+	Model              string
+	SerialNumber       string
+	ChargeLevelPercent int
+	Status             redfishcommon.Status
+}
+
+// GetSmartStorageConfigs retrieves the configuration of every Smart Array controller of the system at systemURI,
+// linked from its Oem.Hpe.SmartStorageConfig property
+func GetSmartStorageConfigs(c redfishcommon.Client, systemURI string) ([]*SmartStorageConfig, error) {
+	resp, err := c.Get(systemURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var system struct {
+		Oem struct {
+			Hpe struct {
+				SmartStorageConfig redfishcommon.Links
+			}
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&system); err != nil {
+		return nil, err
+	}
+	configs := []*SmartStorageConfig{}
+	for _, uri := range system.Oem.Hpe.SmartStorageConfig.ToStrings() {
+		config, err := GetSmartStorageConfig(c, uri)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching %s: %s", uri, err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// GetSmartStorageConfig retrieves the configuration of a Smart Array controller, or its pending changes from the settings object
+func GetSmartStorageConfig(c redfishcommon.Client, uri string) (*SmartStorageConfig, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var config SmartStorageConfig
+	if err = json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// GetSmartStorageBatteries retrieves the Smart Storage batteries of the chassis at chassisURI, from its Oem.Hpe.SmartStorageBattery property
+func GetSmartStorageBatteries(c redfishcommon.Client, chassisURI string) ([]*SmartStorageBattery, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var chassis struct {
+		Oem struct {
+			Hpe struct {
+				SmartStorageBattery []*SmartStorageBattery
+			}
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&chassis); err != nil {
+		return nil, err
+	}
+	if chassis.Oem.Hpe.SmartStorageBattery == nil {
+		return []*SmartStorageBattery{}, nil
+	}
+	return chassis.Oem.Hpe.SmartStorageBattery, nil
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSmartStorageConfigs(t *testing.T) {
+	cases := []struct {
+		noTest    int
+		bodies    []string
+		locations []string
+	}{
+		{1, []string{`{"Id":"1","Oem":{"Hpe":{"SmartStorageConfig":[{"@odata.id":"/redfish/v1/Systems/1/SmartStorageConfig"},{"@odata.id":"/redfish/v1/Systems/1/SmartStorageConfig1"}]}}}`,
+			`{"@odata.id":"/redfish/v1/Systems/1/SmartStorageConfig","Location":"Slot 0","ReadCachePercent":10}`,
+			`{"@odata.id":"/redfish/v1/Systems/1/SmartStorageConfig1","Location":"Slot 3","ReadCachePercent":0}`}, []string{"Slot 0", "Slot 3"}},
+		{2, []string{`{"Id":"System.Embedded.1"}`}, []string{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.bodies {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		configs, err := GetSmartStorageConfigs(testClient, "/redfish/v1/Systems/1")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(configs) != len(v.locations) {
+			t.Errorf("Test number %v: expected %d controllers, got %d", v.noTest, len(v.locations), len(configs))
+			continue
+		}
+		for i, config := range configs {
+			if config.Location != v.locations[i] || config.ReadCachePercent == nil {
+				t.Errorf("Test number %v: unexpected controller %+v", v.noTest, config)
+			}
+		}
+	}
+}
+
+func TestGetSmartStorageBatteries(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected []SmartStorageBattery
+	}{
+		{1, `{"Id":"1","Oem":{"Hpe":{"SmartStorageBattery":[{"Index":1,"Model":"875241-B21","ChargeLevelPercent":80,"Status":{"Health":"Warning","State":"Enabled"}}]}}}`,
+			[]SmartStorageBattery{{Index: 1, Model: "875241-B21", ChargeLevelPercent: 80, Status: redfishcommon.Status{Health: "Warning", State: "Enabled"}}}},
+		{2, `{"Id":"1"}`, []SmartStorageBattery{}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		batteries, err := GetSmartStorageBatteries(testClient, "/redfish/v1/Chassis/1")
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if len(batteries) != len(v.expected) {
+			t.Errorf("Test number %v: expected %d batteries, got %d", v.noTest, len(v.expected), len(batteries))
+			continue
+		}
+		for i, battery := range batteries {
+			if *battery != v.expected[i] {
+				t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected[i], *battery)
+			}
+		}
+	}
+}
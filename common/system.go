@@ -0,0 +1,95 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"time"
+)
+
+// GetSystem returns the computer system with the given id (i.e. System.Embedded.1 or 1). An empty id returns
+// the first one, which is the only one of most BMCs. Blade chassis and multi-node trays expose several.
+func GetSystem(c *gofish.APIClient, id string) (*redfish.ComputerSystem, error) {
+	systems, err := c.Service.Systems()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching computer systems: %s", err)
+	}
+	if len(systems) == 0 {
+		return nil, fmt.Errorf("no computer systems found")
+	}
+	if id == "" {
+		return systems[0], nil
+	}
+	ids := []string{}
+	for _, system := range systems {
+		if system.ID == id {
+			return system, nil
+		}
+		ids = append(ids, system.ID)
+	}
+	return nil, fmt.Errorf("computer system %s not found. Available systems: %v", id, ids)
+}
+
+// GetManager returns the manager with the given id (i.e. iDRAC.Embedded.1 or 1). An empty id returns the first one.
+func GetManager(c *gofish.APIClient, id string) (*redfish.Manager, error) {
+	managers, err := c.Service.Managers()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching managers: %s", err)
+	}
+	if len(managers) == 0 {
+		return nil, fmt.Errorf("no managers found")
+	}
+	if id == "" {
+		return managers[0], nil
+	}
+	ids := []string{}
+	for _, manager := range managers {
+		if manager.ID == id {
+			return manager, nil
+		}
+		ids = append(ids, manager.ID)
+	}
+	return nil, fmt.Errorf("manager %s not found. Available managers: %v", id, ids)
+}
+
+// bootOverrideState is the part of a computer system holding its boot source override
+type bootOverrideState struct {
+	Boot struct {
+		BootSourceOverrideEnabled string
+		BootSourceOverrideTarget  string
+	}
+}
+
+// ClearBootOverrideAndVerify disables the boot source override of the system at systemURI when it targets target
+// (i.e. Cd, set to boot from a virtual media) and reads it back until it is disabled, disabling it again up to attempts times,
+// timeBetweenAttempts seconds apart. The overrides targeting other devices are left as they are.
+func ClearBootOverrideAndVerify(c redfishcommon.Client, systemURI string, target redfish.BootSourceOverrideTarget, attempts int, timeBetweenAttempts int) error {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.Get(systemURI)
+		if err != nil {
+			return fmt.Errorf("error fetching %s: %s", systemURI, err)
+		}
+		var state bootOverrideState
+		err = json.NewDecoder(resp.Body).Decode(&state)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error decoding %s: %s", systemURI, err)
+		}
+		enabled := state.Boot.BootSourceOverrideEnabled
+		if enabled == "" || enabled == string(redfish.DisabledBootSourceOverrideEnabled) || state.Boot.BootSourceOverrideTarget != string(target) {
+			return nil
+		}
+		if attempt == attempts {
+			return fmt.Errorf("the boot override from %s of %s is still %s after %d attempts to clear it", target, systemURI, enabled, attempts)
+		}
+		err = PatchResource(c, systemURI, map[string]interface{}{
+			"Boot": map[string]interface{}{"BootSourceOverrideEnabled": redfish.DisabledBootSourceOverrideEnabled},
+		})
+		if err != nil {
+			return err
+		}
+		time.Sleep(time.Duration(timeBetweenAttempts) * time.Second)
+	}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClearBootOverrideAndVerify(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		bodies     []string
+		patches    int
+		shouldPass bool
+	}{
+		{1, []string{`{"Boot":{"BootSourceOverrideEnabled":"Disabled","BootSourceOverrideTarget":"None"}}`}, 0, true},
+		{2, []string{`{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Pxe"}}`}, 0, true},
+		{3, []string{`{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`, `{"Boot":{"BootSourceOverrideEnabled":"Disabled","BootSourceOverrideTarget":"Cd"}}`}, 1, true},
+		{4, []string{`{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`, `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`, `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`, `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`}, 3, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.bodies {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		for i := 0; i < v.patches; i++ {
+			testClient.CustomReturnForActions[http.MethodPatch] = append(testClient.CustomReturnForActions[http.MethodPatch], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			})
+		}
+		err := ClearBootOverrideAndVerify(testClient, "/redfish/v1/Systems/System.Embedded.1", redfish.CdBootSourceOverrideTarget, 3, 0)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		patches := 0
+		for _, call := range testClient.CapturedCalls() {
+			if call.Action == http.MethodPatch {
+				patches++
+			}
+		}
+		if patches != v.patches {
+			t.Errorf("Test number %v: expected %d patches, got %d", v.noTest, v.patches, patches)
+		}
+	}
+}
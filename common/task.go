@@ -0,0 +1,166 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"strings"
+)
+
+// tasksURI is the collection of the TaskService tasks
+const tasksURI string = "/redfish/v1/TaskService/Tasks"
+
+// Task is a TaskService task or a job from the iDRAC job queue, decoded into the same fields
+type Task struct {
+	ODataID         string
+	ID              string
+	Name            string
+	State           string
+	Status          string
+	PercentComplete int
+	StartTime       string
+	EndTime         string
+	// Type is the Dell job type (i.e. BIOSConfiguration). Empty for TaskService tasks, which have none
+	Type string
+	// Messages are the messages of the task, oldest first
+	Messages []TaskMessage
+	// PackageURI is the image of the SimpleUpdate request the task runs, from its payload.
+	// Empty when the BMC does not report the payload of its tasks, as iDRAC jobs
+	PackageURI string
+}
+
+// TaskMessage is a message reported by a task
+type TaskMessage struct {
+	MessageID string
+	Message   string
+	Severity  string
+}
+
+// TaskURI returns the URI of a task from its Id. iDRAC jobs (JID_XXXX) are read from the iDRAC job queue,
+// which holds more details than the TaskService, the rest from the TaskService.
+func TaskURI(taskID string) string {
+	if strings.HasPrefix(taskID, "JID_") {
+		return dellJobsURI + "/" + taskID
+	}
+	return tasksURI + "/" + taskID
+}
+
+// GetTask retrieves a TaskService task or an iDRAC job
+func GetTask(c redfishcommon.Client, taskURI string) (*Task, error) {
+	resp, err := c.Get(taskURI)
+	if err != nil {
+		return nil, WrapHTTPError(err)
+	}
+	defer resp.Body.Close()
+	var raw struct {
+		ODataID         string `json:"@odata.id"`
+		ID              string `json:"Id"`
+		Name            string
+		TaskState       string
+		TaskStatus      string
+		PercentComplete int
+		StartTime       string
+		EndTime         string
+		Messages        []struct {
+			MessageID string `json:"MessageId"`
+			Message   string
+			Severity  string
+		}
+		Payload struct {
+			JSONBody string `json:"JsonBody"`
+		}
+		// iDRAC jobs
+		JobState       string
+		JobType        string
+		Message        string
+		MessageID      string `json:"MessageId"`
+		CompletionTime string
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	task := &Task{
+		ODataID:         raw.ODataID,
+		ID:              raw.ID,
+		Name:            raw.Name,
+		State:           raw.TaskState,
+		Status:          raw.TaskStatus,
+		PercentComplete: raw.PercentComplete,
+		StartTime:       raw.StartTime,
+		EndTime:         raw.EndTime,
+		Messages:        []TaskMessage{},
+	}
+	if task.ODataID == "" {
+		task.ODataID = taskURI
+	}
+	if raw.Payload.JSONBody != "" {
+		var body struct {
+			ImageURI string
+		}
+		if err := json.Unmarshal([]byte(raw.Payload.JSONBody), &body); err == nil {
+			task.PackageURI = body.ImageURI
+		}
+	}
+	for _, message := range raw.Messages {
+		task.Messages = append(task.Messages, TaskMessage{MessageID: message.MessageID, Message: message.Message, Severity: message.Severity})
+	}
+	if raw.JobState != "" {
+		task.State = raw.JobState
+		task.Type = raw.JobType
+		task.EndTime = raw.CompletionTime
+		if raw.Message != "" {
+			task.Messages = append(task.Messages, TaskMessage{MessageID: raw.MessageID, Message: raw.Message})
+		}
+	}
+	return task, nil
+}
+
+// Finished reports if the task has reached a final state
+func (t *Task) Finished() bool {
+	return StateFinished(t.State)
+}
+
+// StateFinished reports if the state of a task (TaskState) or iDRAC job (JobState) is final
+func StateFinished(state string) bool {
+	switch state {
+	case "Completed", "CompletedWithErrors", "Failed", "Killed", "Exception", "Cancelling", "Cancelled":
+		return true
+	}
+	return false
+}
+
+// GetTasks retrieves every TaskService task, finished or not.
+// BMCs without a TaskService have no tasks.
+func GetTasks(c redfishcommon.Client) ([]*Task, error) {
+	collection, err := redfishcommon.GetCollection(c, tasksURI)
+	if err != nil {
+		if IsNotFound(err) {
+			return []*Task{}, nil
+		}
+		return nil, err
+	}
+	tasks := []*Task{}
+	for _, link := range collection.ItemLinks {
+		task, err := GetTask(c, link)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// GetActiveTasks retrieves the TaskService tasks that have not finished yet.
+// BMCs without a TaskService have no active tasks.
+func GetActiveTasks(c redfishcommon.Client) ([]*Task, error) {
+	tasks, err := GetTasks(c)
+	if err != nil {
+		return nil, err
+	}
+	active := []*Task{}
+	for _, task := range tasks {
+		if !task.Finished() {
+			active = append(active, task)
+		}
+	}
+	return active, nil
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetTask(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		body     string
+		expected Task
+	}{
+		{1, `{"@odata.id":"/redfish/v1/TaskService/Tasks/1","Id":"1","Name":"Update","TaskState":"Completed","TaskStatus":"OK","PercentComplete":100,"StartTime":"2026-10-16T10:00:00Z","EndTime":"2026-10-16T10:05:00Z","Messages":[{"MessageId":"Base.1.8.Success","Message":"Done","Severity":"OK"}]}`,
+			Task{ODataID: "/redfish/v1/TaskService/Tasks/1", ID: "1", Name: "Update", State: "Completed", Status: "OK", PercentComplete: 100, StartTime: "2026-10-16T10:00:00Z", EndTime: "2026-10-16T10:05:00Z", Messages: []TaskMessage{{MessageID: "Base.1.8.Success", Message: "Done", Severity: "OK"}}}},
+		{2, `{"Id":"JID_000000000001","Name":"Configure: BIOS.Setup.1-1","JobState":"Failed","JobType":"BIOSConfiguration","PercentComplete":100,"StartTime":"TIME_NOW","CompletionTime":"2026-10-16T10:05:00","Message":"Job failed.","MessageId":"SYS051"}`,
+			Task{ODataID: "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_000000000001", ID: "JID_000000000001", Name: "Configure: BIOS.Setup.1-1", State: "Failed", PercentComplete: 100, StartTime: "TIME_NOW", EndTime: "2026-10-16T10:05:00", Type: "BIOSConfiguration", Messages: []TaskMessage{{MessageID: "SYS051", Message: "Job failed."}}}},
+		{3, `{"@odata.id":"/redfish/v1/TaskService/Tasks/3","Id":"3","Name":"Update","TaskState":"Running","Payload":{"TargetUri":"/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate","JsonBody":"{\"ImageURI\":\"http://repo/ilo5_278.bin\"}"}}`,
+			Task{ODataID: "/redfish/v1/TaskService/Tasks/3", ID: "3", Name: "Update", State: "Running", Messages: []TaskMessage{}, PackageURI: "http://repo/ilo5_278.bin"}},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(v.body)),
+		})
+		task, err := GetTask(testClient, TaskURI(v.expected.ID))
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !reflect.DeepEqual(*task, v.expected) {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, *task)
+		}
+	}
+}
+
+func TestGetActiveTasks(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	for _, body := range []string{
+		`{"Members":[{"@odata.id":"/redfish/v1/TaskService/Tasks/1"},{"@odata.id":"/redfish/v1/TaskService/Tasks/2"}],"Members@odata.count":2}`,
+		`{"Id":"1","Name":"Update","TaskState":"Completed"}`,
+		`{"Id":"2","Name":"Update","TaskState":"Running"}`,
+	} {
+		testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		})
+	}
+	tasks, err := GetActiveTasks(testClient)
+	if err != nil {
+		t.Fatalf("Error getting the active tasks: %s", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "2" {
+		t.Errorf("Expected task 2 to be the only active one, got %+v", tasks)
+	}
+}
+
+func TestStateFinished(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		state    string
+		expected bool
+	}{
+		{1, "Completed", true},
+		{2, "Exception", true},
+		{3, "Failed", true},
+		{4, "Running", false},
+		{5, "Scheduled", false},
+		{6, "Cancelled", true},
+		{7, "", false},
+	}
+	for _, v := range cases {
+		if finished := StateFinished(v.state); finished != v.expected {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, finished)
+		}
+	}
+}
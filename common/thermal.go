@@ -0,0 +1,95 @@
+package common
+
+import (
+	"encoding/json"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+)
+
+// ThermalSubsystem is the DMTF ThermalSubsystem of a chassis, which replaces the Thermal resource in newer BMCs.
+// gofish does not support it, so it is decoded here.
+type ThermalSubsystem struct {
+	ODataID       string `json:"@odata.id"`
+	FanRedundancy []RedundantGroup
+	// Fans are the members of the Fans collection, filled by GetThermalSubsystem
+	Fans []*Fan `json:"-"`
+}
+
+// RedundantGroup is a redundancy group of a ThermalSubsystem (i.e. the fans backing each other up)
+type RedundantGroup struct {
+	RedundancyType      string
+	MaxSupportedInGroup int
+	MinNeededInGroup    int
+	Status              redfishcommon.Status
+}
+
+// Fan is a member of the Fans collection of a ThermalSubsystem
+type Fan struct {
+	ODataID      string `json:"@odata.id"`
+	ID           string `json:"Id"`
+	Name         string
+	SpeedPercent struct {
+		Reading  *float64
+		SpeedRPM *float64
+	}
+	Status redfishcommon.Status
+}
+
+// GetThermalSubsystem retrieves the ThermalSubsystem of the chassis at chassisURI along with its fans.
+// It returns nil for the chassis not exposing it, which only have the Thermal resource.
+func GetThermalSubsystem(c redfishcommon.Client, chassisURI string) (*ThermalSubsystem, error) {
+	resp, err := c.Get(chassisURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var chassis struct {
+		ThermalSubsystem redfishcommon.Link
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&chassis); err != nil {
+		return nil, err
+	}
+	if chassis.ThermalSubsystem == "" {
+		return nil, nil
+	}
+	subsystemResp, err := c.Get(string(chassis.ThermalSubsystem))
+	if err != nil {
+		return nil, err
+	}
+	defer subsystemResp.Body.Close()
+	var subsystem struct {
+		ThermalSubsystem
+		Fans redfishcommon.Link
+	}
+	if err = json.NewDecoder(subsystemResp.Body).Decode(&subsystem); err != nil {
+		return nil, err
+	}
+	result := subsystem.ThermalSubsystem
+	if result.ODataID == "" {
+		result.ODataID = string(chassis.ThermalSubsystem)
+	}
+	result.Fans = []*Fan{}
+	if subsystem.Fans == "" {
+		return &result, nil
+	}
+	collection, err := redfishcommon.GetCollection(c, string(subsystem.Fans))
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range collection.ItemLinks {
+		fanResp, err := c.Get(link)
+		if err != nil {
+			return nil, err
+		}
+		var fan Fan
+		err = json.NewDecoder(fanResp.Body).Decode(&fan)
+		fanResp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if fan.ODataID == "" {
+			fan.ODataID = link
+		}
+		result.Fans = append(result.Fans, &fan)
+	}
+	return &result, nil
+}
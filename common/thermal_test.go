@@ -0,0 +1,71 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetThermalSubsystem(t *testing.T) {
+	const chassisURI = "/redfish/v1/Chassis/1"
+	const subsystemURI = chassisURI + "/ThermalSubsystem"
+	cases := []struct {
+		noTest     int
+		responses  []string
+		expected   bool
+		redundancy string
+		fans       []string
+	}{
+		{1, []string{
+			`{"Id":"1","ThermalSubsystem":{"@odata.id":"` + subsystemURI + `"}}`,
+			`{"Id":"ThermalSubsystem","FanRedundancy":[{"RedundancyType":"NPlusM","MaxSupportedInGroup":6,"MinNeededInGroup":5,"Status":{"Health":"OK"}}],` +
+				`"Fans":{"@odata.id":"` + subsystemURI + `/Fans"}}`,
+			`{"Members":[{"@odata.id":"` + subsystemURI + `/Fans/1"}],"Members@odata.count":1}`,
+			`{"Id":"1","Name":"Fan 1","SpeedPercent":{"Reading":42,"SpeedRPM":5880},"Status":{"Health":"OK","State":"Enabled"}}`,
+		}, true, "NPlusM", []string{"1"}},
+		{2, []string{
+			`{"Id":"1","ThermalSubsystem":{"@odata.id":"` + subsystemURI + `"}}`,
+			`{"@odata.id":"` + subsystemURI + `","Id":"ThermalSubsystem"}`,
+		}, true, "", []string{}},
+		{3, []string{`{"Id":"1","Thermal":{"@odata.id":"` + chassisURI + `/Thermal"}}`}, false, "", nil},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.responses {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		subsystem, err := GetThermalSubsystem(testClient, chassisURI)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if (subsystem != nil) != v.expected {
+			t.Errorf("Test number %v: expected a thermal subsystem %v, got %+v", v.noTest, v.expected, subsystem)
+			continue
+		}
+		if subsystem == nil {
+			continue
+		}
+		if subsystem.ODataID != subsystemURI {
+			t.Errorf("Test number %v: expected URI %s, got %s", v.noTest, subsystemURI, subsystem.ODataID)
+		}
+		if v.redundancy != "" && (len(subsystem.FanRedundancy) != 1 || subsystem.FanRedundancy[0].RedundancyType != v.redundancy) {
+			t.Errorf("Test number %v: unexpected redundancy %+v", v.noTest, subsystem.FanRedundancy)
+		}
+		if len(subsystem.Fans) != len(v.fans) {
+			t.Errorf("Test number %v: expected %d fans, got %d", v.noTest, len(v.fans), len(subsystem.Fans))
+			continue
+		}
+		for i, fan := range subsystem.Fans {
+			if fan.ID != v.fans[i] || fan.ODataID != subsystemURI+"/Fans/"+v.fans[i] || fan.SpeedPercent.Reading == nil {
+				t.Errorf("Test number %v: unexpected fan %+v", v.noTest, fan)
+			}
+		}
+	}
+}
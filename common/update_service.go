@@ -0,0 +1,55 @@
+package common
+
+import (
+	"encoding/json"
+	"github.com/stmcginnis/gofish"
+)
+
+// UpdateServiceCapabilities describes how firmware can be pushed to a BMC
+type UpdateServiceCapabilities struct {
+	ServiceEnabled bool
+	// HTTPPushURI accepts images POSTed as the request body
+	HTTPPushURI string
+	// MultipartHTTPPushURI accepts images POSTed as multipart/form-data
+	MultipartHTTPPushURI string
+	// SimpleUpdateURI is the target of the SimpleUpdate action. Empty when it is not supported
+	SimpleUpdateURI string
+	// TransferProtocols are the protocols SimpleUpdate can pull images with
+	TransferProtocols []string
+	// MaxImageSizeBytes is the largest image the service accepts. 0 when the service does not report it
+	MaxImageSizeBytes int64
+}
+
+// GetUpdateServiceCapabilities returns the capabilities of the update service.
+// gofish does not expose the multipart push URI nor the maximum image size, so they are decoded here.
+func GetUpdateServiceCapabilities(c *gofish.APIClient) (*UpdateServiceCapabilities, error) {
+	updateService, err := c.Service.UpdateService()
+	if err != nil {
+		return nil, err
+	}
+	capabilities := &UpdateServiceCapabilities{
+		ServiceEnabled:    updateService.ServiceEnabled,
+		HTTPPushURI:       updateService.HTTPPushURI,
+		SimpleUpdateURI:   updateService.UpdateServiceTarget,
+		TransferProtocols: updateService.TransferProtocol,
+	}
+	if capabilities.TransferProtocols == nil {
+		capabilities.TransferProtocols = []string{}
+	}
+
+	resp, err := c.Get(updateService.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var extra struct {
+		MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+		MaxImageSizeBytes    int64
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&extra); err != nil {
+		return nil, err
+	}
+	capabilities.MultipartHTTPPushURI = extra.MultipartHTTPPushURI
+	capabilities.MaxImageSizeBytes = extra.MaxImageSizeBytes
+	return capabilities, nil
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"net/http"
+	"time"
+)
+
+// GetVirtualMediaForType returns the first virtual media of the managers that can be inserted with the given media type.
+// I.e. redfish.CDMediaType to mount ISO images. A managerID restricts the search to that manager.
+func GetVirtualMediaForType(c *gofish.APIClient, managerID string, mediaType redfish.VirtualMediaType) (*redfish.VirtualMedia, error) {
+	managers, err := c.Service.Managers()
+	if err != nil {
+		return nil, err
+	}
+	for _, manager := range managers {
+		if managerID != "" && manager.ID != managerID {
+			continue
+		}
+		virtualMedia, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range virtualMedia {
+			if !vm.SupportsMediaInsert {
+				continue
+			}
+			for _, t := range vm.MediaTypes {
+				if t == mediaType {
+					return vm, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("no virtual media supporting %s was found", mediaType)
+}
+
+// DetachAttempts is the number of times the media is ejected, or the boot override cleared, before giving up.
+// Some BMCs intermittently report success while leaving the media attached.
+const DetachAttempts = 3
+
+// virtualMediaState is the part of a virtual media telling if an image is attached
+type virtualMediaState struct {
+	Image    string
+	Inserted bool
+}
+
+// EjectVirtualMediaAndVerify ejects the image of the virtual media at uri and reads the media back until it is no longer
+// inserted, ejecting it again up to attempts times, timeBetweenAttempts seconds apart. It does nothing when no image is inserted.
+func EjectVirtualMediaAndVerify(c redfishcommon.Client, uri string, attempts int, timeBetweenAttempts int) error {
+	for attempt := 0; ; attempt++ {
+		state, err := getVirtualMediaState(c, uri)
+		if err != nil {
+			return fmt.Errorf("error fetching %s: %s", uri, err)
+		}
+		if !state.Inserted {
+			return nil
+		}
+		if attempt == attempts {
+			return fmt.Errorf("%s is still inserted in %s after %d eject attempts", state.Image, uri, attempts)
+		}
+		if attempt > 0 {
+			log.Printf("[DEBUG] %s is still inserted in %s, ejecting it again", state.Image, uri)
+		}
+		resp, err := c.Post(uri+"/Actions/VirtualMedia.EjectMedia", map[string]interface{}{})
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("the image was not ejected. Status code was %d", resp.StatusCode)
+		}
+		time.Sleep(time.Duration(timeBetweenAttempts) * time.Second)
+	}
+}
+
+func getVirtualMediaState(c redfishcommon.Client, uri string) (*virtualMediaState, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var state virtualMediaState
+	if err = json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
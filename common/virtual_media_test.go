@@ -0,0 +1,55 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEjectVirtualMediaAndVerify(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		bodies     []string
+		ejects     int
+		shouldPass bool
+	}{
+		{1, []string{`{"Inserted":false,"Image":null}`}, 0, true},
+		{2, []string{`{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":false,"Image":null}`}, 1, true},
+		{3, []string{`{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":false,"Image":null}`}, 2, true},
+		{4, []string{`{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`, `{"Inserted":true,"Image":"http://10.0.0.10/update.iso"}`}, 3, false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		for _, body := range v.bodies {
+			testClient.CustomReturnForActions[http.MethodGet] = append(testClient.CustomReturnForActions[http.MethodGet], &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			})
+		}
+		for i := 0; i < v.ejects; i++ {
+			testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			})
+		}
+		err := EjectVirtualMediaAndVerify(testClient, "/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD", 3, 0)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		ejects := 0
+		for _, call := range testClient.CapturedCalls() {
+			if call.Action == http.MethodPost && strings.HasSuffix(call.URL, "/Actions/VirtualMedia.EjectMedia") {
+				ejects++
+			}
+		}
+		if ejects != v.ejects {
+			t.Errorf("Test number %v: expected %d ejects, got %d", v.noTest, v.ejects, ejects)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"net/http"
+	"strings"
+)
+
+// Volume initialization types of the Volume.Initialize action
+const (
+	FastInitializeType string = "Fast"
+	SlowInitializeType string = "Slow"
+)
+
+// Background operations of a volume that can be cancelled
+const (
+	VolumeInitialization   string = "BackgroundInitialization"
+	VolumeConsistencyCheck string = "CheckConsistency"
+)
+
+// dellRaidServiceURI is the Dell OEM service holding the RAID actions missing from the standard
+const dellRaidServiceURI string = "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService"
+
+// InitializeVolume erases a volume through the Volume.Initialize action.
+// Parameters:
+//   - volumeURI -> ODataID of the volume.
+//   - initializeType -> FastInitializeType or SlowInitializeType.
+//
+// Returns the URI of the job created to initialize the volume.
+func InitializeVolume(c redfishcommon.Client, volumeURI string, initializeType string) (string, error) {
+	return postVolumeAction(c, volumeURI+"/Actions/Volume.Initialize", map[string]interface{}{"InitializeType": initializeType})
+}
+
+// CheckVolumeConsistency starts a consistency check of a redundant volume through the Volume.CheckConsistency action.
+// Returns the URI of the job created to check the volume.
+func CheckVolumeConsistency(c redfishcommon.Client, volumeURI string) (string, error) {
+	return postVolumeAction(c, volumeURI+"/Actions/Volume.CheckConsistency", map[string]interface{}{})
+}
+
+// CancelVolumeOperation cancels a background operation of a volume (VolumeInitialization or VolumeConsistencyCheck).
+// The standard has no such action, so the Dell RAID service is used.
+func CancelVolumeOperation(c redfishcommon.Client, volumeURI string, operation string) error {
+	// The Id of Dell volumes is their FQDD (i.e. Disk.Virtual.0:RAID.Integrated.1-1)
+	fqdd := volumeURI[strings.LastIndex(volumeURI, "/")+1:]
+	payload := map[string]interface{}{"TargetFQDD": fqdd}
+	resp, err := c.Post(fmt.Sprintf("%s/Actions/DellRaidService.Cancel%s", dellRaidServiceURI, operation), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("the cancellation was not accepted. Status code was %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func postVolumeAction(c redfishcommon.Client, actionURI string, payload map[string]interface{}) (string, error) {
+	resp, err := c.Post(actionURI, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("the action was not accepted. Status code was %d", resp.StatusCode)
+	}
+	// Services completing the action right away do not create a job
+	return resp.Header.Get("Location"), nil
+}
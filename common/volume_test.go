@@ -0,0 +1,63 @@
+package common
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testVolumeURI = "/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Volumes/Disk.Virtual.0:RAID.Integrated.1-1"
+
+func TestInitializeVolume(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		statusCode int
+		location   string
+		shouldPass bool
+	}{
+		{1, http.StatusAccepted, "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/JID_000000000001", true},
+		{2, http.StatusNoContent, "", true},
+		{3, http.StatusBadRequest, "", false},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = make(map[string][]interface{})
+		testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+			StatusCode: v.statusCode,
+			Header:     http.Header{"Location": []string{v.location}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		})
+		jobURI, err := InitializeVolume(testClient, testVolumeURI, SlowInitializeType)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if v.shouldPass && jobURI != v.location {
+			t.Errorf("Test number %v: expected job %q, got %q", v.noTest, v.location, jobURI)
+		}
+		calls := testClient.CapturedCalls()
+		if len(calls) != 1 || calls[0].URL != testVolumeURI+"/Actions/Volume.Initialize" || !strings.Contains(calls[0].Payload, "InitializeType:Slow") {
+			t.Errorf("Test number %v sent an unexpected request %v", v.noTest, calls)
+		}
+	}
+}
+
+func TestCancelVolumeOperation(t *testing.T) {
+	testClient := &redfishcommon.TestClient{}
+	testClient.CustomReturnForActions = make(map[string][]interface{})
+	testClient.CustomReturnForActions[http.MethodPost] = append(testClient.CustomReturnForActions[http.MethodPost], &http.Response{
+		StatusCode: http.StatusAccepted,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	})
+	if err := CancelVolumeOperation(testClient, testVolumeURI, VolumeConsistencyCheck); err != nil {
+		t.Fatalf("CancelVolumeOperation failed %v", err)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != dellRaidServiceURI+"/Actions/DellRaidService.CancelCheckConsistency" || !strings.Contains(calls[0].Payload, "TargetFQDD:Disk.Virtual.0:RAID.Integrated.1-1") {
+		t.Errorf("CancelVolumeOperation sent an unexpected request %v", calls)
+	}
+}
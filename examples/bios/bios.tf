@@ -3,6 +3,11 @@ resource "redfish_bios" "bios" {
     "NumLock" = "On"
   }
   settings_apply_time = "OnReset"
+  // Fail if a BIOS job is already pending, or delete it with clear_stale_jobs
+  // check_job_queue = true
+  // clear_stale_jobs = true
+  // Discard stale pending BIOS settings before applying the attributes
+  // clear_pending = true
 }
 
 data "redfish_bios" "bios" {
@@ -11,3 +16,33 @@ data "redfish_bios" "bios" {
 output "bios_attributes" {
   value = "${data.redfish_bios.bios.attributes}"
 }
+
+variable "bios_setup_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "redfish_bios_password" "setup" {
+  password_name = "SetupPassword"
+  new_password  = var.bios_setup_password
+  // Current password, needed when one is already set
+  // old_password = var.bios_old_setup_password
+  reset_type = "GracefulRestart"
+}
+
+resource "redfish_boot_order_lock" "lock" {
+  // Keep tenants from changing the boot order or the BIOS settings from the OS
+  lock_boot_order     = true
+  lock_bios_settings  = true
+  settings_apply_time = "OnReset"
+}
+
+// Discard stale pending BIOS and RAID controller settings (i.e. left by a failed
+// job) before applying fresh configuration. Change the trigger to clear them again
+resource "redfish_clear_pending" "stale" {
+  bios                = true
+  storage_controllers = ["RAID.Integrated.1-1"]
+  triggers = {
+    ticket = "INC-1234"
+  }
+}
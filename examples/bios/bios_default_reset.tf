@@ -0,0 +1,33 @@
+// Golden BIOS: start from the defaults, then apply the attribute set.
+// Bump the version to reset and apply it again
+locals {
+  golden_bios_version = "2026.10"
+}
+
+resource "redfish_bios_default_reset" "baseline" {
+  reset_type = "GracefulRestart"
+  triggers = {
+    golden_bios_version = local.golden_bios_version
+  }
+}
+
+resource "redfish_bios" "golden" {
+  attributes = {
+    "ProcVirtualization" = "Enabled"
+    "SysProfile"         = "PerfOptimized"
+  }
+  settings_apply_time = "Immediate"
+
+  depends_on = [redfish_bios_default_reset.baseline]
+}
+
+// Review the requests of a change before carrying it out: planned_requests lists them,
+// then dry_run = false replaces the resource, sending them to the BMC
+resource "redfish_bios_default_reset" "review" {
+  reset_type = "GracefulRestart"
+  dry_run    = true
+}
+
+output "bios_default_reset_requests" {
+  value = redfish_bios_default_reset.review.planned_requests
+}
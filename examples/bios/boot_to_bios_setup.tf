@@ -0,0 +1,8 @@
+// Reboot into the BIOS setup once, i.e. to troubleshoot from the virtual console.
+// Change the trigger to do it again
+resource "redfish_boot_to_bios_setup" "setup" {
+  reset_type = "GracefulRestart"
+  triggers = {
+    ticket = "INC-1234"
+  }
+}
@@ -0,0 +1,10 @@
+// Fan redundancy of the chassis, along with the thermal profile of redfish_thermal_profile.
+// Most BMCs only report the redundancy mode, redundancy_mode can be set on the ones exposing it as writable.
+resource "redfish_fan" "gpu_node" {
+  // Dell only: keep the fans fast for the GPUs the iDRAC does not know the cooling needs of
+  third_party_pcie_fan_response = true
+}
+
+output "fan_redundancy" {
+  value = "${redfish_fan.gpu_node.redundancy_mode} (${redfish_fan.gpu_node.redundancy_status}), ${redfish_fan.gpu_node.min_fans_needed} fans needed"
+}
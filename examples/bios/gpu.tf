@@ -0,0 +1,12 @@
+// Recommended settings for GPU servers passing the GPUs to VMs (SR-IOV, MMIO above 4GB,
+// performance profile, processor virtualization and extra cooling).
+// The BIOS changes are applied on the next reboot, i.e. with redfish_boot_to_bios_setup or a power cycle
+resource "redfish_gpu" "gpu" {
+  profile               = "virtualization"
+  numa_nodes_per_socket = 1
+  cooling_offset        = "High"
+}
+
+output "gpu_attributes" {
+  value = redfish_gpu.gpu.attributes
+}
@@ -0,0 +1,12 @@
+// Memory mirroring with node interleaving, and the persistent memory of the first socket
+// provisioned as a single App Direct region. The provider does not create the namespaces, as the
+// BMCs do not expose them: they are created from the OS once the region exists (i.e. ndctl create-namespace)
+resource "redfish_memory_settings" "mirror" {
+  operating_mode    = "Mirror"
+  node_interleaving = true
+  reset_type        = "GracefulRestart"
+
+  persistent_memory_goal {
+    memory_domain = "PMem.Socket.1"
+  }
+}
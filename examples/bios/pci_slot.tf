@@ -0,0 +1,13 @@
+// Quad NVMe adapter in slot 3, which needs the slot split in four x4 links, and an
+// unused slot disabled. The slots are checked against the PCIe slot inventory of
+// the chassis, and the changes are applied on the next reboot.
+resource "redfish_pci_slot" "nvme_adapter" {
+  slot {
+    number      = 3
+    bifurcation = "x4x4x4x4"
+  }
+  slot {
+    number  = 4
+    enabled = false
+  }
+}
@@ -0,0 +1,15 @@
+// The BIOS changes are only applied if the server is healthy and powered off, and there
+// are no other jobs pending (i.e. a firmware update scheduled for the next reboot).
+// Otherwise the apply fails before anything is sent to the server.
+resource "redfish_bios" "maintenance" {
+  attributes = {
+    "SriovGlobalEnable" = "Enabled"
+  }
+  settings_apply_time = "OnReset"
+
+  preconditions {
+    health         = "OK"
+    power_state    = "Off"
+    no_active_jobs = true
+  }
+}
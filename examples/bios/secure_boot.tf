@@ -0,0 +1,20 @@
+// Compliance check: the custom db certificate must be installed before Secure Boot is enforced
+variable "custom_db_fingerprint" {
+  type        = string
+  description = "SHA-256 fingerprint of the custom db certificate, in lowercase hexadecimal"
+}
+
+data "redfish_secure_boot" "secure_boot" {
+}
+
+locals {
+  db_digests = flatten([for db in data.redfish_secure_boot.secure_boot.databases : db.digests if db.id == "db"])
+}
+
+output "custom_key_installed" {
+  value = contains(local.db_digests, var.custom_db_fingerprint)
+}
+
+output "secure_boot" {
+  value = "${data.redfish_secure_boot.secure_boot.current_boot} (${data.redfish_secure_boot.secure_boot.mode})"
+}
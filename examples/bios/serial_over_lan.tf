@@ -0,0 +1,8 @@
+// Serial console of the host over the BMC (ipmitool sol activate on Dell, vsp on HPE).
+// The BIOS changes are applied on the next reboot.
+resource "redfish_serial_over_lan" "sol" {
+  enabled   = true
+  baud_rate = 115200
+  // The kernel console should match it, i.e. console=ttyS1,115200n8
+  port = "COM2"
+}
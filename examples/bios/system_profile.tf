@@ -0,0 +1,14 @@
+// Latency sensitive workloads (i.e. trading or real-time analytics): the processors stay out of the idle
+// power states and the uncore runs at its maximum frequency, while turbo boost is kept. The change is
+// applied on the next reboot.
+resource "redfish_system_profile" "low_latency" {
+  profile          = "custom"
+  c_states         = "disabled"
+  turbo_boost      = "enabled"
+  uncore_frequency = "maximum"
+}
+
+// Virtualization hosts sized for power efficiency
+# resource "redfish_system_profile" "efficient" {
+#   profile = "performance_per_watt"
+# }
@@ -0,0 +1,13 @@
+// Cooling of a GPU-dense server: the fans spin faster than the default profile requires,
+// as the GPUs are cooled by the system fans. On HPE, only one of thermal_profile and
+// fan_speed_offset can be set, and the change is applied on the next reboot.
+resource "redfish_thermal_profile" "gpu_node" {
+  thermal_profile   = "maximum_performance"
+  fan_speed_offset  = "Medium"
+  minimum_fan_speed = 30
+}
+
+// Quiet office deployments
+# resource "redfish_thermal_profile" "office" {
+#   thermal_profile = "minimum_power"
+# }
@@ -0,0 +1,114 @@
+resource "redfish_firmware_update" "bios" {
+  image_uri = "http://192.168.10.20/repo/BIOS_XXXXX_WN64_2.7.7.EXE"
+  // transfer_protocol = "HTTP"
+  // Reinstall the previous BIOS when the resource is destroyed
+  // on_destroy = "rollback"
+  // Change rollback to reinstall the version recorded in previous_versions
+  // right away, i.e. after a bad update
+  // rollback = "2026-10-16"
+  // Package to roll back with when the BMC keeps no previous image
+  // rollback_packages = {
+  //   "159" = "http://192.168.10.20/repo/BIOS_XXXXX_WN64_2.6.4.EXE"
+  // }
+}
+
+resource "redfish_firmware_update" "latest" {
+  catalog_url = "https://downloads.dell.com/catalog/Catalog.xml.gz"
+  // Pull the packages from a local mirror instead of downloads.dell.com
+  // catalog_base_uri = "http://192.168.10.20/repo"
+}
+
+output "firmware_versions" {
+  value = redfish_firmware_update.latest.firmware_versions
+}
+
+// What the last apply changed, i.e. for a change record:
+// [{ name = "BIOS", software_id = "159", previous_version = "2.7.7", new_version = "2.8.2" }]
+output "components_updated" {
+  value = redfish_firmware_update.latest.components_updated
+}
+
+data "redfish_applicable_updates" "urgent" {
+  catalog_url = "https://downloads.dell.com/catalog/Catalog.xml.gz"
+  criticality = ["Urgent"]
+}
+
+output "urgent_updates" {
+  value = data.redfish_applicable_updates.urgent.updates
+}
+
+resource "redfish_firmware_update" "iso" {
+  // Bootable update ISO, for components that cannot be updated through Redfish
+  update_iso_uri = "http://192.168.10.20/repo/PER740_BOOTABLE_20.08.00.iso"
+  timeouts {
+    create = "120m"
+  }
+}
+
+// Actions performed by the last apply (pushes, resets, jobs). Set operation_log_file
+// in the provider to also keep them in a local JSON lines file
+output "iso_operation_log" {
+  value = redfish_firmware_update.iso.operation_log
+}
+
+// Add a notification block to the provider to be told when the firmware jobs finish:
+// provider "redfish" {
+//   ...
+//   notification {
+//     url     = "https://chat.example.com/hooks/firmware"
+//     headers = { Authorization = "Bearer ${var.webhook_token}" }
+//     events  = ["job_completion"]
+//   }
+// }
+
+resource "redfish_firmware_update" "nic_port_2" {
+  image_uri = "http://192.168.10.20/repo/Network_Firmware_XXXXX_WN64_22.00.6.EXE"
+  // Only update one of the identical NICs
+  targets = ["NIC.Integrated.1-2-1"]
+}
+
+resource "redfish_firmware_update" "broadcom_nics" {
+  image_uri = "http://192.168.10.20/repo/Network_Firmware_XXXXX_WN64_22.00.6.EXE"
+  // Every NIC with this SoftwareId, whatever the BMC firmware revision names them.
+  // fqdd takes precedence over software_id, which takes precedence over name
+  target {
+    software_id = "101548"
+  }
+}
+
+data "redfish_hardware_errata" "errata" {
+  // Fail the plan before deploying workloads on known-bad firmware
+  fail_on_violation = true
+  errata {
+    id              = "SITE-CPLD-MINIMUM"
+    description     = "CPLD firmware older than 1.0.6 resets the system under load"
+    component_regex = "CPLD"
+    minimum_version = "1.0.6"
+  }
+}
+
+# Only pull the image from HTTPS when the BMC supports it
+data "redfish_update_service" "update_service" {
+}
+
+output "firmware_transfer" {
+  value = contains(data.redfish_update_service.update_service.transfer_protocols, "HTTPS") ? "simple_update" : "push"
+}
+
+variable "share_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "redfish_firmware_update" "from_cifs" {
+  // image_uri is a path in the share
+  image_uri = "BIOS_XXXXX_WN64_2.7.7.EXE"
+  share {
+    ip         = "192.168.10.20"
+    share_type = "CIFS"
+    share_name = "repo"
+    username   = "svc_firmware"
+    // Stored in the state, as every package pushed from the share needs it
+    password   = var.share_password
+  }
+}
@@ -0,0 +1,12 @@
+data "redfish_accounts" "accounts" {
+}
+
+// Accounts other than the expected ones, i.e. created by hand on the BMC
+variable "allowed_usernames" {
+  type    = list(string)
+  default = ["root", "terraform"]
+}
+
+output "unexpected_accounts" {
+  value = setsubtract(data.redfish_accounts.accounts.usernames, var.allowed_usernames)
+}
@@ -0,0 +1,19 @@
+// Invoke vendor actions the provider has no resource for yet, validated against their ActionInfo.
+// Bump the trigger to invoke them again
+resource "redfish_action" "clear_sel" {
+  resource_uri = "/redfish/v1/Managers/iDRAC.Embedded.1/LogServices/Sel"
+  action       = "LogService.ClearLog"
+  triggers = {
+    date = "2026-10-16"
+  }
+}
+
+resource "redfish_action" "lc_status" {
+  resource_uri = "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellLCService"
+  action       = "DellLCService.GetRemoteServicesAPIStatus"
+  parameters   = jsonencode({})
+}
+
+output "lifecycle_controller_status" {
+  value = jsondecode(redfish_action.lc_status.response).LCStatus
+}
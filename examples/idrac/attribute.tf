@@ -0,0 +1,30 @@
+// Escape hatch for the settings the provider does not model yet
+resource "redfish_attribute" "ntp" {
+  uri  = "/redfish/v1/Managers/iDRAC.Embedded.1/NetworkProtocol"
+  body = jsonencode({
+    NTP = {
+      ProtocolEnabled = true
+      NTPServers      = ["10.0.0.1", "10.0.0.2"]
+    }
+  })
+  // Revert the changes made outside terraform
+  detect_drift = true
+}
+
+// Settings objects are applied by a configuration job on the next reboot
+resource "redfish_attribute" "bios_settings" {
+  uri               = "/redfish/v1/Systems/System.Embedded.1/Bios/Settings"
+  read_uri          = "/redfish/v1/Systems/System.Embedded.1/Bios"
+  body              = jsonencode({ Attributes = { LogicalProc = "Disabled" } })
+  create_config_job = true
+}
+
+// Read any Redfish resource, i.e. to check the result of a redfish_attribute
+data "redfish_rest" "network_protocol" {
+  path   = "/redfish/v1/Managers/iDRAC.Embedded.1/NetworkProtocol"
+  select = ["HostName", "NTP"]
+}
+
+output "ntp_servers" {
+  value = jsondecode(data.redfish_rest.network_protocol.json).NTP.NTPServers
+}
@@ -0,0 +1,7 @@
+# Flips a factory-fresh server into automated onboarding: on the next reset the iDRAC
+# imports the Server Configuration Profile announced by the DHCP server
+resource "redfish_idrac_auto_config" "onboarding" {
+  dhcp_enabled  = true
+  dns_from_dhcp = true
+  auto_config   = "Enable Once After Reset"
+}
@@ -0,0 +1,25 @@
+variable "backup_passphrase" {
+  type      = string
+  sensitive = true
+}
+
+// Known-good snapshot of the server profile, taken before risky changes
+resource "redfish_backup_restore" "known_good" {
+  share {
+    ip         = "10.0.0.10"
+    share_type = "NFS"
+    share_name = "/backups"
+  }
+  image_name = "r740-known-good"
+  passphrase = var.backup_passphrase
+
+  // Bump to take a new snapshot
+  triggers = {
+    change = "CHG0001234"
+  }
+
+  // Bump to restore the snapshot after a failed change. The host is rebooted.
+  // restore_triggers = {
+  //   rollback = "1"
+  // }
+}
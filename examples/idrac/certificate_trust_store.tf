@@ -0,0 +1,11 @@
+# Upload the CA of the directory servers to the LDAP trust store, so the BMC can validate their certificates
+resource "redfish_certificate_trust_store" "ldap_ca" {
+  store       = "ldap"
+  certificate = file("${path.module}/certs/directory-ca.pem")
+}
+
+# Upload the CA of the key management server used by redfish_kmip (Dell iDRAC only)
+resource "redfish_certificate_trust_store" "kms_ca" {
+  store       = "kms"
+  certificate = file("${path.module}/certs/kms-ca.pem")
+}
@@ -0,0 +1,15 @@
+// Event subscriptions of the BMC that were not created by terraform, to review the
+// destinations that are gone (i.e. a decommissioned syslog relay) and remove them.
+data "redfish_event_subscriptions" "all" {}
+
+output "unmanaged_subscriptions" {
+  value = {
+    for subscription in data.redfish_event_subscriptions.all.subscriptions :
+    subscription.odata_id => subscription.destination if !subscription.managed
+  }
+}
+
+// Subscriptions of a single monitoring system
+data "redfish_event_subscriptions" "monitoring" {
+  destination_regex = "^https://monitoring\\.example\\.com/"
+}
@@ -0,0 +1,6 @@
+// Security baselines require Group Manager to be off, as the group can push its
+// settings to the iDRAC. While it is enabled, every resource changing the
+// configuration reports a warning.
+resource "redfish_group_manager" "group_manager" {
+  enabled = false
+}
@@ -0,0 +1,8 @@
+resource "redfish_hostinterface" "host_interface" {
+  interface_enabled = true
+
+  # Lets in-band tooling on the host OS create its own BMC account on first boot
+  credential_bootstrapping_enabled            = true
+  credential_bootstrapping_enable_after_reset = false
+  credential_bootstrapping_role_id            = "Operator"
+}
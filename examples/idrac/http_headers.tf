@@ -0,0 +1,18 @@
+variable "proxy_token" {
+  type      = string
+  sensitive = true
+}
+
+// The BMC is reached through an authenticating reverse proxy, which logs the
+// User-Agent and the change ticket of every request for auditing
+provider "redfish" {
+  alias            = "proxied"
+  redfish_endpoint = "https://bmc-proxy.example.com/server1"
+  user             = "user"
+  password         = "password"
+  user_agent       = "terraform-redfish/ci"
+  http_headers = {
+    "Proxy-Authorization" = "Bearer ${var.proxy_token}"
+    "X-Change-Ticket"     = "CHG-1234"
+  }
+}
@@ -0,0 +1,21 @@
+// Audit of the SNMP settings of the iDRAC, without managing them
+data "redfish_idrac_attributes" "snmp" {
+  name_glob = "SNMP.*"
+}
+
+output "snmp_agent_enabled" {
+  value = lookup(data.redfish_idrac_attributes.snmp.attributes, "SNMP.1.AgentEnable", "") == "Enabled"
+}
+
+// Processor settings of the BIOS, to branch on them (i.e. enable the GPU preset only with virtualization on)
+data "redfish_idrac_attributes" "processor" {
+  source     = "bios"
+  name_regex = "^Proc"
+}
+
+// Attributes of a NIC partition
+# data "redfish_idrac_attributes" "partition" {
+#   source             = "nic"
+#   network_adapter_id = "NIC.Integrated.1"
+#   function_id        = "NIC.Integrated.1-1-1"
+# }
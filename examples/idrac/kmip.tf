@@ -0,0 +1,20 @@
+variable "kmip_password" {
+  type                     = string
+  sensitive                = true
+}
+
+// Keys of the self-encrypting drives stored in a KMIP server (SEKM).
+// The certificate is signed by the KMIP server CA from the CSR the iDRAC generates with the common_name and the rest of the subject
+resource "redfish_kmip" "sekm" {
+  mode                     = "SEKM"
+  primary_server_address   = "kms1.example.com"
+  redundant_server_address = "kms2.example.com"
+  port                     = 5696
+  username                 = "idrac-r740-01"
+  password                 = var.kmip_password
+  common_name              = "idrac-r740-01"
+  organization             = "Example"
+  country                  = "US"
+  kms_ca_certificate       = file("${path.module}/kms_ca.pem")
+  sekm_certificate         = file("${path.module}/idrac-r740-01.pem")
+}
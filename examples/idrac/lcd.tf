@@ -0,0 +1,4 @@
+resource "redfish_idrac_lcd" "lcd" {
+  configuration       = "User Defined"
+  user_defined_string = "team-a / rack 12"
+}
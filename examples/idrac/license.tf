@@ -0,0 +1,11 @@
+// Fleet audit: list the evaluation and expired licenses of the BMC
+data "redfish_license" "licenses" {
+}
+
+output "evaluation_licenses" {
+  value = [for l in data.redfish_license.licenses.licenses : l.description if l.license_type == "Trial"]
+}
+
+output "expired_licenses" {
+  value = [for l in data.redfish_license.licenses.licenses : "${l.description} (${l.expiration_date})" if l.expired]
+}
@@ -0,0 +1,8 @@
+// Physical location of the server, so it can be found from the BMC and the inventory tools reading it
+resource "redfish_location" "location" {
+  data_center = "DC1"
+  room        = "Hall 2"
+  aisle       = "B"
+  rack        = "R12"
+  rack_slot   = 20
+}
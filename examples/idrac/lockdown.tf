@@ -0,0 +1,6 @@
+resource "redfish_system_lockdown" "lockdown" {
+  enabled = true
+  // Resources changing the server fail at plan time while lockdown is enabled.
+  // Set lockdown_bypass = true in the provider to let them disable it while
+  // they apply their changes, enabling it again afterwards.
+}
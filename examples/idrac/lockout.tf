@@ -0,0 +1,12 @@
+// Only accept connections from the management network, and block for 15 minutes
+// the addresses failing to log in 5 times within a minute.
+// Make sure the range includes the address terraform connects from.
+resource "redfish_idrac_lockout" "lockout" {
+  ip_range_enabled     = true
+  ip_range_address     = "10.20.0.0"
+  ip_range_mask        = "255.255.0.0"
+  lockout_enabled      = true
+  lockout_fail_count   = 5
+  lockout_fail_window  = 60
+  lockout_penalty_time = 900
+}
@@ -0,0 +1,33 @@
+// Rotation of the password of the account the provider connects with, in a single apply.
+// The provider connects with the previous password until redfish_user_account changes it,
+// then with the new one. Drop previous_passwords once the rotation has been applied everywhere
+variable "terraform_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "terraform_previous_password" {
+  type      = string
+  sensitive = true
+}
+
+provider "redfish" {
+  alias              = "rotation"
+  redfish_endpoint   = "https://192.168.10.10"
+  user               = "terraform"
+  password           = var.terraform_password
+  previous_passwords = [var.terraform_previous_password]
+  ssl_insecure       = true
+}
+
+resource "redfish_user_account" "terraform" {
+  provider = redfish.rotation
+  username = "terraform"
+  password = var.terraform_password
+  role_id  = "Administrator"
+  enabled  = true
+}
+
+// Accounts created outside terraform are imported by the Id listed by the redfish_accounts data source:
+//   terraform import redfish_user_account.terraform 3
+// The password is sent again on the first apply after the import, as it cannot be read
@@ -0,0 +1,9 @@
+// Hardening: Quick Sync 2 only shows the inventory to authenticated users, without Wi-Fi,
+// and turns itself off after 5 minutes without use
+resource "redfish_idrac_quick_sync" "quick_sync" {
+  access                   = "Read-only"
+  read_authentication      = true
+  wifi_enabled             = false
+  inactivity_timer_enabled = true
+  inactivity_timeout       = 300
+}
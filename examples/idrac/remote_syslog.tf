@@ -0,0 +1,17 @@
+// Ships the iDRAC logs to the central syslog servers over TLS. The iDRAC validates
+// the servers with the CA of the logging platform, managed with the other trust
+// stores. Alternatively, set ca_certificate on redfish_idrac_remote_syslog.
+resource "redfish_certificate_trust_store" "syslog_ca" {
+  store       = "syslog"
+  certificate = file("${path.module}/syslog-ca.pem")
+}
+
+resource "redfish_idrac_remote_syslog" "tls" {
+  enabled     = true
+  servers     = ["syslog1.example.com", "syslog2.example.com"]
+  tls_enabled = true
+  tls_port    = 6514
+
+  // The CA is imported before TLS is enabled
+  depends_on = [redfish_certificate_trust_store.syslog_ca]
+}
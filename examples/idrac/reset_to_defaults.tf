@@ -0,0 +1,21 @@
+// Repurposing: return the BIOS and the BMC to their defaults, keeping the BMC reachable.
+// Bump the ticket to reset them again
+locals {
+  repurpose_ticket = "RMA-2026-0042"
+}
+
+resource "redfish_bios_default_reset" "bios" {
+  triggers = {
+    ticket = local.repurpose_ticket
+  }
+}
+
+// The BMC restarts once reset, so it goes last
+resource "redfish_manager_reset_to_defaults" "bmc" {
+  reset_type = "PreserveNetworkAndUsers"
+  triggers = {
+    ticket = local.repurpose_ticket
+  }
+
+  depends_on = [redfish_bios_default_reset.bios]
+}
@@ -0,0 +1,9 @@
+# Hardens the BMC: modern TLS only, no plain HTTP, telnet nor IPMI over LAN
+resource "redfish_security_protocols" "hardening" {
+  tls_protocol          = "TLS 1.2 Only"
+  web_session_timeout   = 900
+  ssh_ciphers           = "chacha20-poly1305@openssh.com,aes256-gcm@openssh.com,aes128-gcm@openssh.com"
+  https_only            = true
+  telnet_enabled        = false
+  ipmi_over_lan_enabled = false
+}
@@ -0,0 +1,10 @@
+// Security baseline: idle sessions expire after 15 minutes, and a single virtual console session is allowed
+resource "redfish_assistive_kvm" "session_policy" {
+  session_timeout              = 900
+  web_timeout                  = 900
+  ssh_timeout                  = 900
+  virtual_console_timeout      = 900
+  web_max_sessions             = 4
+  ssh_max_sessions             = 2
+  virtual_console_max_sessions = 1
+}
@@ -0,0 +1,17 @@
+// Enforce the attestation of the devices of the server and audit the result:
+// a device failing to authenticate its identity fails the plan.
+resource "redfish_spdm" "spdm" {
+  enabled = true
+}
+
+data "redfish_component_integrity" "attestation" {
+  depends_on = [redfish_spdm.spdm]
+}
+
+locals {
+  unverified = [for c in data.redfish_component_integrity.attestation.components : c.target_component_uri if c.verification_status != "Success"]
+}
+
+output "attestation" {
+  value = length(local.unverified) == 0 ? "all devices attested" : "unverified devices: ${join(", ", local.unverified)}"
+}
@@ -0,0 +1,27 @@
+// Streams the CPU and power reports to rsyslog and to an HTTP ingest endpoint.
+// Telemetry requires the iDRAC9 Datacenter license
+resource "redfish_idrac_telemetry" "telemetry" {
+  enabled           = true
+  rsyslog_enabled   = true
+  rsyslog_server    = "syslog.example.com"
+  rsyslog_port      = 514
+  http_destinations = ["https://ingest.example.com/redfish"]
+
+  report {
+    name     = "CPUSensor"
+    interval = 60
+  }
+
+  report {
+    name     = "PowerStatistics"
+  }
+
+  report {
+    name     = "GPUMetrics"
+    enabled  = false
+  }
+}
+
+output "telemetry_subscriptions" {
+  value = redfish_idrac_telemetry.telemetry.subscription_uris
+}
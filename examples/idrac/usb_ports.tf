@@ -0,0 +1,9 @@
+// Physical security: no front USB, no front panel changes and no power/NMI buttons.
+// The button changes are BIOS settings, applied on the next reboot
+resource "redfish_usb_ports" "lockout" {
+  front_usb_enabled    = false
+  idrac_direct_mode    = "iDRAC Direct Only"
+  front_panel_access   = "View-Only"
+  power_button_enabled = false
+  nmi_button_enabled   = false
+}
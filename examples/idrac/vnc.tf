@@ -0,0 +1,14 @@
+variable "vnc_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "redfish_idrac_vnc" "vnc" {
+  enabled = true
+  port    = 5901
+  // Write-only: the password is never stored in the state, not even hashed.
+  // Change password_wo_version (i.e. to the rotation date) to send a new one.
+  password_wo         = var.vnc_password
+  password_wo_version = "2026-10-01"
+  timeout             = 300
+}
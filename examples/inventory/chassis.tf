@@ -0,0 +1,8 @@
+// Sleds of a modular chassis (i.e. MX7000) and the systems they hold
+data "redfish_chassis" "sleds" {
+  chassis_types = ["Sled", "Blade"]
+}
+
+output "sled_systems" {
+  value = { for c in data.redfish_chassis.sleds.chassis : c.sku => c.computer_systems }
+}
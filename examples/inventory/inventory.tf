@@ -0,0 +1,12 @@
+data "redfish_inventory_export" "inventory" {
+  include_csv = true
+}
+
+resource "local_file" "inventory_json" {
+  content  = data.redfish_inventory_export.inventory.json
+  filename = "${path.module}/inventory.json"
+}
+
+output "inventory_csv" {
+  value = data.redfish_inventory_export.inventory.csv
+}
@@ -0,0 +1,14 @@
+// GPUs of the server and the slots they are plugged in, i.e. to check they all negotiated x16
+data "redfish_pcie_devices" "gpus" {
+  device_class = "DisplayController"
+}
+
+output "gpu_count" {
+  value = length(data.redfish_pcie_devices.gpus.devices)
+}
+
+output "gpu_slots" {
+  value = {
+    for gpu in data.redfish_pcie_devices.gpus.devices : gpu.slot => "${gpu.model} x${gpu.lanes_in_use} ${gpu.pcie_type}"
+  }
+}
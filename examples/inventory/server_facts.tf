@@ -0,0 +1,14 @@
+// Everything a fleet module usually needs about a server, in a single data source
+data "redfish_server_facts" "server" {
+}
+
+output "server" {
+  value = {
+    service_tag   = data.redfish_server_facts.server.service_tag
+    model         = data.redfish_server_facts.server.system[0].model
+    bios          = data.redfish_server_facts.server.firmware[0].bios
+    bmc           = data.redfish_server_facts.server.firmware[0].bmc
+    bmc_mac       = data.redfish_server_facts.server.manager[0].mac_address
+    mac_addresses = data.redfish_server_facts.server.mac_addresses
+  }
+}
@@ -0,0 +1,13 @@
+// Fails the plan on BMCs older than the Redfish version the module is written for
+data "redfish_service_root" "bmc" {
+  minimum_redfish_version = "1.6.0"
+}
+
+output "bmc" {
+  value = {
+    redfish_version = data.redfish_service_root.bmc.redfish_version
+    vendor          = data.redfish_service_root.bmc.oem_vendor
+    // i.e. to only use $expand in redfish_rest paths when the BMC supports it
+    expand_supported = data.redfish_service_root.bmc.protocol_features[0].expand_query
+  }
+}
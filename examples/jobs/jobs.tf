@@ -0,0 +1,27 @@
+// Fails the plan while a job is pending, instead of colliding with it
+data "redfish_jobs" "pending" {
+  unfinished_only = true
+}
+
+// Reports the firmware jobs that failed
+data "redfish_jobs" "failed_firmware" {
+  states = ["Failed", "CompletedWithErrors", "Exception"]
+  types  = ["FirmwareUpdate"]
+}
+
+resource "redfish_bios" "bios" {
+  attributes = {
+    "NumLock" = "On"
+  }
+
+  lifecycle {
+    precondition {
+      condition     = length(data.redfish_jobs.pending.jobs) == 0
+      error_message = "Jobs are pending on the BMC: ${join(", ", data.redfish_jobs.pending.jobs[*].id)}"
+    }
+  }
+}
+
+output "failed_firmware_jobs" {
+  value = { for job in data.redfish_jobs.failed_firmware.jobs : job.id => job.message }
+}
@@ -0,0 +1,13 @@
+// Gate later steps on a job started outside terraform (i.e. by a script or the iDRAC UI)
+data "redfish_task" "bios_job" {
+  task_id = "JID_000000000001"
+  // task_uri = "/redfish/v1/TaskService/Tasks/JID_000000000001"
+}
+
+resource "null_resource" "after_bios_job" {
+  count = data.redfish_task.bios_job.state == "Completed" ? 1 : 0
+
+  provisioner "local-exec" {
+    command = "echo BIOS job finished at ${data.redfish_task.bios_job.end_time}"
+  }
+}
@@ -0,0 +1,31 @@
+// Multi-node trays and blade chassis expose several computer systems behind one BMC.
+// One provider alias per node selects the system (and manager) its resources manage.
+provider "redfish" {
+  alias            = "node1"
+  redfish_endpoint = "https://192.168.10.10"
+  user             = "user"
+  password         = "password"
+  ssl_insecure     = true
+  system_id        = "Node1"
+  manager_id       = "BMC"
+}
+
+provider "redfish" {
+  alias            = "node2"
+  redfish_endpoint = "https://192.168.10.10"
+  user             = "user"
+  password         = "password"
+  ssl_insecure     = true
+  system_id        = "Node2"
+  manager_id       = "BMC"
+}
+
+resource "redfish_boot_order_lock" "node1" {
+  provider        = redfish.node1
+  lock_boot_order = true
+}
+
+resource "redfish_boot_order_lock" "node2" {
+  provider        = redfish.node2
+  lock_boot_order = true
+}
@@ -0,0 +1,20 @@
+// Sleds of an MX7000 enclosure, power cycled through the enclosure rather than the system of the sled.
+// Changing the triggers performs the action again.
+data "redfish_chassis" "sleds" {
+  chassis_types = ["Sled"]
+}
+
+resource "redfish_chassis_sled_power" "cycle" {
+  for_each   = { for c in data.redfish_chassis.sleds.chassis : c.id => c }
+  chassis_id = each.key
+  action     = "PowerCycle"
+  triggers = {
+    maintenance = "2026-10"
+  }
+}
+
+// A sled whose iDRAC stopped answering can be virtually reseated, which removes and restores its power
+# resource "redfish_chassis_sled_power" "reseat" {
+#   chassis_id = "Sled.Slot.3"
+#   action     = "VirtualReseat"
+# }
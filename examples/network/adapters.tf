@@ -0,0 +1,10 @@
+data "redfish_fc_hbas" "hbas" {
+}
+
+data "redfish_dpus" "dpus" {
+  // model_regex = "(?i)bluefield"
+}
+
+output "wwpns" {
+  value = data.redfish_fc_hbas.hbas.wwpns
+}
@@ -0,0 +1,15 @@
+// iSCSI boot from the second port: the boot environment uses a static DNS server and
+// a fixed initiator name, so the network boot is the same on every reinstall. The
+// changes are applied on the next reboot of the server.
+resource "redfish_host_name_dns" "iscsi_boot" {
+  network_adapter_id = "NIC.Integrated.1"
+  function_id        = "NIC.Integrated.1-1-2"
+  boot_protocol      = "iSCSI"
+  ip_via_dhcp        = false
+  target_via_dhcp    = true
+  primary_dns        = "10.0.0.53"
+  secondary_dns      = "10.0.1.53"
+  initiator_name     = "iqn.2001-05.com.example:server1"
+  // Lets the DHCP server hand out the iSCSI root path only to the boot requests
+  dhcp_vendor_id = "iSCSI-server1"
+}
@@ -0,0 +1,20 @@
+// Virtual MAC address of the first port, allocated by the IPAM, so the DHCP
+// reservations follow the server profile instead of the hardware. The change is
+// applied on the next reboot of the server.
+resource "redfish_sb_nic_mac_address" "port1" {
+  network_adapter_id = "NIC.Integrated.1"
+  function_id        = "NIC.Integrated.1-1-1"
+  mac_address        = "02:00:0A:00:01:10"
+}
+
+// Virtual WWNs of an FCoE partition, so the SAN zoning survives a board replacement
+resource "redfish_sb_nic_mac_address" "fcoe" {
+  network_adapter_id = "NIC.Integrated.1"
+  function_id        = "NIC.Integrated.1-1-2"
+  wwnn               = "20:00:00:0E:1E:C2:00:10"
+  wwpn               = "20:01:00:0E:1E:C2:00:10"
+}
+
+output "permanent_mac_address" {
+  value = redfish_sb_nic_mac_address.port1.permanent_mac_address
+}
@@ -0,0 +1,27 @@
+// Converged network build: the first port of the NIC is split into partitions with
+// their own VLAN and share of the bandwidth. The NIC configuration jobs are applied
+// by a graceful restart of the server.
+resource "redfish_virtual_network" "converged" {
+  network_adapter_id = "NIC.Integrated.1"
+  partitioning       = "Enabled"
+  reset_type         = "GracefulRestart"
+
+  // Management and VM traffic
+  partition {
+    function_id           = "NIC.Integrated.1-1-1"
+    vlan_id               = 100
+    min_bandwidth_percent = 25
+    max_bandwidth_percent = 100
+  }
+  // Storage traffic, untagged
+  partition {
+    function_id           = "NIC.Integrated.1-1-2"
+    vlan_id               = 0
+    min_bandwidth_percent = 75
+    max_bandwidth_percent = 100
+  }
+}
+
+output "virtual_network_jobs" {
+  value = redfish_virtual_network.converged.config_job_uris
+}
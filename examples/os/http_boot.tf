@@ -0,0 +1,7 @@
+// Provision the server from an image served over HTTPS, without a PXE infrastructure.
+// The changes are applied by the BIOS on the next reboot.
+resource "redfish_boot_certificate" "http_boot" {
+  boot_uri          = "https://images.example.com/ubuntu-22.04-live-server-amd64.iso"
+  https_certificate = file("${path.module}/images-ca.pem")
+  dns_servers       = ["10.0.0.53", "10.0.1.53"]
+}
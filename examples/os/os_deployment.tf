@@ -0,0 +1,22 @@
+// Boots the host from an ISO image of an NFS share, exposing the Lifecycle Controller drivers
+// for the OS as a USB device. Destroying the resource detaches both.
+// An interrupted apply (i.e. a timeout waiting for the boot job) is resumed by the next apply
+// without booting the host again, see the checkpoint attribute.
+resource "redfish_os_deployment" "esxi" {
+  image_name      = "VMware-VMvisor-Installer-7.0U3.iso"
+  driver_pack_os  = "VMware ESXi 7.0"
+  expose_duration = 240
+  share {
+    ip         = "192.168.10.20"
+    share_type = "NFS"
+    share_name = "/isos"
+  }
+  // Change to install the OS again
+  triggers = {
+    build = "1"
+  }
+}
+
+output "driver_packs" {
+  value = redfish_os_deployment.esxi.available_driver_packs
+}
@@ -0,0 +1,13 @@
+// Power thresholds of the server: the BMC raises a warning above 700 W and a
+// critical alert above 850 W, and sends the alerts of the sensor to the webhook
+// of the monitoring system.
+resource "redfish_power_metrics" "alerts" {
+  chassis_id        = "System.Embedded.1"
+  warning_watts     = 700
+  critical_watts    = 850
+  alert_destination = "https://alerts.example.com/redfish"
+}
+
+output "power_consumed_watts" {
+  value = redfish_power_metrics.alerts.power_consumed_watts
+}
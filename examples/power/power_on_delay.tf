@@ -0,0 +1,15 @@
+variable "servers" {
+  type = map(string)
+}
+
+// Spread the power on of the fleet over 60-240 seconds when AC power is restored.
+// Each server needs its own provider configuration pointing at its BMC.
+resource "redfish_power_on_delay" "fleet" {
+  for_each = var.servers
+
+  ac_power_recovery      = "On"
+  stagger_key            = each.key
+  stagger_min_seconds    = 60
+  stagger_window_seconds = 180
+  settings_apply_time    = "OnReset"
+}
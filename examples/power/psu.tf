@@ -0,0 +1,5 @@
+resource "redfish_chassis_psu" "psu" {
+  redundancy_policy     = "A/B Grid Redundant"
+  hot_spare_enabled     = true
+  hot_spare_primary_psu = "PSU1"
+}
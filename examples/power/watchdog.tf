@@ -0,0 +1,8 @@
+// Power cycles hung hosts. The host OS must keep the watchdog from expiring
+// (i.e. the iDRAC Service Module, or the OS watchdog driver).
+resource "redfish_watchdog" "ha" {
+  enabled        = true
+  timeout_action = "PowerCycle"
+  // Dell iDRAC only
+  timeout        = 300
+}
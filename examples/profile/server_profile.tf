@@ -0,0 +1,33 @@
+# Applies BIOS, boot order, users and BMC network settings with a single reboot.
+# An interrupted apply (i.e. a timeout waiting for the BIOS job) is resumed by the
+# next apply without rebooting again, see the checkpoint attribute.
+resource "redfish_server_profile" "web" {
+  bios_attributes = {
+    "LogicalProc" = "Disabled"
+    "SysProfile"  = "PerfOptimized"
+  }
+
+  boot_order = ["Boot0003", "Boot0001"]
+
+  user {
+    username = "ops"
+    password = var.ops_password
+    role_id  = "Operator"
+  }
+
+  ntp {
+    servers = ["0.pool.ntp.org", "1.pool.ntp.org"]
+  }
+
+  network {
+    hostname     = "web01-idrac"
+    name_servers = ["10.0.0.53"]
+  }
+
+  reset_type = "GracefulRestart"
+}
+
+variable "ops_password" {
+  type      = string
+  sensitive = true
+}
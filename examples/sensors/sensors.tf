@@ -0,0 +1,12 @@
+data "redfish_sensors" "inlet" {
+  name_regex    = "(?i)inlet"
+  reading_types = ["Temperature"]
+}
+
+// Inlet sensors reading above their upper caution threshold
+output "inlet_over_caution" {
+  value = [
+    for s in data.redfish_sensors.inlet.sensors : s.name
+    if s.has_reading && s.reading >= lookup(s.thresholds, "upper_caution", 1000)
+  ]
+}
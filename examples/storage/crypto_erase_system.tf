@@ -0,0 +1,12 @@
+// Decommissioning: erases the BIOS and iDRAC settings, the Lifecycle Controller data and
+// the self-encrypting drives. The erase is aborted if the BMC belongs to another server.
+resource "redfish_crypto_erase_system" "decommission" {
+  components          = ["bios", "idrac", "lc_data", "drives"]
+  drive_erase_method  = "cryptographic"
+  confirm_erase       = true
+  confirm_service_tag = "ABC1234"
+
+  preconditions {
+    power_state = "Off"
+  }
+}
@@ -0,0 +1,16 @@
+// The two lowest SSDs of the controller, always the same ones, mirrored for the OS
+data "redfish_drives" "boot" {
+  storage_controller_id = "RAID.Integrated.1-1"
+  media_types           = ["SSD"]
+  health                = ["OK"]
+  sort_by               = "slot"
+  limit                 = 2
+}
+
+resource "redfish_storage_volume" "boot" {
+  storage_controller_id = "RAID.Integrated.1-1"
+  volume_name           = "OS"
+  volume_type           = "Mirrored"
+  volume_disks          = data.redfish_drives.boot.names
+  settings_apply_time   = "Immediate"
+}
@@ -0,0 +1,14 @@
+// Cache policy of the embedded Smart Array controller of an HPE server. Most of the cache
+// module caches writes, which stay protected by the Smart Storage battery on a power failure.
+// The changes are applied on the next reboot.
+resource "redfish_smart_storage_battery" "embedded" {
+  location                         = "Slot 0"
+  read_cache_percent               = 10
+  write_cache_without_battery      = false
+  write_cache_bypass_threshold_kib = 1040
+  drive_write_cache                = "disabled"
+}
+
+output "smart_storage_battery" {
+  value = "${redfish_smart_storage_battery.embedded.battery_status} (${redfish_smart_storage_battery.embedded.battery_charge_percent}%)"
+}
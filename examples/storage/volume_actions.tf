@@ -0,0 +1,30 @@
+provider "redfish" {
+  redfish_endpoint = "https://localhost:5000"
+  user = "root"
+  password = "calvin"
+  ssl_insecure = true
+}
+
+resource "redfish_storage_volume" "volume" {
+    storage_controller_id = "RAID.Integrated.1-1"
+    volume_name = "MyVol"
+    volume_type = "Mirrored"
+    volume_disks = ["Physical Disk 0:1:0", "Physical Disk 0:1:1"]
+    settings_apply_time = "Immediate"
+}
+
+// Destroying it while the initialization is running cancels it
+resource "redfish_virtual_disk_initialize" "init" {
+    volume_id = redfish_storage_volume.volume.id
+    initialize_type = "Slow"
+}
+
+// Change the trigger to check the volume again
+resource "redfish_virtual_disk_consistency_check" "check" {
+    volume_id = redfish_storage_volume.volume.id
+    wait = false
+    triggers = {
+        schedule = "2026-10"
+    }
+    depends_on = [redfish_virtual_disk_initialize.init]
+}
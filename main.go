@@ -8,4 +8,6 @@ import (
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: redfish.Provider})
+	// Serve returns once terraform is done with the provider
+	redfish.Shutdown()
 }
@@ -0,0 +1,297 @@
+// Package mock implements a Redfish service serving canned responses, so the provider can be
+// developed and tested without a physical BMC.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Vendors with fixtures in testdata
+const (
+	DellVendor string = "dell"
+	HPEVendor  string = "hpe"
+)
+
+// Request is a request received by the server, as recorded for assertions.
+type Request struct {
+	Method string
+	URI    string
+	Body   map[string]interface{}
+}
+
+// Server is a Redfish service backed by the fixtures of a vendor.
+// It keeps the resources in memory, so the changes requested by PATCH, POST and DELETE are
+// seen by later requests:
+//   - PATCH merges the body into the resource. Settings resources (i.e. Bios/Settings) are also
+//     merged into their parent, as if the system had been rebooted to apply them.
+//   - POST to an action creates a completed task of the TaskService, returned in the Location header.
+//     Actions with a fixture (i.e. the ones returning data, as GetAttachStatus) return it instead.
+//   - POST to a collection creates a member from the body.
+//   - DELETE removes the resource and its collection membership. Settings resources are emptied instead.
+//
+// GET supports the $expand query parameter on collections, returning the members in full.
+type Server struct {
+	*httptest.Server
+	lock      sync.Mutex
+	resources map[string]map[string]interface{}
+	requests  []Request
+	tasks     int
+}
+
+// NewServer starts a TLS server with the fixtures of vendor. Close it when done.
+func NewServer(vendor string) (*Server, error) {
+	resources, err := LoadFixtures(vendor)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{resources: resources}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s, nil
+}
+
+// LoadFixtures reads the fixtures of vendor, indexed by URI.
+func LoadFixtures(vendor string) (map[string]map[string]interface{}, error) {
+	// The fixtures are found relative to this file, so tests of every package can use them
+	_, file, _, _ := runtime.Caller(0)
+	data, err := ioutil.ReadFile(filepath.Join(filepath.Dir(file), "testdata", vendor+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no fixtures for vendor %s: %s", vendor, err)
+	}
+	resources := make(map[string]map[string]interface{})
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("error parsing the fixtures of vendor %s: %s", vendor, err)
+	}
+	return resources, nil
+}
+
+// Resource returns a copy of the current state of a resource, or nil if it does not exist.
+func (s *Server) Resource(uri string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	resource, ok := s.resources[normalizeURI(uri)]
+	if !ok {
+		return nil
+	}
+	return copyJSON(resource).(map[string]interface{})
+}
+
+// SetResource replaces a resource, i.e. to prepare the server for a test.
+func (s *Server) SetResource(uri string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resources[normalizeURI(uri)] = resource
+}
+
+// Requests returns the requests received so far, except the GETs.
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	uri := normalizeURI(r.URL.Path)
+	var body map[string]interface{}
+	if r.Method != http.MethodGet {
+		if data, err := ioutil.ReadAll(r.Body); err == nil && len(data) > 0 {
+			if err := json.Unmarshal(data, &body); err != nil {
+				writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON", err.Error())
+				return
+			}
+		}
+		s.requests = append(s.requests, Request{Method: r.Method, URI: uri, Body: body})
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, uri, r.URL.Query().Get("$expand") != "")
+	case http.MethodPatch:
+		s.patch(w, uri, body)
+	case http.MethodPost:
+		s.post(w, uri, body)
+	case http.MethodDelete:
+		s.delete(w, uri)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed", r.Method+" is not supported")
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, uri string, expand bool) {
+	resource, ok := s.resources[uri]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", uri+" not found")
+		return
+	}
+	if members, ok := resource["Members"].([]interface{}); ok && expand {
+		expanded := copyJSON(resource).(map[string]interface{})
+		for i, member := range members {
+			link, _ := member.(map[string]interface{})["@odata.id"].(string)
+			if memberResource, ok := s.resources[normalizeURI(link)]; ok {
+				expanded["Members"].([]interface{})[i] = memberResource
+			}
+		}
+		resource = expanded
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) patch(w http.ResponseWriter, uri string, body map[string]interface{}) {
+	resource, ok := s.resources[uri]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", uri+" not found")
+		return
+	}
+	// The apply time is an annotation of the request, not a property of the resource
+	delete(body, "@Redfish.SettingsApplyTime")
+	mergeJSON(resource, body)
+	if strings.HasSuffix(uri, "/Settings") {
+		if parent, ok := s.resources[strings.TrimSuffix(uri, "/Settings")]; ok {
+			mergeJSON(parent, body)
+		}
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) post(w http.ResponseWriter, uri string, body map[string]interface{}) {
+	if strings.Contains(uri, "/Actions/") {
+		if result, ok := s.resources[uri]; ok {
+			writeJSON(w, http.StatusOK, result)
+			return
+		}
+		s.tasks++
+		taskURI := fmt.Sprintf("/redfish/v1/TaskService/Tasks/%d", s.tasks)
+		s.resources[taskURI] = map[string]interface{}{
+			"@odata.id":       taskURI,
+			"Id":              fmt.Sprintf("%d", s.tasks),
+			"Name":            "Task " + uri[strings.LastIndex(uri, "/")+1:],
+			"TaskState":       "Completed",
+			"TaskStatus":      "OK",
+			"PercentComplete": 100,
+			"Messages":        []interface{}{},
+		}
+		if tasks, ok := s.resources["/redfish/v1/TaskService/Tasks"]; ok {
+			members, _ := tasks["Members"].([]interface{})
+			tasks["Members"] = append(members, map[string]interface{}{"@odata.id": taskURI})
+			tasks["Members@odata.count"] = len(members) + 1
+		}
+		w.Header().Set("Location", taskURI)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	collection, ok := s.resources[uri]
+	members, isCollection := collection["Members"].([]interface{})
+	if !ok || !isCollection {
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed", "POST is not supported on "+uri)
+		return
+	}
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+	id := fmt.Sprintf("%d", len(members)+1)
+	if v, ok := body["Id"].(string); ok {
+		id = v
+	}
+	memberURI := uri + "/" + id
+	body["@odata.id"] = memberURI
+	body["Id"] = id
+	if strings.HasSuffix(uri, "/SessionService/Sessions") {
+		// Tokens are not checked, but clients need one to authenticate
+		delete(body, "Password")
+		w.Header().Set("X-Auth-Token", "mock-token-"+id)
+	}
+	s.resources[memberURI] = body
+	collection["Members"] = append(members, map[string]interface{}{"@odata.id": memberURI})
+	collection["Members@odata.count"] = len(members) + 1
+	w.Header().Set("Location", memberURI)
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (s *Server) delete(w http.ResponseWriter, uri string) {
+	resource, ok := s.resources[uri]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI", uri+" not found")
+		return
+	}
+	// Deleting a settings object discards the pending settings, the object itself remains
+	if strings.HasSuffix(uri, "/Settings") {
+		resource["Attributes"] = map[string]interface{}{}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	delete(s.resources, uri)
+	if collection, ok := s.resources[uri[:strings.LastIndex(uri, "/")]]; ok {
+		if members, ok := collection["Members"].([]interface{}); ok {
+			kept := []interface{}{}
+			for _, member := range members {
+				if member.(map[string]interface{})["@odata.id"] != uri {
+					kept = append(kept, member)
+				}
+			}
+			collection["Members"] = kept
+			collection["Members@odata.count"] = len(kept)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// normalizeURI removes the trailing slash, except from the service root, which gofish requests with it
+func normalizeURI(uri string) string {
+	if uri == "/redfish/v1" {
+		return "/redfish/v1/"
+	}
+	if uri != "/redfish/v1/" {
+		return strings.TrimSuffix(uri, "/")
+	}
+	return uri
+}
+
+// mergeJSON applies patch to resource as a JSON merge patch
+func mergeJSON(resource map[string]interface{}, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(resource, key)
+			continue
+		}
+		patchObject, isObject := value.(map[string]interface{})
+		resourceObject, hasObject := resource[key].(map[string]interface{})
+		if isObject && hasObject {
+			mergeJSON(resourceObject, patchObject)
+		} else {
+			resource[key] = value
+		}
+	}
+}
+
+func copyJSON(value interface{}) interface{} {
+	data, _ := json.Marshal(value)
+	var result interface{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, messageID string, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    messageID,
+			"message": message,
+			"@Message.ExtendedInfo": []interface{}{
+				map[string]interface{}{"MessageId": messageID, "Message": message, "Severity": "Critical"},
+			},
+		},
+	})
+}
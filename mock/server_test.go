@@ -0,0 +1,120 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestNewServer(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		shouldPass bool
+	}{
+		{1, DellVendor, true},
+		{2, HPEVendor, true},
+		{3, "acme", false},
+	}
+	for _, v := range cases {
+		server, err := NewServer(v.vendor)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if err != nil {
+			continue
+		}
+		// Every link of the fixtures must lead to a resource, or the provider would get 404s the BMC does not return
+		for uri, resource := range server.resources {
+			for _, link := range links(resource) {
+				if _, ok := server.resources[normalizeURI(link)]; !ok {
+					t.Errorf("Test number %v: %s links to %s, which has no fixture", v.noTest, uri, link)
+				}
+			}
+		}
+		server.Close()
+	}
+}
+
+func TestServer(t *testing.T) {
+	server, err := NewServer(DellVendor)
+	if err != nil {
+		t.Fatalf("error starting the server %s", err)
+	}
+	defer server.Close()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	do := func(method string, uri string, body interface{}) *http.Response {
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, server.URL+uri, bytes.NewReader(data))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed %s", method, uri, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	const biosURI = "/redfish/v1/Systems/System.Embedded.1/Bios"
+
+	cases := []struct {
+		noTest     int
+		method     string
+		uri        string
+		body       interface{}
+		statusCode int
+	}{
+		{1, http.MethodGet, "/redfish/v1", nil, http.StatusOK},
+		{2, http.MethodGet, "/redfish/v1/Systems/", nil, http.StatusOK},
+		{3, http.MethodGet, "/redfish/v1/Missing", nil, http.StatusNotFound},
+		{4, http.MethodPatch, biosURI + "/Settings", map[string]interface{}{"Attributes": map[string]interface{}{"NumLock": "Off"}}, http.StatusOK},
+		{5, http.MethodPost, "/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset", map[string]interface{}{"ResetType": "On"}, http.StatusAccepted},
+		{6, http.MethodPost, "/redfish/v1/TaskService/Tasks", map[string]interface{}{"Name": "Task"}, http.StatusCreated},
+		{7, http.MethodPost, biosURI, nil, http.StatusMethodNotAllowed},
+		{8, http.MethodDelete, "/redfish/v1/TaskService/Tasks/1", nil, http.StatusNoContent},
+		{9, http.MethodPost, "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellOSDeploymentService/Actions/DellOSDeploymentService.GetAttachStatus", nil, http.StatusOK},
+	}
+	for _, v := range cases {
+		if resp := do(v.method, v.uri, v.body); resp.StatusCode != v.statusCode {
+			t.Errorf("Test number %v: expected status %d, got %d", v.noTest, v.statusCode, resp.StatusCode)
+		}
+	}
+
+	// The settings are applied to the BIOS right away, the other attributes are kept
+	attributes := server.Resource(biosURI)["Attributes"].(map[string]interface{})
+	if attributes["NumLock"] != "Off" || attributes["BootMode"] != "Uefi" {
+		t.Errorf("unexpected BIOS attributes %v", attributes)
+	}
+	// The task of the reset was deleted, the one created through the collection is left.
+	// GetAttachStatus returned its fixture, without creating a task
+	tasks := server.Resource("/redfish/v1/TaskService/Tasks")["Members"].([]interface{})
+	if len(tasks) != 1 || server.Resource("/redfish/v1/TaskService/Tasks/1") != nil {
+		t.Errorf("unexpected tasks %v", tasks)
+	}
+	if requests := server.Requests(); len(requests) != 6 || requests[1].Body["ResetType"] != "On" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+// links returns the @odata.id of the resources linked from value
+func links(value interface{}) []string {
+	result := []string{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if key == "@odata.id" && len(v) == 1 {
+				result = append(result, child.(string))
+			} else {
+				result = append(result, links(child)...)
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			result = append(result, links(child)...)
+		}
+	}
+	return result
+}
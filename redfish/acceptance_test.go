@@ -0,0 +1,1196 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/dell/terraform-provider-redfish/mock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Acceptance tests run the resources and data sources of the provider against the BMC set in
+// REDFISH_ENDPOINT, REDFISH_USER and REDFISH_PASSWORD when TF_ACC is set. With REDFISH_MOCK=1 they
+// run against the mock Redfish service instead, with the fixtures of REDFISH_MOCK_VENDOR
+// (dell by default), so no physical BMC is needed:
+//
+//	REDFISH_MOCK=1 go test ./redfish -v -run TestAcc
+//
+// They call the CRUD functions in-process, as terraform would, so no terraform binary is needed either.
+const (
+	testAccEnvVar           string = "TF_ACC"
+	testAccMockEnvVar       string = "REDFISH_MOCK"
+	testAccMockVendorEnvVar string = "REDFISH_MOCK_VENDOR"
+	// testAccSessionAuthEnvVar runs the tests with session_auth when set to 1
+	testAccSessionAuthEnvVar string = "REDFISH_SESSION_AUTH"
+)
+
+// testAccMockServer is the mock service the acceptance tests run against in mock mode, nil otherwise
+var testAccMockServer *mock.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testAccMockEnvVar) == "1" {
+		server, err := mock.NewServer(testAccMockVendor())
+		if err != nil {
+			log.Fatalf("error starting the mock Redfish service: %s", err)
+		}
+		testAccMockServer = server
+		os.Setenv("REDFISH_ENDPOINT", server.URL)
+		os.Setenv("REDFISH_USER", "root")
+		os.Setenv("REDFISH_PASSWORD", "calvin")
+		os.Setenv(testAccEnvVar, "1")
+	}
+	code := m.Run()
+	if testAccMockServer != nil {
+		testAccMockServer.Close()
+	}
+	os.Exit(code)
+}
+
+func testAccMockVendor() string {
+	if vendor := os.Getenv(testAccMockVendorEnvVar); vendor != "" {
+		return vendor
+	}
+	return mock.DellVendor
+}
+
+// testAccProvider configures the provider for the BMC of the acceptance tests, skipping the test if there is none.
+// vendors restricts the test to the mocks of the vendors whose OEM extensions it needs. Empty means every vendor.
+func testAccProvider(t *testing.T, vendors ...string) interface{} {
+	t.Helper()
+	if os.Getenv(testAccEnvVar) == "" {
+		t.Skipf("acceptance tests skipped unless %s or %s=1 are set", testAccEnvVar, testAccMockEnvVar)
+	}
+	if testAccMockServer != nil && len(vendors) > 0 && !stringInSlice(testAccMockVendor(), vendors) {
+		t.Skipf("the %s mock does not implement the OEM extensions of %v", testAccMockVendor(), vendors)
+	}
+	for _, name := range []string{"REDFISH_ENDPOINT", "REDFISH_USER", "REDFISH_PASSWORD"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests", name)
+		}
+	}
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"redfish_endpoint": os.Getenv("REDFISH_ENDPOINT"),
+		"user":             os.Getenv("REDFISH_USER"),
+		"password":         os.Getenv("REDFISH_PASSWORD"),
+		"ssl_insecure":     true,
+		"session_auth":     os.Getenv(testAccSessionAuthEnvVar) == "1",
+	}))
+	testAccCheckDiags(t, "configuring the provider", diags)
+	return provider.Meta()
+}
+
+// testAccApply creates a resource from raw and reads it back, as terraform apply does
+func testAccApply(t *testing.T, m interface{}, name string, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	r := Provider().ResourcesMap[name]
+	testAccCheckDiags(t, "validating "+name, r.Validate(terraform.NewResourceConfigRaw(raw)))
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.MarkNewResource()
+	testAccCheckDiags(t, "creating "+name, r.CreateContext(context.Background(), d, m))
+	if d.Id() == "" {
+		t.Fatalf("%s was created without an id", name)
+	}
+	testAccCheckDiags(t, "reading "+name, r.ReadContext(context.Background(), d, m))
+	return d
+}
+
+// testAccDestroy destroys a resource created by testAccApply
+func testAccDestroy(t *testing.T, m interface{}, name string, d *schema.ResourceData) {
+	t.Helper()
+	r := Provider().ResourcesMap[name]
+	testAccCheckDiags(t, "destroying "+name, r.DeleteContext(context.Background(), d, m))
+}
+
+// testAccDataSource reads a data source configured with raw
+func testAccDataSource(t *testing.T, m interface{}, name string, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	r := Provider().DataSourcesMap[name]
+	testAccCheckDiags(t, "validating "+name, r.Validate(terraform.NewResourceConfigRaw(raw)))
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	testAccCheckDiags(t, "reading "+name, r.ReadContext(context.Background(), d, m))
+	if d.Id() == "" {
+		t.Fatalf("%s was read without an id", name)
+	}
+	return d
+}
+
+func testAccCheckDiags(t *testing.T, action string, diags diag.Diagnostics) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			t.Fatalf("error %s: %s %s", action, d.Summary, d.Detail)
+		}
+	}
+}
+
+func testAccCheckAttr(t *testing.T, d *schema.ResourceData, key string, expected string) {
+	t.Helper()
+	if value := d.Get(key); value != expected {
+		t.Errorf("expected %s to be %q, got %v", key, expected, value)
+	}
+}
+
+// testAccCheckMockRequest checks the mock service received a request whose URI contains uri.
+// It passes right away against real BMCs.
+func testAccCheckMockRequest(t *testing.T, method string, uri string) {
+	t.Helper()
+	if testAccMockServer == nil {
+		return
+	}
+	for _, request := range testAccMockServer.Requests() {
+		if request.Method == method && strings.Contains(request.URI, uri) {
+			return
+		}
+	}
+	t.Errorf("the mock service received no %s request to %s", method, uri)
+}
+
+func stringInSlice(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAccRedfishServiceRoot(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_service_root", map[string]interface{}{"minimum_redfish_version": "1.0.0"})
+	if d.Get("redfish_version").(string) == "" {
+		t.Errorf("no redfish_version in the state")
+	}
+	testAccCheckAttr(t, d, "oem_vendor", m.(*providerConfig).oem.Vendor())
+	if features := d.Get("protocol_features").([]interface{}); len(features) != 1 {
+		t.Errorf("expected protocol_features, got %v", features)
+	}
+
+	r := Provider().DataSourcesMap["redfish_service_root"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"minimum_redfish_version": "99.0"})
+	if diags := r.ReadContext(context.Background(), d, m); !diags.HasError() {
+		t.Errorf("redfish_service_root was read with a minimum_redfish_version not met")
+	}
+}
+
+func TestAccRedfishJobs(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_jobs", map[string]interface{}{"states": []interface{}{"completed"}})
+	jobs := d.Get("jobs").([]interface{})
+	if len(jobs) == 0 {
+		t.Fatalf("no completed job found")
+	}
+	for _, v := range jobs {
+		job := v.(map[string]interface{})
+		if !job["finished"].(bool) || job["uri"].(string) == "" {
+			t.Errorf("unexpected job %v", job)
+		}
+	}
+	d = testAccDataSource(t, m, "redfish_jobs", map[string]interface{}{"unfinished_only": true, "types": []interface{}{"FirmwareUpdate"}})
+	if jobs := d.Get("jobs").([]interface{}); len(jobs) != 0 {
+		t.Errorf("unexpected unfinished firmware jobs %v", jobs)
+	}
+}
+
+func TestAccRedfishDrives(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_drives", map[string]interface{}{"media_types": []interface{}{"SSD"}, "limit": 2})
+	names := d.Get("names").([]interface{})
+	if len(names) == 0 || len(names) != len(d.Get("ids").([]interface{})) {
+		t.Fatalf("unexpected drives %v", d.Get("drives"))
+	}
+	slot := -1
+	for _, v := range d.Get("drives").([]interface{}) {
+		drive := v.(map[string]interface{})
+		if drive["media_type"].(string) != "SSD" || drive["slot"].(int) < slot {
+			t.Errorf("unexpected drive %v", drive)
+		}
+		slot = drive["slot"].(int)
+	}
+}
+
+func TestAccRedfishBios(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_bios", map[string]interface{}{
+		"attributes":          map[string]interface{}{"NumLock": "Off"},
+		"settings_apply_time": "OnReset",
+	})
+	if testAccMockServer != nil {
+		// The mock applies the pending settings right away
+		testAccCheckAttr(t, d, "attributes.NumLock", "Off")
+	}
+	testAccDestroy(t, m, "redfish_bios", d)
+}
+
+func TestAccRedfishPreconditions(t *testing.T) {
+	m := testAccProvider(t)
+	system, err := common.GetSystem(m.(*providerConfig).client, "")
+	if err != nil {
+		t.Fatalf("error fetching the system: %s", err)
+	}
+	r := Provider().ResourcesMap["redfish_bios"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"attributes": map[string]interface{}{"NumLock": "Off"},
+		"preconditions": []interface{}{
+			map[string]interface{}{"power_state": map[string]string{"On": "Off", "Off": "On"}[string(system.PowerState)]},
+		},
+	})
+	d.MarkNewResource()
+	if diags := r.CreateContext(context.Background(), d, m); !diags.HasError() || d.Id() != "" {
+		t.Errorf("redfish_bios was applied with a power_state precondition not met")
+	}
+
+	d = testAccApply(t, m, "redfish_bios", map[string]interface{}{
+		"attributes": map[string]interface{}{"NumLock": "Off"},
+		"preconditions": []interface{}{
+			// Real BMCs might be running unrelated jobs
+			map[string]interface{}{"power_state": string(system.PowerState), "no_active_jobs": testAccMockServer != nil},
+		},
+	})
+	testAccDestroy(t, m, "redfish_bios", d)
+}
+
+func TestAccRedfishAttribute(t *testing.T) {
+	m := testAccProvider(t)
+	managers := testAccDataSource(t, m, "redfish_rest", map[string]interface{}{"path": "/redfish/v1/Managers"})
+	var collection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		}
+	}
+	if err := json.Unmarshal([]byte(managers.Get("json").(string)), &collection); err != nil || len(collection.Members) == 0 {
+		t.Fatalf("no managers found in %s", managers.Get("json"))
+	}
+	manager := collection.Members[0].ODataID
+	d := testAccApply(t, m, "redfish_attribute", map[string]interface{}{
+		"uri":  manager + "/NetworkProtocol",
+		"body": `{"SSH":{"ProtocolEnabled":true}}`,
+	})
+	testAccCheckMockRequest(t, "PATCH", "/NetworkProtocol")
+	if !strings.Contains(d.Get("current").(string), `"ProtocolEnabled":true`) {
+		t.Errorf("unexpected current value %s", d.Get("current"))
+	}
+	testAccDestroy(t, m, "redfish_attribute", d)
+}
+
+func TestAccRedfishSystemLockdown(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_system_lockdown", map[string]interface{}{"enabled": false})
+	if d.Get("enabled").(bool) {
+		t.Errorf("system lockdown is still enabled")
+	}
+	testAccDestroy(t, m, "redfish_system_lockdown", d)
+}
+
+func TestAccRedfishGroupManager(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_group_manager", map[string]interface{}{"enabled": false})
+	if d.Get("enabled").(bool) {
+		t.Errorf("group manager is still enabled")
+	}
+	testAccDestroy(t, m, "redfish_group_manager", d)
+}
+
+func TestAccRedfishBootToBiosSetup(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_boot_to_bios_setup", map[string]interface{}{"wait": false})
+	testAccCheckMockRequest(t, "POST", "ComputerSystem.Reset")
+	testAccDestroy(t, m, "redfish_boot_to_bios_setup", d)
+}
+
+func TestAccRedfishBiosDefaultReset(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_bios_default_reset", map[string]interface{}{"wait": false})
+	testAccCheckMockRequest(t, "POST", "Bios.ResetBios")
+	testAccCheckMockRequest(t, "POST", "ComputerSystem.Reset")
+	testAccDestroy(t, m, "redfish_bios_default_reset", d)
+}
+
+func TestAccRedfishDryRun(t *testing.T) {
+	m := testAccProvider(t)
+	sent := 0
+	if testAccMockServer != nil {
+		sent = len(testAccMockServer.Requests())
+	}
+	d := testAccApply(t, m, "redfish_bios_default_reset", map[string]interface{}{"wait": false, "dry_run": true})
+	requests := d.Get("planned_requests").([]interface{})
+	if len(requests) != 2 || d.Get("dry_run_stopped").(string) != "" {
+		t.Fatalf("unexpected planned requests %v, stopped %q", requests, d.Get("dry_run_stopped"))
+	}
+	for i, action := range []string{"Bios.ResetBios", "ComputerSystem.Reset"} {
+		request := requests[i].(map[string]interface{})
+		if request["method"].(string) != "POST" || !strings.Contains(request["uri"].(string), action) {
+			t.Errorf("expected a POST to %s, got %v", action, request)
+		}
+	}
+	if testAccMockServer != nil {
+		for _, request := range testAccMockServer.Requests()[sent:] {
+			if request.Method != "GET" {
+				t.Errorf("the dry run sent %s %s", request.Method, request.URI)
+			}
+		}
+	}
+	testAccDestroy(t, m, "redfish_bios_default_reset", d)
+}
+
+func TestAccRedfishDataSources(t *testing.T) {
+	m := testAccProvider(t)
+	bios := testAccDataSource(t, m, "redfish_bios", map[string]interface{}{})
+	if bios.Get("attributes.BootMode") == "" {
+		t.Errorf("BIOS attribute BootMode not found")
+	}
+	chassis := testAccDataSource(t, m, "redfish_chassis", map[string]interface{}{"chassis_types": []interface{}{"RackMount"}})
+	if len(chassis.Get("chassis").([]interface{})) == 0 {
+		t.Errorf("no rack mount chassis found")
+	}
+	updateService := testAccDataSource(t, m, "redfish_update_service", map[string]interface{}{})
+	if !updateService.Get("simple_update_supported").(bool) {
+		t.Errorf("the update service does not support SimpleUpdate")
+	}
+	root := testAccDataSource(t, m, "redfish_rest", map[string]interface{}{"path": "/redfish/v1/", "select": []interface{}{"RedfishVersion"}})
+	if root.Get("attributes.RedfishVersion") == "" {
+		t.Errorf("RedfishVersion not found in the service root")
+	}
+	nics := testAccDataSource(t, m, "redfish_pcie_devices", map[string]interface{}{"device_class": "NetworkController"})
+	if len(nics.Get("devices").([]interface{})) == 0 || nics.Get("devices.0.functions.0.vendor_id") == "" {
+		t.Errorf("no PCIe network controller found")
+	}
+}
+
+func TestAccRedfishSystemSelection(t *testing.T) {
+	m := testAccProvider(t)
+	config := m.(*providerConfig)
+	system, err := common.GetSystem(config.client, "")
+	if err != nil {
+		t.Fatalf("error fetching the first computer system: %s", err)
+	}
+	config.systemID = system.ID
+	bios := testAccDataSource(t, m, "redfish_bios", map[string]interface{}{})
+	if !strings.HasPrefix(bios.Get("odata_id").(string), system.ODataID) {
+		t.Errorf("the BIOS of %s was not read: %s", system.ID, bios.Get("odata_id"))
+	}
+	config.systemID = "Missing.System.1"
+	r := Provider().DataSourcesMap["redfish_bios"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.ReadContext(context.Background(), d, m); !diags.HasError() {
+		t.Errorf("the BIOS was read from a system that does not exist")
+	}
+}
+
+func TestAccRedfishUserAccount(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_user_account", map[string]interface{}{
+		"username": "tfacc",
+		"password": "T3rraform!",
+		"enabled":  true,
+		"role_id":  "ReadOnly",
+	})
+	testAccCheckAttr(t, d, "username", "tfacc")
+	testAccCheckAttr(t, d, "role_id", "ReadOnly")
+	id := d.Id()
+	accounts := testAccDataSource(t, m, "redfish_accounts", map[string]interface{}{})
+	found := false
+	for i, username := range accounts.Get("usernames").([]interface{}) {
+		found = found || (username == "tfacc" && accounts.Get(fmt.Sprintf("accounts.%d.id", i)) == id)
+	}
+	if !found {
+		t.Errorf("account tfacc not listed by redfish_accounts: %v", accounts.Get("accounts"))
+	}
+	testAccDestroy(t, m, "redfish_user_account", d)
+	if testAccMockServer != nil {
+		if account := testAccMockServer.Resource("/redfish/v1/AccountService/Accounts/" + id); account == nil || account["UserName"] != "" {
+			t.Errorf("the account slot was not released: %v", account)
+		}
+	}
+}
+
+func TestAccRedfishUsbPorts(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_usb_ports", map[string]interface{}{
+		"front_usb_enabled":    false,
+		"power_button_enabled": true,
+	})
+	if d.Get("front_usb_enabled").(bool) {
+		t.Errorf("the front USB ports are still enabled")
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Attributes")
+	testAccDestroy(t, m, "redfish_usb_ports", d)
+}
+
+func TestAccRedfishIdracQuickSync(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_idrac_quick_sync", map[string]interface{}{
+		"access":              "Read-only",
+		"read_authentication": true,
+		"wifi_enabled":        false,
+		"inactivity_timeout":  300,
+	})
+	testAccCheckAttr(t, d, "access", "Read-only")
+	testAccCheckAttr(t, d, "presence", "Present")
+	if d.Get("wifi_enabled").(bool) {
+		t.Errorf("the Quick Sync Wi-Fi is still enabled")
+	}
+	if d.Get("inactivity_timeout").(int) != 300 {
+		t.Errorf("unexpected inactivity timeout %v", d.Get("inactivity_timeout"))
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Attributes")
+	testAccDestroy(t, m, "redfish_idrac_quick_sync", d)
+}
+
+func TestAccRedfishClearPending(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_clear_pending", map[string]interface{}{"bios": true})
+	if cleared := d.Get("cleared").([]interface{}); len(cleared) != 1 || !strings.HasSuffix(cleared[0].(string), "/Bios/Settings") {
+		t.Errorf("unexpected cleared settings %v", cleared)
+	}
+	testAccDestroy(t, m, "redfish_clear_pending", d)
+}
+
+func TestAccRedfishKmip(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_kmip", map[string]interface{}{
+		"mode":                   "SEKM",
+		"primary_server_address": "kms.example.com",
+		"port":                   5696,
+		"username":               "idrac-sekm",
+		"common_name":            "idrac-sekm",
+		"kms_ca_certificate":     "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+	})
+	testAccCheckAttr(t, d, "mode", "SEKM")
+	testAccCheckAttr(t, d, "primary_server_address", "kms.example.com")
+	testAccCheckMockRequest(t, "POST", "DelliDRACCardService.ImportSSLCertificate")
+	testAccDestroy(t, m, "redfish_kmip", d)
+}
+
+func TestAccRedfishGpu(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_gpu", map[string]interface{}{
+		"profile":        "virtualization",
+		"cooling_offset": "Max",
+	})
+	if skipped := d.Get("skipped_attributes").([]interface{}); len(skipped) == 0 {
+		t.Errorf("the attributes missing from the BIOS were not skipped")
+	}
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) == 0 {
+		t.Errorf("no preset attributes in the state")
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	testAccDestroy(t, m, "redfish_gpu", d)
+}
+
+func TestAccRedfishSerialOverLan(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_serial_over_lan", map[string]interface{}{
+		"enabled":   true,
+		"baud_rate": 57600,
+		"port":      "COM1",
+	})
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) == 0 {
+		t.Errorf("no Serial-over-LAN attributes in the state")
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	testAccDestroy(t, m, "redfish_serial_over_lan", d)
+}
+
+func TestAccRedfishThermalProfile(t *testing.T) {
+	m := testAccProvider(t)
+	config := map[string]interface{}{"thermal_profile": "maximum_performance"}
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		config["fan_speed_offset"] = "Medium"
+		config["minimum_fan_speed"] = 30
+	}
+	d := testAccApply(t, m, "redfish_thermal_profile", config)
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) == 0 {
+		t.Errorf("no thermal attributes in the state")
+	}
+	testAccDestroy(t, m, "redfish_thermal_profile", d)
+}
+
+func TestAccRedfishSystemProfile(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_system_profile", map[string]interface{}{
+		"profile":          "custom",
+		"c_states":         "disabled",
+		"turbo_boost":      "enabled",
+		"uncore_frequency": "maximum",
+	})
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) != 4 {
+		t.Errorf("expected 4 system profile attributes in the state, got %v", attributes)
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	testAccDestroy(t, m, "redfish_system_profile", d)
+}
+
+func TestAccRedfishSmartStorageBattery(t *testing.T) {
+	m := testAccProvider(t, mock.HPEVendor)
+	d := testAccApply(t, m, "redfish_smart_storage_battery", map[string]interface{}{
+		"read_cache_percent":          25,
+		"write_cache_without_battery": false,
+		"drive_write_cache":           "disabled",
+	})
+	if percent := d.Get("read_cache_percent").(int); percent != 25 {
+		t.Errorf("expected read_cache_percent 25, got %d", percent)
+	}
+	testAccCheckAttr(t, d, "location", "Slot 0")
+	testAccCheckAttr(t, d, "battery_status", "OK")
+	testAccCheckMockRequest(t, "PATCH", "/SmartStorageConfig/Settings")
+	testAccDestroy(t, m, "redfish_smart_storage_battery", d)
+}
+
+func TestAccRedfishPciSlot(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_pci_slot", map[string]interface{}{
+		"slot": []interface{}{
+			map[string]interface{}{"number": 1, "enabled": true, "bifurcation": "x8x8"},
+			map[string]interface{}{"number": 2, "enabled": false},
+		},
+	})
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) != 3 {
+		t.Errorf("expected 3 slot attributes in the state, got %v", attributes)
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	testAccDestroy(t, m, "redfish_pci_slot", d)
+}
+
+func TestAccRedfishMemorySettings(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"operating_mode":    "Mirror",
+		"node_interleaving": false,
+	}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		raw["persistent_memory_goal"] = []interface{}{
+			map[string]interface{}{"memory_domain": "PMem.Socket.1"},
+		}
+	}
+	d := testAccApply(t, m, "redfish_memory_settings", raw)
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) != 2 {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	if dell {
+		if chunks := d.Get("memory_chunk_uris").([]interface{}); len(chunks) != 1 {
+			t.Errorf("expected 1 memory chunk, got %v", chunks)
+		}
+		testAccCheckMockRequest(t, "POST", "/MemoryChunks")
+	}
+	testAccDestroy(t, m, "redfish_memory_settings", d)
+}
+
+func TestAccRedfishVirtualNetwork(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"network_adapter_id": "DE07A000",
+		"partition": []interface{}{
+			map[string]interface{}{"function_id": "1", "vlan_id": 100},
+		},
+	}
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		raw = map[string]interface{}{
+			"network_adapter_id": "NIC.Integrated.1",
+			"partitioning":       "Enabled",
+			"partition": []interface{}{
+				map[string]interface{}{"function_id": "NIC.Integrated.1-1-1", "vlan_id": 100, "min_bandwidth_percent": 25, "max_bandwidth_percent": 100},
+				map[string]interface{}{"function_id": "NIC.Integrated.1-1-2", "vlan_id": 0},
+			},
+		}
+	}
+	d := testAccApply(t, m, "redfish_virtual_network", raw)
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		testAccCheckMockRequest(t, "PATCH", "DellNetworkAttributes/NIC.Integrated.1-1-1/Settings")
+		if jobs := d.Get("config_job_uris").([]interface{}); len(jobs) != 2 {
+			t.Errorf("expected a configuration job per function, got %v", jobs)
+		}
+	} else {
+		testAccCheckMockRequest(t, "PATCH", "NetworkDeviceFunctions/1/Settings")
+	}
+	if testAccMockServer != nil {
+		if attributes := d.Get("attributes").(map[string]interface{}); attributes["NIC.Integrated.1-1-1/VLanId"] != "100" && attributes["1/VLANId"] != "100" {
+			t.Errorf("the VLAN was not applied: %v", attributes)
+		}
+	}
+	testAccDestroy(t, m, "redfish_virtual_network", d)
+}
+
+func TestAccRedfishCryptoEraseSystem(t *testing.T) {
+	m := testAccProvider(t)
+	if testAccMockServer == nil {
+		t.Skip("the erase cannot be undone, so it is only tested against the mock service")
+	}
+	raw := map[string]interface{}{
+		"components":          []interface{}{"bios", "drives"},
+		"confirm_erase":       true,
+		"confirm_service_tag": "NOTTHIS",
+	}
+	r := Provider().ResourcesMap["redfish_crypto_erase_system"]
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.MarkNewResource()
+	if diags := r.CreateContext(context.Background(), d, m); !diags.HasError() || d.Id() != "" {
+		t.Errorf("redfish_crypto_erase_system erased a server with another service tag")
+	}
+
+	raw["confirm_service_tag"] = "MOCK123"
+	d = testAccApply(t, m, "redfish_crypto_erase_system", raw)
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		testAccCheckMockRequest(t, "POST", "DellLCService.SystemErase")
+	} else {
+		testAccCheckMockRequest(t, "POST", "Bios.ResetBios")
+		testAccCheckMockRequest(t, "POST", "Drive.SecureErase")
+	}
+	testAccDestroy(t, m, "redfish_crypto_erase_system", d)
+}
+
+func TestAccRedfishIdracTelemetry(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_idrac_telemetry", map[string]interface{}{
+		"enabled":           true,
+		"rsyslog_enabled":   true,
+		"rsyslog_server":    "syslog.example.com",
+		"http_destinations": []interface{}{"https://ingest.example.com/metrics"},
+		"report": []interface{}{
+			map[string]interface{}{"name": "CPUSensor", "enabled": true, "interval": 30},
+		},
+	})
+	if !d.Get("enabled").(bool) {
+		t.Errorf("telemetry was not enabled")
+	}
+	testAccCheckAttr(t, d, "rsyslog_server", "syslog.example.com")
+	if subscriptions := d.Get("subscription_uris").([]interface{}); len(subscriptions) != 1 {
+		t.Errorf("expected 1 subscription, got %v", subscriptions)
+	}
+	testAccCheckMockRequest(t, "POST", "/EventService/Subscriptions")
+	testAccDestroy(t, m, "redfish_idrac_telemetry", d)
+	testAccCheckMockRequest(t, "DELETE", "/EventService/Subscriptions/")
+}
+
+func TestAccRedfishOSDeployment(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_os_deployment", map[string]interface{}{
+		"share": []interface{}{
+			map[string]interface{}{"ip": "10.0.0.10", "share_type": "NFS", "share_name": "/isos"},
+		},
+		"image_name":      "esxi.iso",
+		"driver_pack_os":  "VMware ESXi 7.0",
+		"expose_duration": 120,
+	})
+	if packs := d.Get("available_driver_packs").([]interface{}); len(packs) == 0 {
+		t.Errorf("no driver packs in the state")
+	}
+	testAccCheckMockRequest(t, "POST", "DellOSDeploymentService.UnpackAndAttach")
+	testAccCheckMockRequest(t, "POST", "DellOSDeploymentService.BootToNetworkISO")
+	testAccDestroy(t, m, "redfish_os_deployment", d)
+}
+
+func TestAccRedfishBackupRestore(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_backup_restore", map[string]interface{}{
+		"share": []interface{}{
+			map[string]interface{}{"ip": "10.0.0.10", "share_type": "NFS", "share_name": "/backups"},
+		},
+		"image_name": "r740-known-good",
+		"passphrase": "T3rraform!",
+	})
+	if d.Get("backup_job_uri") == "" {
+		t.Errorf("no backup job in the state")
+	}
+	testAccCheckMockRequest(t, "POST", "DellLCService.BackupImage")
+	testAccDestroy(t, m, "redfish_backup_restore", d)
+}
+
+func TestAccRedfishWatchdog(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_watchdog", map[string]interface{}{
+		"enabled":        true,
+		"timeout_action": "PowerCycle",
+	})
+	if !d.Get("enabled").(bool) {
+		t.Errorf("the watchdog was not enabled")
+	}
+	testAccCheckAttr(t, d, "timeout_action", "PowerCycle")
+	testAccCheckMockRequest(t, "PATCH", "/Systems/")
+	testAccDestroy(t, m, "redfish_watchdog", d)
+}
+
+func TestAccRedfishLocation(t *testing.T) {
+	m := testAccProvider(t)
+	config := map[string]interface{}{
+		"data_center": "DC1",
+		"room":        "Hall 2",
+		"aisle":       "B",
+		"rack":        "R12",
+		"rack_slot":   20,
+	}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if !dell {
+		config["contact_email"] = "infra@example.com"
+	}
+	d := testAccApply(t, m, "redfish_location", config)
+	testAccCheckAttr(t, d, "rack", "R12")
+	if d.Get("rack_slot").(int) != 20 {
+		t.Errorf("expected rack_slot to be 20, got %v", d.Get("rack_slot"))
+	}
+	if dell {
+		if d.Get("size_u").(int) != 1 {
+			t.Errorf("expected size_u to be 1, got %v", d.Get("size_u"))
+		}
+		testAccCheckMockRequest(t, "PATCH", "/Managers/System.Embedded.1/Attributes")
+	} else {
+		testAccCheckAttr(t, d, "contact_email", "infra@example.com")
+		testAccCheckMockRequest(t, "PATCH", "/Chassis/")
+	}
+	testAccDestroy(t, m, "redfish_location", d)
+}
+
+func TestAccRedfishCertificateTrustStore(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_certificate_trust_store", map[string]interface{}{
+		"store":       "ldap",
+		"certificate": testCACertificate(t, "Directory CA"),
+	})
+	testAccCheckAttr(t, d, "collection_uri", "/redfish/v1/AccountService/LDAP/Certificates")
+	testAccCheckAttr(t, d, "subject", "CN=Directory CA")
+	uri := d.Get("certificate_uri").(string)
+	if !strings.HasPrefix(uri, "/redfish/v1/AccountService/LDAP/Certificates/") {
+		t.Errorf("expected the certificate to be in the LDAP trust store, got %s", uri)
+	}
+	testAccCheckMockRequest(t, "POST", "/AccountService/LDAP/Certificates")
+	testAccDestroy(t, m, "redfish_certificate_trust_store", d)
+	testAccCheckMockRequest(t, "DELETE", uri)
+}
+
+func TestAccRedfishIdracRemoteSyslog(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_idrac_remote_syslog", map[string]interface{}{
+		"enabled":        true,
+		"servers":        []interface{}{"syslog1.example.com", "syslog2.example.com"},
+		"tls_enabled":    true,
+		"tls_port":       6514,
+		"ca_certificate": testCACertificate(t, "Syslog CA"),
+	})
+	testAccCheckMockRequest(t, "POST", "DelliDRACCardService.ImportSSLCertificate")
+	testAccCheckMockRequest(t, "PATCH", "/Attributes")
+	if !d.Get("enabled").(bool) || !d.Get("tls_enabled").(bool) {
+		t.Errorf("expected remote syslog over TLS to be enabled")
+	}
+	testAccCheckAttr(t, d, "servers.1", "syslog2.example.com")
+	if servers := d.Get("servers").([]interface{}); len(servers) != 2 {
+		t.Errorf("expected 2 servers, got %v", servers)
+	}
+	testAccDestroy(t, m, "redfish_idrac_remote_syslog", d)
+
+	// The CA of the syslog servers can be managed by the trust store instead
+	store := testAccApply(t, m, "redfish_certificate_trust_store", map[string]interface{}{
+		"store":       "syslog",
+		"certificate": testCACertificate(t, "Syslog CA"),
+	})
+	if !strings.HasPrefix(store.Id(), "RSYSLOG_SERVER_CA#") {
+		t.Errorf("unexpected id %s", store.Id())
+	}
+	testAccDestroy(t, m, "redfish_certificate_trust_store", store)
+}
+
+func TestAccRedfishHostNameDNS(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"network_adapter_id": "DE07A000",
+		"function_id":        "2",
+		"boot_protocol":      "iSCSI",
+		"ip_via_dhcp":        false,
+		"primary_dns":        "10.0.0.53",
+		"initiator_name":     "iqn.2001-05.com.example:server1",
+	}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		raw["network_adapter_id"] = "NIC.Integrated.1"
+		raw["function_id"] = "NIC.Integrated.1-1-2"
+		raw["dhcp_vendor_id"] = "PXEClient"
+	}
+	d := testAccApply(t, m, "redfish_host_name_dns", raw)
+	if dell {
+		testAccCheckMockRequest(t, "PATCH", "DellNetworkAttributes/NIC.Integrated.1-1-2/Settings")
+		testAccCheckAttr(t, d, "dhcp_vendor_id", "PXEClient")
+	} else {
+		testAccCheckMockRequest(t, "PATCH", "NetworkDeviceFunctions/2/Settings")
+	}
+	if testAccMockServer != nil {
+		testAccCheckAttr(t, d, "boot_protocol", "iSCSI")
+		testAccCheckAttr(t, d, "primary_dns", "10.0.0.53")
+		if d.Get("ip_via_dhcp").(bool) {
+			t.Errorf("expected ip_via_dhcp to be false")
+		}
+	}
+	testAccDestroy(t, m, "redfish_host_name_dns", d)
+}
+
+func TestAccRedfishSbNicMacAddress(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"network_adapter_id": "DE07A000",
+		"function_id":        "2",
+		"mac_address":        "02:00:0a:00:01:10",
+	}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		raw["network_adapter_id"] = "NIC.Integrated.1"
+		raw["function_id"] = "NIC.Integrated.1-1-1"
+	}
+	d := testAccApply(t, m, "redfish_sb_nic_mac_address", raw)
+	if dell {
+		testAccCheckMockRequest(t, "PATCH", "DellNetworkAttributes/NIC.Integrated.1-1-1/Settings")
+	} else {
+		testAccCheckMockRequest(t, "PATCH", "NetworkDeviceFunctions/2/Settings")
+	}
+	if testAccMockServer != nil {
+		testAccCheckAttr(t, d, "mac_address", "02:00:0a:00:01:10")
+		if d.Get("permanent_mac_address").(string) == "" {
+			t.Errorf("expected permanent_mac_address to be set")
+		}
+	}
+	testAccDestroy(t, m, "redfish_sb_nic_mac_address", d)
+}
+
+func TestAccRedfishPowerMetrics(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"chassis_id":     "1",
+		"warning_watts":  700,
+		"critical_watts": 850,
+	}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		raw["chassis_id"] = "System.Embedded.1"
+		raw["alert_destination"] = "https://alerts.example.com/redfish"
+	}
+	d := testAccApply(t, m, "redfish_power_metrics", raw)
+	testAccCheckMockRequest(t, "PATCH", "/Sensors/")
+	if d.Get("warning_watts").(int) != 700 || d.Get("critical_watts").(int) != 850 {
+		t.Errorf("expected thresholds 700 and 850, got %v and %v", d.Get("warning_watts"), d.Get("critical_watts"))
+	}
+	if dell {
+		testAccCheckMockRequest(t, "POST", "/EventService/Subscriptions")
+		if d.Get("subscription_uri").(string) == "" {
+			t.Errorf("expected subscription_uri to be set")
+		}
+	}
+	if testAccMockServer != nil {
+		if d.Get("power_consumed_watts").(float64) != 212 {
+			t.Errorf("expected power_consumed_watts to be 212, got %v", d.Get("power_consumed_watts"))
+		}
+		if dell {
+			testAccCheckAttr(t, d, "sensor_id", "SystemBoardPwrConsumption")
+		}
+	}
+	testAccDestroy(t, m, "redfish_power_metrics", d)
+	if dell {
+		testAccCheckMockRequest(t, "DELETE", "/EventService/Subscriptions/")
+	}
+
+	r := Provider().ResourcesMap["redfish_power_metrics"]
+	invalid := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"chassis_id": raw["chassis_id"], "sensor_id": "Unknown"})
+	if !r.CreateContext(context.Background(), invalid, m).HasError() {
+		t.Errorf("expected an error for an unknown sensor")
+	}
+}
+
+func TestAccRedfishSecureBoot(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_secure_boot", map[string]interface{}{})
+	if testAccMockServer == nil {
+		return
+	}
+	testAccCheckAttr(t, d, "mode", "UserMode")
+	databases := d.Get("databases").([]interface{})
+	if m.(*providerConfig).oem.Vendor() != "dell" {
+		if len(databases) != 0 {
+			t.Errorf("expected no databases, got %v", databases)
+		}
+		return
+	}
+	if len(databases) != 2 {
+		t.Fatalf("expected the db and dbx databases, got %v", databases)
+	}
+	db := databases[0].(map[string]interface{})
+	if digests := db["digests"].([]interface{}); len(digests) != 1 || digests[0] != "dab9b54a23f1e9c85ba4435aa0afdece41871445aa87cff9b8f6cb117fd6560e" {
+		t.Errorf("unexpected digests of db %v", digests)
+	}
+	dbx := databases[1].(map[string]interface{})
+	if keys := dbx["keys"].([]interface{}); len(keys) != 1 || keys[0].(map[string]interface{})["signature_type"] != "EFI_CERT_SHA256_GUID" {
+		t.Errorf("unexpected keys of dbx %v", keys)
+	}
+}
+
+func TestAccRedfishIdracLockout(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	d := testAccApply(t, m, "redfish_idrac_lockout", map[string]interface{}{
+		"ip_range_enabled":     true,
+		"ip_range_address":     "10.20.0.0",
+		"ip_range_mask":        "255.255.0.0",
+		"lockout_fail_count":   5,
+		"lockout_penalty_time": 900,
+	})
+	testAccCheckAttr(t, d, "ip_range_address", "10.20.0.0")
+	if !d.Get("ip_range_enabled").(bool) || !d.Get("lockout_enabled").(bool) {
+		t.Errorf("expected the IP range filtering and the lockout to be enabled")
+	}
+	if d.Get("lockout_fail_count").(int) != 5 || d.Get("lockout_penalty_time").(int) != 900 {
+		t.Errorf("unexpected lockout settings %v %v", d.Get("lockout_fail_count"), d.Get("lockout_penalty_time"))
+	}
+	testAccCheckMockRequest(t, "PATCH", "/iDRAC.Embedded.1/Attributes")
+	testAccDestroy(t, m, "redfish_idrac_lockout", d)
+}
+
+func TestAccRedfishFan(t *testing.T) {
+	m := testAccProvider(t)
+	config := map[string]interface{}{}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		config["third_party_pcie_fan_response"] = false
+		// The mock exposes a writable redundancy, which real BMCs rarely do
+		if testAccMockServer != nil {
+			config["redundancy_mode"] = "Sparing"
+		}
+	}
+	d := testAccApply(t, m, "redfish_fan", config)
+	if fans := d.Get("fans").([]interface{}); len(fans) == 0 {
+		t.Errorf("no fans in the state")
+	}
+	if dell && d.Get("third_party_pcie_fan_response").(bool) {
+		t.Errorf("expected the third party PCIe fan response to be disabled")
+	}
+	if testAccMockServer != nil {
+		if dell {
+			testAccCheckAttr(t, d, "redundancy_mode", "Sparing")
+			testAccCheckMockRequest(t, "PATCH", "/Thermal")
+		} else {
+			testAccCheckAttr(t, d, "redundancy_mode", "N+m")
+			testAccCheckAttr(t, d, "redundancy_status", "OK")
+		}
+	}
+	testAccDestroy(t, m, "redfish_fan", d)
+}
+
+func TestAccRedfishServerFacts(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_server_facts", map[string]interface{}{})
+	if d.Get("service_tag").(string) == "" || d.Get("firmware.0.bios").(string) == "" || d.Get("firmware.0.bmc").(string) == "" {
+		t.Errorf("missing service tag or firmware versions")
+	}
+	if macAddresses := d.Get("mac_addresses").(map[string]interface{}); len(macAddresses) == 0 {
+		t.Errorf("no MAC addresses in the state")
+	}
+	if testAccMockServer == nil {
+		return
+	}
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		testAccCheckAttr(t, d, "service_tag", "MOCK123")
+		// The dots of the storage Id cannot be addressed by Get
+		if raidControllers := d.Get("firmware.0.raid_controllers").(map[string]interface{}); raidControllers["RAID.Integrated.1-1"] != "51.16.0-4076" {
+			t.Errorf("unexpected RAID controller firmware %v", raidControllers)
+		}
+		testAccCheckAttr(t, d, "manager.0.mac_address", "D0:94:66:10:04:B3")
+	} else {
+		testAccCheckAttr(t, d, "firmware.0.nics.DE07A000", "20.14.54")
+		testAccCheckAttr(t, d, "mac_addresses.1", "14:02:EC:5A:10:31")
+	}
+}
+
+func TestAccRedfishChassisSledPower(t *testing.T) {
+	m := testAccProvider(t, mock.DellVendor)
+	if testAccMockServer == nil {
+		t.Skip("the sled is power cycled, so it is only tested against the mock service")
+	}
+	d := testAccApply(t, m, "redfish_chassis_sled_power", map[string]interface{}{"chassis_id": "Sled.Slot.1", "action": "PowerCycle"})
+	testAccCheckAttr(t, d, "power_state", "On")
+	testAccCheckMockRequest(t, "POST", "/Sled.Slot.1/Actions/Chassis.Reset")
+	testAccDestroy(t, m, "redfish_chassis_sled_power", d)
+
+	d = testAccApply(t, m, "redfish_chassis_sled_power", map[string]interface{}{"chassis_id": "Sled.Slot.1", "action": "VirtualReseat"})
+	testAccCheckMockRequest(t, "POST", "/Sled.Slot.1/Actions/Oem/DellChassis.VirtualReseat")
+	testAccDestroy(t, m, "redfish_chassis_sled_power", d)
+
+	// The rack server does not support a virtual reseat
+	r := Provider().ResourcesMap["redfish_chassis_sled_power"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"chassis_id": "System.Embedded.1", "action": "VirtualReseat"})
+	if diags := r.CreateContext(context.Background(), d, m); !diags.HasError() {
+		t.Errorf("expected the virtual reseat of a rack server to fail")
+	}
+}
+
+func TestAccRedfishSpdm(t *testing.T) {
+	m := testAccProvider(t)
+	if testAccMockServer == nil {
+		t.Skip("the attestation of the devices is changed, so it is only tested against the mock service")
+	}
+	d := testAccApply(t, m, "redfish_spdm", map[string]interface{}{"enabled": false})
+	if d.Get("components.0.enabled").(bool) {
+		t.Errorf("expected the attestation of the components to be disabled")
+	}
+	testAccCheckMockRequest(t, "PATCH", "/ComponentIntegrity/")
+	testAccDestroy(t, m, "redfish_spdm", d)
+
+	r := Provider().ResourcesMap["redfish_spdm"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"enabled": true, "component_ids": []interface{}{"Missing.1"}})
+	if diags := r.CreateContext(context.Background(), d, m); !diags.HasError() {
+		t.Errorf("expected the attestation of an unknown component to fail")
+	}
+}
+
+func TestAccRedfishComponentIntegrity(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_component_integrity", map[string]interface{}{})
+	if testAccMockServer == nil {
+		return
+	}
+	testAccCheckAttr(t, d, "components.0.type", "SPDM")
+	testAccCheckAttr(t, d, "components.0.verification_status", "Success")
+	testAccCheckAttr(t, d, "components.0.measurements.0.type", "MutableFirmwareVersion")
+}
+
+func TestAccRedfishLicense(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_license", map[string]interface{}{})
+	if testAccMockServer == nil {
+		return
+	}
+	if m.(*providerConfig).oem.Vendor() != "dell" {
+		testAccCheckAttr(t, d, "licenses.0.description", "iLO Advanced")
+		return
+	}
+	testAccCheckAttr(t, d, "licenses.0.entitlement_id", "ABCDE12345")
+	testAccCheckAttr(t, d, "licenses.1.license_type", "Trial")
+	if d.Get("licenses.0.expired").(bool) || !d.Get("licenses.1.expired").(bool) {
+		t.Errorf("expected only the evaluation license to be expired")
+	}
+}
+
+func TestAccRedfishEventSubscriptions(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccDataSource(t, m, "redfish_event_subscriptions", map[string]interface{}{
+		"destination_regex": `^https://10\.0\.9\.20`,
+	})
+	if testAccMockServer == nil {
+		return
+	}
+	if subscriptions := d.Get("subscriptions").([]interface{}); len(subscriptions) != 1 {
+		t.Fatalf("expected 1 subscription, got %v", subscriptions)
+	}
+	testAccCheckAttr(t, d, "subscriptions.0.context", "legacy-syslog-relay")
+	testAccCheckAttr(t, d, "subscriptions.0.event_types.0", "Alert")
+	if d.Get("subscriptions.0.managed").(bool) {
+		t.Errorf("expected the subscription not to be managed by the provider")
+	}
+}
+
+func TestAccRedfishIdracAttributes(t *testing.T) {
+	m := testAccProvider(t)
+	raw := map[string]interface{}{
+		"source":     "bios",
+		"name_regex": "^Proc",
+	}
+	prefix, expected := "Proc", "ProcVirtualization"
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		raw = map[string]interface{}{"name_glob": "SSH.*"}
+		prefix, expected = "SSH.", "SSH.1.Timeout"
+	}
+	d := testAccDataSource(t, m, "redfish_idrac_attributes", raw)
+	attributes := d.Get("attributes").(map[string]interface{})
+	if _, ok := attributes[expected]; !ok {
+		t.Errorf("expected %s in the attributes, got %v", expected, attributes)
+	}
+	for name := range attributes {
+		if !strings.HasPrefix(name, prefix) {
+			t.Errorf("attribute %s does not match the filters", name)
+		}
+	}
+}
+
+func TestAccRedfishBootCertificate(t *testing.T) {
+	m := testAccProvider(t)
+	d := testAccApply(t, m, "redfish_boot_certificate", map[string]interface{}{
+		"boot_uri":          "https://images.example.com/boot.efi",
+		"https_certificate": testCACertificate(t, "Boot CA"),
+		"dns_servers":       []interface{}{"10.0.0.53"},
+	})
+	uri := d.Get("certificate_uri").(string)
+	if !strings.Contains(uri, "/Boot/Certificates/") {
+		t.Errorf("expected the certificate to be in the boot certificates, got %s", uri)
+	}
+	if attributes := d.Get("attributes").(map[string]interface{}); len(attributes) == 0 {
+		t.Errorf("no HTTP boot attributes in the state")
+	}
+	testAccCheckMockRequest(t, "POST", "/Boot/Certificates")
+	testAccCheckMockRequest(t, "PATCH", "/Bios/Settings")
+	testAccDestroy(t, m, "redfish_boot_certificate", d)
+	testAccCheckMockRequest(t, "DELETE", uri)
+}
+
+func TestAccRedfishManagerResetToDefaults(t *testing.T) {
+	m := testAccProvider(t)
+	if testAccMockServer == nil {
+		t.Skip("the BMC is reset to its defaults, so it is only tested against the mock service")
+	}
+	d := testAccApply(t, m, "redfish_manager_reset_to_defaults", map[string]interface{}{"reset_type": "PreserveNetworkAndUsers"})
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		testAccCheckMockRequest(t, "POST", "/Actions/Oem/DellManager.ResetToDefaults")
+	} else {
+		testAccCheckMockRequest(t, "POST", "/Actions/Manager.ResetToDefaults")
+	}
+	testAccDestroy(t, m, "redfish_manager_reset_to_defaults", d)
+}
+
+func TestAccRedfishAssistiveKvm(t *testing.T) {
+	m := testAccProvider(t)
+	config := map[string]interface{}{"session_timeout": 900}
+	dell := m.(*providerConfig).oem.Vendor() == "dell"
+	if dell {
+		config["web_timeout"] = 900
+		config["ssh_max_sessions"] = 2
+		config["virtual_console_max_sessions"] = 1
+	}
+	d := testAccApply(t, m, "redfish_assistive_kvm", config)
+	if d.Get("session_timeout").(int) != 900 {
+		t.Errorf("expected the session timeout to be 900, got %d", d.Get("session_timeout").(int))
+	}
+	if dell && (d.Get("web_timeout").(int) != 900 || d.Get("virtual_console_max_sessions").(int) != 1) {
+		t.Errorf("unexpected session attributes %d and %d", d.Get("web_timeout").(int), d.Get("virtual_console_max_sessions").(int))
+	}
+	testAccDestroy(t, m, "redfish_assistive_kvm", d)
+}
+
+func TestAccRedfishAction(t *testing.T) {
+	m := testAccProvider(t)
+	if testAccMockServer == nil {
+		t.Skip("the BMC is restarted, so it is only tested against the mock service")
+	}
+	manager, err := common.GetManager(m.(*providerConfig).clientWithContext(context.Background()), m.(*providerConfig).managerID)
+	if err != nil {
+		t.Fatalf("error fetching manager: %s", err)
+	}
+	d := testAccApply(t, m, "redfish_action", map[string]interface{}{
+		"resource_uri": manager.ODataID,
+		"action":       "Manager.Reset",
+		"parameters":   `{"ResetType":"GracefulRestart"}`,
+		"wait":         false,
+	})
+	testAccCheckAttr(t, d, "target", manager.ODataID+"/Actions/Manager.Reset")
+	testAccCheckMockRequest(t, "POST", "/Actions/Manager.Reset")
+	testAccDestroy(t, m, "redfish_action", d)
+
+	r := Provider().ResourcesMap["redfish_action"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"resource_uri": manager.ODataID,
+		"action":       "Manager.Reset",
+		"parameters":   `{"ResetType":"PowerCycle"}`,
+	})
+	if diags := r.CreateContext(context.Background(), d, m); !diags.HasError() {
+		t.Errorf("expected a reset type not allowed to fail")
+	}
+}
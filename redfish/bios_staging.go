@@ -0,0 +1,130 @@
+package redfish
+
+import (
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"sort"
+)
+
+// biosConfigJobAttribute is the computed attribute holding the URI of the job applying the staged BIOS changes
+const biosConfigJobAttribute = "bios_config_job_uri"
+
+// biosChanges returns the attributes whose value differs from the current one of the BIOS,
+// and the sorted names of the attributes the BIOS does not have
+func biosChanges(bios *redfish.Bios, attributes map[string]string) (map[string]interface{}, []string) {
+	payload := make(map[string]interface{})
+	missing := []string{}
+	for key, value := range attributes {
+		if currentValue, ok := bios.Attributes[key]; !ok {
+			missing = append(missing, key)
+		} else if !equivalentValues(fmt.Sprintf("%v", currentValue), value) {
+			payload[key] = value
+		}
+	}
+	sort.Strings(missing)
+	return payload, missing
+}
+
+// stageBiosAttributes sends payload to the BIOS settings object, so the next reboot applies it, and schedules the
+// configuration job when the vendor needs one. The URI of the job is stored in bios_config_job_uri and returned.
+// feature names the settings in the errors (i.e. thermal).
+func stageBiosAttributes(conn *gofish.APIClient, oem common.OEMHandler, d *schema.ResourceData, opLog *operationLog, bios *redfish.Bios, payload map[string]interface{}, feature string) (string, error) {
+	settingsURI := bios.ODataID + "/Settings"
+	applyTime := ""
+	for _, allowed := range bios.AllowedAttributeUpdateApplyTimes() {
+		if allowed == redfishcommon.OnResetApplyTime {
+			applyTime = string(redfishcommon.OnResetApplyTime)
+		}
+	}
+	jobURI, err := patchBiosSettings(bios, payload, applyTime)
+	opLog.record("bios_settings_patch", settingsURI, jobURI, err)
+	if err != nil {
+		return "", fmt.Errorf("error updating %s BIOS attributes: %s", feature, err)
+	}
+	if jobURI == "" {
+		jobURI, err = oem.CreateConfigJob(conn, settingsURI)
+		opLog.record("config_job_create", settingsURI, jobURI, err)
+		if err != nil {
+			return "", fmt.Errorf("error creating the BIOS configuration job: %s", err)
+		}
+	}
+	if err := d.Set(biosConfigJobAttribute, jobURI); err != nil {
+		return "", fmt.Errorf("error setting %s: %s", biosConfigJobAttribute, err)
+	}
+	log.Printf("[DEBUG] %s: %s BIOS changes %v will be applied on the next reboot", d.Id(), feature, payload)
+	return jobURI, nil
+}
+
+// setStagedBiosAttributes stores the attributes managed by the resource. The pending BIOS changes are stored
+// as applied, so they are not sent again on every apply, and readStagedBiosAttributes keeps them until the reboot.
+func setStagedBiosAttributes(d *schema.ResourceData, attributes map[string]string) error {
+	if err := d.Set("attributes", attributes); err != nil {
+		return fmt.Errorf("error setting attributes: %s", err)
+	}
+	return nil
+}
+
+// planStagedAttributes plans attributes as the attributes of the resource when they differ from the stored ones,
+// so the attributes changed out of band (i.e. from the BIOS setup) get applied again. It is meant for CustomizeDiff,
+// once the resource exists.
+func planStagedAttributes(d *schema.ResourceDiff, attributes map[string]string) error {
+	current := d.Get("attributes").(map[string]interface{})
+	desired := make(map[string]interface{})
+	drifted := false
+	for key, value := range attributes {
+		desired[key] = value
+		if currentValue, ok := current[key]; !ok || !equivalentValues(currentValue.(string), value) {
+			drifted = true
+		}
+	}
+	if drifted || len(desired) != len(current) {
+		return d.SetNew("attributes", desired)
+	}
+	return nil
+}
+
+// readStagedBiosAttributes overrides attributes with their current BIOS values, unless the job at
+// bios_config_job_uri has not applied the pending changes yet, in which case they are kept as stored
+func readStagedBiosAttributes(conn *gofish.APIClient, d *schema.ResourceData, systemID string, attributes map[string]string) error {
+	if configJobPending(conn, d, d.Get(biosConfigJobAttribute).(string)) {
+		return nil
+	}
+	bios, err := getBios(conn, systemID)
+	if err != nil {
+		return fmt.Errorf("error fetching bios resource: %s", err)
+	}
+	biosAttributes := make(map[string]string)
+	if err = copyBiosAttributes(bios, biosAttributes); err != nil {
+		return fmt.Errorf("error fetching BIOS attributes: %s", err)
+	}
+	for key := range attributes {
+		if value, ok := biosAttributes[key]; ok {
+			attributes[key] = value
+		}
+	}
+	return nil
+}
+
+// configJobPending reports if any of the configuration jobs has not finished yet, so the settings it applies
+// on the next reboot are kept as stored. Jobs that cannot be read (i.e. deleted from the queue) are considered finished.
+func configJobPending(conn *gofish.APIClient, d *schema.ResourceData, jobURIs ...string) bool {
+	for _, jobURI := range jobURIs {
+		if jobURI == "" {
+			continue
+		}
+		task, err := common.GetTask(conn, jobURI)
+		if err != nil {
+			log.Printf("[DEBUG] %s: error fetching job %s, considering it finished: %s", d.Id(), jobURI, err)
+			continue
+		}
+		if !task.Finished() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package redfish
+
+import (
+	"github.com/stmcginnis/gofish/redfish"
+	"reflect"
+	"testing"
+)
+
+func TestBiosChanges(t *testing.T) {
+	bios := &redfish.Bios{Attributes: redfish.BiosAttributes{"ProcCStates": "Enabled", "SysProfile": "Custom", "NumLock": "On"}}
+	payload, missing := biosChanges(bios, map[string]string{"ProcCStates": "Disabled", "SysProfile": "custom", "UncoreFrequency": "MaxUncore", "ProcTurboMode": "Enabled"})
+	if expected := map[string]interface{}{"ProcCStates": "Disabled"}; !reflect.DeepEqual(payload, expected) {
+		t.Errorf("Expected payload %v, got %v", expected, payload)
+	}
+	if expected := []string{"ProcTurboMode", "UncoreFrequency"}; !reflect.DeepEqual(missing, expected) {
+		t.Errorf("Expected missing attributes %v, got %v", expected, missing)
+	}
+}
+
+func TestBiosSettingsAttributes(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		settings   func() (map[string]string, error)
+		expected   map[string]string
+		shouldPass bool
+	}{
+		{1, func() (map[string]string, error) {
+			return newSystemProfileSettings("dell", "performance", "", "", "")
+		}, map[string]string{
+			"SysProfile": "PerfOptimized",
+		}, true},
+		{2, func() (map[string]string, error) {
+			return newSystemProfileSettings("dell", "custom", "disabled", "enabled", "maximum")
+		}, map[string]string{
+			"SysProfile":      "Custom",
+			"ProcCStates":     "Disabled",
+			"ProcTurboMode":   "Enabled",
+			"UncoreFrequency": "MaxUncore",
+		}, true},
+		{3, func() (map[string]string, error) {
+			return newSystemProfileSettings("hpe", "performance_per_watt", "", "", "")
+		}, map[string]string{
+			"WorkloadProfile": "GeneralPowerEfficientCompute",
+		}, true},
+		{4, func() (map[string]string, error) {
+			return newSystemProfileSettings("hpe", "custom", "enabled", "", "dynamic")
+		}, map[string]string{
+			"WorkloadProfile":   "Custom",
+			"MinProcIdlePower":  "C6",
+			"UncoreFreqScaling": "Auto",
+		}, true},
+		{5, func() (map[string]string, error) {
+			return newSystemProfileSettings("dell", "performance", "disabled", "", "")
+		}, nil, false},
+		{6, func() (map[string]string, error) {
+			return newSystemProfileSettings("generic", "performance", "", "", "")
+		}, nil, false},
+		{7, func() (map[string]string, error) {
+			return gpuPresetAttributes("dell", gpuPerformanceProfile, 1, "High")
+		}, map[string]string{
+			"SysProfile":                       "PerfOptimized",
+			"MmioAbove4Gb":                     "Enabled",
+			"SriovGlobalEnable":                "Enabled",
+			"NumaNodesPerSocket":               "1",
+			"SubNumaCluster":                   "Disabled",
+			"ThermalSettings.1.FanSpeedOffset": "High Fan Speed",
+		}, true},
+		{8, func() (map[string]string, error) {
+			return gpuPresetAttributes("dell", gpuVirtualizationProfile, 4, "Off")
+		}, map[string]string{
+			"SysProfile":                       "PerfOptimized",
+			"MmioAbove4Gb":                     "Enabled",
+			"SriovGlobalEnable":                "Enabled",
+			"ProcVirtualization":               "Enabled",
+			"NumaNodesPerSocket":               "4",
+			"SubNumaCluster":                   "Enabled",
+			"ThermalSettings.1.FanSpeedOffset": "Off",
+		}, true},
+		{9, func() (map[string]string, error) {
+			return gpuPresetAttributes("hpe", gpuPerformanceProfile, 2, "Max")
+		}, map[string]string{
+			"Sriov":                      "Enabled",
+			"WorkloadProfile":            "GraphicProcessing",
+			"NumaMemoryDomainsPerSocket": "TwoMemoryDomains",
+			"SubNumaClustering":          "Enabled",
+			"ThermalConfig":              "MaximumCooling",
+		}, true},
+		{10, func() (map[string]string, error) {
+			return gpuPresetAttributes("hpe", gpuVirtualizationProfile, 1, "Low")
+		}, map[string]string{
+			"Sriov":                      "Enabled",
+			"ProcVirtualization":         "Enabled",
+			"WorkloadProfile":            "Virtualization-MaxPerformance",
+			"NumaMemoryDomainsPerSocket": "OneMemoryDomain",
+			"SubNumaClustering":          "Disabled",
+			"ThermalConfig":              "OptimalCooling",
+		}, true},
+		{11, func() (map[string]string, error) {
+			return gpuPresetAttributes("generic", gpuPerformanceProfile, 1, "High")
+		}, nil, false},
+		{12, func() (map[string]string, error) {
+			return thermalSettingsAttributes("dell", "minimum_power", "", -1)
+		}, map[string]string{
+			"ThermalSettings.1.ThermalProfile": "Minimum Power",
+		}, true},
+		{13, func() (map[string]string, error) {
+			return thermalSettingsAttributes("dell", "default", "High", 40)
+		}, map[string]string{
+			"ThermalSettings.1.ThermalProfile":  "Default Thermal Profile Settings",
+			"ThermalSettings.1.FanSpeedOffset":  "High Fan Speed",
+			"ThermalSettings.1.MinimumFanSpeed": "40",
+		}, true},
+		{14, func() (map[string]string, error) {
+			return thermalSettingsAttributes("dell", "", "Off", -1)
+		}, map[string]string{
+			"ThermalSettings.1.FanSpeedOffset": "Off",
+		}, true},
+		{15, func() (map[string]string, error) {
+			return thermalSettingsAttributes("hpe", "maximum_performance", "", -1)
+		}, map[string]string{
+			"ThermalConfig": "MaximumCooling",
+		}, true},
+		{16, func() (map[string]string, error) {
+			return thermalSettingsAttributes("hpe", "", "Medium", -1)
+		}, map[string]string{
+			"ThermalConfig": "IncreasedCooling",
+		}, true},
+		{17, func() (map[string]string, error) {
+			return thermalSettingsAttributes("hpe", "default", "High", -1)
+		}, nil, false},
+		{18, func() (map[string]string, error) {
+			return thermalSettingsAttributes("hpe", "", "", 30)
+		}, nil, false},
+		{19, func() (map[string]string, error) {
+			return thermalSettingsAttributes("hpe", "sound_cap", "", -1)
+		}, nil, false},
+		{20, func() (map[string]string, error) {
+			return thermalSettingsAttributes("generic", "default", "", -1)
+		}, nil, false},
+	}
+	for _, v := range cases {
+		attributes, err := v.settings()
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(attributes, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, attributes)
+		}
+	}
+}
+
+// gpuPresetAttributes returns the attributes of the GPU preset of the settings
+func gpuPresetAttributes(vendor string, profile string, numaNodesPerSocket int, coolingOffset string) (map[string]string, error) {
+	preset, err := newGpuPreset(vendor, profile, numaNodesPerSocket, coolingOffset)
+	if err != nil {
+		return nil, err
+	}
+	return preset.attributes(), nil
+}
+
+// thermalSettingsAttributes returns the attributes of the thermal settings
+func thermalSettingsAttributes(vendor string, profile string, fanSpeedOffset string, minimumFanSpeed int) (map[string]string, error) {
+	settings, err := newThermalSettings(vendor, profile, fanSpeedOffset, minimumFanSpeed)
+	if err != nil {
+		return nil, err
+	}
+	return settings.attributes(), nil
+}
@@ -0,0 +1,289 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// bmcLockHeartbeat is how often the holder of a BMC lock increments the heartbeat of the lock file
+	bmcLockHeartbeat = 15 * time.Second
+	// bmcLockStaleAfter is how long a waiter must see the lock file unchanged before it considers it left
+	// behind by a crashed apply, and removes it
+	bmcLockStaleAfter = 4 * bmcLockHeartbeat
+	// bmcLockPollInterval is how often a held lock is checked while waiting for it
+	bmcLockPollInterval = 2 * time.Second
+)
+
+// bmcLockFileChars are the characters of the endpoint kept in the name of the lock file
+var bmcLockFileChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// bmcLockOwner is the content of a lock file, identifying the apply holding it
+type bmcLockOwner struct {
+	Hostname string `json:"hostname"`
+	PID      int    `json:"pid"`
+	Endpoint string `json:"endpoint"`
+	Acquired string `json:"acquired"`
+	// Token identifies the acquisition, so the holder never takes the lock file of another apply for its own
+	Token string `json:"token"`
+	// Heartbeat is incremented by the holder while it runs. The waiters time how long it stays the same on
+	// their own clock, so the clocks of the hosts sharing the directory do not need to agree
+	Heartbeat int64 `json:"heartbeat"`
+}
+
+// bmcLock is a cooperative lock serializing the changes of several workspaces to the same BMC.
+// It is a file in a directory every workspace reaches (i.e. an NFS mount), created exclusively by the
+// first change of a provider run and kept until the provider stops, so no other workspace changes the
+// BMC in the middle of an apply. The resources of the same provider share the lock, so they keep applying
+// in parallel. The holder rewrites the heartbeat of the file regularly, so the locks left behind by crashed
+// applies are detected and removed.
+type bmcLock struct {
+	path     string
+	endpoint string
+	timeout  time.Duration
+	// lock protects owner and stop, and serializes the acquisitions of the resources
+	lock sync.Mutex
+	// owner is the content of the lock file while held, nil otherwise
+	owner *bmcLockOwner
+	stop  chan struct{}
+}
+
+// newBMCLock returns the lock of the BMC at endpoint, as a file in dir
+func newBMCLock(dir string, endpoint string, timeout time.Duration) *bmcLock {
+	name := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	return &bmcLock{
+		path:     filepath.Join(dir, bmcLockFileChars.ReplaceAllString(name, "_")+".lock"),
+		endpoint: endpoint,
+		timeout:  timeout,
+	}
+}
+
+// acquire waits until the lock is free and takes it, for up to the timeout of the lock.
+// The lock is kept until release, so resources of the provider already holding it get it right away.
+func (l *bmcLock) acquire(ctx context.Context) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.owner != nil {
+		return nil
+	}
+	deadline := time.Now().Add(l.timeout)
+	// seen is the content of the lock file of the other apply, unchanged since seenAt
+	var seen []byte
+	var seenAt time.Time
+	for {
+		owner, err := l.create()
+		if err == nil {
+			log.Printf("[DEBUG] Acquired the lock %s", l.path)
+			l.owner = owner
+			l.stop = make(chan struct{})
+			go l.heartbeat(l.stop)
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("error creating the lock file %s: %s", l.path, err)
+		}
+		content, err := ioutil.ReadFile(l.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err == nil {
+			if !bytes.Equal(content, seen) {
+				seen, seenAt = content, time.Now()
+			} else if time.Since(seenAt) >= bmcLockStaleAfter && l.removeStale(content) {
+				continue
+			}
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("timeout after %s waiting for the lock of %s, held by %s. Remove %s if no apply is running", l.timeout, l.endpoint, describeBMCLockOwner(content), l.path)
+		}
+		log.Printf("[DEBUG] Waiting for the lock %s, held by %s", l.path, describeBMCLockOwner(content))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for the lock of %s: %s", l.endpoint, ctx.Err())
+		case <-time.After(bmcLockPollInterval):
+		}
+	}
+}
+
+// release gives the lock back, removing the lock file if it is still the one of this provider.
+// It is called when the provider stops.
+func (l *bmcLock) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.owner == nil {
+		return
+	}
+	close(l.stop)
+	if l.holds() {
+		if err := os.Remove(l.path); err != nil {
+			log.Printf("[DEBUG] Error removing the lock file %s: %s", l.path, err)
+		}
+	}
+	l.owner = nil
+	log.Printf("[DEBUG] Released the lock %s", l.path)
+}
+
+// create creates the lock file, failing with an os.IsExist error when another apply holds the lock
+func (l *bmcLock) create() (*bmcLockOwner, error) {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hostname, _ := os.Hostname()
+	owner := &bmcLockOwner{
+		Hostname: hostname,
+		PID:      os.Getpid(),
+		Endpoint: l.endpoint,
+		Acquired: time.Now().UTC().Format(time.RFC3339),
+		Token:    newBMCLockToken(),
+	}
+	return owner, json.NewEncoder(file).Encode(owner)
+}
+
+// holds reports if the lock file is still the one created by this provider
+func (l *bmcLock) holds() bool {
+	content, err := ioutil.ReadFile(l.path)
+	if err != nil {
+		return false
+	}
+	var owner bmcLockOwner
+	return json.Unmarshal(content, &owner) == nil && owner.Token == l.owner.Token
+}
+
+// heartbeat increments the heartbeat of the lock file until stop is closed. The file is replaced
+// atomically, so the waiters never read it half written.
+func (l *bmcLock) heartbeat(stop chan struct{}) {
+	ticker := time.NewTicker(bmcLockHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.lock.Lock()
+			if l.owner == nil {
+				l.lock.Unlock()
+				return
+			}
+			if !l.holds() {
+				// The next change takes the lock again instead of running unprotected
+				log.Printf("[WARN] The lock %s was taken over by %s after missing its heartbeats", l.path, l.describeOwner())
+				l.owner = nil
+				l.lock.Unlock()
+				return
+			}
+			if err := l.beat(); err != nil {
+				log.Printf("[WARN] Error refreshing the lock file %s: %s", l.path, err)
+			}
+			l.lock.Unlock()
+		}
+	}
+}
+
+// beat writes the next heartbeat to the lock file, which must be held
+func (l *bmcLock) beat() error {
+	l.owner.Heartbeat++
+	content, err := json.Marshal(l.owner)
+	if err != nil {
+		return err
+	}
+	next := l.path + ".heartbeat-" + l.owner.Token
+	if err := ioutil.WriteFile(next, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(next, l.path)
+}
+
+// removeStale removes the lock file if it still holds content, reporting if it did. The file is first
+// renamed away, which only one waiter can do, and checked afterwards: if another waiter already replaced
+// the stale lock with its own, that one is put back.
+func (l *bmcLock) removeStale(content []byte) bool {
+	stale := l.path + ".stale-" + newBMCLockToken()
+	if err := os.Rename(l.path, stale); err != nil {
+		return false
+	}
+	defer os.Remove(stale)
+	moved, err := ioutil.ReadFile(stale)
+	if err == nil && bytes.Equal(moved, content) {
+		log.Printf("[WARN] Removed the lock %s held by %s, whose heartbeat stopped for %s", l.path, describeBMCLockOwner(content), bmcLockStaleAfter)
+		return true
+	}
+	// Link fails if yet another lock was created meanwhile, whose holder detects the loss on its next heartbeat
+	if err := os.Link(stale, l.path); err != nil {
+		log.Printf("[WARN] Error restoring the lock %s of another apply: %s", l.path, err)
+	}
+	return false
+}
+
+// describeBMCLockOwner describes the apply holding the lock, from the content of the lock file
+func describeBMCLockOwner(content []byte) string {
+	var owner bmcLockOwner
+	if err := json.Unmarshal(content, &owner); err != nil || owner.Token == "" {
+		return "an unknown apply"
+	}
+	return fmt.Sprintf("process %d on %s since %s", owner.PID, owner.Hostname, owner.Acquired)
+}
+
+// describeOwner describes the apply holding the lock file
+func (l *bmcLock) describeOwner() string {
+	content, _ := ioutil.ReadFile(l.path)
+	return describeBMCLockOwner(content)
+}
+
+// newBMCLockToken returns a random token identifying an acquisition of a lock
+func newBMCLockToken() string {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+	return hex.EncodeToString(token)
+}
+
+// withBMCLock makes the resources take the lock of the BMC, when lock_path is set in the provider,
+// before they create, change or destroy anything. Reads do not take it. Once taken, the lock is
+// kept until the provider stops.
+func withBMCLock(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, resource := range resources {
+		if resource.CreateContext != nil {
+			resource.CreateContext = bmcLockGuard(resource.CreateContext)
+		}
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = bmcLockGuard(resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			resource.DeleteContext = bmcLockGuard(resource.DeleteContext)
+		}
+	}
+	return resources
+}
+
+func bmcLockGuard(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		lock := m.(*providerConfig).bmcLock
+		if lock == nil {
+			return f(ctx, d, m)
+		}
+		if err := lock.acquire(ctx); err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
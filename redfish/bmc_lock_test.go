@@ -0,0 +1,145 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBMCLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bmc_lock")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	first := newBMCLock(dir, "https://192.168.10.10:443", 0)
+	second := newBMCLock(dir, "https://192.168.10.10:443", 0)
+	other := newBMCLock(dir, "https://192.168.10.11", 0)
+	if first.path != filepath.Join(dir, "192.168.10.10_443.lock") {
+		t.Errorf("Unexpected lock file %s", first.path)
+	}
+
+	if err := first.acquire(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The resources of the same provider share the lock
+	if err := first.acquire(context.Background()); err != nil {
+		t.Errorf("the lock was not reentrant: %v", err)
+	}
+	if err := second.acquire(context.Background()); err == nil {
+		t.Errorf("the lock was acquired by two providers")
+	}
+	if err := other.acquire(context.Background()); err != nil {
+		t.Errorf("the lock of another BMC was not acquired: %v", err)
+	}
+	other.release()
+
+	// The heartbeat keeps the same owner
+	if err := first.beat(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !first.holds() {
+		t.Errorf("the lock was lost after a heartbeat")
+	}
+	if second.owner != nil {
+		t.Errorf("the lock of another provider was taken for its own")
+	}
+	first.release()
+	if _, err := os.Stat(first.path); !os.IsNotExist(err) {
+		t.Errorf("the lock file was not removed on release: %v", err)
+	}
+	if err := second.acquire(context.Background()); err != nil {
+		t.Errorf("the released lock was not acquired: %v", err)
+	}
+	second.release()
+}
+
+func TestBMCLockStale(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bmc_lock")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	crashed := newBMCLock(dir, "https://192.168.10.10", 0)
+	if _, err := crashed.create(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lock := newBMCLock(dir, "https://192.168.10.10", 0)
+	if err := lock.acquire(context.Background()); err == nil {
+		t.Fatalf("a lock just created was removed")
+	}
+	stale, err := ioutil.ReadFile(crashed.path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Another waiter replaced the stale lock with its own meanwhile
+	if err := os.Remove(crashed.path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	waiter := newBMCLock(dir, "https://192.168.10.10", 0)
+	if err := waiter.acquire(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lock.removeStale(stale) {
+		t.Errorf("the lock of another waiter was removed as stale")
+	}
+	if !waiter.holds() {
+		t.Errorf("the lock of another waiter was not put back")
+	}
+	waiter.release()
+
+	if err := ioutil.WriteFile(crashed.path, stale, 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !lock.removeStale(stale) {
+		t.Errorf("the stale lock was not removed")
+	}
+	if err := lock.acquire(context.Background()); err != nil {
+		t.Fatalf("the lock was not acquired after removing the stale one: %v", err)
+	}
+	lock.release()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil || len(files) != 0 {
+		t.Errorf("Unexpected files left in the lock directory %v %v", files, err)
+	}
+}
+
+func TestBMCLockGuard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bmc_lock")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	holder := newBMCLock(dir, "https://192.168.10.10", 0)
+	if err := holder.acquire(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	r := Provider().ResourcesMap["redfish_idrac_lcd"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("/redfish/v1/Managers/System.Embedded.1/Attributes")
+	config := &providerConfig{bmcLock: newBMCLock(dir, "https://192.168.10.10", 0)}
+	if diags := r.DeleteContext(context.Background(), d, config); !diags.HasError() {
+		t.Errorf("the resource was destroyed while another provider held the lock")
+	}
+	if d.Id() == "" {
+		t.Errorf("the resource was removed from the state while another provider held the lock")
+	}
+
+	holder.release()
+	if diags := r.DeleteContext(context.Background(), d, config); diags.HasError() {
+		t.Errorf("the resource was not destroyed: %v", diags)
+	}
+	// The lock is kept until the provider stops
+	if _, err := os.Stat(config.bmcLock.path); err != nil {
+		t.Errorf("the lock file was removed before the provider stopped: %v", err)
+	}
+	config.bmcLock.release()
+	if _, err := os.Stat(config.bmcLock.path); !os.IsNotExist(err) {
+		t.Errorf("the lock file was not removed when the provider stopped: %v", err)
+	}
+}
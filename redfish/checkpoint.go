@@ -0,0 +1,115 @@
+package redfish
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"sort"
+	"time"
+)
+
+// checkpointAttribute is the computed attribute holding the steps completed by an interrupted apply of a multi-step resource
+const checkpointAttribute = "checkpoint"
+
+// checkpointFingerprintKey is the key of the checkpoint holding the fingerprint of the configuration it was taken with
+const checkpointFingerprintKey = "fingerprint"
+
+// checkpoint tracks the steps of a multi-step resource (i.e. stage the BIOS changes, reboot, wait for the job) completed
+// by an apply. The SDK does not give the resources access to their private state, so the steps are kept in the checkpoint
+// attribute, which Terraform stores even when the apply fails. The next apply with the same configuration resumes after
+// the last completed step instead of starting over, which would reboot the system again. A successful apply clears it.
+// It is used by redfish_server_profile and redfish_os_deployment. The provider has no resource importing a Server
+// Configuration Profile, so there is none to resume. As Terraform taints a resource whose creation failed, an
+// interrupted creation is only resumed after terraform untaint, otherwise the resource is replaced.
+type checkpoint struct {
+	fingerprint string
+	steps       map[string]interface{}
+	resuming    bool
+}
+
+// newCheckpoint loads the checkpoint of the resource. It only resumes when the values of keys, the configuration
+// the steps depend on, are the ones of the interrupted apply.
+func newCheckpoint(d *schema.ResourceData, keys ...string) *checkpoint {
+	c := &checkpoint{fingerprint: checkpointFingerprint(d, keys), steps: make(map[string]interface{})}
+	stored, _ := d.Get(checkpointAttribute).(map[string]interface{})
+	if len(stored) > 0 && stored[checkpointFingerprintKey] == c.fingerprint {
+		c.steps = stored
+		c.resuming = true
+		log.Printf("[INFO] %s: Resuming the interrupted apply after the steps %v", d.Id(), c.completedSteps())
+	} else if len(stored) > 0 {
+		log.Printf("[DEBUG] %s: The configuration changed since the interrupted apply, starting over", d.Id())
+	}
+	c.steps[checkpointFingerprintKey] = c.fingerprint
+	return c
+}
+
+// done reports if the interrupted apply completed step
+func (c *checkpoint) done(step string) bool {
+	_, ok := c.steps[step]
+	return ok && step != checkpointFingerprintKey
+}
+
+// complete records step as completed, with the time it was, in the state of the resource
+func (c *checkpoint) complete(d *schema.ResourceData, step string) {
+	c.steps[step] = time.Now().UTC().Format(time.RFC3339)
+	if err := d.Set(checkpointAttribute, c.steps); err != nil {
+		log.Printf("[DEBUG] %s: error setting %s: %s", d.Id(), checkpointAttribute, err)
+	}
+}
+
+// finish clears the checkpoint once every step is completed, so the next apply runs them all again
+func (c *checkpoint) finish(d *schema.ResourceData) {
+	if err := d.Set(checkpointAttribute, map[string]interface{}{}); err != nil {
+		log.Printf("[DEBUG] %s: error setting %s: %s", d.Id(), checkpointAttribute, err)
+	}
+}
+
+// completedSteps returns the names of the completed steps, sorted
+func (c *checkpoint) completedSteps() []string {
+	steps := []string{}
+	for step := range c.steps {
+		if step != checkpointFingerprintKey {
+			steps = append(steps, step)
+		}
+	}
+	sort.Strings(steps)
+	return steps
+}
+
+// checkpointFingerprint returns a hash of the values of keys, so the checkpoint does not keep sensitive values
+func checkpointFingerprint(d *schema.ResourceData, keys []string) string {
+	values := make(map[string]interface{})
+	for _, key := range keys {
+		values[key] = d.Get(key)
+	}
+	// json.Marshal sorts the keys of the maps, so the fingerprint is stable
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		log.Printf("[DEBUG] error encoding the checkpoint fingerprint: %s", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
+
+// resumeInterruptedApply is the CustomizeDiff of the multi-step resources. An interrupted apply leaves the configuration
+// in the state, so the next plan would have nothing to change: the checkpoint is planned to change to resume it.
+func resumeInterruptedApply(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if stored, _ := d.Get(checkpointAttribute).(map[string]interface{}); len(stored) > 0 && d.Id() != "" {
+		return d.SetNewComputed(checkpointAttribute)
+	}
+	return nil
+}
+
+// checkpointSchema is the schema of the attribute holding the checkpoint of a multi-step resource
+func checkpointSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Description: "Steps completed by the last apply, with the time they were, when it was interrupted. The next apply with the same " +
+			"configuration resumes after them. Run terraform untaint on a resource whose creation was interrupted to resume it instead of replacing it",
+		Elem: &schema.Schema{Type: schema.TypeString},
+	}
+}
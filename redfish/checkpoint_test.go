@@ -0,0 +1,45 @@
+package redfish
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	raw := map[string]interface{}{"bios_attributes": map[string]interface{}{"LogicalProc": "Disabled"}}
+	cases := []struct {
+		noTest   int
+		stored   func(fingerprint string) map[string]interface{}
+		resuming bool
+		done     bool
+	}{
+		{1, func(fingerprint string) map[string]interface{} { return map[string]interface{}{} }, false, false},
+		{2, func(fingerprint string) map[string]interface{} {
+			return map[string]interface{}{checkpointFingerprintKey: fingerprint, "reset": "2026-10-16T10:00:00Z"}
+		}, true, true},
+		{3, func(fingerprint string) map[string]interface{} {
+			return map[string]interface{}{checkpointFingerprintKey: "0000000000000000", "reset": "2026-10-16T10:00:00Z"}
+		}, false, false},
+	}
+	for _, v := range cases {
+		d := schema.TestResourceDataRaw(t, resourceRedfishServerProfile().Schema, raw)
+		if err := d.Set(checkpointAttribute, v.stored(checkpointFingerprint(d, []string{"bios_attributes"}))); err != nil {
+			t.Fatalf("Test number %v: %s", v.noTest, err)
+		}
+		cp := newCheckpoint(d, "bios_attributes")
+		if cp.resuming != v.resuming || cp.done("reset") != v.done {
+			t.Errorf("Test number %v: expected resuming %v and reset done %v, got %v and %v", v.noTest, v.resuming, v.done, cp.resuming, cp.done("reset"))
+		}
+		if cp.done(checkpointFingerprintKey) {
+			t.Errorf("Test number %v: the fingerprint is not a step", v.noTest)
+		}
+		cp.complete(d, "users")
+		if steps := d.Get(checkpointAttribute).(map[string]interface{}); steps["users"] == nil || steps[checkpointFingerprintKey] != cp.fingerprint {
+			t.Errorf("Test number %v: unexpected checkpoint %v", v.noTest, steps)
+		}
+		cp.finish(d)
+		if steps := d.Get(checkpointAttribute).(map[string]interface{}); len(steps) != 0 {
+			t.Errorf("Test number %v: expected the checkpoint to be cleared, got %v", v.noTest, steps)
+		}
+	}
+}
@@ -1,10 +1,39 @@
 package redfish
 
 import (
+	"context"
+	"crypto/tls"
+	"github.com/dell/terraform-provider-redfish/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stmcginnis/gofish"
+	"io"
+	"net/http"
+	"time"
 )
 
+// providerConfig is the meta shared by every resource and data source of the provider
+type providerConfig struct {
+	// client is the connection to the redfish API
+	client *gofish.APIClient
+	// lockdownBypass lets resources disable System Lockdown while they apply changes, re-enabling it afterwards
+	lockdownBypass bool
+	// readOnly makes the resources refuse to create, change or destroy anything on the server
+	readOnly bool
+	// systemID and managerID select the computer system and the manager the resources manage. Empty means the first one
+	systemID  string
+	managerID string
+	// endpoint is the redfish endpoint, used to identify the server in the operation log
+	endpoint string
+	// operationLogFile is the local file the operation records are appended to. Empty means no file
+	operationLogFile string
+	// notifications are the webhooks the operation records are sent to
+	notifications []notification
+	// bmcLock serializes the changes of several workspaces to the BMC. Nil when lock_path is not set
+	bmcLock *bmcLock
+	// oem handles the vendor specific parts of the workflows (job queues, firmware targets)
+	oem common.OEMHandler
+}
+
 // NewConfig function creates the needed gofish structs to query the redfish API
 func NewConfig(d *schema.ResourceData) (*gofish.APIClient, error) {
 	//Check if the ssl config param has been set
@@ -12,12 +41,132 @@ func NewConfig(d *schema.ResourceData) (*gofish.APIClient, error) {
 	if v, ok := d.GetOk("ssl_insecure"); ok {
 		sslMode = v.(bool)
 	}
+	// The HTTP client is built here, instead of letting gofish do it, so every request is bounded by request_timeout,
+	// honors Retry-After, sends If-Match on PATCHes and, with session_auth, renews the session when it expires.
+	// With previous_passwords, it falls back to the previous passwords while the password is rotated, and with
+	// cache_ttl, the service root and the collections below it are only read once. user_agent and http_headers
+	// are set on every request
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:                 defaultTransport.Proxy,
+		DialContext:           defaultTransport.DialContext,
+		MaxIdleConns:          defaultTransport.MaxIdleConns,
+		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: sslMode,
+		},
+	}
+	// The headers are added right before the requests are sent, so the ones the other layers send
+	// (i.e. to fetch ETags or create sessions) get them too
+	headers := http.Header{}
+	if v, ok := d.GetOk("user_agent"); ok {
+		headers.Set("User-Agent", v.(string))
+	}
+	for name, value := range d.Get("http_headers").(map[string]interface{}) {
+		headers.Set(name, value.(string))
+	}
+	if len(headers) > 0 {
+		transport = &headerTransport{base: transport, headers: headers}
+	}
+	transport = &etagTransport{base: &retryAfterTransport{base: transport}}
+	previousPasswords := []string{}
+	for _, password := range d.Get("previous_passwords").([]interface{}) {
+		previousPasswords = append(previousPasswords, password.(string))
+	}
+	if d.Get("session_auth").(bool) {
+		sessions := &sessionTransport{
+			base:              transport,
+			username:          d.Get("user").(string),
+			password:          d.Get("password").(string),
+			previousPasswords: previousPasswords,
+		}
+		onShutdown(sessions.close)
+		transport = sessions
+	} else if len(previousPasswords) > 0 {
+		transport = &basicAuthTransport{
+			base:      transport,
+			username:  d.Get("user").(string),
+			passwords: append([]string{d.Get("password").(string)}, previousPasswords...),
+		}
+	}
+	// The cache is the outermost layer, so the requests it answers skip the authentication too
+	if ttl := d.Get("cache_ttl").(int); ttl > 0 {
+		transport = &cacheTransport{base: transport, ttl: time.Duration(ttl) * time.Second}
+	}
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(d.Get("request_timeout").(int)) * time.Second,
+	}
 	clientConfig := gofish.ClientConfig{
-		Endpoint:  d.Get("redfish_endpoint").(string),
-		Username:  d.Get("user").(string),
-		Password:  d.Get("password").(string),
-		BasicAuth: true,
-		Insecure:  sslMode,
+		Endpoint:   d.Get("redfish_endpoint").(string),
+		Username:   d.Get("user").(string),
+		Password:   d.Get("password").(string),
+		BasicAuth:  true,
+		Insecure:   sslMode,
+		HTTPClient: httpClient,
 	}
 	return gofish.Connect(clientConfig)
 }
+
+// clientWithContext returns a copy of the client whose requests are cancelled as soon as ctx is done,
+// so operations stop promptly when terraform is interrupted or the resource timeout expires.
+func (p *providerConfig) clientWithContext(ctx context.Context) *gofish.APIClient {
+	c := *p.client
+	httpClient := *p.client.HTTPClient
+	httpClient.Transport = &contextTransport{ctx: ctx, base: p.client.HTTPClient.Transport}
+	c.HTTPClient = &httpClient
+	// The service keeps a reference to the client it was fetched with, which is used to fetch everything else
+	service := *p.client.Service
+	service.SetClient(&c)
+	c.Service = &service
+	return &c
+}
+
+// contextTransport is an http.RoundTripper that cancels the requests when its context is done,
+// on top of any deadline the request already has (i.e. the per-request timeout of the http.Client).
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The request context must live until the body has been read
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,57 @@
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContextTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fast" {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		noTest     int
+		path       string
+		cancel     time.Duration
+		shouldPass bool
+	}{
+		{1, "/slow", 0, false},
+		{2, "/slow", 100 * time.Millisecond, false},
+		{3, "/fast", time.Minute, true},
+	}
+	for _, v := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		if v.cancel == 0 {
+			cancel()
+		} else {
+			time.AfterFunc(v.cancel, cancel)
+		}
+		client := &http.Client{Transport: &contextTransport{ctx: ctx, base: http.DefaultTransport}}
+		start := time.Now()
+		resp, err := client.Get(server.URL + v.path)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Errorf("Test number %v was not cancelled promptly", v.noTest)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,102 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+func dataSourceRedfishAccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishAccountsRead,
+		Schema: map[string]*schema.Schema{
+			"accounts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "User accounts of the BMC. Empty account slots are not listed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the account, used to import it as a redfish_user_account (i.e. terraform import redfish_user_account.admin 2)",
+						},
+						"odata_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"locked": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the account is locked out after too many failed logins",
+						},
+					},
+				},
+			},
+			"usernames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Usernames of the accounts, in the same order. Handy to check that no unexpected account exists",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishAccountsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	accountList, err := getAccountList(conn)
+	if err != nil {
+		return diag.Errorf("error fetching user accounts: %s", err)
+	}
+
+	accounts := []map[string]interface{}{}
+	usernames := []string{}
+	for _, account := range accountList {
+		if len(account.UserName) == 0 {
+			continue
+		}
+		accounts = append(accounts, flattenAccount(account))
+		usernames = append(usernames, account.UserName)
+	}
+
+	if err := d.Set("accounts", accounts); err != nil {
+		return diag.Errorf("error setting accounts: %s", err)
+	}
+	if err := d.Set("usernames", usernames); err != nil {
+		return diag.Errorf("error setting usernames: %s", err)
+	}
+
+	d.SetId("/redfish/v1/AccountService/Accounts")
+
+	return diags
+}
+
+func flattenAccount(account *redfish.ManagerAccount) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       account.ID,
+		"odata_id": account.ODataID,
+		"username": account.UserName,
+		"role_id":  account.RoleID,
+		"enabled":  account.Enabled,
+		"locked":   account.Locked,
+	}
+}
@@ -0,0 +1,150 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishApplicableUpdates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishApplicableUpdatesRead,
+		Schema: map[string]*schema.Schema{
+			firmwareCatalogURL: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "URL of a firmware repository catalog (i.e. Dell Catalog.xml or Catalog.xml.gz)",
+			},
+			firmwareCatalogBaseURI: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base URI of the repository mirror used to build the image URIs. By default the catalog baseLocation is used",
+			},
+			firmwareSystemModel: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "System model used to select the catalog packages (i.e. PowerEdge R740). By default it is read from the system",
+			},
+			"criticality": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the updates with any of these criticalities (i.e. Urgent, Recommended, Optional)",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"updates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Catalog packages newer than the installed firmware, one per component",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware inventory name of the component",
+						},
+						"component_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware inventory Id of the component",
+						},
+						"installed_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware version installed on the component",
+						},
+						"available_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware version of the catalog package",
+						},
+						"package_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the catalog package",
+						},
+						"package_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the catalog package",
+						},
+						"image_uri": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URI the BMC can pull the package from",
+						},
+						"component_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the component (i.e. BIOS, FRMW, DRVR)",
+						},
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Category of the package (i.e. BIOS, Network, Storage)",
+						},
+						"criticality": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Criticality of the package (i.e. Urgent, Recommended, Optional)",
+						},
+						"reboot_required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the package needs a reboot to be applied",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishApplicableUpdatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	catalog, model, inventory, err := loadCatalog(ctx, conn, d, meta.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error loading catalog: %s", err)
+	}
+
+	criticalities := []string{}
+	for _, v := range d.Get("criticality").([]interface{}) {
+		criticalities = append(criticalities, v.(string))
+	}
+
+	baseURI := d.Get(firmwareCatalogBaseURI).(string)
+	updates := []map[string]interface{}{}
+	for _, update := range catalog.ApplicableUpdates(model, inventory) {
+		criticality := update.Component.CriticalityName()
+		if len(criticalities) > 0 && !common.ContainsFold(criticalities, criticality) {
+			continue
+		}
+		updates = append(updates, map[string]interface{}{
+			"component":         update.Installed.Name,
+			"component_id":      update.Installed.ID,
+			"installed_version": update.Installed.Version,
+			"available_version": update.Component.Version(),
+			"package_name":      update.Component.Name,
+			"package_id":        update.Component.PackageID,
+			"image_uri":         catalog.PackageURI(baseURI, update.Component),
+			"component_type":    update.Component.ComponentType.Value,
+			"category":          update.Component.Category.Display,
+			"criticality":       criticality,
+			"reboot_required":   update.Component.RebootRequired,
+		})
+	}
+
+	if err := d.Set("updates", updates); err != nil {
+		return diag.Errorf("error setting applicable updates: %s", err)
+	}
+
+	d.SetId(d.Get(firmwareCatalogURL).(string))
+
+	return diags
+}
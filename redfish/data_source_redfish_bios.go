@@ -3,9 +3,9 @@ package redfish
 import (
 	"context"
 	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/stmcginnis/gofish"
 )
 
 func dataSourceRedfishBios() *schema.Resource {
@@ -38,16 +38,14 @@ func dataSourceRedfishBios() *schema.Resource {
 func dataSourceRedfishBiosRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*gofish.APIClient)
+	conn := meta.(*providerConfig).clientWithContext(ctx)
 
-	service := conn.Service
-	systems, err := service.Systems()
+	system, err := common.GetSystem(conn, meta.(*providerConfig).systemID)
 	if err != nil {
-		return diag.Errorf("error fetching computer systems collection: %s", err)
-
+		return diag.FromErr(err)
 	}
 
-	bios, err := systems[0].Bios()
+	bios, err := system.Bios()
 	if err != nil {
 		return diag.Errorf("error fetching bios: %s", err)
 	}
@@ -0,0 +1,158 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishChassis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishChassisRead,
+		Schema: map[string]*schema.Schema{
+			"chassis_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Chassis types to list (i.e. 'Enclosure', 'Sled', 'Blade' or 'StorageEnclosure'). All of them are listed when empty",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"chassis": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Chassis of the Redfish service, including the enclosures, sleds and backplanes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the chassis",
+						},
+						"odata_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ODataID of the chassis",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the chassis",
+						},
+						"chassis_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the chassis (i.e. 'RackMount', 'Enclosure' or 'Sled')",
+						},
+						"manufacturer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Manufacturer of the chassis",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Model of the chassis",
+						},
+						"serial_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Serial number of the chassis",
+						},
+						"part_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Part number of the chassis",
+						},
+						"sku": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SKU of the chassis, which is the service tag on Dell systems",
+						},
+						"power_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Power state of the chassis",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the chassis",
+						},
+						"contained_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ODataID of the chassis holding this one (i.e. the enclosure of a sled). Empty for top level chassis",
+						},
+						"contains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "ODataIDs of the chassis held by this one",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"computer_systems": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "ODataIDs of the systems in the chassis, to target resources per sled",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"managed_by": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "ODataIDs of the managers of the chassis",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishChassisRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	chassisTypes := d.Get("chassis_types").(*schema.Set)
+
+	chassisList, err := conn.Service.Chassis()
+	if err != nil {
+		return diag.Errorf("error fetching chassis: %s", err)
+	}
+
+	chassis := []map[string]interface{}{}
+	for _, c := range chassisList {
+		if chassisTypes.Len() > 0 && !chassisTypes.Contains(string(c.ChassisType)) {
+			continue
+		}
+		links, err := common.GetChassisLinks(conn, c.ODataID)
+		if err != nil {
+			return diag.Errorf("error fetching links of chassis %s: %s", c.ID, err)
+		}
+		chassis = append(chassis, map[string]interface{}{
+			"id":               c.ID,
+			"odata_id":         c.ODataID,
+			"name":             c.Name,
+			"chassis_type":     string(c.ChassisType),
+			"manufacturer":     c.Manufacturer,
+			"model":            c.Model,
+			"serial_number":    c.SerialNumber,
+			"part_number":      c.PartNumber,
+			"sku":              c.SKU,
+			"power_state":      string(c.PowerState),
+			"health":           string(c.Status.Health),
+			"contained_by":     links.ContainedBy,
+			"contains":         links.Contains,
+			"computer_systems": links.ComputerSystems,
+			"managed_by":       links.ManagedBy,
+		})
+	}
+
+	if err := d.Set("chassis", chassis); err != nil {
+		return diag.Errorf("error setting chassis: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#chassis")
+
+	return diags
+}
@@ -0,0 +1,148 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishComponentIntegrity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishComponentIntegrityRead,
+		Schema: map[string]*schema.Schema{
+			"components": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Attestation results of the components of the server. Empty when the BMC does not support device attestation",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the ComponentIntegrity member",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the ComponentIntegrity member",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Security technology of the attestation (i.e. SPDM or TPM)",
+						},
+						"type_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the security technology",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the attestation of the component is enabled",
+						},
+						"target_component_uri": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URI of the device the attestation applies to",
+						},
+						"last_updated": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the last attestation of the component",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the component integrity",
+						},
+						"verification_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Result of the authentication of the component identity ('Success' or 'Failed')",
+						},
+						"measurements": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Measurements reported by the component",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"index": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Index of the measurement",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Type of the measurement (i.e. MutableFirmwareVersion)",
+									},
+									"measurement": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Base64 encoded value of the measurement",
+									},
+									"last_updated": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Date of the measurement",
+									},
+									"security_version_number": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Security version number of the measured firmware",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishComponentIntegrityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	components, err := common.GetComponentIntegrity(conn, conn.Service.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching component integrity: %s", err)
+	}
+
+	componentList := []map[string]interface{}{}
+	for _, component := range components {
+		measurements := []map[string]interface{}{}
+		for _, measurement := range component.SPDM.MeasurementSet.Measurements {
+			measurements = append(measurements, map[string]interface{}{
+				"index":                   measurement.MeasurementIndex,
+				"type":                    measurement.MeasurementType,
+				"measurement":             measurement.Measurement,
+				"last_updated":            measurement.LastUpdated,
+				"security_version_number": measurement.SecurityVersionNumber,
+			})
+		}
+		componentList = append(componentList, map[string]interface{}{
+			"id":                   component.ID,
+			"name":                 component.Name,
+			"type":                 component.ComponentIntegrityType,
+			"type_version":         component.ComponentIntegrityTypeVersion,
+			"enabled":              component.ComponentIntegrityEnabled,
+			"target_component_uri": component.TargetComponentURI,
+			"last_updated":         component.LastUpdated,
+			"health":               string(component.Status.Health),
+			"verification_status":  component.SPDM.IdentityAuthentication.VerificationStatus,
+			"measurements":         measurements,
+		})
+	}
+	if err := d.Set("components", componentList); err != nil {
+		return diag.Errorf("error setting components: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#component_integrity")
+
+	return diags
+}
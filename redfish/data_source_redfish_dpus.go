@@ -0,0 +1,72 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+)
+
+// defaultDpuModelRegex matches the DPUs and SmartNICs known to expose themselves as network adapters
+const defaultDpuModelRegex = `(?i)bluefield|pensando|\bdpu\b|\bipu\b|smartnic|smart nic`
+
+func dataSourceRedfishDpus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishDpusRead,
+		Schema: map[string]*schema.Schema{
+			"model_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDpuModelRegex,
+				Description:  "Regular expression matched against the network adapter model and name to identify DPUs/SmartNICs",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"dpus": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Network adapters identified as DPUs or SmartNICs",
+				Elem: &schema.Resource{
+					Schema: networkAdapterSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishDpusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	modelRegex := regexp.MustCompile(d.Get("model_regex").(string))
+
+	adapters, err := getNetworkAdapters(conn.Service)
+	if err != nil {
+		return diag.Errorf("error fetching network adapters: %s", err)
+	}
+
+	dpus := []map[string]interface{}{}
+	for _, adapter := range adapters {
+		if !modelRegex.MatchString(adapter.Model) && !modelRegex.MatchString(adapter.Name) {
+			continue
+		}
+		functions, err := adapter.NetworkDeviceFunctions()
+		if err != nil {
+			return diag.Errorf("error fetching network device functions of %s: %s", adapter.ID, err)
+		}
+		dpu, err := flattenNetworkAdapter(adapter, functions)
+		if err != nil {
+			return diag.Errorf("error reading network adapter %s: %s", adapter.ID, err)
+		}
+		dpus = append(dpus, dpu)
+	}
+
+	if err := d.Set("dpus", dpus); err != nil {
+		return diag.Errorf("error setting DPUs: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#dpus")
+
+	return diags
+}
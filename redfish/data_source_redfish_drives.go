@@ -0,0 +1,335 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// dellDriveBayRegexp extracts the bay from the FQDD of the drives of Dell iDRAC (i.e. Disk.Bay.3:Enclosure.Internal.0-1:RAID.Integrated.1-1)
+var dellDriveBayRegexp = regexp.MustCompile(`^Disk\.Bay\.(\d+):`)
+
+// storageDrive is a drive along with the storage controller it is attached to
+type storageDrive struct {
+	controllerID string
+	drive        *redfish.Drive
+	// slot is the bay the drive is inserted in, -1 when the BMC does not report it
+	slot int
+}
+
+// driveFilter holds the filters of the redfish_drives data source. Empty lists match every drive
+type driveFilter struct {
+	mediaTypes       []string
+	protocols        []string
+	health           []string
+	minCapacityBytes int
+	// slotMin and slotMax bound the slot, when not -1
+	slotMin int
+	slotMax int
+}
+
+func dataSourceRedfishDrives() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishDrivesRead,
+		Schema: map[string]*schema.Schema{
+			"storage_controller_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the drives of this storage controller (i.e. RAID.Integrated.1-1). If not set, the drives of every controller are listed",
+			},
+			"media_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the drives of these media types ('SSD' or 'HDD'), compared ignoring case",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"protocols": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the drives using these protocols (i.e. 'NVMe', 'SAS' or 'SATA'), compared ignoring case",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"health": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the drives with one of these health states ('OK', 'Warning' or 'Critical'), compared ignoring case",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"min_capacity_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Only list the drives of at least this capacity",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"slot_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				Description:  "Only list the drives in this slot or higher ones. Drives whose slot is unknown are not listed when it is set",
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"slot_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				Description:  "Only list the drives in this slot or lower ones. Drives whose slot is unknown are not listed when it is set",
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"sort_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "slot",
+				Description:  "Order of the drives. Applicable values are 'slot' (drives whose slot is unknown last), 'capacity' (smallest first) and 'id'. Ties are ordered by controller and id, so the lists are the same on every read",
+				ValidateFunc: validation.StringInSlice([]string{"slot", "capacity", "id"}, false),
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of drives listed, the first ones in sort_by order (i.e. the two lowest slots for a RAID-1). 0 means no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"drives": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Drives matching the filters, in sort_by order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the drive, its FQDD on Dell iDRAC (i.e. Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1)",
+						},
+						"odata_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ODataID of the drive",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the drive, as used by volume_disks of redfish_storage_volume",
+						},
+						"storage_controller_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the storage controller the drive is attached to",
+						},
+						"slot": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Slot the drive is inserted in. -1 when the BMC does not report it",
+						},
+						"media_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Media type of the drive",
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Protocol of the drive",
+						},
+						"capacity_bytes": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Capacity of the drive",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the drive",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Model of the drive",
+						},
+						"serial_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Serial number of the drive",
+						},
+					},
+				},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ids (FQDDs on Dell iDRAC) of the drives, in sort_by order",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the drives, in sort_by order, ready to be used as volume_disks of redfish_storage_volume",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishDrivesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	drives, err := getStorageDrives(conn, meta.(*providerConfig).systemID, d.Get("storage_controller_id").(string))
+	if err != nil {
+		return diag.Errorf("error fetching drives: %s", err)
+	}
+
+	filter := driveFilter{
+		minCapacityBytes: d.Get("min_capacity_bytes").(int),
+		slotMin:          d.Get("slot_min").(int),
+		slotMax:          d.Get("slot_max").(int),
+	}
+	for _, v := range d.Get("media_types").([]interface{}) {
+		filter.mediaTypes = append(filter.mediaTypes, v.(string))
+	}
+	for _, v := range d.Get("protocols").([]interface{}) {
+		filter.protocols = append(filter.protocols, v.(string))
+	}
+	for _, v := range d.Get("health").([]interface{}) {
+		filter.health = append(filter.health, v.(string))
+	}
+	drives = filterDrives(drives, filter)
+	sortDrives(drives, d.Get("sort_by").(string))
+	if limit := d.Get("limit").(int); limit > 0 && len(drives) > limit {
+		drives = drives[:limit]
+	}
+
+	driveList := []map[string]interface{}{}
+	ids := []string{}
+	names := []string{}
+	for _, v := range drives {
+		driveList = append(driveList, map[string]interface{}{
+			"id":                    v.drive.ID,
+			"odata_id":              v.drive.ODataID,
+			"name":                  v.drive.Name,
+			"storage_controller_id": v.controllerID,
+			"slot":                  v.slot,
+			"media_type":            string(v.drive.MediaType),
+			"protocol":              string(v.drive.Protocol),
+			"capacity_bytes":        int(v.drive.CapacityBytes),
+			"health":                string(v.drive.Status.Health),
+			"model":                 v.drive.Model,
+			"serial_number":         v.drive.SerialNumber,
+		})
+		ids = append(ids, v.drive.ID)
+		names = append(names, v.drive.Name)
+	}
+
+	if err := d.Set("drives", driveList); err != nil {
+		return diag.Errorf("error setting drives: %s", err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("error setting ids: %s", err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("error setting names: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#drives")
+
+	return diags
+}
+
+// getStorageDrives returns the drives of the storage controllers of the system, or only of controllerID when set
+func getStorageDrives(conn *gofish.APIClient, systemID string, controllerID string) ([]*storageDrive, error) {
+	var controllers []*redfish.Storage
+	if controllerID != "" {
+		storage, err := getStorageController(conn, systemID, controllerID)
+		if err != nil {
+			return nil, err
+		}
+		controllers = []*redfish.Storage{storage}
+	} else {
+		system, err := common.GetSystem(conn, systemID)
+		if err != nil {
+			return nil, err
+		}
+		if controllers, err = system.Storage(); err != nil {
+			return nil, err
+		}
+	}
+	drives := []*storageDrive{}
+	for _, storage := range controllers {
+		storageDrives, err := storage.Drives()
+		if err != nil {
+			return nil, err
+		}
+		for _, drive := range storageDrives {
+			drives = append(drives, &storageDrive{controllerID: storage.ID, drive: drive, slot: driveSlot(drive)})
+		}
+	}
+	return drives, nil
+}
+
+// driveSlot returns the bay a drive is inserted in: from the FQDD on Dell iDRAC, from the physical location
+// on the rest or, failing that, from a numeric Id (i.e. HPE iLO). -1 when none of them is reported.
+func driveSlot(drive *redfish.Drive) int {
+	if match := dellDriveBayRegexp.FindStringSubmatch(drive.ID); match != nil {
+		slot, _ := strconv.Atoi(match[1])
+		return slot
+	}
+	if location := drive.PhysicalLocation.PartLocation; location.LocationType != "" {
+		return location.LocationOrdinalValue
+	}
+	if slot, err := strconv.Atoi(drive.ID); err == nil {
+		return slot
+	}
+	return -1
+}
+
+// filterDrives returns the drives matching every filter
+func filterDrives(drives []*storageDrive, filter driveFilter) []*storageDrive {
+	filtered := []*storageDrive{}
+	for _, v := range drives {
+		if len(filter.mediaTypes) > 0 && !common.ContainsFold(filter.mediaTypes, string(v.drive.MediaType)) {
+			continue
+		}
+		if len(filter.protocols) > 0 && !common.ContainsFold(filter.protocols, string(v.drive.Protocol)) {
+			continue
+		}
+		if len(filter.health) > 0 && !common.ContainsFold(filter.health, string(v.drive.Status.Health)) {
+			continue
+		}
+		if v.drive.CapacityBytes < int64(filter.minCapacityBytes) {
+			continue
+		}
+		if filter.slotMin != -1 && (v.slot == -1 || v.slot < filter.slotMin) {
+			continue
+		}
+		if filter.slotMax != -1 && (v.slot == -1 || v.slot > filter.slotMax) {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+	return filtered
+}
+
+// sortDrives sorts the drives by slot, capacity or id. Ties are sorted by controller and id, so the order is the same on every read
+func sortDrives(drives []*storageDrive, sortBy string) {
+	sort.SliceStable(drives, func(i, j int) bool {
+		a, b := drives[i], drives[j]
+		switch {
+		case sortBy == "slot" && a.slot != b.slot:
+			// Drives whose slot is unknown go last
+			return b.slot == -1 || (a.slot != -1 && a.slot < b.slot)
+		case sortBy == "capacity" && a.drive.CapacityBytes != b.drive.CapacityBytes:
+			return a.drive.CapacityBytes < b.drive.CapacityBytes
+		case a.controllerID != b.controllerID:
+			return a.controllerID < b.controllerID
+		}
+		return a.drive.ID < b.drive.ID
+	})
+}
@@ -0,0 +1,63 @@
+package redfish
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"reflect"
+	"testing"
+)
+
+func TestDriveSlot(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		drive    redfish.Drive
+		expected int
+	}{
+		{1, redfish.Drive{Entity: redfishcommon.Entity{ID: "Disk.Bay.12:Enclosure.Internal.0-1:RAID.Integrated.1-1"}}, 12},
+		{2, redfish.Drive{Entity: redfishcommon.Entity{ID: "3"}}, 3},
+		{3, redfish.Drive{Entity: redfishcommon.Entity{ID: "Drive.A"}, PhysicalLocation: redfishcommon.Location{PartLocation: redfishcommon.PartLocation{LocationType: redfishcommon.BayLocationType, LocationOrdinalValue: 5}}}, 5},
+		{4, redfish.Drive{Entity: redfishcommon.Entity{ID: "Drive.A"}}, -1},
+	}
+	for _, v := range cases {
+		if slot := driveSlot(&v.drive); slot != v.expected {
+			t.Errorf("Test number %v: expected slot %v, got %v", v.noTest, v.expected, slot)
+		}
+	}
+}
+
+func TestFilterDrives(t *testing.T) {
+	newDrive := func(id string, slot int, media redfish.MediaType, protocol redfishcommon.Protocol, capacity int64, health redfishcommon.Health) *storageDrive {
+		drive := &redfish.Drive{Entity: redfishcommon.Entity{ID: id}, MediaType: media, Protocol: protocol, CapacityBytes: capacity, Status: redfishcommon.Status{Health: health}}
+		return &storageDrive{controllerID: "RAID.Integrated.1-1", drive: drive, slot: slot}
+	}
+	drives := []*storageDrive{
+		newDrive("d", -1, redfish.SSDMediaType, redfishcommon.NVMeProtocol, 800, redfishcommon.OKHealth),
+		newDrive("c", 2, redfish.HDDMediaType, redfishcommon.SATAProtocol, 2000, redfishcommon.OKHealth),
+		newDrive("b", 1, redfish.SSDMediaType, redfishcommon.SASProtocol, 480, redfishcommon.WarningHealth),
+		newDrive("a", 0, redfish.SSDMediaType, redfishcommon.SASProtocol, 480, redfishcommon.OKHealth),
+	}
+	cases := []struct {
+		noTest   int
+		filter   driveFilter
+		sortBy   string
+		expected []string
+	}{
+		{1, driveFilter{slotMin: -1, slotMax: -1}, "slot", []string{"a", "b", "c", "d"}},
+		{2, driveFilter{mediaTypes: []string{"ssd"}, slotMin: -1, slotMax: -1}, "capacity", []string{"a", "b", "d"}},
+		{3, driveFilter{protocols: []string{"SAS", "SATA"}, health: []string{"OK"}, slotMin: -1, slotMax: -1}, "id", []string{"a", "c"}},
+		{4, driveFilter{minCapacityBytes: 500, slotMin: -1, slotMax: -1}, "capacity", []string{"d", "c"}},
+		{5, driveFilter{slotMin: 1, slotMax: 2}, "slot", []string{"b", "c"}},
+		{6, driveFilter{slotMin: -1, slotMax: 0}, "slot", []string{"a"}},
+	}
+	for _, v := range cases {
+		filtered := filterDrives(drives, v.filter)
+		sortDrives(filtered, v.sortBy)
+		ids := []string{}
+		for _, drive := range filtered {
+			ids = append(ids, drive.drive.ID)
+		}
+		if !reflect.DeepEqual(ids, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, ids)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strings"
+)
+
+func dataSourceRedfishEventSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishEventSubscriptionsRead,
+		Schema: map[string]*schema.Schema{
+			"destination_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression matched against the destination of the subscriptions. If not set, every subscription is returned",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"subscriptions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Event subscriptions of the BMC matching destination_regex, including the ones not created by terraform",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the subscription",
+						},
+						"odata_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ODataID of the subscription, which identifies it to remove the ones of the destinations that are gone",
+						},
+						"destination": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL the events are sent to",
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Protocol the events are sent with (i.e. Redfish or SNMPv2c)",
+						},
+						"context": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "String sent with every event, set by the client that created the subscription",
+						},
+						"event_format_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Format of the events sent ('Event' or 'MetricReport')",
+						},
+						"event_types": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Types of the events sent (i.e. Alert or MetricReport)",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"registry_prefixes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Prefixes of the message registries of the events sent. Empty when they are not filtered by registry",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"message_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Ids of the messages of the events sent. Empty when they are not filtered by message",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"managed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the subscription was created by a resource of this provider, going by its context",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishEventSubscriptionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	var destinationRegex *regexp.Regexp
+	if v, ok := d.GetOk("destination_regex"); ok {
+		destinationRegex = regexp.MustCompile(v.(string))
+	}
+
+	subscriptions, err := common.GetEventSubscriptions(conn)
+	if err != nil {
+		return diag.Errorf("error fetching event subscriptions: %s", err)
+	}
+
+	subscriptionList := []map[string]interface{}{}
+	for _, subscription := range subscriptions {
+		if destinationRegex != nil && !destinationRegex.MatchString(subscription.Destination) {
+			continue
+		}
+		eventTypes := []string{}
+		for _, eventType := range subscription.EventTypes {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+		subscriptionList = append(subscriptionList, map[string]interface{}{
+			"id":                subscription.ID,
+			"odata_id":          subscription.ODataID,
+			"destination":       subscription.Destination,
+			"protocol":          string(subscription.Protocol),
+			"context":           subscription.Context,
+			"event_format_type": string(subscription.EventFormatType),
+			"event_types":       eventTypes,
+			"registry_prefixes": subscription.RegistryPrefixes,
+			"message_ids":       subscription.MessageIDs,
+			"managed":           strings.HasPrefix(subscription.Context, common.SubscriptionContextPrefix),
+		})
+	}
+	if err := d.Set("subscriptions", subscriptionList); err != nil {
+		return diag.Errorf("error setting subscriptions: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#event_subscriptions")
+
+	return diags
+}
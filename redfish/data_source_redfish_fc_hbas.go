@@ -0,0 +1,268 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+func dataSourceRedfishFcHbas() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishFcHbasRead,
+		Schema: map[string]*schema.Schema{
+			"hbas": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Network adapters with FibreChannel functions",
+				Elem: &schema.Resource{
+					Schema: networkAdapterSchema(),
+				},
+			},
+			"wwpns": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "WWPNs of every FibreChannel function, ready to be used for SAN zoning",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishFcHbasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	adapters, err := getNetworkAdapters(conn.Service)
+	if err != nil {
+		return diag.Errorf("error fetching network adapters: %s", err)
+	}
+
+	hbas := []map[string]interface{}{}
+	wwpns := []string{}
+	for _, adapter := range adapters {
+		functions, err := adapter.NetworkDeviceFunctions()
+		if err != nil {
+			return diag.Errorf("error fetching network device functions of %s: %s", adapter.ID, err)
+		}
+		fcFunctions := []*redfish.NetworkDeviceFunction{}
+		for _, function := range functions {
+			if function.NetDevFuncType == redfish.FibreChannelNetworkDeviceTechnology {
+				fcFunctions = append(fcFunctions, function)
+				wwpns = append(wwpns, function.FibreChannel.WWPN)
+			}
+		}
+		if len(fcFunctions) == 0 {
+			continue
+		}
+		hba, err := flattenNetworkAdapter(adapter, fcFunctions)
+		if err != nil {
+			return diag.Errorf("error reading network adapter %s: %s", adapter.ID, err)
+		}
+		hbas = append(hbas, hba)
+	}
+
+	if err := d.Set("hbas", hbas); err != nil {
+		return diag.Errorf("error setting FibreChannel HBAs: %s", err)
+	}
+	if err := d.Set("wwpns", wwpns); err != nil {
+		return diag.Errorf("error setting WWPNs: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#fc_hbas")
+
+	return diags
+}
+
+// getNetworkAdapters returns the network adapters of every chassis
+func getNetworkAdapters(service *gofish.Service) ([]*redfish.NetworkAdapter, error) {
+	chassis, err := service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+	adapters := []*redfish.NetworkAdapter{}
+	for _, c := range chassis {
+		chassisAdapters, err := c.NetworkAdapters()
+		if err != nil {
+			return nil, err
+		}
+		adapters = append(adapters, chassisAdapters...)
+	}
+	return adapters, nil
+}
+
+// networkAdapterSchema is the schema of a network adapter, shared by the network adapter data sources
+func networkAdapterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Id of the network adapter",
+		},
+		"odata_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ODataID of the network adapter",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the network adapter",
+		},
+		"manufacturer": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Manufacturer of the network adapter",
+		},
+		"model": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Model of the network adapter",
+		},
+		"serial_number": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Serial number of the network adapter",
+		},
+		"firmware_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Firmware package version of the network adapter controller",
+		},
+		"health": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Health of the network adapter",
+		},
+		"ports": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Physical ports of the network adapter",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"link_status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"current_speed_mbps": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"link_technology": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"fabric_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"connection_type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"addresses": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Network addresses (MAC or WWN) associated to the port",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"functions": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Network device functions of the network adapter",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"mac_address": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"wwpn": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"wwnn": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"permanent_wwpn": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"permanent_wwnn": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenNetworkAdapter converts a network adapter and the given functions to the networkAdapterSchema
+func flattenNetworkAdapter(adapter *redfish.NetworkAdapter, functions []*redfish.NetworkDeviceFunction) (map[string]interface{}, error) {
+	ports, err := adapter.NetworkPorts()
+	if err != nil {
+		return nil, err
+	}
+	portList := []map[string]interface{}{}
+	for _, port := range ports {
+		portList = append(portList, map[string]interface{}{
+			"id":                 port.ID,
+			"link_status":        string(port.LinkStatus),
+			"current_speed_mbps": port.CurrentLinkSpeedMbps,
+			"link_technology":    string(port.ActiveLinkTechnology),
+			"fabric_name":        port.FCFabricName,
+			"connection_type":    string(port.FCPortConnectionType),
+			"addresses":          port.AssociatedNetworkAddresses,
+		})
+	}
+	functionList := []map[string]interface{}{}
+	for _, function := range functions {
+		functionList = append(functionList, map[string]interface{}{
+			"id":             function.ID,
+			"type":           string(function.NetDevFuncType),
+			"mac_address":    function.Ethernet.MACAddress,
+			"wwpn":           function.FibreChannel.WWPN,
+			"wwnn":           function.FibreChannel.WWNN,
+			"permanent_wwpn": function.FibreChannel.PermanentWWPN,
+			"permanent_wwnn": function.FibreChannel.PermanentWWNN,
+		})
+	}
+	firmwareVersion := ""
+	if len(adapter.Controllers) > 0 {
+		firmwareVersion = adapter.Controllers[0].FirmwarePackageVersion
+	}
+	return map[string]interface{}{
+		"id":               adapter.ID,
+		"odata_id":         adapter.ODataID,
+		"name":             adapter.Name,
+		"manufacturer":     adapter.Manufacturer,
+		"model":            adapter.Model,
+		"serial_number":    adapter.SerialNumber,
+		"firmware_version": firmwareVersion,
+		"health":           string(adapter.Status.Health),
+		"ports":            portList,
+		"functions":        functionList,
+	}, nil
+}
@@ -0,0 +1,193 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+)
+
+func dataSourceRedfishHardwareErrata() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishHardwareErrataRead,
+		Schema: map[string]*schema.Schema{
+			"include_bundled_errata": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Check the errata bundled with the provider on top of the ones set in errata",
+			},
+			"errata": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional errata to check",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Identifier of the erratum",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Description of the problem",
+						},
+						"model_regex": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Regular expression matched against the system model (i.e. PowerEdge R740). If not set, every model is affected",
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"component_regex": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Regular expression matched against the firmware inventory name (i.e. ^BIOS$ or CPLD)",
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"minimum_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Oldest acceptable firmware version",
+						},
+						"bad_versions": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Firmware versions known to be bad",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"fail_on_violation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the plan when any violation is found, instead of only returning them",
+			},
+			firmwareSystemModel: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "System model the errata are matched against (i.e. PowerEdge R740). By default it is read from the system",
+			},
+			"violations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Installed firmware matching any of the errata",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"erratum_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the erratum",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the problem",
+						},
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware inventory name of the component",
+						},
+						"component_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware inventory Id of the component",
+						},
+						"installed_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware version installed on the component",
+						},
+						"minimum_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Oldest acceptable firmware version",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishHardwareErrataRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	model := d.Get(firmwareSystemModel).(string)
+	if len(model) == 0 {
+		system, err := common.GetSystem(conn, meta.(*providerConfig).systemID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		model = system.Model
+		if err := d.Set(firmwareSystemModel, model); err != nil {
+			return diag.Errorf("error setting system model: %s", err)
+		}
+	}
+
+	inventory, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return diag.Errorf("error fetching firmware inventory: %s", err)
+	}
+
+	errata := []common.Erratum{}
+	if d.Get("include_bundled_errata").(bool) {
+		errata = append(errata, common.DefaultErrata...)
+	}
+	for _, raw := range d.Get("errata").([]interface{}) {
+		e := raw.(map[string]interface{})
+		erratum := common.Erratum{
+			ID:             e["id"].(string),
+			Description:    e["description"].(string),
+			ModelRegex:     e["model_regex"].(string),
+			ComponentRegex: e["component_regex"].(string),
+			MinimumVersion: e["minimum_version"].(string),
+		}
+		for _, v := range e["bad_versions"].([]interface{}) {
+			erratum.BadVersions = append(erratum.BadVersions, v.(string))
+		}
+		errata = append(errata, erratum)
+	}
+
+	violations, err := common.CheckErrata(model, inventory, errata)
+	if err != nil {
+		return diag.Errorf("error checking errata: %s", err)
+	}
+
+	violationList := []map[string]interface{}{}
+	messages := []string{}
+	for _, violation := range violations {
+		violationList = append(violationList, map[string]interface{}{
+			"erratum_id":        violation.Erratum.ID,
+			"description":       violation.Erratum.Description,
+			"component":         violation.Installed.Name,
+			"component_id":      violation.Installed.ID,
+			"installed_version": violation.Installed.Version,
+			"minimum_version":   violation.Erratum.MinimumVersion,
+		})
+		messages = append(messages, fmt.Sprintf("%s: %s %s (%s)", violation.Erratum.ID, violation.Installed.Name, violation.Installed.Version, violation.Erratum.Description))
+	}
+
+	if err := d.Set("violations", violationList); err != nil {
+		return diag.Errorf("error setting violations: %s", err)
+	}
+
+	if len(messages) > 0 && d.Get("fail_on_violation").(bool) {
+		return diag.Errorf("firmware matching known errata found:\n%s", strings.Join(messages, "\n"))
+	}
+
+	d.SetId(conn.Service.ODataID + "#hardware_errata")
+
+	return diags
+}
@@ -0,0 +1,176 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"path"
+	"regexp"
+)
+
+// Attribute sources of redfish_idrac_attributes
+const (
+	idracAttributesSource               string = "idrac"
+	systemAttributesSource              string = "system"
+	lifecycleControllerAttributesSource string = "lifecycle_controller"
+	biosAttributesSource                string = "bios"
+	nicAttributesSource                 string = "nic"
+)
+
+func dataSourceRedfishIdracAttributes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishIdracAttributesRead,
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  idracAttributesSource,
+				Description: "Attributes to read. Applicable values are 'idrac' (i.e. SNMP.1.AgentEnable), 'system' (i.e. ServerPwr.1.PSRapidOn), " +
+					"'lifecycle_controller' (i.e. LCAttributes.1.AutoUpdate), 'bios' and 'nic', the attributes of the function_id of network_adapter_id. " +
+					"Only 'bios' is available on the BMCs other than Dell iDRAC",
+				ValidateFunc: validation.StringInSlice([]string{idracAttributesSource, systemAttributesSource, lifecycleControllerAttributesSource, biosAttributesSource, nicAttributesSource}, false),
+			},
+			"network_adapter_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Id of the network adapter of the nic attributes (i.e. NIC.Integrated.1)",
+				RequiredWith: []string{"function_id"},
+			},
+			"function_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Id of the network device function of the nic attributes, a port or a NIC partition (i.e. NIC.Integrated.1-1-1)",
+				RequiredWith: []string{"network_adapter_id"},
+			},
+			"name_glob": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Shell pattern matched against the names of the attributes (i.e. SNMP.* or *.Enable). If not set, the names are not filtered by pattern",
+				ValidateFunc: validateGlob,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression matched against the names of the attributes. Combined with name_glob, only the attributes matching both are returned",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Current values of the attributes matching the filters, by name. Every value is a string, as in the resources setting them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishIdracAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	uri, attributes, err := getSourceAttributes(conn, meta.(*providerConfig), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filtered, err := filterAttributes(attributes, d.Get("name_glob").(string), nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("attributes", filtered); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	d.SetId(uri)
+
+	return diags
+}
+
+// getSourceAttributes reads every attribute of the source of redfish_idrac_attributes, returning the URI they were read from
+func getSourceAttributes(conn *gofish.APIClient, config *providerConfig, d *schema.ResourceData) (string, map[string]string, error) {
+	source := d.Get("source").(string)
+	if source == biosAttributesSource {
+		bios, err := getBios(conn, config.systemID)
+		if err != nil {
+			return "", nil, fmt.Errorf("error fetching bios resource: %s", err)
+		}
+		attributes := make(map[string]string)
+		if err := copyBiosAttributes(bios, attributes); err != nil {
+			return "", nil, fmt.Errorf("error fetching BIOS attributes: %s", err)
+		}
+		return bios.ODataID, attributes, nil
+	}
+
+	if vendor := config.oem.Vendor(); vendor != "dell" {
+		return "", nil, fmt.Errorf("the %s attributes are not available on %s BMCs, only on Dell iDRAC", source, vendor)
+	}
+	var uri string
+	switch source {
+	case idracAttributesSource:
+		uri = common.DellIdracAttributesURI
+	case systemAttributesSource:
+		uri = common.DellSystemAttributesURI
+	case lifecycleControllerAttributesSource:
+		uri = common.DellLifecycleControllerAttributesURI
+	case nicAttributesSource:
+		adapterID, functionID := d.Get("network_adapter_id").(string), d.Get("function_id").(string)
+		if functionID == "" {
+			return "", nil, fmt.Errorf("network_adapter_id and function_id must be set to read the nic attributes")
+		}
+		_, functions, err := getVirtualNetworkFunctions(conn, adapterID)
+		if err != nil {
+			return "", nil, err
+		}
+		function, ok := functions[functionID]
+		if !ok {
+			return "", nil, fmt.Errorf("function %s not found in network adapter %s", functionID, adapterID)
+		}
+		uri = function.DellNetworkAttributesURI()
+	}
+	attributes, err := common.GetDellAttributes(conn, uri)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading the %s attributes: %s", source, err)
+	}
+	return uri, attributes, nil
+}
+
+// filterAttributes returns the attributes whose name matches both glob and nameRegex. Empty glob and nil nameRegex match every name.
+func filterAttributes(attributes map[string]string, glob string, nameRegex *regexp.Regexp) (map[string]string, error) {
+	filtered := make(map[string]string)
+	for name, value := range attributes {
+		if glob != "" {
+			matched, err := path.Match(glob, name)
+			if err != nil {
+				return nil, fmt.Errorf("error matching name_glob: %s", err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		filtered[name] = value
+	}
+	return filtered, nil
+}
+
+// validateGlob is the ValidateFunc of the shell patterns
+func validateGlob(v interface{}, k string) ([]string, []error) {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid pattern: %s", k, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+package redfish
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestFilterAttributes(t *testing.T) {
+	attributes := map[string]string{
+		"SNMP.1.AgentEnable":    "Enabled",
+		"SNMP.1.AgentCommunity": "public",
+		"SSH.1.Timeout":         "1800",
+		"SysLog.1.SysLogEnable": "Enabled",
+	}
+	cases := []struct {
+		noTest     int
+		glob       string
+		regex      string
+		expected   []string
+		shouldPass bool
+	}{
+		{1, "", "", []string{"SNMP.1.AgentEnable", "SNMP.1.AgentCommunity", "SSH.1.Timeout", "SysLog.1.SysLogEnable"}, true},
+		{2, "SNMP.*", "", []string{"SNMP.1.AgentEnable", "SNMP.1.AgentCommunity"}, true},
+		{3, "*Enable", "", []string{"SNMP.1.AgentEnable", "SysLog.1.SysLogEnable"}, true},
+		{4, "", "^S[SN]", []string{"SNMP.1.AgentEnable", "SNMP.1.AgentCommunity", "SSH.1.Timeout"}, true},
+		{5, "*Enable", "^SNMP", []string{"SNMP.1.AgentEnable"}, true},
+		{6, "[", "", nil, false},
+	}
+	for _, v := range cases {
+		var nameRegex *regexp.Regexp
+		if v.regex != "" {
+			nameRegex = regexp.MustCompile(v.regex)
+		}
+		filtered, err := filterAttributes(attributes, v.glob, nameRegex)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if len(filtered) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, filtered)
+			continue
+		}
+		for _, name := range v.expected {
+			if filtered[name] != attributes[name] {
+				t.Errorf("Test number %v: expected %s to be %q, got %q", v.noTest, name, attributes[name], filtered[name])
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishInventoryExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishInventoryExportRead,
+		Schema: map[string]*schema.Schema{
+			"include_csv": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also render the components of the inventory as CSV in the csv attribute",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hardware and firmware inventory of the server as normalized JSON, with the system and the list of its components",
+			},
+			"csv": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Components of the inventory as CSV, one row per component. Only set when include_csv is true",
+			},
+		},
+	}
+}
+
+func dataSourceRedfishInventoryExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	inventory, err := common.GetInventory(conn, meta.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error collecting inventory: %s", err)
+	}
+
+	inventoryJSON, err := json.Marshal(inventory)
+	if err != nil {
+		return diag.Errorf("error rendering inventory as JSON: %s", err)
+	}
+	if err := d.Set("json", string(inventoryJSON)); err != nil {
+		return diag.Errorf("error setting json: %s", err)
+	}
+
+	inventoryCSV := ""
+	if d.Get("include_csv").(bool) {
+		if inventoryCSV, err = inventory.CSV(); err != nil {
+			return diag.Errorf("error rendering inventory as CSV: %s", err)
+		}
+	}
+	if err := d.Set("csv", inventoryCSV); err != nil {
+		return diag.Errorf("error setting csv: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#inventory_export")
+
+	return diags
+}
@@ -0,0 +1,159 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishJobs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishJobsRead,
+		Schema: map[string]*schema.Schema{
+			"states": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the jobs in one of these states (i.e. 'Running', 'Scheduled', 'Completed' or 'Failed'), compared ignoring case. iDRAC jobs are compared by their JobState, the rest by their TaskState",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only list the iDRAC jobs of these types (i.e. 'BIOSConfiguration' or 'FirmwareUpdate'), compared ignoring case. TaskService tasks have no type, so they never match",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unfinished_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only list the jobs that have not finished yet, including the ones scheduled for the next reboot. Meant for preconditions checking no job is pending",
+			},
+			"jobs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Jobs matching the filters. They are read from the iDRAC job queue on Dell servers, and from the TaskService on the rest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the job (i.e. JID_000000000001)",
+						},
+						"uri": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URI of the job, accepted by the task_uri of the redfish_task data source",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the job",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the iDRAC job (i.e. 'BIOSConfiguration'). Empty for TaskService tasks",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the job",
+						},
+						"finished": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job has reached a final state, successful or not",
+						},
+						"percent_complete": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Percentage of the job completed",
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the job started",
+						},
+						"end_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the job finished. Empty while it is pending",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Last message reported by the job, which holds the reason of the failure of failed jobs",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishJobsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config := meta.(*providerConfig)
+	conn := config.clientWithContext(ctx)
+
+	tasks, err := config.oem.Jobs(conn)
+	if err != nil {
+		return diag.Errorf("error retrieving the jobs: %s", err)
+	}
+
+	states := []string{}
+	for _, v := range d.Get("states").([]interface{}) {
+		states = append(states, v.(string))
+	}
+	types := []string{}
+	for _, v := range d.Get("types").([]interface{}) {
+		types = append(types, v.(string))
+	}
+
+	jobs := []map[string]interface{}{}
+	for _, task := range filterJobs(tasks, states, types, d.Get("unfinished_only").(bool)) {
+		message := ""
+		if len(task.Messages) > 0 {
+			message = task.Messages[len(task.Messages)-1].Message
+		}
+		jobs = append(jobs, map[string]interface{}{
+			"id":               task.ID,
+			"uri":              task.ODataID,
+			"name":             task.Name,
+			"type":             task.Type,
+			"state":            task.State,
+			"finished":         task.Finished(),
+			"percent_complete": task.PercentComplete,
+			"start_time":       task.StartTime,
+			"end_time":         task.EndTime,
+			"message":          message,
+		})
+	}
+	if err := d.Set("jobs", jobs); err != nil {
+		return diag.Errorf("error setting jobs: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#jobs")
+
+	return diags
+}
+
+// filterJobs returns the tasks in one of states and of one of types, ignoring case. Empty lists match every task.
+func filterJobs(tasks []*common.Task, states []string, types []string, unfinishedOnly bool) []*common.Task {
+	filtered := []*common.Task{}
+	for _, task := range tasks {
+		if unfinishedOnly && task.Finished() {
+			continue
+		}
+		if len(states) > 0 && !common.ContainsFold(states, task.State) {
+			continue
+		}
+		if len(types) > 0 && !common.ContainsFold(types, task.Type) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return filtered
+}
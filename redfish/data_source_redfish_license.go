@@ -0,0 +1,114 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func dataSourceRedfishLicense() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishLicenseRead,
+		Schema: map[string]*schema.Schema{
+			"licenses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Licenses installed on the BMC. Empty when the BMC does not expose a LicenseService",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the license",
+						},
+						"entitlement_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Entitlement id of the license, as issued by the vendor",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the license (i.e. iDRAC9 Enterprise License)",
+						},
+						"license_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the license ('Production', 'Prototype' or 'Trial' for evaluation licenses)",
+						},
+						"install_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date the license was installed",
+						},
+						"expiration_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date the license expires. Empty for perpetual licenses",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the expiration date of the license has passed",
+						},
+						"removable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the license can be deleted",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the license (i.e. Enabled, or Disabled once expired)",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the license",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishLicenseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	licenses, err := common.GetLicenses(conn, conn.Service.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching licenses: %s", err)
+	}
+
+	licenseList := []map[string]interface{}{}
+	for _, license := range licenses {
+		expired := false
+		if expiration, err := time.Parse(time.RFC3339, license.ExpirationDate); err == nil {
+			expired = expiration.Before(time.Now())
+		}
+		licenseList = append(licenseList, map[string]interface{}{
+			"id":              license.ID,
+			"entitlement_id":  license.EntitlementID,
+			"description":     license.Description,
+			"license_type":    license.LicenseType,
+			"install_date":    license.InstallDate,
+			"expiration_date": license.ExpirationDate,
+			"expired":         expired,
+			"removable":       license.Removable,
+			"state":           string(license.Status.State),
+			"health":          string(license.Status.Health),
+		})
+	}
+	if err := d.Set("licenses", licenseList); err != nil {
+		return diag.Errorf("error setting licenses: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#licenses")
+
+	return diags
+}
@@ -0,0 +1,220 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func dataSourceRedfishPcieDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishPcieDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"device_class": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the devices with a function of this class (i.e. 'DisplayController' for GPUs or 'NetworkController' for NICs). Case insensitive",
+			},
+			"devices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "PCIe devices of the system",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"odata_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"manufacturer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"part_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"firmware_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SingleFunction, MultiFunction or Simulated",
+						},
+						"slot": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Label (or number) of the slot the device is plugged in. Empty for embedded devices or services not reporting it",
+						},
+						"pcie_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Negotiated PCIe generation (i.e. 'Gen3')",
+						},
+						"max_pcie_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Highest PCIe generation the device supports",
+						},
+						"lanes_in_use": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Negotiated link width",
+						},
+						"max_lanes": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum link width the device supports",
+						},
+						"health": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"functions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "PCIe functions the device exposes",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"function_id": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"function_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"device_class": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"class_code": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"device_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "PCI device id (i.e. '0x1eb8')",
+									},
+									"vendor_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "PCI vendor id (i.e. '0x10de')",
+									},
+									"subsystem_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"subsystem_vendor_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishPcieDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	system, err := common.GetSystem(conn, meta.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pcieDevices, err := common.GetPCIeDevices(conn, system.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching PCIe devices: %s", err)
+	}
+
+	deviceClass := d.Get("device_class").(string)
+	devices := []map[string]interface{}{}
+	for _, device := range pcieDevices {
+		if deviceClass != "" && !pcieDeviceHasClass(device, deviceClass) {
+			continue
+		}
+		devices = append(devices, flattenPcieDevice(device))
+	}
+
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting PCIe devices: %s", err)
+	}
+
+	d.SetId(system.ODataID + "#pcie_devices")
+
+	return diags
+}
+
+func pcieDeviceHasClass(device *common.PCIeDevice, deviceClass string) bool {
+	for _, function := range device.Functions {
+		if strings.EqualFold(function.DeviceClass, deviceClass) {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenPcieDevice(device *common.PCIeDevice) map[string]interface{} {
+	functions := []map[string]interface{}{}
+	for _, function := range device.Functions {
+		functions = append(functions, map[string]interface{}{
+			"id":                  function.ID,
+			"function_id":         function.FunctionID,
+			"function_type":       function.FunctionType,
+			"device_class":        function.DeviceClass,
+			"class_code":          function.ClassCode,
+			"device_id":           function.DeviceID,
+			"vendor_id":           function.VendorID,
+			"subsystem_id":        function.SubsystemID,
+			"subsystem_vendor_id": function.SubsystemVendorID,
+		})
+	}
+	return map[string]interface{}{
+		"id":               device.ID,
+		"odata_id":         device.ODataID,
+		"name":             device.Name,
+		"manufacturer":     device.Manufacturer,
+		"model":            device.Model,
+		"serial_number":    device.SerialNumber,
+		"part_number":      device.PartNumber,
+		"firmware_version": device.FirmwareVersion,
+		"device_type":      device.DeviceType,
+		"slot":             device.SlotLabel(),
+		"pcie_type":        device.PCIeInterface.PCIeType,
+		"max_pcie_type":    device.PCIeInterface.MaxPCIeType,
+		"lanes_in_use":     device.PCIeInterface.LanesInUse,
+		"max_lanes":        device.PCIeInterface.MaxLanes,
+		"health":           string(device.Status.Health),
+		"functions":        functions,
+	}
+}
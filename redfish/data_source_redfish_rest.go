@@ -0,0 +1,123 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// redfishPathRegexp matches the paths of the Redfish resources, without query or fragment
+var redfishPathRegexp = regexp.MustCompile(`^/redfish/[^?#]*$`)
+
+func dataSourceRedfishRest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishRestRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Redfish path to GET (i.e. /redfish/v1/Managers/iDRAC.Embedded.1/NetworkProtocol)",
+				ValidateFunc: validation.StringMatch(redfishPathRegexp, "must be an absolute path without query"),
+			},
+			"select": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Properties to return, sent as the $select query. Only honored by services supporting it",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"expand": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "$expand query, to include the members of collections and the linked resources in the response (i.e. '*', '.' or '.($levels=2)'). Only honored by services supporting it",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Response body, to be decoded with jsondecode",
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Top level properties of the response. Objects and arrays are JSON encoded",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishRestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	uri := d.Get("path").(string)
+	query := []string{}
+	if v, ok := d.GetOk("select"); ok {
+		properties := []string{}
+		for _, property := range v.([]interface{}) {
+			properties = append(properties, property.(string))
+		}
+		query = append(query, "$select="+strings.Join(properties, ","))
+	}
+	if v, ok := d.GetOk("expand"); ok {
+		query = append(query, "$expand="+v.(string))
+	}
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+
+	resource, err := common.GetResource(conn, uri)
+	if err != nil {
+		return diag.Errorf("error fetching %s: %s", uri, err)
+	}
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return diag.Errorf("error encoding %s: %s", uri, err)
+	}
+	if err := d.Set("json", string(body)); err != nil {
+		return diag.Errorf("error setting json: %s", err)
+	}
+	attributes, err := flattenRestAttributes(resource)
+	if err != nil {
+		return diag.Errorf("error encoding the attributes of %s: %s", uri, err)
+	}
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	d.SetId(uri)
+
+	return diags
+}
+
+// flattenRestAttributes converts the top level properties of a resource to strings.
+// Objects and arrays are JSON encoded and null values are empty strings.
+func flattenRestAttributes(resource map[string]interface{}) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for key, value := range resource {
+		switch v := value.(type) {
+		case nil:
+			attributes[key] = ""
+		case string:
+			attributes[key] = v
+		case float64:
+			attributes[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case map[string]interface{}, []interface{}:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			attributes[key] = string(encoded)
+		default:
+			attributes[key] = fmt.Sprintf("%v", v)
+		}
+	}
+	return attributes, nil
+}
@@ -0,0 +1,32 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenRestAttributes(t *testing.T) {
+	resource := map[string]interface{}{
+		"Id":               "iDRAC.Embedded.1",
+		"PowerState":       nil,
+		"InterfaceEnabled": true,
+		"MaxSessions":      float64(1000000),
+		"Status":           map[string]interface{}{"Health": "OK"},
+		"NTPServers":       []interface{}{"10.0.0.1"},
+	}
+	expected := map[string]string{
+		"Id":               "iDRAC.Embedded.1",
+		"PowerState":       "",
+		"InterfaceEnabled": "true",
+		"MaxSessions":      "1000000",
+		"Status":           `{"Health":"OK"}`,
+		"NTPServers":       `["10.0.0.1"]`,
+	}
+	attributes, err := flattenRestAttributes(resource)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(attributes, expected) {
+		t.Errorf("Expected %v, got %v", expected, attributes)
+	}
+}
@@ -0,0 +1,185 @@
+package redfish
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strings"
+	"time"
+)
+
+func dataSourceRedfishSecureBoot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishSecureBootRead,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether UEFI Secure Boot is enabled, from the next boot on",
+			},
+			"current_boot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether UEFI Secure Boot was enforced on the current boot ('Enabled' or 'Disabled')",
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Secure Boot mode, as defined by UEFI ('SetupMode', 'UserMode', 'AuditMode' or 'DeployedMode')",
+			},
+			"databases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Secure Boot databases and the keys they hold. Empty on BMCs not exposing them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the database (i.e. db, dbx, KEK or PK)",
+						},
+						"database_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UEFI name of the database (i.e. db or dbDefault for the default keys)",
+						},
+						"digests": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Digests of the keys of the database, in the order of keys, for compliance checks",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"keys": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Certificates and signatures of the database",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Id of the key in its collection",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "'Certificate' or 'Signature'",
+									},
+									"signature_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "UEFI type of the signatures (i.e. EFI_CERT_SHA256_GUID). Empty for certificates",
+									},
+									"subject": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Subject of the certificates. Empty for signatures",
+									},
+									"not_after": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Expiration date of the certificates, in RFC3339 format. Empty for signatures",
+									},
+									"digest": {
+										Type:     schema.TypeString,
+										Computed: true,
+										Description: "SHA-256 fingerprint of the certificates, as the fingerprint of redfish_certificate_trust_store, " +
+											"or the hash of the signatures, in hexadecimal. Empty when the BMC does not return the key",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishSecureBootRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	system, err := common.GetSystem(conn, meta.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching computer system: %s", err)
+	}
+	secureBoot, err := common.GetSecureBoot(conn, system.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching Secure Boot: %s", err)
+	}
+	databases, err := common.GetSecureBootDatabases(conn, secureBoot)
+	if err != nil {
+		return diag.Errorf("error fetching Secure Boot databases: %s", err)
+	}
+
+	databaseList := []map[string]interface{}{}
+	for _, database := range databases {
+		keys := []map[string]interface{}{}
+		digests := []string{}
+		for _, certificate := range database.Certificates {
+			key := map[string]interface{}{"id": certificate.ID, "type": "Certificate", "digest": ""}
+			if parsed, err := parsePEMCertificate(certificate.CertificateString); err == nil {
+				key["subject"] = parsed.Subject.String()
+				key["not_after"] = parsed.NotAfter.UTC().Format(time.RFC3339)
+				key["digest"] = certificateFingerprint(parsed)
+			} else {
+				log.Printf("[DEBUG] %s: error parsing certificate %s: %s", database.ODataID, certificate.ID, err)
+			}
+			keys = append(keys, key)
+			digests = append(digests, key["digest"].(string))
+		}
+		for _, signature := range database.Signatures {
+			key := map[string]interface{}{
+				"id":             signature.ID,
+				"type":           "Signature",
+				"signature_type": signature.SignatureType,
+				"digest":         signatureDigest(signature.SignatureString),
+			}
+			keys = append(keys, key)
+			digests = append(digests, key["digest"].(string))
+		}
+		databaseList = append(databaseList, map[string]interface{}{
+			"id":          database.ID,
+			"database_id": database.DatabaseID,
+			"digests":     digests,
+			"keys":        keys,
+		})
+	}
+
+	values := map[string]interface{}{
+		"enabled":      secureBoot.SecureBootEnable,
+		"current_boot": secureBoot.SecureBootCurrentBoot,
+		"mode":         secureBoot.SecureBootMode,
+		"databases":    databaseList,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	d.SetId(secureBoot.ODataID)
+
+	return diags
+}
+
+// signatureDigest returns the hash of a Secure Boot signature in hexadecimal. BMCs return it in base64,
+// as defined by Redfish, or already in hexadecimal
+func signatureDigest(signature string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil && !isHexString(signature) {
+		return hex.EncodeToString(decoded)
+	}
+	return strings.ToLower(signature)
+}
+
+// isHexString reports whether s only holds hexadecimal digits
+func isHexString(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,20 @@
+package redfish
+
+import "testing"
+
+func TestSignatureDigest(t *testing.T) {
+	cases := []struct {
+		noTest    int
+		signature string
+		expected  string
+	}{
+		{1, "gDZwQ8ZTs5r5+pLcqk4dfWnCykfuL8tdK58gSZ0WSvg=", "80367043c653b39af9fa92dcaa4e1d7d69c2ca47ee2fcb5d2b9f20499d164af8"},
+		{2, "80367043C653B39AF9FA92DCAA4E1D7D69C2CA47EE2FCB5D2B9F20499D164AF8", "80367043c653b39af9fa92dcaa4e1d7d69c2ca47ee2fcb5d2b9f20499d164af8"},
+		{3, "", ""},
+	}
+	for _, v := range cases {
+		if digest := signatureDigest(v.signature); digest != v.expected {
+			t.Errorf("Test number %v: expected %s, got %s", v.noTest, v.expected, digest)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+)
+
+func dataSourceRedfishSensors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishSensorsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression matched against the sensor name. If not set, every sensor is returned",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"reading_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Sensor reading types to return (i.e. Temperature, Power, Voltage, Current, Rotational). If not set, every type is returned",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sensors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sensors matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the sensor",
+						},
+						"odata_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ODataID of the sensor",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the sensor",
+						},
+						"chassis_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the chassis the sensor belongs to",
+						},
+						"reading_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the reading (i.e. Temperature)",
+						},
+						"reading": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Current reading of the sensor. Only meaningful when has_reading is true",
+						},
+						"has_reading": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the sensor reported a reading",
+						},
+						"reading_units": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Units of the reading and thresholds (i.e. Cel)",
+						},
+						"physical_context": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Area or device the sensor measures (i.e. CPU)",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the sensor",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the sensor",
+						},
+						"thresholds": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Thresholds defined for the sensor, indexed by lower_caution, lower_critical, lower_fatal, upper_caution, upper_critical and upper_fatal",
+							Elem: &schema.Schema{
+								Type: schema.TypeFloat,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishSensorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	readingTypes := []string{}
+	for _, v := range d.Get("reading_types").([]interface{}) {
+		readingTypes = append(readingTypes, v.(string))
+	}
+
+	sensors, err := common.GetSensors(conn)
+	if err != nil {
+		return diag.Errorf("error fetching sensors: %s", err)
+	}
+
+	sensorList := []map[string]interface{}{}
+	for _, sensor := range sensors {
+		if nameRegex != nil && !nameRegex.MatchString(sensor.Name) {
+			continue
+		}
+		if len(readingTypes) > 0 && !common.ContainsFold(readingTypes, sensor.ReadingType) {
+			continue
+		}
+		reading := 0.0
+		if sensor.Reading != nil {
+			reading = *sensor.Reading
+		}
+		sensorList = append(sensorList, map[string]interface{}{
+			"id":               sensor.ID,
+			"odata_id":         sensor.ODataID,
+			"name":             sensor.Name,
+			"chassis_id":       sensor.ChassisID,
+			"reading_type":     sensor.ReadingType,
+			"reading":          reading,
+			"has_reading":      sensor.Reading != nil,
+			"reading_units":    sensor.ReadingUnits,
+			"physical_context": sensor.PhysicalContext,
+			"health":           string(sensor.Status.Health),
+			"state":            string(sensor.Status.State),
+			"thresholds":       sensor.Thresholds.Readings(),
+		})
+	}
+
+	if err := d.Set("sensors", sensorList); err != nil {
+		return diag.Errorf("error setting sensors: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#sensors")
+
+	return diags
+}
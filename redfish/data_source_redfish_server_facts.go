@@ -0,0 +1,264 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishServerFacts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishServerFactsRead,
+		Schema: map[string]*schema.Schema{
+			"service_tag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Service tag of the server on Dell systems, serial number on the rest",
+			},
+			"system": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Computer system of the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the system",
+						},
+						"manufacturer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Manufacturer of the system",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Model of the system",
+						},
+						"serial_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Serial number of the system",
+						},
+						"sku": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SKU of the system, which is the service tag on Dell systems",
+						},
+						"uuid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the system",
+						},
+						"asset_tag": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Asset tag of the system",
+						},
+						"host_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Host name of the operating system, as reported to the BMC",
+						},
+						"power_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Power state of the system",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the system",
+						},
+						"processor_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of processors of the system",
+						},
+						"processor_model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Model of the processors of the system",
+						},
+						"memory_gib": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Memory of the system, in GiB",
+						},
+					},
+				},
+			},
+			"manager": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "BMC of the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the manager",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Model of the manager",
+						},
+						"firmware_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware version of the manager",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "MAC address of the first network interface of the manager. Empty when the BMC does not expose its interfaces",
+						},
+					},
+				},
+			},
+			"firmware": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Firmware versions of the key components of the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bios": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the BIOS",
+						},
+						"bmc": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firmware version of the BMC",
+						},
+						"nics": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Firmware versions of the network adapters, indexed by adapter Id",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"raid_controllers": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Firmware versions of the storage controllers, indexed by storage Id (i.e. RAID.Integrated.1-1)",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"mac_addresses": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "MAC addresses of the network device functions of the server, indexed by function Id",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishServerFactsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	system, err := common.GetSystem(conn, meta.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching computer system: %s", err)
+	}
+	manager, err := common.GetManager(conn, meta.(*providerConfig).managerID)
+	if err != nil {
+		return diag.Errorf("error fetching manager: %s", err)
+	}
+	managerMAC := ""
+	interfaces, err := manager.EthernetInterfaces()
+	if err != nil {
+		return diag.Errorf("error fetching the network interfaces of the manager: %s", err)
+	}
+	if len(interfaces) > 0 {
+		managerMAC = interfaces[0].MACAddress
+	}
+
+	nics := make(map[string]string)
+	macAddresses := make(map[string]string)
+	adapters, err := getNetworkAdapters(conn.Service)
+	if err != nil {
+		return diag.Errorf("error fetching network adapters: %s", err)
+	}
+	for _, adapter := range adapters {
+		nics[adapter.ID] = ""
+		if len(adapter.Controllers) > 0 {
+			nics[adapter.ID] = adapter.Controllers[0].FirmwarePackageVersion
+		}
+		functions, err := common.GetNetworkDeviceFunctions(conn, adapter.ODataID)
+		if err != nil {
+			return diag.Errorf("error fetching network device functions of %s: %s", adapter.ID, err)
+		}
+		for _, function := range functions {
+			if function.Ethernet.MACAddress != "" {
+				macAddresses[function.ID] = function.Ethernet.MACAddress
+			}
+		}
+	}
+
+	raidControllers := make(map[string]string)
+	storage, err := system.Storage()
+	if err != nil {
+		return diag.Errorf("error fetching storage: %s", err)
+	}
+	for _, s := range storage {
+		if len(s.StorageControllers) > 0 {
+			raidControllers[s.ID] = s.StorageControllers[0].FirmwareVersion
+		}
+	}
+
+	// Dell systems report their service tag as SKU
+	serviceTag := system.SerialNumber
+	if meta.(*providerConfig).oem.Vendor() == "dell" && system.SKU != "" {
+		serviceTag = system.SKU
+	}
+
+	values := map[string]interface{}{
+		"service_tag": serviceTag,
+		"system": []map[string]interface{}{{
+			"id":              system.ID,
+			"manufacturer":    system.Manufacturer,
+			"model":           system.Model,
+			"serial_number":   system.SerialNumber,
+			"sku":             system.SKU,
+			"uuid":            system.UUID,
+			"asset_tag":       system.AssetTag,
+			"host_name":       system.HostName,
+			"power_state":     string(system.PowerState),
+			"health":          string(system.Status.Health),
+			"processor_count": system.ProcessorSummary.Count,
+			"processor_model": system.ProcessorSummary.Model,
+			"memory_gib":      float64(system.MemorySummary.TotalSystemMemoryGiB),
+		}},
+		"manager": []map[string]interface{}{{
+			"id":               manager.ID,
+			"model":            manager.Model,
+			"firmware_version": manager.FirmwareVersion,
+			"mac_address":      managerMAC,
+		}},
+		"firmware": []map[string]interface{}{{
+			"bios":             system.BIOSVersion,
+			"bmc":              manager.FirmwareVersion,
+			"nics":             nics,
+			"raid_controllers": raidControllers,
+		}},
+		"mac_addresses": macAddresses,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	d.SetId(system.ODataID + "#server_facts")
+
+	return diags
+}
@@ -0,0 +1,141 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+)
+
+func dataSourceRedfishServiceRoot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishServiceRootRead,
+		Schema: map[string]*schema.Schema{
+			"minimum_redfish_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Oldest Redfish version the module supports (i.e. 1.6.0). Reading the data source fails on older BMCs",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+(\.\d+)*$`), "must be a version like 1.6.0"),
+			},
+			"redfish_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the Redfish specification the BMC implements (i.e. 1.11.0)",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Vendor of the BMC, as reported by the service root. Empty on BMCs older than Redfish 1.5",
+			},
+			"oem_vendor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Vendor whose OEM extensions the provider uses (i.e. dell, hpe or generic), after vendor_override",
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product name of the BMC (i.e. Integrated Dell Remote Access Controller)",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the Redfish service",
+			},
+			"protocol_features": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Query parameters the BMC supports. All false on BMCs not reporting them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"expand_query": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether $expand is supported, with any of its options",
+						},
+						"expand_max_levels": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Deepest $levels accepted by $expand. 0 when $expand is not supported",
+						},
+						"filter_query": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether $filter is supported",
+						},
+						"select_query": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether $select is supported",
+						},
+						"only_member_query": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the only query parameter is supported",
+						},
+						"excerpt_query": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the excerpt query parameter is supported",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishServiceRootRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	root, err := common.GetServiceRoot(conn, conn.Service.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching the service root: %s", err)
+	}
+
+	if minimum := d.Get("minimum_redfish_version").(string); minimum != "" {
+		comparison, err := common.CompareRedfishVersions(root.RedfishVersion, minimum)
+		if err != nil {
+			return diag.Errorf("error checking the Redfish version: %s", err)
+		}
+		if comparison < 0 {
+			return diag.Errorf("the BMC implements Redfish %s, older than the minimum_redfish_version %s", root.RedfishVersion, minimum)
+		}
+	}
+
+	if err := d.Set("redfish_version", root.RedfishVersion); err != nil {
+		return diag.Errorf("error setting redfish_version: %s", err)
+	}
+	if err := d.Set("vendor", root.Vendor); err != nil {
+		return diag.Errorf("error setting vendor: %s", err)
+	}
+	if err := d.Set("oem_vendor", meta.(*providerConfig).oem.Vendor()); err != nil {
+		return diag.Errorf("error setting oem_vendor: %s", err)
+	}
+	if err := d.Set("product", root.Product); err != nil {
+		return diag.Errorf("error setting product: %s", err)
+	}
+	if err := d.Set("uuid", root.UUID); err != nil {
+		return diag.Errorf("error setting uuid: %s", err)
+	}
+	features := root.ProtocolFeaturesSupported
+	expand := features.ExpandQuery
+	if err := d.Set("protocol_features", []interface{}{map[string]interface{}{
+		"expand_query":      expand.ExpandAll || expand.Levels || expand.Links || expand.NoLinks,
+		"expand_max_levels": expand.MaxLevels,
+		"filter_query":      features.FilterQuery,
+		"select_query":      features.SelectQuery,
+		"only_member_query": features.OnlyMemberQuery,
+		"excerpt_query":     features.ExcerptQuery,
+	}}); err != nil {
+		return diag.Errorf("error setting protocol_features: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID)
+
+	return diags
+}
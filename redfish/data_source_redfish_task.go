@@ -0,0 +1,125 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishTask() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishTaskRead,
+		Schema: map[string]*schema.Schema{
+			"task_uri": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URI of the task or job (i.e. /redfish/v1/TaskService/Tasks/JID_000000000001), as returned in the Location header of the request that started it",
+				ExactlyOneOf: []string{"task_uri", "task_id"},
+			},
+			"task_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Id of the task. iDRAC jobs (JID_XXXX) are read from the iDRAC job queue, the rest from the TaskService",
+				ExactlyOneOf: []string{"task_uri", "task_id"},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the task",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the task (i.e. 'Running', 'Completed' or 'Exception'). iDRAC jobs report their JobState instead (i.e. 'Scheduled', 'Completed' or 'Failed')",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the task (i.e. 'OK' or 'Critical')",
+			},
+			"percent_complete": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Percentage of the task completed",
+			},
+			"start_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the task started",
+			},
+			"end_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the task finished. Empty while it is running",
+			},
+			"messages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Messages reported by the task, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"message_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the message in its registry",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Text of the message",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Severity of the message",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedfishTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	taskURI := d.Get("task_uri").(string)
+	if v, ok := d.GetOk("task_id"); ok {
+		taskURI = common.TaskURI(v.(string))
+	}
+
+	task, err := common.GetTask(conn, taskURI)
+	if err != nil {
+		return diag.Errorf("error fetching task %s: %s", taskURI, err)
+	}
+
+	messages := []map[string]interface{}{}
+	for _, message := range task.Messages {
+		messages = append(messages, map[string]interface{}{
+			"message_id": message.MessageID,
+			"message":    message.Message,
+			"severity":   message.Severity,
+		})
+	}
+	values := map[string]interface{}{
+		"name":             task.Name,
+		"state":            task.State,
+		"status":           task.Status,
+		"percent_complete": task.PercentComplete,
+		"start_time":       task.StartTime,
+		"end_time":         task.EndTime,
+		"messages":         messages,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	d.SetId(task.ODataID)
+
+	return diags
+}
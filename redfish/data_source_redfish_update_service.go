@@ -0,0 +1,83 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRedfishUpdateService() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRedfishUpdateServiceRead,
+		Schema: map[string]*schema.Schema{
+			"service_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the update service is enabled",
+			},
+			"http_push_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI images can be POSTed to as the request body. Empty when not supported",
+			},
+			"multipart_http_push_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI images can be POSTed to as multipart/form-data. Empty when not supported",
+			},
+			"simple_update_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the BMC can pull images with the SimpleUpdate action",
+			},
+			"transfer_protocols": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Protocols SimpleUpdate can pull images with (i.e. HTTP, HTTPS, NFS or CIFS)",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_image_size_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Largest image the update service accepts. 0 when the BMC does not report it",
+			},
+		},
+	}
+}
+
+func dataSourceRedfishUpdateServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*providerConfig).clientWithContext(ctx)
+
+	capabilities, err := common.GetUpdateServiceCapabilities(conn)
+	if err != nil {
+		return diag.Errorf("error fetching the update service: %s", err)
+	}
+
+	if err := d.Set("service_enabled", capabilities.ServiceEnabled); err != nil {
+		return diag.Errorf("error setting service_enabled: %s", err)
+	}
+	if err := d.Set("http_push_uri", capabilities.HTTPPushURI); err != nil {
+		return diag.Errorf("error setting http_push_uri: %s", err)
+	}
+	if err := d.Set("multipart_http_push_uri", capabilities.MultipartHTTPPushURI); err != nil {
+		return diag.Errorf("error setting multipart_http_push_uri: %s", err)
+	}
+	if err := d.Set("simple_update_supported", len(capabilities.SimpleUpdateURI) > 0); err != nil {
+		return diag.Errorf("error setting simple_update_supported: %s", err)
+	}
+	if err := d.Set("transfer_protocols", capabilities.TransferProtocols); err != nil {
+		return diag.Errorf("error setting transfer_protocols: %s", err)
+	}
+	if err := d.Set("max_image_size_bytes", int(capabilities.MaxImageSizeBytes)); err != nil {
+		return diag.Errorf("error setting max_image_size_bytes: %s", err)
+	}
+
+	d.SetId(conn.Service.ODataID + "#update_service")
+
+	return diags
+}
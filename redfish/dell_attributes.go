@@ -0,0 +1,79 @@
+package redfish
+
+import (
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// dellAttributeMapping maps the terraform input variables of a resource to the Dell attributes they manage.
+// TypeBool variables are stored as "Enabled"/"Disabled" and TypeInt variables as integers.
+// String variables whose values the BMC normalizes should use suppressEquivalentValue.
+type dellAttributeMapping map[string]string
+
+// updateDellAttributes sends the variables set in the configuration to the Dell attributes resource at uri.
+// Variables not set are left untouched on the BMC.
+func updateDellAttributes(conn *gofish.APIClient, d *schema.ResourceData, uri string, mapping dellAttributeMapping) error {
+	payload := make(map[string]interface{})
+	for key, attribute := range mapping {
+		// GetOkExists is needed so variables explicitly set to false or 0 are sent too
+		value, ok := d.GetOkExists(key)
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case bool:
+			if v {
+				payload[attribute] = "Enabled"
+			} else {
+				payload[attribute] = "Disabled"
+			}
+		default:
+			payload[attribute] = v
+		}
+	}
+	if len(payload) == 0 {
+		log.Printf("[DEBUG] %s: No attributes to update", uri)
+		return nil
+	}
+	log.Printf("[DEBUG] %s: Updating attributes %v", uri, payload)
+	return common.PatchDellAttributes(conn, uri, payload)
+}
+
+// readDellAttributes sets the variables of a resource from the Dell attributes resource at uri.
+func readDellAttributes(conn *gofish.APIClient, d *schema.ResourceData, uri string, mapping dellAttributeMapping) error {
+	attributes, err := common.GetDellAttributes(conn, uri)
+	if err != nil {
+		return err
+	}
+	for key, attribute := range mapping {
+		value, ok := attributes[attribute]
+		if !ok {
+			log.Printf("[DEBUG] %s: Attribute %s not found", uri, attribute)
+			continue
+		}
+		var err error
+		switch d.Get(key).(type) {
+		case bool:
+			// Some iDRAC versions return the values in other case
+			err = d.Set(key, strings.EqualFold(value, "Enabled"))
+		case int:
+			// Integers might be returned as strings or floats (i.e. "5901" or 5901.0)
+			floatValue, convErr := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if convErr != nil || floatValue != float64(int(floatValue)) {
+				return fmt.Errorf("attribute %s is not an integer: %s", attribute, value)
+			}
+			err = d.Set(key, int(floatValue))
+		default:
+			err = d.Set(key, value)
+		}
+		if err != nil {
+			return fmt.Errorf("error setting %s: %s", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package redfish
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// suppressEquivalentValue is the DiffSuppressFunc of the values BMCs normalize when storing them, so the
+// value read back differs from the configured one only in format. See equivalentValues.
+func suppressEquivalentValue(k, old, new string, d *schema.ResourceData) bool {
+	return equivalentValues(old, new)
+}
+
+// suppressEquivalentJSON is the DiffSuppressFunc of JSON documents, which are equivalent when they have the
+// same structure and their values are equivalent (see equivalentValues), whatever their format or key order.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return equivalentJSON(oldValue, newValue)
+}
+
+// equivalentValues reports if two values only differ in the way BMCs normalize them:
+//   - case (i.e. Enabled and enabled)
+//   - number format, including numbers returned as strings (i.e. 010, 10 and 10.0)
+//   - MAC address case and separators (i.e. 00:1A:2B:3C:4D:5E and 00-1a-2b-3c-4d-5e)
+//   - IP address format and a prefix only set on one side (i.e. 192.168.0.10 and 192.168.0.10/24)
+func equivalentValues(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if a == "" || b == "" {
+		return false
+	}
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		y, err := strconv.ParseFloat(b, 64)
+		return err == nil && x == y
+	}
+	if x, err := net.ParseMAC(a); err == nil {
+		y, err := net.ParseMAC(b)
+		return err == nil && bytes.Equal(x, y)
+	}
+	if x, xPrefix := parseIPValue(a); x != nil {
+		y, yPrefix := parseIPValue(b)
+		return y != nil && x.Equal(y) && (xPrefix == yPrefix || xPrefix == "" || yPrefix == "")
+	}
+	return false
+}
+
+// parseIPValue parses an IP address with an optional prefix length, returning nil if value is not one
+func parseIPValue(value string) (net.IP, string) {
+	prefix := ""
+	if i := strings.Index(value, "/"); i >= 0 {
+		value, prefix = value[:i], value[i+1:]
+		if _, err := strconv.Atoi(prefix); err != nil {
+			return nil, ""
+		}
+	}
+	return net.ParseIP(value), prefix
+}
+
+func equivalentJSON(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equivalentJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equivalentJSON(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if _, ok := b.(map[string]interface{}); ok {
+		return false
+	}
+	if _, ok := b.([]interface{}); ok {
+		return false
+	}
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return equivalentValues(jsonScalarString(a), jsonScalarString(b))
+}
+
+// jsonScalarString formats a decoded JSON string, number or boolean as a string
+func jsonScalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
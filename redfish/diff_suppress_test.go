@@ -0,0 +1,59 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestEquivalentValues(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		a        string
+		b        string
+		expected bool
+	}{
+		{1, "Enabled", "Enabled", true},
+		{2, "Enabled", "enabled", true},
+		{3, "Enabled", "Disabled", false},
+		{4, "010", "10", true},
+		{5, "10", "10.0", true},
+		{6, "10", "11", false},
+		{7, "10", "ten", false},
+		{8, "00:1A:2B:3C:4D:5E", "00-1a-2b-3c-4d-5e", true},
+		{9, "00:1A:2B:3C:4D:5E", "00:1A:2B:3C:4D:5F", false},
+		{10, "192.168.0.10", "192.168.0.10/24", true},
+		{11, "192.168.0.10/24", "192.168.0.10/16", false},
+		{12, "192.168.0.10", "192.168.0.11", false},
+		{13, "2001:DB8::1", "2001:db8:0:0:0:0:0:1", true},
+		{14, "", "0", false},
+		{15, "", "", true},
+	}
+	for _, v := range cases {
+		if got := equivalentValues(v.a, v.b); got != v.expected {
+			t.Errorf("Test number %v: expected %v for %q and %q, got %v", v.noTest, v.expected, v.a, v.b, got)
+		}
+	}
+}
+
+func TestSuppressEquivalentJSON(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		old      string
+		new      string
+		expected bool
+	}{
+		{1, `{"A":"Enabled","B":5}`, `{"B":5,"A":"Enabled"}`, true},
+		{2, `{"Attributes":{"A":"enabled"}}`, `{"Attributes":{"A":"Enabled"}}`, true},
+		{3, `{"A":"5901"}`, `{"A":5901}`, true},
+		{4, `{"A":[1,2]}`, `{"A":["1","2"]}`, true},
+		{5, `{"A":[1,2]}`, `{"A":[2,1]}`, false},
+		{6, `{"A":"Enabled"}`, `{"A":"Enabled","B":"Enabled"}`, false},
+		{7, `{"A":{"B":1}}`, `{"A":1}`, false},
+		{8, `{"A":null}`, `{"A":""}`, false},
+		{9, `{"A":1}`, `not json`, false},
+	}
+	for _, v := range cases {
+		if got := suppressEquivalentJSON("body", v.old, v.new, nil); got != v.expected {
+			t.Errorf("Test number %v: expected %v for %s and %s, got %v", v.noTest, v.expected, v.old, v.new, got)
+		}
+	}
+}
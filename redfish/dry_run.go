@@ -0,0 +1,333 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dryRunResources are the disruptive resources supporting dry_run: they reboot the server, flash it or erase data
+var dryRunResources = []string{
+	"redfish_bios",
+	"redfish_storage_volume",
+	"redfish_firmware_update",
+	"redfish_bios_default_reset",
+	"redfish_boot_to_bios_setup",
+	"redfish_crypto_erase_system",
+	"redfish_virtual_disk_initialize",
+	"redfish_memory_settings",
+	"redfish_os_deployment",
+	"redfish_backup_restore",
+	"redfish_server_profile",
+}
+
+// dryRunMarker is part of the URIs of the tasks and resources the dry runs pretend to create
+const dryRunMarker = "DryRun."
+
+// dryRunRedactedKeys are the parts of the names of the properties whose values are hidden from the planned requests
+var dryRunRedactedKeys = []string{"password", "passphrase", "secret", "token", "privatekey", "credential"}
+
+// dryRunWaitThreshold is the pause between two requests of a workflow from which it is considered to be waiting for the BMC.
+// It is below the 10 seconds the workflows sleep between two polls (common.TimeBetweenAttempts), and well above the time they
+// take to process a response, even on a loaded terraform host.
+const dryRunWaitThreshold = 8 * time.Second
+
+// errDryRunStopped is returned to the workflow when it needs an outcome the dry run cannot provide
+var errDryRunStopped = errors.New("stopped by dry_run")
+
+// plannedRequest is a request the resource would have sent to the BMC
+type plannedRequest struct {
+	method string
+	uri    string
+	body   string
+}
+
+// dryRunTransport is an http.RoundTripper recording the requests changing the BMC instead of sending them.
+// GETs are sent, so the workflow plans against the current state of the BMC. The requests recorded are answered
+// with 202 Accepted and, as they are often expected to start a task, the Location of a fake task. The workflow is
+// stopped as soon as it waits for an outcome: when it reads a fake URI, or reads anything after pausing (i.e. to poll
+// the power state) once a request has been recorded.
+type dryRunTransport struct {
+	base http.RoundTripper
+	// lock protects the fields below, shared by requests running in parallel
+	lock     sync.Mutex
+	requests []plannedRequest
+	// lastRequest is when the workflow sent its last request
+	lastRequest time.Time
+	// stopped is the reason the workflow was stopped, empty if it ran to the end
+	stopped string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if err := t.checkRead(req.URL.Path); err != nil {
+			return nil, err
+		}
+		resp, err := base.RoundTrip(req)
+		t.lock.Lock()
+		t.lastRequest = time.Now()
+		t.lock.Unlock()
+		return resp, err
+	}
+	body, err := sanitizeRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.stopped != "" {
+		return nil, errDryRunStopped
+	}
+	t.requests = append(t.requests, plannedRequest{method: req.Method, uri: req.URL.RequestURI(), body: body})
+	t.lastRequest = time.Now()
+	log.Printf("[DEBUG] dry_run: not sending %s %s", req.Method, req.URL.Path)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Location", "/redfish/v1/TaskService/Tasks/"+dryRunMarker+strconv.Itoa(len(t.requests)))
+	return &http.Response{
+		Status:     "202 Accepted",
+		StatusCode: http.StatusAccepted,
+		Proto:      req.Proto,
+		ProtoMajor: req.ProtoMajor,
+		ProtoMinor: req.ProtoMinor,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// checkRead stops the workflow when the GET of path means it waits for the outcome of the planned requests
+func (t *dryRunTransport) checkRead(path string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.stopped != "" {
+		return errDryRunStopped
+	}
+	if strings.Contains(path, dryRunMarker) {
+		t.stopped = fmt.Sprintf("the workflow waits for %s, which the BMC would have created. The next requests depend on its outcome", path)
+		return errDryRunStopped
+	}
+	if len(t.requests) > 0 && time.Since(t.lastRequest) > dryRunWaitThreshold {
+		t.stopped = fmt.Sprintf("the workflow waits for the BMC to carry out the planned requests, polling %s. The next requests depend on their outcome", path)
+		return errDryRunStopped
+	}
+	return nil
+}
+
+// sanitizeRequestBody returns the body of req as it is shown in the planned requests: JSON bodies with the
+// credentials redacted, and the size of the rest (i.e. the firmware images)
+func sanitizeRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	defer req.Body.Close()
+	if !strings.Contains(req.Header.Get("Content-Type"), "json") {
+		size, err := io.Copy(ioutil.Discard, req.Body)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("<%d bytes of %s>", size, req.Header.Get("Content-Type")), nil
+	}
+	raw, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return fmt.Sprintf("<%d bytes of invalid JSON>", len(raw)), nil
+	}
+	sanitized, err := json.Marshal(redactCredentials(body))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(sanitized)), nil
+}
+
+// redactCredentials hides the values of the credential properties and the passwords of the URLs (i.e. ImageURI) of a JSON value
+func redactCredentials(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, property := range v {
+			redacted := false
+			for _, sensitive := range dryRunRedactedKeys {
+				if strings.Contains(strings.ToLower(key), sensitive) {
+					redacted = true
+				}
+			}
+			if redacted {
+				v[key] = "REDACTED"
+			} else {
+				v[key] = redactCredentials(property)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactCredentials(item)
+		}
+	case string:
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), "REDACTED")
+				return u.String()
+			}
+		}
+	}
+	return value
+}
+
+// dryRunSchema returns the attributes withDryRun adds to the disruptive resources
+func dryRunSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"dry_run": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Instead of changing the server, record the requests the resource would send to the BMC in planned_requests, for change reviews. The BMC is still read. Nothing is changed on destroy either. Setting it back to false replaces the resource, carrying out the requests",
+		},
+		"planned_requests": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Requests the resource would have sent to the BMC, when dry_run is set. Credentials are redacted from the bodies",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"method": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "HTTP method of the request",
+					},
+					"uri": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "URI of the request",
+					},
+					"body": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Body of the request. JSON bodies are shown whole, the rest by their size",
+					},
+				},
+			},
+		},
+		"dry_run_stopped": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Why the dry run stopped before the end of the workflow (i.e. it would wait for a job), so planned_requests only holds the requests up to that point. Empty if the whole workflow was planned",
+		},
+	}
+}
+
+// withDryRun adds dry_run to the resources of dryRunResources. Their Create and Update run with a copy of the
+// provider configuration recording the requests changing the BMC, while their Read and Delete do nothing.
+func withDryRun(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, name := range dryRunResources {
+		resource, ok := resources[name]
+		if !ok {
+			continue
+		}
+		for key, s := range dryRunSchema() {
+			resource.Schema[key] = s
+		}
+		resource.CreateContext = dryRunGuard(resource.CreateContext)
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = dryRunGuard(resource.UpdateContext)
+		}
+		resource.ReadContext = dryRunSkip(resource.ReadContext)
+		resource.DeleteContext = dryRunSkip(resource.DeleteContext)
+	}
+	return resources
+}
+
+// dryRunGuard runs f recording its requests when dry_run is set
+func dryRunGuard(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if !d.Get("dry_run").(bool) {
+			return f(ctx, d, m)
+		}
+		recorder := &dryRunTransport{base: m.(*providerConfig).client.HTTPClient.Transport}
+		diags := f(ctx, d, m.(*providerConfig).dryRunConfig(recorder))
+		if diags.HasError() {
+			switch {
+			case recorder.stopped != "":
+			case len(recorder.requests) > 0:
+				// The workflow needed something of the responses of the requests it planned
+				recorder.stopped = "the workflow could not go on without the responses of the BMC: " + diagsSummary(diags)
+			default:
+				// Nothing was planned, so the workflow would have failed too
+				return diags
+			}
+		}
+		if d.Id() == "" {
+			d.SetId("dry-run")
+		}
+		requests := []map[string]interface{}{}
+		for _, request := range recorder.requests {
+			requests = append(requests, map[string]interface{}{"method": request.method, "uri": request.uri, "body": request.body})
+		}
+		if err := d.Set("planned_requests", requests); err != nil {
+			return diag.Errorf("error setting planned_requests: %s", err)
+		}
+		if err := d.Set("dry_run_stopped", recorder.stopped); err != nil {
+			return diag.Errorf("error setting dry_run_stopped: %s", err)
+		}
+		log.Printf("[DEBUG] %s: dry_run planned %d requests", d.Id(), len(requests))
+		return nil
+	}
+}
+
+// dryRunSkip skips f when dry_run is set, as nothing was changed on the BMC
+func dryRunSkip(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if d.Get("dry_run").(bool) {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// diagsSummary joins the summaries of the errors of diags
+func diagsSummary(diags diag.Diagnostics) string {
+	summaries := []string{}
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+	return strings.Join(summaries, "; ")
+}
+
+// dryRunConfig returns a copy of the provider configuration whose requests go through recorder.
+// Nothing is recorded in the operation log nor notified, as no operation is carried out.
+func (p *providerConfig) dryRunConfig(recorder *dryRunTransport) *providerConfig {
+	client := *p.client
+	httpClient := *p.client.HTTPClient
+	httpClient.Transport = recorder
+	client.HTTPClient = &httpClient
+	service := *p.client.Service
+	service.SetClient(&client)
+	client.Service = &service
+
+	c := *p
+	c.client = &client
+	c.operationLogFile = ""
+	c.notifications = nil
+	return &c
+}
@@ -0,0 +1,34 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"log"
+	"sync"
+)
+
+var (
+	// firmwareCatalogs are the catalogs downloaded during this run of the provider, by URL
+	firmwareCatalogs = make(map[string]*common.Catalog)
+	// firmwareCatalogsLock protects firmwareCatalogs. It is held during the download, so the resources reading
+	// the same catalog in parallel download it once
+	firmwareCatalogsLock sync.Mutex
+)
+
+// fetchFirmwareCatalog returns the catalog at catalogURL, downloading it once per run of the provider. Repository
+// catalogs weigh tens of MB, and every refresh of the redfish_firmware_update resources using them reads them.
+// The catalogs are not expected to change while terraform runs, and the resources only read them.
+func fetchFirmwareCatalog(ctx context.Context, catalogURL string) (*common.Catalog, error) {
+	firmwareCatalogsLock.Lock()
+	defer firmwareCatalogsLock.Unlock()
+	if catalog, ok := firmwareCatalogs[catalogURL]; ok {
+		log.Printf("[DEBUG] Catalog %s already downloaded", catalogURL)
+		return catalog, nil
+	}
+	catalog, err := common.FetchCatalog(ctx, catalogURL)
+	if err != nil {
+		return nil, err
+	}
+	firmwareCatalogs[catalogURL] = catalog
+	return catalog, nil
+}
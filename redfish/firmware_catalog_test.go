@@ -0,0 +1,44 @@
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchFirmwareCatalog(t *testing.T) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		if r.URL.Path == "/missing.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`<Manifest version="1.0"><SoftwareComponent packageID="ABC12"/></Manifest>`))
+	}))
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		catalog, err := fetchFirmwareCatalog(context.Background(), server.URL+"/Catalog.xml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(catalog.Components) != 1 || catalog.Components[0].PackageID != "ABC12" {
+			t.Errorf("unexpected catalog %v", catalog)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("expected the catalog to be downloaded once, got %v downloads", downloads)
+	}
+
+	// Failed downloads are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := fetchFirmwareCatalog(context.Background(), server.URL+"/missing.xml"); err == nil {
+			t.Errorf("the download of a missing catalog did not fail")
+		}
+	}
+	if downloads != 3 {
+		t.Errorf("expected the failed downloads to be retried, got %v downloads", downloads)
+	}
+}
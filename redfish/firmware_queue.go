@@ -0,0 +1,91 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var (
+	// firmwareQueues are the queues of the firmware updates, by BMC
+	firmwareQueues = make(map[string]*firmwareQueue)
+	// firmwareQueuesLock protects firmwareQueues
+	firmwareQueuesLock sync.Mutex
+)
+
+// firmwareQueue serializes the firmware updates of the resources targeting the same BMC. BMCs reject an update
+// job while another one is running ("another update in progress"), which the parallelism of terraform would
+// otherwise trigger whenever several redfish_firmware_update resources apply at once. The updates run one at
+// a time, in the order they started waiting.
+type firmwareQueue struct {
+	endpoint string
+	// slot is held by the running update
+	slot chan struct{}
+	// lock protects waiting
+	lock    sync.Mutex
+	waiting int
+}
+
+// getFirmwareQueue returns the queue of the firmware updates of the BMC at endpoint
+func getFirmwareQueue(endpoint string) *firmwareQueue {
+	key := strings.ToLower(endpoint)
+	if u, err := url.Parse(key); err == nil && u.Host != "" {
+		key = u.Host
+	}
+	firmwareQueuesLock.Lock()
+	defer firmwareQueuesLock.Unlock()
+	queue, ok := firmwareQueues[key]
+	if !ok {
+		queue = &firmwareQueue{endpoint: endpoint, slot: make(chan struct{}, 1)}
+		firmwareQueues[key] = queue
+	}
+	return queue
+}
+
+// acquire waits until the updates queued before are done. It stops waiting as soon as ctx is done,
+// so the time spent in the queue counts against the timeout of the resource.
+func (q *firmwareQueue) acquire(ctx context.Context) error {
+	select {
+	case q.slot <- struct{}{}:
+		return nil
+	default:
+	}
+	q.lock.Lock()
+	q.waiting++
+	log.Printf("[INFO] Waiting for the firmware update running on %s to finish, %d update(s) queued", q.endpoint, q.waiting)
+	q.lock.Unlock()
+	defer func() {
+		q.lock.Lock()
+		q.waiting--
+		q.lock.Unlock()
+	}()
+	select {
+	case q.slot <- struct{}{}:
+		log.Printf("[DEBUG] Starting the queued firmware update of %s", q.endpoint)
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for the other firmware updates of %s to finish: %s", q.endpoint, ctx.Err())
+	}
+}
+
+// release lets the next queued update run
+func (q *firmwareQueue) release() {
+	<-q.slot
+}
+
+// withFirmwareQueue makes f wait for the other firmware updates of the BMC to finish before running
+func withFirmwareQueue(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		queue := getFirmwareQueue(m.(*providerConfig).endpoint)
+		if err := queue.acquire(ctx); err != nil {
+			return diag.FromErr(err)
+		}
+		defer queue.release()
+		return f(ctx, d, m)
+	}
+}
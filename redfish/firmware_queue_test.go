@@ -0,0 +1,46 @@
+package redfish
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFirmwareQueue(t *testing.T) {
+	queue := getFirmwareQueue("https://192.168.10.20:443")
+	if getFirmwareQueue("https://192.168.10.20:443/") != queue || getFirmwareQueue("HTTPS://192.168.10.20:443") != queue {
+		t.Errorf("the same BMC got different queues")
+	}
+	if getFirmwareQueue("https://192.168.10.21") == queue {
+		t.Errorf("another BMC got the same queue")
+	}
+
+	if err := queue.acquire(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := queue.acquire(ctx); err == nil {
+		t.Errorf("two updates of the same BMC ran at once")
+	}
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- queue.acquire(context.Background())
+	}()
+	select {
+	case <-acquired:
+		t.Fatalf("the queued update ran before the running one finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	queue.release()
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the queued update did not run once the running one finished")
+	}
+	queue.release()
+}
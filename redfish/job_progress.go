@@ -0,0 +1,76 @@
+package redfish
+
+import (
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"time"
+)
+
+// lastTaskMessageAttribute is the computed attribute holding the last message of the jobs of a resource
+const lastTaskMessageAttribute = "last_task_message"
+
+// jobProgressInterval is how often the progress of a job is logged when it does not change
+const jobProgressInterval = 60 * time.Second
+
+// jobProgress logs the progress of the jobs a resource waits for and keeps their last message,
+// so the reason of a failure reported by the BMC ends up in the state.
+type jobProgress struct {
+	resource   string
+	last       common.JobProgress
+	lastReport time.Time
+}
+
+func newJobProgress(resource string) *jobProgress {
+	return &jobProgress{resource: resource}
+}
+
+// reporter returns the common.JobProgressFunc for the job at jobURI
+func (p *jobProgress) reporter(jobURI string) common.JobProgressFunc {
+	return func(progress common.JobProgress) {
+		changed := progress != p.last
+		if len(progress.Message) == 0 {
+			// Keep the last message seen, as some BMCs clear it when the job moves on
+			progress.Message = p.last.Message
+		}
+		p.last = progress
+		if !changed && time.Since(p.lastReport) < jobProgressInterval {
+			return
+		}
+		p.lastReport = time.Now()
+		log.Printf("[INFO] %s: job %s is %s, %d%% complete: %s", p.resource, jobURI, progress.State, progress.PercentComplete, progress.Message)
+	}
+}
+
+// save stores the last job message in the state of the resource
+func (p *jobProgress) save(d *schema.ResourceData) {
+	if len(p.last.Message) == 0 {
+		return
+	}
+	if err := d.Set(lastTaskMessageAttribute, p.last.Message); err != nil {
+		log.Printf("[DEBUG] %s: error setting %s: %s", d.Id(), lastTaskMessageAttribute, err)
+	}
+}
+
+// warning returns the last progress of the jobs as a warning, for the operations whose state is not kept (i.e. Delete)
+func (p *jobProgress) warning() diag.Diagnostics {
+	if len(p.last.State) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Last progress of the %s jobs", p.resource),
+		Detail:   fmt.Sprintf("%s, %d%% complete: %s", p.last.State, p.last.PercentComplete, p.last.Message),
+	}}
+}
+
+// lastTaskMessageSchema is the schema of the attribute holding the last job message of a resource
+func lastTaskMessageSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Last message reported by the BMC for the jobs of the resource, which holds the reason when a job fails",
+	}
+}
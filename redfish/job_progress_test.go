@@ -0,0 +1,38 @@
+package redfish
+
+import (
+	"github.com/dell/terraform-provider-redfish/common"
+	"testing"
+)
+
+func TestJobProgressReporter(t *testing.T) {
+	progress := newJobProgress("redfish_firmware_update")
+	report := progress.reporter("/redfish/v1/TaskService/Tasks/JID_000000000001")
+	cases := []struct {
+		noTest   int
+		progress common.JobProgress
+		expected string
+	}{
+		{1, common.JobProgress{State: "Running", PercentComplete: 10, Message: "Downloading"}, "Downloading"},
+		{2, common.JobProgress{State: "Running", PercentComplete: 50}, "Downloading"},
+		{3, common.JobProgress{State: "Exception", PercentComplete: 100, Message: "The image is not valid"}, "The image is not valid"},
+	}
+	for _, v := range cases {
+		report(v.progress)
+		if progress.last.Message != v.expected {
+			t.Errorf("Test number %v: expected last message %q, got %q", v.noTest, v.expected, progress.last.Message)
+		}
+	}
+}
+
+func TestJobProgressWarning(t *testing.T) {
+	progress := newJobProgress("redfish_storage_volume")
+	if diags := progress.warning(); diags != nil {
+		t.Errorf("Expected no warning before any progress, got %+v", diags)
+	}
+	progress.reporter("/redfish/v1/TaskService/Tasks/JID_000000000001")(common.JobProgress{State: "Running", PercentComplete: 40, Message: "Deleting the volume"})
+	diags := progress.warning()
+	if len(diags) != 1 || diags[0].Detail != "Running, 40% complete: Deleting the volume" {
+		t.Errorf("Unexpected warning %+v", diags)
+	}
+}
@@ -0,0 +1,118 @@
+package redfish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net/http"
+	"time"
+)
+
+// notificationTimeout bounds the requests to the webhooks, so an unreachable one does not hold the apply
+const notificationTimeout = 10 * time.Second
+
+// defaultNotificationEvents are the actions notified when a notification block does not set events
+var defaultNotificationEvents = []string{"job_completion"}
+
+// notification is a webhook the operation records are POSTed to, as JSON, when their action is one of events
+type notification struct {
+	url     string
+	headers map[string]string
+	events  []string
+}
+
+// notificationSchema is the schema of the notification blocks of the provider
+func notificationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Webhooks the provider POSTs a JSON summary to when an operation against the BMC finishes (i.e. a firmware job or a RAID creation), to integrate the applies with chat or ticketing tools. The summary holds the same fields as the lines of operation_log_file",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "URL of the webhook",
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+				"headers": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Headers sent with the requests, i.e. an Authorization token",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"events": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Actions notified, as reported in the operation_log of the resources (i.e. 'job_completion', 'firmware_push', 'reset' or 'bios_reset'). Defaults to 'job_completion', recorded when a job or task finishes, successfully or not",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// expandNotifications reads the notification blocks of the provider configuration
+func expandNotifications(d *schema.ResourceData) []notification {
+	notifications := []notification{}
+	for _, raw := range d.Get("notification").([]interface{}) {
+		n := raw.(map[string]interface{})
+		headers := map[string]string{}
+		for key, value := range n["headers"].(map[string]interface{}) {
+			headers[key] = value.(string)
+		}
+		events := []string{}
+		for _, event := range n["events"].([]interface{}) {
+			events = append(events, event.(string))
+		}
+		if len(events) == 0 {
+			events = defaultNotificationEvents
+		}
+		notifications = append(notifications, notification{url: n["url"].(string), headers: headers, events: events})
+	}
+	return notifications
+}
+
+// notify POSTs the record to the webhooks notified of its action. A failing webhook does not fail the
+// operation, as the action has already been performed, but it is logged.
+func notify(notifications []notification, record operationRecord) {
+	for _, n := range notifications {
+		if !common.ContainsFold(n.events, record.Action) {
+			continue
+		}
+		if err := n.send(record); err != nil {
+			log.Printf("[WARN] error notifying %s of %s on %s: %s", n.url, record.Action, record.Target, err)
+		}
+	}
+}
+
+// send POSTs the record to the webhook
+func (n notification) send(record operationRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+	client := &http.Client{Timeout: notificationTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("the webhook answered with status code %d", resp.StatusCode)
+	}
+	return nil
+}
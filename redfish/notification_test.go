@@ -0,0 +1,64 @@
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	received := []operationRecord{}
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var record operationRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, record)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	config := &providerConfig{
+		endpoint: "https://192.168.10.10",
+		notifications: []notification{
+			{url: webhook.URL, headers: map[string]string{"Authorization": "Bearer token"}, events: []string{"job_completion", "reset"}},
+			{url: failing.URL, headers: map[string]string{}, events: defaultNotificationEvents},
+		},
+	}
+	opLog := newOperationLog(config, "redfish_firmware_update")
+	opLog.record("firmware_push", "http://repo/BIOS.EXE", "/redfish/v1/TaskService/Tasks/JID_1", nil)
+	opLog.record("job_completion", "http://repo/BIOS.EXE", "/redfish/v1/TaskService/Tasks/JID_1", fmt.Errorf("job failed"))
+	opLog.record("reset", "/redfish/v1/Systems/System.Embedded.1", "", nil)
+
+	cases := []struct {
+		noTest int
+		action string
+		status string
+	}{
+		{1, "job_completion", "Failed"},
+		{2, "reset", "Succeeded"},
+	}
+	if len(received) != len(cases) {
+		t.Fatalf("Expected %v notifications, got %+v", len(cases), received)
+	}
+	for i, v := range cases {
+		record := received[i]
+		if record.Action != v.action || record.Status != v.status || record.Endpoint != config.endpoint || record.Resource != "redfish_firmware_update" {
+			t.Errorf("Test number %v: unexpected notification %+v", v.noTest, record)
+		}
+	}
+	if len(opLog.records) != 3 {
+		t.Errorf("a failing webhook stopped the operation log: %+v", opLog.records)
+	}
+}
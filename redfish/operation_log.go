@@ -0,0 +1,145 @@
+package redfish
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// operationLogAttribute is the computed attribute holding the actions performed by the last apply of a resource
+const operationLogAttribute string = "operation_log"
+
+// operationLogFileLock serializes the writes of the resources to the operation_log_file
+var operationLogFileLock sync.Mutex
+
+// operationRecord is an action performed against the BMC (i.e. a firmware push, a reset or a job completion)
+type operationRecord struct {
+	Timestamp string `json:"timestamp"`
+	Endpoint  string `json:"endpoint"`
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	JobURI    string `json:"job_uri,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// operationLog collects the actions performed by a resource during an operation.
+// Every action is appended as a JSON line to operation_log_file, if set in the provider, and sent
+// to the notification webhooks of its action. The whole log is stored in the operation_log attribute by save.
+type operationLog struct {
+	config   *providerConfig
+	resource string
+	records  []operationRecord
+}
+
+func newOperationLog(m interface{}, resource string) *operationLog {
+	return &operationLog{
+		config:   m.(*providerConfig),
+		resource: resource,
+	}
+}
+
+// record adds an action to the log. A failure writing operation_log_file does not fail the
+// operation, as the action has already been performed, but it is logged.
+func (l *operationLog) record(action string, target string, jobURI string, err error) {
+	record := operationRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Endpoint:  l.config.endpoint,
+		Resource:  l.resource,
+		Action:    action,
+		Target:    target,
+		JobURI:    jobURI,
+		Status:    "Succeeded",
+	}
+	if err != nil {
+		record.Status = "Failed"
+		record.Message = err.Error()
+	}
+	l.records = append(l.records, record)
+	notify(l.config.notifications, record)
+
+	if l.config.operationLogFile == "" {
+		return
+	}
+	line, jsonErr := json.Marshal(record)
+	if jsonErr != nil {
+		log.Printf("[WARN] error encoding operation record: %s", jsonErr)
+		return
+	}
+	operationLogFileLock.Lock()
+	defer operationLogFileLock.Unlock()
+	f, fileErr := os.OpenFile(l.config.operationLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if fileErr != nil {
+		log.Printf("[WARN] error opening operation log file %s: %s", l.config.operationLogFile, fileErr)
+		return
+	}
+	defer f.Close()
+	if _, fileErr = f.Write(append(line, '\n')); fileErr != nil {
+		log.Printf("[WARN] error writing operation log file %s: %s", l.config.operationLogFile, fileErr)
+	}
+}
+
+// save stores the log in the operation_log attribute of the resource
+func (l *operationLog) save(d *schema.ResourceData) {
+	records := []map[string]interface{}{}
+	for _, record := range l.records {
+		records = append(records, map[string]interface{}{
+			"timestamp": record.Timestamp,
+			"action":    record.Action,
+			"target":    record.Target,
+			"job_uri":   record.JobURI,
+			"status":    record.Status,
+			"message":   record.Message,
+		})
+	}
+	if err := d.Set(operationLogAttribute, records); err != nil {
+		log.Printf("[WARN] %s: error setting %s: %s", d.Id(), operationLogAttribute, err)
+	}
+}
+
+// operationLogSchema is the schema of the operation_log attribute
+func operationLogSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Actions performed against the BMC by the last operation of the resource, for auditing purposes",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timestamp": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "When the action finished, in RFC3339 format",
+				},
+				"action": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Action performed (i.e. firmware_push, reset, job_completion)",
+				},
+				"target": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URI or image the action was performed on",
+				},
+				"job_uri": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URI of the job or task created or tracked by the action",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Result of the action, 'Succeeded' or 'Failed'",
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Error message of failed actions",
+				},
+			},
+		},
+	}
+}
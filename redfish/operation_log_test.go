@@ -0,0 +1,57 @@
+package redfish
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOperationLogRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "operation_log")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	config := &providerConfig{
+		endpoint:         "https://192.168.10.10",
+		operationLogFile: filepath.Join(dir, "operations.jsonl"),
+	}
+
+	opLog := newOperationLog(config, "redfish_firmware_update")
+	opLog.record("firmware_push", "http://repo/BIOS.EXE", "/redfish/v1/TaskService/Tasks/JID_1", nil)
+	opLog.record("job_completion", "http://repo/BIOS.EXE", "/redfish/v1/TaskService/Tasks/JID_1", fmt.Errorf("job failed"))
+
+	cases := []struct {
+		noTest int
+		action string
+		status string
+	}{
+		{1, "firmware_push", "Succeeded"},
+		{2, "job_completion", "Failed"},
+	}
+	if len(opLog.records) != len(cases) {
+		t.Fatalf("Expected %v records, got %v", len(cases), len(opLog.records))
+	}
+	f, err := os.Open(config.operationLogFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for _, v := range cases {
+		if !scanner.Scan() {
+			t.Fatalf("Test number %v: line missing in the operation log file", v.noTest)
+		}
+		var record operationRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Test number %v failed %v", v.noTest, err)
+		}
+		if record.Action != v.action || record.Status != v.status || record.Endpoint != config.endpoint {
+			t.Errorf("Test number %v: unexpected record %+v", v.noTest, record)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+// preconditionsAttribute is the block of the disruptive resources checking the state of the server before changing it
+const preconditionsAttribute string = "preconditions"
+
+// preconditionsSchema is the schema of the preconditions block
+func preconditionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Checks of the live state of the server run at the start of the apply. If any fails, the apply is aborted before anything is sent to the server",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"health": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Health the computer system must report. Applicable values are 'OK', 'Warning' and 'Critical'",
+					ValidateFunc: validation.StringInSlice([]string{"OK", "Warning", "Critical"}, false),
+				},
+				"power_state": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Power state the computer system must be in. Applicable values are 'On' and 'Off'",
+					ValidateFunc: validation.StringInSlice([]string{"On", "Off"}, false),
+				},
+				"no_active_jobs": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether the BMC must have no unfinished jobs, including the ones scheduled for the next reboot (i.e. a pending BIOS configuration job)",
+				},
+			},
+		},
+	}
+}
+
+// withPreconditions checks the preconditions of the resource before calling f.
+// It should wrap withLockdownBypass, so nothing is changed on the server (not even the lockdown) when a check fails.
+func withPreconditions(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		preconditions := d.Get(preconditionsAttribute).([]interface{})
+		if len(preconditions) == 0 || preconditions[0] == nil {
+			return f(ctx, d, m)
+		}
+		failed, err := checkPreconditions(ctx, m.(*providerConfig), preconditions[0].(map[string]interface{}))
+		if err != nil {
+			return diag.Errorf("error checking the preconditions: %s", err)
+		}
+		if len(failed) > 0 {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Preconditions not met, nothing was changed on the server",
+				Detail:   strings.Join(failed, "\n"),
+			}}
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// checkPreconditions returns a message for every precondition the server does not meet
+func checkPreconditions(ctx context.Context, config *providerConfig, preconditions map[string]interface{}) ([]string, error) {
+	conn := config.clientWithContext(ctx)
+	failed := []string{}
+
+	health := preconditions["health"].(string)
+	powerState := preconditions["power_state"].(string)
+	if health != "" || powerState != "" {
+		system, err := common.GetSystem(conn, config.systemID)
+		if err != nil {
+			return nil, err
+		}
+		if health != "" && string(system.Status.Health) != health {
+			failed = append(failed, fmt.Sprintf("system %s health is %q, expected %q", system.ID, system.Status.Health, health))
+		}
+		if powerState != "" && string(system.PowerState) != powerState {
+			failed = append(failed, fmt.Sprintf("system %s power state is %q, expected %q", system.ID, system.PowerState, powerState))
+		}
+	}
+
+	if preconditions["no_active_jobs"].(bool) {
+		active, err := config.oem.ActiveJobs(conn)
+		if err != nil {
+			return nil, err
+		}
+		if len(active) > 0 {
+			failed = append(failed, fmt.Sprintf("there are active jobs: %s", strings.Join(active, ", ")))
+		}
+	}
+
+	log.Printf("[DEBUG] Preconditions %v checked, %d failed", preconditions, len(failed))
+	return failed, nil
+}
@@ -1,7 +1,13 @@
 package redfish
 
 import (
+	"errors"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"time"
 )
 
 func Provider() *schema.Provider {
@@ -17,6 +23,13 @@ func Provider() *schema.Provider {
 				Required:    true,
 				Description: "This field is the password related to the user given",
 			},
+			"previous_passwords": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passwords the user had before password, tried in order when the BMC rejects it. It allows rotating the password of the user with redfish_user_account in a single apply: the provider connects with the previous password and switches to the new one once it has been changed. Each switch costs a rejected login, which counts towards the lockout of the BMC",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"redfish_endpoint": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -27,19 +40,167 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "This field indicates if the SSL/TLS certificate must be verified",
 			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				Description:  "Maximum time in seconds a single request to the redfish API can take. It is independent from the timeouts of the resources, which bound whole operations. It includes the waits requested by the service through Retry-After when it is busy (503 or 429). 0 means no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User-Agent of the requests to the redfish API, i.e. to tell the changes of terraform apart in the logs of the BMC or of a proxy. If not set, the one of gofish is sent",
+			},
+			"http_headers": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Extra headers sent with every request to the redfish API, i.e. the authentication or audit tags a proxy or a firewall in front of the BMC requires. The headers authenticating to the BMC cannot be overridden",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateHTTPHeaders,
+			},
+			"cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Time in seconds the responses of the service root and of the services and collections right below it (i.e. /redfish/v1/Systems) are cached, so the resources do not read them again on every operation. The cache is emptied whenever a change is sent to the BMC, and the responses read while a change was being sent are not cached. 0, the default, disables the cache",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"session_auth": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "This field makes the provider authenticate with a Redfish session instead of basic authentication. The session is created again whenever it expires, so operations outliving it (i.e. RAID initializations or firmware jobs) keep polling. " +
+					"The sessions replaced and the last one are deleted, so they do not count against the session limit of the BMC",
+			},
+			"operation_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Local file every action performed against the BMC (firmware pushes, resets, jobs) is appended to as a JSON line, for auditing purposes",
+			},
+			"notification": notificationSchema(),
+			"lockdown_bypass": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "This field allows resources to disable iDRAC System Lockdown while they apply changes, enabling it again afterwards. If not set, resources fail at plan time when System Lockdown is enabled",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "This field makes the provider refuse any change to the server. Plans creating or changing resources fail, as do destroys, while reads and data sources keep working. Meant for compliance scans and drift reports against production hardware",
+			},
+			"lock_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Directory shared by every workspace managing the server (i.e. an NFS mount) where the provider keeps a lock file from its first change until the end of the run of terraform, so only one apply changes the BMC at a time and their jobs do not collide. If not set, no lock is taken",
+			},
+			"lock_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      600,
+				Description:  "Maximum time in seconds an apply waits for the lock of lock_path, held by another workspace, before failing. Locks whose holder stopped refreshing them for a minute are taken over, so it should be longer than that",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"system_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Id of the computer system the resources manage (i.e. System.Embedded.1 or 1), for BMCs exposing several ones, such as blade chassis and multi-node trays. Defaults to the first member of the Systems collection",
+			},
+			"manager_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Id of the manager the resources manage (i.e. iDRAC.Embedded.1 or 1), for BMCs exposing several ones. Defaults to the first member of the Managers collection",
+			},
+			"vendor_override": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Vendor whose OEM extensions are used (i.e. dell or hpe), instead of the one detected from the service root. Meant for testing against emulators",
+				ValidateFunc: validation.StringInSlice(common.OEMVendors(), true),
+			},
 		},
 
-		ResourcesMap: map[string]*schema.Resource{
-			"redfish_user_account":   resourceUserAccount(),
-			"redfish_bios":           resourceRedfishBios(),
-			"redfish_storage_volume": resourceRedfishStorageVolume(),
-		},
+		ResourcesMap: withReadOnly(withBMCLock(withDryRun(withStateUpgrades(map[string]*schema.Resource{
+			"redfish_user_account":                   resourceUserAccount(),
+			"redfish_bios":                           resourceRedfishBios(),
+			"redfish_storage_volume":                 resourceRedfishStorageVolume(),
+			"redfish_firmware_update":                resourceRedfishFirmwareUpdate(),
+			"redfish_idrac_lcd":                      resourceRedfishIdracLcd(),
+			"redfish_power_on_delay":                 resourceRedfishPowerOnDelay(),
+			"redfish_system_lockdown":                resourceRedfishSystemLockdown(),
+			"redfish_bios_password":                  resourceRedfishBiosPassword(),
+			"redfish_boot_order_lock":                resourceRedfishBootOrderLock(),
+			"redfish_idrac_vnc":                      resourceRedfishIdracVnc(),
+			"redfish_hostinterface":                  resourceRedfishHostInterface(),
+			"redfish_server_profile":                 resourceRedfishServerProfile(),
+			"redfish_chassis_psu":                    resourceRedfishChassisPsu(),
+			"redfish_security_protocols":             resourceRedfishSecurityProtocols(),
+			"redfish_idrac_auto_config":              resourceRedfishIdracAutoConfig(),
+			"redfish_virtual_disk_initialize":        resourceRedfishVirtualDiskInitialize(),
+			"redfish_virtual_disk_consistency_check": resourceRedfishVirtualDiskConsistencyCheck(),
+			"redfish_group_manager":                  resourceRedfishGroupManager(),
+			"redfish_attribute":                      resourceRedfishAttribute(),
+			"redfish_boot_to_bios_setup":             resourceRedfishBootToBiosSetup(),
+			"redfish_usb_ports":                      resourceRedfishUsbPorts(),
+			"redfish_clear_pending":                  resourceRedfishClearPending(),
+			"redfish_kmip":                           resourceRedfishKmip(),
+			"redfish_gpu":                            resourceRedfishGpu(),
+			"redfish_idrac_telemetry":                resourceRedfishIdracTelemetry(),
+			"redfish_os_deployment":                  resourceRedfishOSDeployment(),
+			"redfish_watchdog":                       resourceRedfishWatchdog(),
+			"redfish_serial_over_lan":                resourceRedfishSerialOverLan(),
+			"redfish_backup_restore":                 resourceRedfishBackupRestore(),
+			"redfish_thermal_profile":                resourceRedfishThermalProfile(),
+			"redfish_pci_slot":                       resourceRedfishPciSlot(),
+			"redfish_crypto_erase_system":            resourceRedfishCryptoEraseSystem(),
+			"redfish_virtual_network":                resourceRedfishVirtualNetwork(),
+			"redfish_idrac_quick_sync":               resourceRedfishIdracQuickSync(),
+			"redfish_bios_default_reset":             resourceRedfishBiosDefaultReset(),
+			"redfish_memory_settings":                resourceRedfishMemorySettings(),
+			"redfish_location":                       resourceRedfishLocation(),
+			"redfish_certificate_trust_store":        resourceRedfishCertificateTrustStore(),
+			"redfish_host_name_dns":                  resourceRedfishHostNameDNS(),
+			"redfish_idrac_lockout":                  resourceRedfishIdracLockout(),
+			"redfish_fan":                            resourceRedfishFan(),
+			"redfish_chassis_sled_power":             resourceRedfishChassisSledPower(),
+			"redfish_spdm":                           resourceRedfishSpdm(),
+			"redfish_boot_certificate":               resourceRedfishBootCertificate(),
+			"redfish_manager_reset_to_defaults":      resourceRedfishManagerResetToDefaults(),
+			"redfish_assistive_kvm":                  resourceRedfishAssistiveKvm(),
+			"redfish_action":                         resourceRedfishAction(),
+			"redfish_sb_nic_mac_address":             resourceRedfishSbNicMacAddress(),
+			"redfish_power_metrics":                  resourceRedfishPowerMetrics(),
+			"redfish_idrac_remote_syslog":            resourceRedfishIdracRemoteSyslog(),
+			"redfish_system_profile":                 resourceRedfishSystemProfile(),
+			"redfish_smart_storage_battery":          resourceRedfishSmartStorageBattery(),
+		})))),
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"redfish_bios": dataSourceRedfishBios(),
+			"redfish_bios":                dataSourceRedfishBios(),
+			"redfish_applicable_updates":  dataSourceRedfishApplicableUpdates(),
+			"redfish_fc_hbas":             dataSourceRedfishFcHbas(),
+			"redfish_dpus":                dataSourceRedfishDpus(),
+			"redfish_sensors":             dataSourceRedfishSensors(),
+			"redfish_hardware_errata":     dataSourceRedfishHardwareErrata(),
+			"redfish_inventory_export":    dataSourceRedfishInventoryExport(),
+			"redfish_update_service":      dataSourceRedfishUpdateService(),
+			"redfish_chassis":             dataSourceRedfishChassis(),
+			"redfish_task":                dataSourceRedfishTask(),
+			"redfish_rest":                dataSourceRedfishRest(),
+			"redfish_pcie_devices":        dataSourceRedfishPcieDevices(),
+			"redfish_accounts":            dataSourceRedfishAccounts(),
+			"redfish_service_root":        dataSourceRedfishServiceRoot(),
+			"redfish_jobs":                dataSourceRedfishJobs(),
+			"redfish_drives":              dataSourceRedfishDrives(),
+			"redfish_secure_boot":         dataSourceRedfishSecureBoot(),
+			"redfish_server_facts":        dataSourceRedfishServerFacts(),
+			"redfish_component_integrity": dataSourceRedfishComponentIntegrity(),
+			"redfish_license":             dataSourceRedfishLicense(),
+			"redfish_event_subscriptions": dataSourceRedfishEventSubscriptions(),
+			"redfish_idrac_attributes":    dataSourceRedfishIdracAttributes(),
 		},
-
-		//StopFunc: NEEDS TO BE IMPLEMENTED to revoke the redfish token
 	}
 
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
@@ -56,13 +217,44 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
-	/*Redfish token issued by iDRAC needs to be revoked when the provider is done.
-	At the moment, the terraform SDK (Provider.StopFunc) is not implemented. To follow up, please refer to this pull request:
-	https://github.com/hashicorp/terraform-plugin-sdk/pull/377
-	*/
+	// The Redfish sessions of the configuration, if any, are deleted by Shutdown when the provider stops
 	c, err := NewConfig(d)
 	if err != nil {
-		return nil, err
+		return nil, connectionError(d, err)
+	}
+	var oem common.OEMHandler
+	if v, ok := d.GetOk("vendor_override"); ok {
+		oem, err = common.GetOEMHandler(v.(string))
+	} else {
+		oem, err = common.DetectOEM(c)
+	}
+	if err != nil {
+		return nil, connectionError(d, err)
+	}
+	log.Printf("[DEBUG] Using the %s OEM extensions", oem.Vendor())
+	var lock *bmcLock
+	if v, ok := d.GetOk("lock_path"); ok {
+		lock = newBMCLock(v.(string), d.Get("redfish_endpoint").(string), time.Duration(d.Get("lock_timeout").(int))*time.Second)
+		onShutdown(lock.release)
+	}
+	return &providerConfig{
+		client:           c,
+		oem:              oem,
+		lockdownBypass:   d.Get("lockdown_bypass").(bool),
+		readOnly:         d.Get("read_only").(bool),
+		systemID:         d.Get("system_id").(string),
+		managerID:        d.Get("manager_id").(string),
+		endpoint:         d.Get("redfish_endpoint").(string),
+		operationLogFile: d.Get("operation_log_file").(string),
+		bmcLock:          lock,
+		notifications:    expandNotifications(d),
+	}, nil
+}
+
+// connectionError explains the errors connecting to the BMC caused by the provider configuration
+func connectionError(d *schema.ResourceData, err error) error {
+	if errors.Is(common.WrapHTTPError(err), common.ErrUnauthorized) {
+		return fmt.Errorf("%s rejected the credentials of user %s: %w", d.Get("redfish_endpoint").(string), d.Get("user").(string), err)
 	}
-	return c, nil
+	return err
 }
@@ -0,0 +1,54 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withReadOnly makes the resources refuse to change the server when read_only is set in the provider.
+// Creations and updates fail at plan time, through CustomizeDiff. Destructions do not go through it,
+// so they fail at apply time, before anything is sent to the server. Reads keep working, so
+// terraform plan and refresh can be used to report drift.
+func withReadOnly(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, resource := range resources {
+		if resource.CustomizeDiff != nil {
+			// It runs last, so the drift planned by the resource (i.e. redfish_gpu presets) is refused too
+			resource.CustomizeDiff = customdiff.Sequence(resource.CustomizeDiff, checkReadOnly(name))
+		} else {
+			resource.CustomizeDiff = checkReadOnly(name)
+		}
+		destroyError := fmt.Errorf("%s cannot be destroyed, as the provider is in read_only mode. Use terraform state rm to stop managing it", name)
+		if resource.DeleteContext != nil {
+			resource.DeleteContext = readOnlyGuard(resource.DeleteContext, destroyError)
+		}
+	}
+	return resources
+}
+
+// checkReadOnly returns a CustomizeDiff function failing the plans creating or changing resources of type name
+func checkReadOnly(name string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !m.(*providerConfig).readOnly {
+			return nil
+		}
+		if d.Id() == "" {
+			return fmt.Errorf("%s cannot be created, as the provider is in read_only mode", name)
+		}
+		if changes := d.GetChangedKeysPrefix(""); len(changes) > 0 {
+			return fmt.Errorf("%s %s cannot be changed, as the provider is in read_only mode. Changed attributes: %v", name, d.Id(), changes)
+		}
+		return nil
+	}
+}
+
+func readOnlyGuard(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, err error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if m.(*providerConfig).readOnly {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
@@ -0,0 +1,56 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+func TestReadOnlyPlan(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "/redfish/v1/Managers/System.Embedded.1/Attributes",
+		Attributes: map[string]string{
+			"id":                  "/redfish/v1/Managers/System.Embedded.1/Attributes",
+			"configuration":       "Service Tag",
+			"user_defined_string": "",
+		},
+	}
+	cases := []struct {
+		noTest     int
+		readOnly   bool
+		state      *terraform.InstanceState
+		config     map[string]interface{}
+		shouldPass bool
+	}{
+		{1, true, nil, map[string]interface{}{"configuration": "Service Tag"}, false},
+		{2, true, state, map[string]interface{}{"configuration": "Model Name"}, false},
+		{3, true, state, map[string]interface{}{"configuration": "Service Tag"}, true},
+		{4, true, state, map[string]interface{}{}, true},
+	}
+	for _, v := range cases {
+		r := Provider().ResourcesMap["redfish_idrac_lcd"]
+		_, err := r.Diff(context.Background(), v.state, terraform.NewResourceConfigRaw(v.config), &providerConfig{readOnly: v.readOnly})
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
+
+func TestReadOnlyDestroy(t *testing.T) {
+	r := Provider().ResourcesMap["redfish_idrac_lcd"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("/redfish/v1/Managers/System.Embedded.1/Attributes")
+	if diags := r.DeleteContext(context.Background(), d, &providerConfig{readOnly: true}); !diags.HasError() {
+		t.Errorf("the resource was destroyed in read_only mode")
+	}
+	if d.Id() == "" {
+		t.Errorf("the resource was removed from the state in read_only mode")
+	}
+	if diags := r.DeleteContext(context.Background(), d, &providerConfig{}); diags.HasError() {
+		t.Errorf("the resource was not destroyed: %v", diags)
+	}
+}
@@ -0,0 +1,186 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"time"
+)
+
+// defaultActionTimeout is the time to wait for the task of an action to finish
+const defaultActionTimeout = 30 * time.Minute
+
+func resourceRedfishAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishActionCreate),
+		ReadContext:   resourceRedfishActionRead,
+		DeleteContext: resourceRedfishActionDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultActionTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_uri": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "URI of the resource the action belongs to (i.e. /redfish/v1/Managers/iDRAC.Embedded.1)",
+				ValidateFunc: validation.StringMatch(redfishPathRegexp, "must be an absolute path without query"),
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Name of the action, standard or OEM, without the leading # (i.e. Manager.Reset or DellManager.ResetToDefaults). " +
+					"OEM actions nested under the key of their vendor are found too",
+			},
+			"parameters": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "{}",
+				Description: "Parameters of the action, as a JSON object (i.e. jsonencode({ResetType = \"GracefulRestart\"})). They are validated against " +
+					"the ActionInfo of the action, or the allowable values annotated on it for the services without ActionInfo",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the task the service creates to perform the action, if any",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that invoke the action again when changed",
+			},
+			"target": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI the action was invoked with",
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "HTTP status code of the response of the action",
+			},
+			"task_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the task the service created to perform the action. Empty for the actions performed right away",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Response body of the action as JSON, to be decoded with jsondecode. '{}' for the actions not returning data",
+			},
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			operationLogAttribute:    operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning action")
+	opLog := newOperationLog(m, "redfish_action")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_action")
+	defer progress.save(d)
+
+	resourceURI := d.Get("resource_uri").(string)
+	name := d.Get("action").(string)
+	actions, err := common.GetActions(conn, resourceURI)
+	if err != nil {
+		return diag.Errorf("error fetching the actions of %s: %s", resourceURI, err)
+	}
+	action, ok := actions[name]
+	if !ok || action.Target == "" {
+		available := []string{}
+		for actionName := range actions {
+			available = append(available, actionName)
+		}
+		sort.Strings(available)
+		return diag.Errorf("action %s not found on %s. Available actions: %v", name, resourceURI, available)
+	}
+
+	parameters := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(d.Get("parameters").(string)), &parameters); err != nil {
+		return diag.Errorf("error decoding parameters: %s", err)
+	}
+	if action.ActionInfoURI != "" {
+		info, err := common.GetActionInfo(conn, action.ActionInfoURI)
+		if err != nil {
+			return diag.Errorf("error fetching the ActionInfo of %s: %s", name, err)
+		}
+		if err := info.ValidateParameters(parameters); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := action.ValidateAllowableValues(parameters); err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := common.InvokeAction(conn, action.Target, parameters)
+	jobURI := ""
+	if response != nil {
+		jobURI = response.TaskURI
+	}
+	opLog.record("action", action.Target, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error invoking %s: %s", name, err)
+	}
+	d.SetId(action.Target)
+
+	body, err := json.Marshal(response.Body)
+	if err != nil {
+		return diag.Errorf("error encoding the response of %s: %s", name, err)
+	}
+	values := map[string]interface{}{
+		"target":      action.Target,
+		"status_code": response.StatusCode,
+		"task_uri":    response.TaskURI,
+		"response":    string(body),
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	if response.TaskURI != "" && d.Get("wait").(bool) {
+		err := common.WaitForJobToFinishWithProgress(ctx, conn, response.TaskURI, common.TimeBetweenAttempts, int(d.Timeout(schema.TimeoutCreate).Seconds()), progress.reporter(response.TaskURI))
+		opLog.record("job_completion", action.Target, response.TaskURI, err)
+		if err != nil {
+			return diag.Errorf("error waiting for the task of %s to finish: %s", name, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Action finished successfully", d.Id())
+	return resourceRedfishActionRead(ctx, d, m)
+}
+
+func resourceRedfishActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The action has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Actions cannot be undone
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,177 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+// assistiveKvmAttributes maps the redfish_assistive_kvm variables to the Dell iDRAC attributes
+var assistiveKvmAttributes = dellAttributeMapping{
+	"web_timeout":                  "WebServer.1.Timeout",
+	"web_max_sessions":             "WebServer.1.MaxNumberOfSessions",
+	"ssh_timeout":                  "SSH.1.Timeout",
+	"ssh_max_sessions":             "SSH.1.MaxSessions",
+	"virtual_console_timeout":      "VirtualConsole.1.Timeout",
+	"virtual_console_max_sessions": "VirtualConsole.1.MaxSessions",
+}
+
+func resourceRedfishAssistiveKvm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishAssistiveKvmUpdate),
+		ReadContext:   resourceRedfishAssistiveKvmRead,
+		UpdateContext: withLockdownBypass(resourceRedfishAssistiveKvmUpdate),
+		DeleteContext: resourceRedfishAssistiveKvmDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"session_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds an idle Redfish session is kept, set on the SessionService. Supported by every BMC",
+				ValidateFunc: validation.IntBetween(30, 86400),
+			},
+			"web_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds an idle web interface session is kept. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(60, 10800),
+			},
+			"web_max_sessions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Maximum number of concurrent web interface sessions. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(1, 8),
+			},
+			"ssh_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds an idle SSH session is kept. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(60, 10800),
+			},
+			"ssh_max_sessions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Maximum number of concurrent SSH sessions. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(1, 4),
+			},
+			"virtual_console_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds an idle virtual console (KVM) session is kept. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(60, 10800),
+			},
+			"virtual_console_max_sessions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Maximum number of concurrent virtual console (KVM) sessions. Only supported on Dell iDRAC",
+				ValidateFunc: validation.IntBetween(1, 6),
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishAssistiveKvmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	vendor := m.(*providerConfig).oem.Vendor()
+
+	log.Printf("[DEBUG] Beginning session policy update")
+	opLog := newOperationLog(m, "redfish_assistive_kvm")
+	defer opLog.save(d)
+
+	sessionServiceURI, err := common.GetLinkURI(conn, conn.Service.ODataID, "SessionService")
+	if err != nil {
+		return diag.Errorf("error finding the session service: %s", err)
+	}
+	d.SetId(sessionServiceURI + "#assistive_kvm")
+
+	if v, ok := d.GetOk("session_timeout"); ok {
+		current, err := getSessionTimeout(conn, sessionServiceURI)
+		if err != nil {
+			return diag.Errorf("error fetching the session timeout: %s", err)
+		}
+		if v.(int) != current {
+			err := common.PatchResource(conn, sessionServiceURI, map[string]interface{}{"SessionTimeout": v.(int)})
+			opLog.record("session_service_patch", sessionServiceURI, "", err)
+			if err != nil {
+				return diag.Errorf("error updating the session timeout: %s", err)
+			}
+		}
+	}
+
+	if vendor == "dell" {
+		err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, assistiveKvmAttributes)
+		opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating session attributes: %s", err)
+		}
+	} else {
+		for key := range assistiveKvmAttributes {
+			if _, ok := d.GetOk(key); ok {
+				return diag.Errorf("%s is not supported on %s BMCs", key, vendor)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishAssistiveKvmRead(ctx, d, m)
+}
+
+func resourceRedfishAssistiveKvmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	sessionServiceURI, err := common.GetLinkURI(conn, conn.Service.ODataID, "SessionService")
+	if err != nil {
+		return diag.Errorf("error finding the session service: %s", err)
+	}
+	timeout, err := getSessionTimeout(conn, sessionServiceURI)
+	if err != nil {
+		return diag.Errorf("error fetching the session timeout: %s", err)
+	}
+	if err := d.Set("session_timeout", timeout); err != nil {
+		return diag.Errorf("error setting session_timeout: %s", err)
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, assistiveKvmAttributes); err != nil {
+			return diag.Errorf("error reading session attributes: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishAssistiveKvmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as restoring the defaults on destroy would loosen the session policy
+	d.SetId("")
+
+	return diags
+}
+
+// getSessionTimeout returns the SessionTimeout of the session service at uri, in seconds
+func getSessionTimeout(conn *gofish.APIClient, uri string) (int, error) {
+	sessionService, err := common.GetResource(conn, uri)
+	if err != nil {
+		return 0, err
+	}
+	timeout, ok := sessionService["SessionTimeout"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("the session service does not report its SessionTimeout")
+	}
+	return int(timeout), nil
+}
@@ -0,0 +1,203 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"log"
+	"time"
+)
+
+// defaultAttributeTimeout is the time to wait for the task applying a generic PATCH
+const defaultAttributeTimeout = 30 * time.Minute
+
+func resourceRedfishAttribute() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishAttributeUpdate),
+		ReadContext:   resourceRedfishAttributeRead,
+		UpdateContext: withLockdownBypass(resourceRedfishAttributeUpdate),
+		DeleteContext: resourceRedfishAttributeDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultAttributeTimeout),
+			Update: schema.DefaultTimeout(defaultAttributeTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"uri": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "URI of the Redfish resource to PATCH (i.e. /redfish/v1/Managers/iDRAC.Embedded.1/Attributes or /redfish/v1/Systems/System.Embedded.1/Bios/Settings)",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "JSON object sent as the body of the PATCH. It is sent again whenever it changes. Values the BMC normalizes (i.e. case, or numbers as strings) are not considered changes",
+				ValidateFunc:     validateJSONObject,
+				DiffSuppressFunc: suppressEquivalentJSON,
+			},
+			"read_uri": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URI the applied values are read from, when it is not uri (i.e. the resource owning a settings object). By default uri",
+			},
+			"detect_drift": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether body is refreshed with the values read from read_uri, so changes made outside terraform are reverted. Leave it unset for write-only properties (i.e. passwords), which would never converge",
+			},
+			"create_config_job": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether a configuration job applying the settings object at uri is created after the PATCH, on BMCs with a job queue (i.e. iDRAC). The job runs on the next reboot",
+			},
+			"clear_pending": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the changes pending in the settings object of uri are discarded before the PATCH, so stale pending changes are not applied with body. Only for resources with settings objects (i.e. the BIOS)",
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the task created by the PATCH to finish. Configuration jobs are never waited for, as they need a reboot",
+			},
+			"job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the task or configuration job created by the last PATCH",
+			},
+			"current": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the current values, read from read_uri, of the properties in body",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishAttributeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	uri := d.Get("uri").(string)
+
+	log.Printf("[DEBUG] %s: Beginning PATCH", uri)
+	opLog := newOperationLog(m, "redfish_attribute")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_attribute")
+	defer progress.save(d)
+
+	body, err := structure.ExpandJsonFromString(d.Get("body").(string))
+	if err != nil {
+		return diag.Errorf("error decoding body, it must be a JSON object: %s", err)
+	}
+	if d.Get("clear_pending").(bool) {
+		if _, err := clearPendingSettings(conn, m.(*providerConfig).oem, opLog, uri); err != nil {
+			return diag.Errorf("error clearing the pending settings of %s: %s", uri, err)
+		}
+	}
+	jobURI, err := common.PatchResourceWithJob(conn, uri, body)
+	opLog.record("patch", uri, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error updating %s: %s", uri, err)
+	}
+	d.SetId(uri)
+
+	if jobURI != "" && d.Get("wait").(bool) {
+		timeout := d.Timeout(schema.TimeoutCreate)
+		if !d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutUpdate)
+		}
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(timeout.Seconds()), progress.reporter(jobURI))
+		opLog.record("job_completion", uri, jobURI, err)
+		if err != nil {
+			return diag.Errorf("error waiting for task %s to finish: %s", jobURI, err)
+		}
+	}
+
+	if d.Get("create_config_job").(bool) {
+		jobURI, err = m.(*providerConfig).oem.CreateConfigJob(conn, uri)
+		opLog.record("config_job_create", uri, jobURI, err)
+		if err != nil {
+			return diag.Errorf("error creating the configuration job of %s: %s", uri, err)
+		}
+	}
+	if err := d.Set("job_uri", jobURI); err != nil {
+		return diag.Errorf("error setting job_uri: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishAttributeRead(ctx, d, m)
+}
+
+func resourceRedfishAttributeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	readURI := d.Get("read_uri").(string)
+	if readURI == "" {
+		readURI = d.Get("uri").(string)
+	}
+	resource, err := common.GetResource(conn, readURI)
+	if err != nil {
+		return diag.Errorf("error fetching %s: %s", readURI, err)
+	}
+	body, err := structure.ExpandJsonFromString(d.Get("body").(string))
+	if err != nil {
+		return diag.Errorf("error decoding body, it must be a JSON object: %s", err)
+	}
+	current, err := json.Marshal(projectJSON(body, resource))
+	if err != nil {
+		return diag.Errorf("error encoding the current values: %s", err)
+	}
+	if err := d.Set("current", string(current)); err != nil {
+		return diag.Errorf("error setting current: %s", err)
+	}
+	if d.Get("detect_drift").(bool) {
+		if err := d.Set("body", string(current)); err != nil {
+			return diag.Errorf("error setting body: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishAttributeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The previous values are unknown, so the properties are left as they are
+	d.SetId("")
+
+	return diags
+}
+
+// projectJSON returns the values of current for the properties in shape, recursing into nested objects,
+// so the current values can be compared with a PATCH body. Missing properties are nil.
+func projectJSON(shape interface{}, current interface{}) interface{} {
+	shapeObject, ok := shape.(map[string]interface{})
+	if !ok {
+		return current
+	}
+	currentObject, _ := current.(map[string]interface{})
+	projection := make(map[string]interface{})
+	for key, value := range shapeObject {
+		projection[key] = projectJSON(value, currentObject[key])
+	}
+	return projection
+}
+
+// validateJSONObject fails for JSON values that are not objects, as PATCH bodies must be
+func validateJSONObject(v interface{}, k string) ([]string, []error) {
+	if _, err := structure.ExpandJsonFromString(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object: %s", k, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectJSON(t *testing.T) {
+	current := map[string]interface{}{
+		"HostName": "idrac-01",
+		"NTP": map[string]interface{}{
+			"ProtocolEnabled": true,
+			"NTPServers":      []interface{}{"10.0.0.1"},
+			"Port":            float64(123),
+		},
+		"Id": "NetworkProtocol",
+	}
+	cases := []struct {
+		noTest   int
+		shape    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{1, map[string]interface{}{"HostName": "idrac-02"}, map[string]interface{}{"HostName": "idrac-01"}},
+		{2, map[string]interface{}{"NTP": map[string]interface{}{"ProtocolEnabled": false}}, map[string]interface{}{"NTP": map[string]interface{}{"ProtocolEnabled": true}}},
+		{3, map[string]interface{}{"NTP": map[string]interface{}{"NTPServers": []interface{}{}}}, map[string]interface{}{"NTP": map[string]interface{}{"NTPServers": []interface{}{"10.0.0.1"}}}},
+		{4, map[string]interface{}{"Missing": map[string]interface{}{"Key": 1}}, map[string]interface{}{"Missing": map[string]interface{}{"Key": nil}}},
+	}
+	for _, v := range cases {
+		projection := projectJSON(v.shape, current)
+		if !reflect.DeepEqual(projection, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, projection)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"time"
+)
+
+// defaultBackupRestoreTimeout is the time to wait for the backup and restore jobs, which include the firmware images
+const defaultBackupRestoreTimeout = 60 * time.Minute
+
+func resourceRedfishBackupRestore() *schema.Resource {
+	share := shareSchema("Network share the backup image is saved to and restored from")
+	share.Optional = false
+	share.Required = true
+
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBackupRestoreUpdate),
+		ReadContext:   resourceRedfishBackupRestoreRead,
+		UpdateContext: withLockdownBypass(resourceRedfishBackupRestoreUpdate),
+		DeleteContext: resourceRedfishBackupRestoreDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishBackupRestoreCustomizeDiff, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultBackupRestoreTimeout),
+			Update: schema.DefaultTimeout(defaultBackupRestoreTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			shareAttribute: share,
+			"image_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the backup image in the share",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashPassword,
+				Description: "Passphrase encrypting the backup image, needed to restore it. Only its SHA-256 hash is stored in the state",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that take a new backup when changed (i.e. before risky changes). Changing the share, image_name or passphrase takes one too",
+			},
+			"restore_triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that restore the backup image when changed. The restore reboots the host. It cannot be planned together with a new backup",
+			},
+			"backup_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the job of the last backup",
+			},
+			"restore_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the job of the last restore",
+			},
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			operationLogAttribute:    operationLogSchema(),
+		},
+	}
+}
+
+// backupAttributes are the variables whose changes take a new backup
+var backupAttributes = []string{shareAttribute, "image_name", "passphrase", "triggers"}
+
+func resourceRedfishBackupRestoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning backup/restore update")
+	opLog := newOperationLog(m, "redfish_backup_restore")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_backup_restore")
+	defer progress.save(d)
+
+	share, err := expandShare(d)
+	if err != nil {
+		return diag.Errorf("error in share: %s", err)
+	}
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	imageName := d.Get("image_name").(string)
+	// StateFunc only applies to the state, so the configuration holds the passphrase in clear
+	passphrase := d.Get("passphrase").(string)
+
+	// Restores are only run on updates, as there is nothing to restore until a backup was taken
+	if !d.IsNewResource() && d.HasChange("restore_triggers") {
+		jobURI, err := common.RestoreImage(conn, share, imageName, passphrase)
+		opLog.record("restore_image", share.URI(imageName), jobURI, err)
+		if err != nil {
+			return diag.Errorf("error restoring %s: %s", share.URI(imageName), err)
+		}
+		if err := d.Set("restore_job_uri", jobURI); err != nil {
+			return diag.Errorf("error setting restore_job_uri: %s", err)
+		}
+		if diags := waitForBackupRestoreJob(ctx, conn, jobURI, timeout, opLog, progress); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.IsNewResource() || d.HasChanges(backupAttributes...) {
+		jobURI, err := common.BackupImage(conn, share, imageName, passphrase)
+		opLog.record("backup_image", share.URI(imageName), jobURI, err)
+		if err != nil {
+			return diag.Errorf("error backing up to %s: %s", share.URI(imageName), err)
+		}
+		d.SetId(common.DellLCServiceURI + "#" + imageName)
+		if err := d.Set("backup_job_uri", jobURI); err != nil {
+			return diag.Errorf("error setting backup_job_uri: %s", err)
+		}
+		if diags := waitForBackupRestoreJob(ctx, conn, jobURI, timeout, opLog, progress); diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := clearShareWriteOnly(d); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishBackupRestoreRead(ctx, d, m)
+}
+
+func resourceRedfishBackupRestoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The backup image lives in the share, which the provider cannot reach, so there is nothing to refresh
+	return diags
+}
+
+func resourceRedfishBackupRestoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The backup image is kept in the share
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishBackupRestoreCustomizeDiff checks the BMC is a Dell iDRAC, as the other vendors do not expose
+// backups through Redfish, and that a restore is not planned together with a new backup, whose order would be ambiguous.
+func resourceRedfishBackupRestoreCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor != "dell" {
+		return fmt.Errorf("backup and restore are not supported on %s BMCs, only on Dell iDRAC", vendor)
+	}
+	if d.Id() == "" || !d.HasChange("restore_triggers") {
+		return nil
+	}
+	for _, key := range backupAttributes {
+		if d.HasChange(key) {
+			return fmt.Errorf("restore_triggers cannot change together with %s, as it is unclear whether to restore the previous backup or take a new one. Apply them separately", key)
+		}
+	}
+	return nil
+}
+
+func waitForBackupRestoreJob(ctx context.Context, conn *gofish.APIClient, jobURI string, timeout time.Duration, opLog *operationLog, progress *jobProgress) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if jobURI == "" {
+		return diags
+	}
+	err := common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(timeout.Seconds()), progress.reporter(jobURI))
+	opLog.record("job_completion", common.DellLCServiceURI, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error waiting for job %s to finish: %s", jobURI, err)
+	}
+	return diags
+}
@@ -0,0 +1,44 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+func TestBackupRestorePlan(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: common.DellLCServiceURI + "#r740-known-good",
+		Attributes: map[string]string{
+			"id":                        common.DellLCServiceURI + "#r740-known-good",
+			"share.#":                   "1",
+			"share.0.ip":                "10.0.0.10",
+			"share.0.share_type":        "NFS",
+			"share.0.share_name":        "/backups",
+			"share.0.ignore_cert":       "false",
+			"image_name":                "r740-known-good",
+			"restore_triggers.%":        "1",
+			"restore_triggers.rollback": "1",
+		},
+	}
+	share := []interface{}{map[string]interface{}{"ip": "10.0.0.10", "share_type": "NFS", "share_name": "/backups"}}
+	cases := []struct {
+		noTest int
+		vendor string
+		config map[string]interface{}
+	}{
+		{1, "hpe", map[string]interface{}{"share": share, "image_name": "r740-known-good"}},
+		{2, "dell", map[string]interface{}{"share": share, "image_name": "r740-other", "restore_triggers": map[string]interface{}{"rollback": "2"}}},
+	}
+	for _, v := range cases {
+		oem, err := common.GetOEMHandler(v.vendor)
+		if err != nil {
+			t.Fatalf("Test number %v: %s", v.noTest, err)
+		}
+		r := Provider().ResourcesMap["redfish_backup_restore"]
+		if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(v.config), &providerConfig{oem: oem}); err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/stmcginnis/gofish"
-	"github.com/stmcginnis/gofish/common"
+	redfishcommon "github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"log"
 	"strconv"
@@ -17,15 +18,17 @@ import (
 
 func resourceRedfishBios() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceRedfishBiosUpdate,
+		CreateContext: withPreconditions(withLockdownBypass(resourceRedfishBiosUpdate)),
 		ReadContext:   resourceRedfishBiosRead,
-		UpdateContext: resourceRedfishBiosUpdate,
+		UpdateContext: withPreconditions(withLockdownBypass(resourceRedfishBiosUpdate)),
 		DeleteContext: resourceRedfishBiosDelete,
+		CustomizeDiff: checkSystemLockdown,
 		Schema: map[string]*schema.Schema{
 			"attributes": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Description: "Bios attributes",
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Description:      "Bios attributes. Values the BMC normalizes (i.e. case, numbers as strings or MAC addresses format) are not considered changes",
+				DiffSuppressFunc: suppressEquivalentValue,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -36,10 +39,10 @@ func resourceRedfishBios() *schema.Resource {
 				Optional:    true,
 				Description: "The time when the BIOS settings can be applied. Applicable values are 'OnReset', 'Immediate', 'AtMaintenanceWindowStart' and 'InMaintenanceWindowStart'.",
 				ValidateFunc: validation.StringInSlice([]string{
-					string(common.ImmediateApplyTime),
-					string(common.OnResetApplyTime),
-					string(common.AtMaintenanceWindowStartApplyTime),
-					string(common.InMaintenanceWindowOnResetApplyTime),
+					string(redfishcommon.ImmediateApplyTime),
+					string(redfishcommon.OnResetApplyTime),
+					string(redfishcommon.AtMaintenanceWindowStartApplyTime),
+					string(redfishcommon.InMaintenanceWindowOnResetApplyTime),
 				}, false),
 			},
 
@@ -48,6 +51,29 @@ func resourceRedfishBios() *schema.Resource {
 				Description: "BIOS configuration job uri",
 				Computed:    true,
 			},
+
+			"check_job_queue": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify there are no pending BIOS configuration jobs in the job queue before submitting a new one",
+			},
+
+			"clear_pending": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Discard the pending BIOS settings before applying the attributes, so stale pending changes are not applied with them",
+			},
+
+			operationLogAttribute:  operationLogSchema(),
+			preconditionsAttribute: preconditionsSchema(),
+			"clear_stale_jobs": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When check_job_queue is set, delete the pending BIOS configuration jobs that are not running instead of failing",
+			},
 		},
 	}
 }
@@ -57,7 +83,9 @@ func resourceRedfishBiosUpdate(ctx context.Context, d *schema.ResourceData, m in
 	log.Printf("[DEBUG] Beginning update")
 	var diags diag.Diagnostics
 
-	conn := m.(*gofish.APIClient)
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_bios")
+	defer opLog.save(d)
 
 	// check if there is already a bios config job in progress
 	// if yes, then check the current status of the job. If it
@@ -70,7 +98,7 @@ func resourceRedfishBiosUpdate(ctx context.Context, d *schema.ResourceData, m in
 			task, _ := redfish.GetTask(conn, taskUri)
 			if task != nil {
 				if task.TaskState != redfish.CompletedTaskState {
-					log.Println("[DEBUG] %s: BIOS config task state = %s", d.Id(), task.TaskState)
+					log.Printf("[DEBUG] %s: BIOS config task state = %s", d.Id(), task.TaskState)
 					pending = true
 				}
 			} else {
@@ -82,7 +110,7 @@ func resourceRedfishBiosUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	bios, err := getBios(conn)
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
 	if err != nil {
 		return diag.Errorf("error fetching bios resource: %s", err)
 	}
@@ -115,7 +143,7 @@ func resourceRedfishBiosUpdate(ctx context.Context, d *schema.ResourceData, m in
 					attrsPayload[key] = intVal
 				}
 			} else {
-				if val != oldVal {
+				if !equivalentValues(val.(string), oldVal) {
 					attrsPayload[key] = val
 				}
 			}
@@ -127,7 +155,19 @@ func resourceRedfishBiosUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	if len(attrsPayload) != 0 {
 		if !pending {
+			if d.Get("check_job_queue").(bool) {
+				err = m.(*providerConfig).oem.CheckJobQueue(conn, []string{common.BiosConfigurationJobType}, d.Get("clear_stale_jobs").(bool))
+				if err != nil {
+					return diag.Errorf("error checking the job queue: %s", err)
+				}
+			}
+			if d.Get("clear_pending").(bool) {
+				if _, err := clearPendingSettings(conn, m.(*providerConfig).oem, opLog, bios.ODataID); err != nil {
+					return diag.Errorf("error clearing the pending BIOS settings: %s", err)
+				}
+			}
 			err = updateBiosAttributes(d, bios, attrsPayload)
+			opLog.record("bios_settings_patch", bios.ODataID+"/Settings", d.Get("bios_config_job_uri").(string), err)
 			if err != nil {
 				return diag.Errorf("error updating bios attributes: %s", err)
 			}
@@ -159,9 +199,9 @@ func resourceRedfishBiosRead(ctx context.Context, d *schema.ResourceData, m inte
 	log.Printf("[DEBUG] %s: Beginning read", d.Id())
 	var diags diag.Diagnostics
 
-	conn := m.(*gofish.APIClient)
+	conn := m.(*providerConfig).clientWithContext(ctx)
 
-	bios, err := getBios(conn)
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
 	if err != nil {
 		return diag.Errorf("error fetching BIOS resource: %s", err)
 	}
@@ -193,15 +233,14 @@ func resourceRedfishBiosDelete(ctx context.Context, d *schema.ResourceData, m in
 	return diags
 }
 
-func getBios(conn *gofish.APIClient) (*redfish.Bios, error) {
+func getBios(conn *gofish.APIClient, systemID string) (*redfish.Bios, error) {
 
-	service := conn.Service
-	systems, err := service.Systems()
+	system, err := common.GetSystem(conn, systemID)
 	if err != nil {
 		return nil, err
 	}
 
-	bios, err := systems[0].Bios()
+	bios, err := system.Bios()
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +257,9 @@ func copyBiosAttributes(bios *redfish.Bios, attributes map[string]string) error
 	for key, value := range bios.Attributes {
 		if attr_val, ok := value.(string); ok {
 			attributes[key] = attr_val
+		} else if float_val, ok := value.(float64); ok {
+			// %v would format large numbers in exponent notation (i.e. 1e+06)
+			attributes[key] = strconv.FormatFloat(float_val, 'f', -1, 64)
 		} else {
 			attributes[key] = fmt.Sprintf("%v", value)
 		}
@@ -228,9 +270,7 @@ func copyBiosAttributes(bios *redfish.Bios, attributes map[string]string) error
 
 func updateBiosAttributes(d *schema.ResourceData, bios *redfish.Bios, attributes map[string]interface{}) error {
 
-	payload := make(map[string]interface{})
-	payload["Attributes"] = attributes
-
+	applyTime := ""
 	if settingsApplyTime, ok := d.GetOk("settings_apply_time"); ok {
 		allowedValues := bios.AllowedAttributeUpdateApplyTimes()
 		allowed := false
@@ -246,8 +286,34 @@ func updateBiosAttributes(d *schema.ResourceData, bios *redfish.Bios, attributes
 			return err
 		}
 
+		applyTime = settingsApplyTime.(string)
+	}
+
+	taskUri, err := patchBiosSettings(bios, attributes, applyTime)
+	if err != nil {
+		return err
+	}
+
+	if len(taskUri) > 0 {
+		if err = d.Set("bios_config_job_uri", taskUri); err != nil {
+			log.Printf("[DEBUG] error setting the task uri: %s", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// patchBiosSettings sends the attributes to the BIOS settings object, returning the URI of the
+// configuration job when the service creates one. An empty applyTime leaves it to the service.
+func patchBiosSettings(bios *redfish.Bios, attributes map[string]interface{}, applyTime string) (string, error) {
+
+	payload := make(map[string]interface{})
+	payload["Attributes"] = attributes
+
+	if len(applyTime) > 0 {
 		payload["@Redfish.SettingsApplyTime"] = map[string]interface{}{
-			"ApplyTime": settingsApplyTime.(string),
+			"ApplyTime": applyTime,
 		}
 	}
 
@@ -255,21 +321,16 @@ func updateBiosAttributes(d *schema.ResourceData, bios *redfish.Bios, attributes
 
 	resp, err := bios.Client.Patch(settingsObjectURI, payload)
 	if err != nil {
-		log.Println("[DEBUG] error sending the patch request: %s", err)
-		return err
+		log.Printf("[DEBUG] error sending the patch request: %s", err)
+		return "", err
 	}
+	defer resp.Body.Close()
 
 	// check if location is present in the response header
 	if location, err := resp.Location(); err == nil {
-		log.Printf("[DEBUG] BIOS configuration job uri:", location.String())
-
-		taskUri := location.EscapedPath()
-
-		if err = d.Set("bios_config_job_uri", taskUri); err != nil {
-			log.Printf("[DEBUG] error setting the task uri: %s", err)
-			return err
-		}
+		log.Printf("[DEBUG] BIOS configuration job uri: %s", location.String())
+		return location.EscapedPath(), nil
 	}
 
-	return nil
+	return "", nil
 }
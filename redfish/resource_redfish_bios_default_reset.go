@@ -0,0 +1,138 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+// defaultBiosDefaultResetTimeout is the time to wait for the POST applying the BIOS defaults
+const defaultBiosDefaultResetTimeout = 30 * time.Minute
+
+func resourceRedfishBiosDefaultReset() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBiosDefaultResetCreate),
+		ReadContext:   resourceRedfishBiosDefaultResetRead,
+		DeleteContext: resourceRedfishBiosDefaultResetDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultBiosDefaultResetTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"reset_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     string(redfish.ForceRestartResetType),
+				Description: "How the system is rebooted to apply the defaults. Applicable values are 'None' (applied on the next reboot), 'ForceRestart', 'GracefulRestart' and 'PowerCycle'. Systems powered off are powered on, unless 'None' is set",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None",
+					string(redfish.ForceRestartResetType),
+					string(redfish.GracefulRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the system to finish the POST applying the defaults, so the BIOS attributes applied next start from them. Only possible on systems reporting their boot progress, the rest return right after the reboot",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that reset the BIOS to its defaults again when changed (i.e. the version of the golden attribute set)",
+			},
+			"boot_progress": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last boot progress state reported by the system after the reboot (i.e. 'OSRunning'). Empty if the system does not report it or was not waited for",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishBiosDefaultResetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning BIOS reset to defaults")
+	opLog := newOperationLog(m, "redfish_bios_default_reset")
+	defer opLog.save(d)
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	err = bios.ResetBios()
+	opLog.record("bios_reset", bios.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the BIOS to its defaults: %s", err)
+	}
+	d.SetId(bios.ODataID + "#reset")
+
+	if d.Get("reset_type").(string) == "None" {
+		log.Printf("[DEBUG] %s: The BIOS defaults will be applied on the next reboot", d.Id())
+		return resourceRedfishBiosDefaultResetRead(ctx, d, m)
+	}
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	before, err := common.GetSystemBootProgress(conn, system.ODataID)
+	if err != nil {
+		return diag.Errorf("error reading the boot progress of the system: %s", err)
+	}
+	resetType := redfish.ResetType(d.Get("reset_type").(string))
+	if system.PowerState != redfish.OnPowerState {
+		resetType = redfish.OnResetType
+	}
+	err = system.Reset(resetType)
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the system: %s", err)
+	}
+
+	if d.Get("wait").(bool) {
+		state, err := common.WaitForPOST(ctx, conn, system.ODataID, before, common.TimeBetweenAttempts, int(d.Timeout(schema.TimeoutCreate).Seconds()))
+		opLog.record("boot_progress", system.ODataID, "", err)
+		if err := d.Set("boot_progress", state); err != nil {
+			return diag.Errorf("error setting boot_progress: %s", err)
+		}
+		if err != nil {
+			return diag.Errorf("error waiting for the system to apply the BIOS defaults: %s", err)
+		}
+		if state == "" {
+			log.Printf("[DEBUG] %s: The system does not report its boot progress, not waiting for the POST", d.Id())
+		}
+	}
+
+	log.Printf("[DEBUG] %s: BIOS reset to defaults finished successfully", d.Id())
+	return resourceRedfishBiosDefaultResetRead(ctx, d, m)
+}
+
+func resourceRedfishBiosDefaultResetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The reset has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishBiosDefaultResetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The previous BIOS settings cannot be restored
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,181 @@
+package redfish
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+// defaultBiosPasswordTimeout is the time to wait for the reboot applying a BIOS password change
+const defaultBiosPasswordTimeout = 30 * time.Minute
+
+func resourceRedfishBiosPassword() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBiosPasswordUpdate),
+		ReadContext:   resourceRedfishBiosPasswordRead,
+		UpdateContext: withLockdownBypass(resourceRedfishBiosPasswordUpdate),
+		DeleteContext: resourceRedfishBiosPasswordDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultBiosPasswordTimeout),
+			Update: schema.DefaultTimeout(defaultBiosPasswordTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"password_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "BIOS password to manage. On Dell systems 'SysPassword' (system password) or 'SetupPassword' (setup/admin password)",
+			},
+			"new_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				StateFunc:    hashPassword,
+				Description:  "Password to set. Only its SHA-256 hash is stored in the state",
+				ExactlyOneOf: []string{"new_password", "new_password" + writeOnlySuffix},
+			},
+			"new_password" + writeOnlySuffix:        writeOnlyPasswordSchema("new_password", "new_password"+writeOnlyVersionSuffix, "Password to set"),
+			"old_password" + writeOnlySuffix:        writeOnlyPasswordSchema("old_password", "new_password"+writeOnlyVersionSuffix, "Current password, sent together with new_password_wo"),
+			"new_password" + writeOnlyVersionSuffix: writeOnlyVersionSchema("new_password" + writeOnlySuffix),
+			"old_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashPassword,
+				Description: "Current password. Required when a password is already set, including when new_password changes. Only its SHA-256 hash is stored in the state",
+			},
+			"reset_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "None",
+				Description: "How the system is rebooted to apply the change. Applicable values are 'None' (applied on the next reboot), 'GracefulRestart', 'ForceRestart' and 'PowerCycle'. Systems powered off are not powered on",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None",
+					string(redfish.GracefulRestartResetType),
+					string(redfish.ForceRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job that applies the change, on BMCs with a Dell job queue",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishBiosPasswordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning BIOS password update")
+	opLog := newOperationLog(m, "redfish_bios_password")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_bios_password")
+	defer progress.save(d)
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	passwordName := d.Get("password_name").(string)
+
+	// StateFunc only applies to the state, so the configuration holds the passwords in clear
+	newPassword, oldPassword := d.Get("new_password").(string), d.Get("old_password").(string)
+	// Write-only passwords are only known when they are sent
+	if password, ok := passwordToSend(d, "new_password"+writeOnlySuffix); ok {
+		newPassword, oldPassword = password, d.Get("old_password"+writeOnlySuffix).(string)
+	} else if newPassword == "" {
+		log.Printf("[DEBUG] %s: %s did not change, nothing to update", d.Id(), "new_password"+writeOnlyVersionSuffix)
+		return resourceRedfishBiosPasswordRead(ctx, d, m)
+	}
+	err = common.ChangeBiosPassword(conn, bios.ODataID, passwordName, oldPassword, newPassword)
+	opLog.record("bios_password_change", bios.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error changing BIOS password %s: %s", passwordName, err)
+	}
+	d.SetId(fmt.Sprintf("%s#%s", bios.ODataID, passwordName))
+	if err := clearWriteOnly(d, "new_password"+writeOnlySuffix, "old_password"+writeOnlySuffix); err != nil {
+		return diag.FromErr(err)
+	}
+
+	jobURI, err := m.(*providerConfig).oem.CreateConfigJob(conn, bios.ODataID+"/Settings")
+	opLog.record("config_job_create", bios.ODataID+"/Settings", jobURI, err)
+	if err != nil {
+		return diag.Errorf("error creating the BIOS configuration job: %s", err)
+	}
+	if err := d.Set("bios_config_job_uri", jobURI); err != nil {
+		return diag.Errorf("error setting bios_config_job_uri: %s", err)
+	}
+
+	resetType := d.Get("reset_type").(string)
+	if resetType == "None" {
+		log.Printf("[DEBUG] %s: The password change will be applied on the next reboot", d.Id())
+		return resourceRedfishBiosPasswordRead(ctx, d, m)
+	}
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if system.PowerState != redfish.OnPowerState {
+		log.Printf("[DEBUG] %s: The system is %s, the password change will be applied on the next power on", d.Id(), system.PowerState)
+		return resourceRedfishBiosPasswordRead(ctx, d, m)
+	}
+	err = system.Reset(redfish.ResetType(resetType))
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the system: %s", err)
+	}
+
+	if jobURI != "" {
+		timeout := d.Timeout(schema.TimeoutCreate)
+		if !d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutUpdate)
+		}
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(timeout.Seconds()), progress.reporter(jobURI))
+		opLog.record("job_completion", bios.ODataID+"/Settings", jobURI, err)
+		if err != nil {
+			return diag.Errorf("error waiting for BIOS configuration job %s to finish: %s", jobURI, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishBiosPasswordRead(ctx, d, m)
+}
+
+func resourceRedfishBiosPasswordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// BIOS passwords cannot be read back, so the state is kept as is
+
+	return diags
+}
+
+func resourceRedfishBiosPasswordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The password is left set on the system, removing it requires the current password in clear
+	d.SetId("")
+
+	return diags
+}
+
+// hashPassword is the StateFunc of the password variables, so they are not stored in clear in the state
+func hashPassword(v interface{}) string {
+	password, ok := v.(string)
+	if !ok || password == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(password)))
+}
@@ -0,0 +1,297 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// httpBootSettings are the settings of a redfish_boot_certificate configuration
+type httpBootSettings struct {
+	enabled    bool
+	bootURI    string
+	nicID      string
+	tls        bool
+	dnsServers []string
+}
+
+// expandHTTPBootSettings reads the settings of a redfish_boot_certificate configuration or plan
+func expandHTTPBootSettings(d interface {
+	Get(string) interface{}
+}) httpBootSettings {
+	settings := httpBootSettings{
+		enabled:    d.Get("enabled").(bool),
+		bootURI:    d.Get("boot_uri").(string),
+		nicID:      d.Get("interface").(string),
+		tls:        d.Get("https_certificate").(string) != "",
+		dnsServers: []string{},
+	}
+	for _, v := range d.Get("dns_servers").([]interface{}) {
+		settings.dnsServers = append(settings.dnsServers, v.(string))
+	}
+	return settings
+}
+
+// newHTTPBootAttributes maps the settings of redfish_boot_certificate to the BIOS attributes of the vendor.
+// An empty boot URI or interface leaves them untouched.
+func newHTTPBootAttributes(vendor string, settings httpBootSettings) (map[string]string, error) {
+	attributes := make(map[string]string)
+	switch vendor {
+	case "dell":
+		attributes["HttpDev1EnDis"] = "Disabled"
+		if settings.enabled {
+			attributes["HttpDev1EnDis"] = "Enabled"
+		}
+		if settings.bootURI != "" {
+			attributes["HttpDev1Uri"] = settings.bootURI
+		}
+		if settings.nicID != "" {
+			attributes["HttpDev1Interface"] = settings.nicID
+		}
+		attributes["HttpDev1TlsMode"] = "None"
+		if settings.tls {
+			attributes["HttpDev1TlsMode"] = "OneWay"
+		}
+		attributes["HttpDev1DnsDhcpEnDis"] = "Enabled"
+		if len(settings.dnsServers) > 0 {
+			attributes["HttpDev1DnsDhcpEnDis"] = "Disabled"
+			attributes["HttpDev1Dns1"] = settings.dnsServers[0]
+			attributes["HttpDev1Dns2"] = ""
+			if len(settings.dnsServers) > 1 {
+				attributes["HttpDev1Dns2"] = settings.dnsServers[1]
+			}
+		}
+	case "hpe":
+		if settings.nicID != "" {
+			return nil, fmt.Errorf("interface is not supported on %s servers, which boot over HTTP from the pre-boot network interface", vendor)
+		}
+		attributes["HttpSupport"] = "Disabled"
+		if settings.enabled {
+			attributes["HttpSupport"] = "Auto"
+			if settings.tls {
+				attributes["HttpSupport"] = "HttpsOnly"
+			}
+		}
+		if settings.bootURI != "" {
+			attributes["UrlBootFile"] = settings.bootURI
+		}
+		if len(settings.dnsServers) > 0 {
+			attributes["Ipv4PrimaryDNS"] = settings.dnsServers[0]
+			attributes["Ipv4SecondaryDNS"] = ""
+			if len(settings.dnsServers) > 1 {
+				attributes["Ipv4SecondaryDNS"] = settings.dnsServers[1]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("HTTP boot settings are not supported on %s servers. Use redfish_bios with the HTTP boot attributes of the vendor instead", vendor)
+	}
+	return attributes, nil
+}
+
+func resourceRedfishBootCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBootCertificateUpdate),
+		ReadContext:   resourceRedfishBootCertificateRead,
+		UpdateContext: withLockdownBypass(resourceRedfishBootCertificateUpdate),
+		DeleteContext: withLockdownBypass(resourceRedfishBootCertificateDelete),
+		CustomizeDiff: customdiff.Sequence(resourceRedfishBootCertificateCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the UEFI firmware boots over HTTP(S). Mapped to HttpDev1EnDis on Dell and HttpSupport on HPE",
+			},
+			"boot_uri": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "URI of the boot image (i.e. https://images.example.com/ubuntu.iso or an EFI binary). " +
+					"Not set leaves it to the one set before, or to the one the DHCP server provides",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"interface": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Network device function the system boots from (i.e. NIC.Integrated.1-1-1). Only supported on Dell systems. Not set leaves it untouched",
+			},
+			"https_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "CA certificate in PEM format the UEFI firmware validates the HTTPS boot server with. It is uploaded to the boot certificates " +
+					"of the system, and the firmware only boots over HTTPS when it is set",
+				ValidateFunc: validatePEMCertificate,
+			},
+			"dns_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 2,
+				Description: "DNS servers the UEFI network stack resolves the boot URI with. Not set uses the ones of the DHCP server. " +
+					"On HPE, they are only used when the pre-boot network is not configured through DHCP",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"certificate_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the HTTPS boot certificate on the BMC",
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "BIOS attributes the settings manage, with their current values. Pending changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishBootCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning HTTP boot update")
+	opLog := newOperationLog(m, "redfish_boot_certificate")
+	defer opLog.save(d)
+
+	attributes, err := newHTTPBootAttributes(oem.Vendor(), expandHTTPBootSettings(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	d.SetId(bios.ODataID + "#http_boot")
+
+	// The certificate is replaced first, so the firmware never requires TLS without the CA to validate the server with
+	if d.IsNewResource() || d.HasChange("https_certificate") {
+		if uri := d.Get("certificate_uri").(string); uri != "" {
+			err := common.DeleteCertificate(conn, uri)
+			opLog.record("certificate_delete", uri, "", err)
+			if err != nil {
+				return diag.Errorf("error deleting the previous HTTPS boot certificate: %s", err)
+			}
+			if err := d.Set("certificate_uri", ""); err != nil {
+				return diag.Errorf("error setting certificate_uri: %s", err)
+			}
+		}
+		if certificate := d.Get("https_certificate").(string); certificate != "" {
+			system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+			if err != nil {
+				return diag.Errorf("error fetching the computer system: %s", err)
+			}
+			collectionURI, err := common.GetLinkURI(conn, system.ODataID, "Boot", "Certificates")
+			if err != nil {
+				return diag.Errorf("the system does not support HTTPS boot certificates: %s", err)
+			}
+			uri, err := common.ImportCertificate(conn, collectionURI, certificate)
+			opLog.record("certificate_import", collectionURI, "", err)
+			if err != nil {
+				return diag.Errorf("error importing the HTTPS boot certificate: %s", err)
+			}
+			if err := d.Set("certificate_uri", uri); err != nil {
+				return diag.Errorf("error setting certificate_uri: %s", err)
+			}
+		}
+	}
+
+	biosPayload, missing := biosChanges(bios, attributes)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, HTTP boot is not supported by this system", missing[0])
+	}
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "HTTP boot"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setStagedBiosAttributes(d, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishBootCertificateRead(ctx, d, m)
+}
+
+func resourceRedfishBootCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// A certificate removed from the BMC is uploaded again on the next apply
+	if uri := d.Get("certificate_uri").(string); uri != "" {
+		if _, err := common.GetCertificate(conn, uri); err != nil {
+			if !common.IsNotFound(err) {
+				return diag.Errorf("error fetching the HTTPS boot certificate: %s", err)
+			}
+			log.Printf("[WARN] %s: the HTTPS boot certificate %s was removed from the BMC", d.Id(), uri)
+			values := map[string]interface{}{"certificate_uri": "", "https_certificate": ""}
+			for key, value := range values {
+				if err := d.Set(key, value); err != nil {
+					return diag.Errorf("error setting %s: %s", key, err)
+				}
+			}
+		}
+	}
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishBootCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	opLog := newOperationLog(m, "redfish_boot_certificate")
+	defer opLog.save(d)
+
+	// The BIOS settings are kept, as the system might still boot from them, only the certificate is removed
+	if uri := d.Get("certificate_uri").(string); uri != "" {
+		err := common.DeleteCertificate(conn, uri)
+		opLog.record("certificate_delete", uri, "", err)
+		if err != nil {
+			return diag.Errorf("error deleting the HTTPS boot certificate: %s", err)
+		}
+	}
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishBootCertificateCustomizeDiff checks the settings are supported by the vendor and plans their attributes
+func resourceRedfishBootCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	attributes, err := newHTTPBootAttributes(m.(*providerConfig).oem.Vendor(), expandHTTPBootSettings(d))
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, attributes)
+}
@@ -0,0 +1,60 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestNewHTTPBootAttributes(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		settings   httpBootSettings
+		expected   map[string]string
+		shouldPass bool
+	}{
+		{1, "dell", httpBootSettings{enabled: true, bootURI: "https://images.example.com/boot.efi", tls: true, dnsServers: []string{"10.0.0.53"}}, map[string]string{
+			"HttpDev1EnDis":        "Enabled",
+			"HttpDev1Uri":          "https://images.example.com/boot.efi",
+			"HttpDev1TlsMode":      "OneWay",
+			"HttpDev1DnsDhcpEnDis": "Disabled",
+			"HttpDev1Dns1":         "10.0.0.53",
+			"HttpDev1Dns2":         "",
+		}, true},
+		{2, "dell", httpBootSettings{enabled: false, dnsServers: []string{}}, map[string]string{
+			"HttpDev1EnDis":        "Disabled",
+			"HttpDev1TlsMode":      "None",
+			"HttpDev1DnsDhcpEnDis": "Enabled",
+		}, true},
+		{3, "hpe", httpBootSettings{enabled: true, bootURI: "http://images.example.com/boot.iso", dnsServers: []string{"10.0.0.53", "10.0.1.53"}}, map[string]string{
+			"HttpSupport":      "Auto",
+			"UrlBootFile":      "http://images.example.com/boot.iso",
+			"Ipv4PrimaryDNS":   "10.0.0.53",
+			"Ipv4SecondaryDNS": "10.0.1.53",
+		}, true},
+		{4, "hpe", httpBootSettings{enabled: true, tls: true}, map[string]string{"HttpSupport": "HttpsOnly"}, true},
+		{5, "hpe", httpBootSettings{enabled: true, nicID: "NIC.Integrated.1-1-1"}, nil, false},
+		{6, "generic", httpBootSettings{enabled: true}, nil, false},
+	}
+	for _, v := range cases {
+		attributes, err := newHTTPBootAttributes(v.vendor, v.settings)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if len(attributes) != len(v.expected) {
+			t.Errorf("Test number %v: expected attributes %v, got %v", v.noTest, v.expected, attributes)
+			continue
+		}
+		for key, value := range v.expected {
+			if attributes[key] != value {
+				t.Errorf("Test number %v: expected %s to be %q, got %q", v.noTest, key, value, attributes[key])
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"log"
+)
+
+// Dell BIOS attributes that protect the boot order and BIOS settings from the operating system
+const (
+	uefiVariableAccessAttribute  string = "UefiVariableAccess"
+	inBandManageabilityAttribute string = "InBandManageabilityInterface"
+)
+
+func resourceRedfishBootOrderLock() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBootOrderLockUpdate),
+		ReadContext:   resourceRedfishBootOrderLockRead,
+		UpdateContext: withLockdownBypass(resourceRedfishBootOrderLockUpdate),
+		DeleteContext: resourceRedfishBootOrderLockDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"lock_boot_order": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Prevent the operating system from changing the UEFI boot order and boot variables (UefiVariableAccess set to 'Controlled')",
+			},
+			"lock_bios_settings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Prevent in-band tools running on the operating system from changing the BIOS settings (InBandManageabilityInterface set to 'Disabled')",
+			},
+			"settings_apply_time": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The time when the BIOS settings can be applied. Applicable values are 'OnReset', 'Immediate', 'AtMaintenanceWindowStart' and 'InMaintenanceWindowStart'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfishcommon.ImmediateApplyTime),
+					string(redfishcommon.OnResetApplyTime),
+					string(redfishcommon.AtMaintenanceWindowStartApplyTime),
+					string(redfishcommon.InMaintenanceWindowOnResetApplyTime),
+				}, false),
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Description: "BIOS configuration job uri",
+				Computed:    true,
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishBootOrderLockUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning boot order lock update")
+	opLog := newOperationLog(m, "redfish_boot_order_lock")
+	defer opLog.save(d)
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+
+	desired := make(map[string]interface{})
+	if v, ok := d.GetOkExists("lock_boot_order"); ok {
+		desired[uefiVariableAccessAttribute] = "Standard"
+		if v.(bool) {
+			desired[uefiVariableAccessAttribute] = "Controlled"
+		}
+	}
+	if v, ok := d.GetOkExists("lock_bios_settings"); ok {
+		desired[inBandManageabilityAttribute] = "Enabled"
+		if v.(bool) {
+			desired[inBandManageabilityAttribute] = "Disabled"
+		}
+	}
+
+	// Only send the attributes that are different from the current ones
+	attrsPayload := make(map[string]interface{})
+	for key, value := range desired {
+		current, ok := bios.Attributes[key]
+		if !ok {
+			return diag.Errorf("BIOS attribute %s not found, the protection is not supported by this system", key)
+		}
+		if current != value {
+			attrsPayload[key] = value
+		}
+	}
+
+	if len(attrsPayload) != 0 {
+		err = updateBiosAttributes(d, bios, attrsPayload)
+		opLog.record("bios_settings_patch", bios.ODataID+"/Settings", d.Get("bios_config_job_uri").(string), err)
+		if err != nil {
+			return diag.Errorf("error updating boot order lock attributes: %s", err)
+		}
+	} else {
+		log.Printf("[DEBUG] Boot order lock attributes are already set")
+	}
+
+	d.SetId(bios.ODataID)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishBootOrderLockRead(ctx, d, m)
+}
+
+func resourceRedfishBootOrderLockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching BIOS resource: %s", err)
+	}
+
+	if v, ok := bios.Attributes[uefiVariableAccessAttribute]; ok {
+		if err := d.Set("lock_boot_order", v == "Controlled"); err != nil {
+			return diag.Errorf("error setting lock_boot_order: %s", err)
+		}
+	}
+	if v, ok := bios.Attributes[inBandManageabilityAttribute]; ok {
+		if err := d.Set("lock_bios_settings", v == "Disabled"); err != nil {
+			return diag.Errorf("error setting lock_bios_settings: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishBootOrderLockDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
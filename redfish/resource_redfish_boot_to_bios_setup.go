@@ -0,0 +1,125 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+// defaultBootToBiosSetupTimeout is the time to wait for the system to enter the BIOS setup
+const defaultBootToBiosSetupTimeout = 15 * time.Minute
+
+func resourceRedfishBootToBiosSetup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishBootToBiosSetupCreate),
+		ReadContext:   resourceRedfishBootToBiosSetupRead,
+		DeleteContext: resourceRedfishBootToBiosSetupDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultBootToBiosSetupTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"reset_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     string(redfish.ForceRestartResetType),
+				Description: "How the system is rebooted when it is powered on. Applicable values are 'ForceRestart', 'GracefulRestart' and 'PowerCycle'. Systems powered off are powered on",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfish.ForceRestartResetType),
+					string(redfish.GracefulRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the system to enter the BIOS setup. Only possible on systems reporting their boot progress, the rest return right after the reboot",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that reboot the system into the BIOS setup again when changed",
+			},
+			"boot_progress": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last boot progress state reported by the system (i.e. 'SetupEntered'). Empty if the system does not report it",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishBootToBiosSetupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning boot to BIOS setup")
+	opLog := newOperationLog(m, "redfish_boot_to_bios_setup")
+	defer opLog.save(d)
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = system.SetBoot(redfish.Boot{
+		BootSourceOverrideTarget:  redfish.BiosSetupBootSourceOverrideTarget,
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+	})
+	opLog.record("set_boot_once", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error setting one-time boot to BIOS setup: %s", err)
+	}
+	resetType := redfish.ResetType(d.Get("reset_type").(string))
+	if system.PowerState != redfish.OnPowerState {
+		resetType = redfish.OnResetType
+	}
+	err = system.Reset(resetType)
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the system: %s", err)
+	}
+	d.SetId(system.ODataID + "#bios_setup")
+
+	if d.Get("wait").(bool) {
+		state, err := common.WaitForBootProgress(ctx, conn, system.ODataID, common.SetupEnteredBootProgress, common.TimeBetweenAttempts, int(d.Timeout(schema.TimeoutCreate).Seconds()))
+		opLog.record("boot_progress", system.ODataID, "", err)
+		if err := d.Set("boot_progress", state); err != nil {
+			return diag.Errorf("error setting boot_progress: %s", err)
+		}
+		if err != nil {
+			return diag.Errorf("error waiting for the system to enter the BIOS setup: %s", err)
+		}
+		if state == "" {
+			log.Printf("[DEBUG] %s: The system does not report its boot progress, not waiting for the BIOS setup", d.Id())
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Boot to BIOS setup finished successfully", d.Id())
+	return resourceRedfishBootToBiosSetupRead(ctx, d, m)
+}
+
+func resourceRedfishBootToBiosSetupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The reboot has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishBootToBiosSetupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
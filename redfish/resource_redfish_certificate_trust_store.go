@@ -0,0 +1,247 @@
+package redfish
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"time"
+)
+
+// certificateTrustStoreLinks are the properties of the AccountService leading to the certificate collection of each trust store
+var certificateTrustStoreLinks = map[string][]string{
+	"ldap":             {"LDAP", "Certificates"},
+	"active_directory": {"ActiveDirectory", "Certificates"},
+	"client":           {"MultiFactorAuth", "ClientCertificate", "Certificates"},
+}
+
+// kmsTrustStore is the trust store of the CA of the key management server. Dell iDRAC does not expose it as a
+// certificate collection, the certificate is imported through the DelliDRACCardService instead.
+const kmsTrustStore = "kms"
+
+// syslogTrustStore is the trust store of the CA of the remote syslog servers, imported like kmsTrustStore
+const syslogTrustStore = "syslog"
+
+// dellTrustStoreCertificateTypes maps the trust stores imported through the DelliDRACCardService to their certificate type
+var dellTrustStoreCertificateTypes = map[string]string{
+	kmsTrustStore:    common.KMSCACertificateType,
+	syslogTrustStore: common.SyslogCACertificateType,
+}
+
+func resourceRedfishCertificateTrustStore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishCertificateTrustStoreCreate),
+		ReadContext:   resourceRedfishCertificateTrustStoreRead,
+		DeleteContext: withLockdownBypass(resourceRedfishCertificateTrustStoreDelete),
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"store": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Trust store the CA certificate is uploaded to. Applicable values are 'ldap' and 'active_directory' (the CAs the directory " +
+					"servers are validated with), 'client' (the CAs the client certificates of Redfish and web logins are validated with) and " +
+					"'kms' (the CA the key management server of redfish_kmip is validated with, Dell iDRAC only) and 'syslog' (the CA the " +
+					"TLS remote syslog servers of redfish_idrac_remote_syslog are validated with, Dell iDRAC only)",
+				ValidateFunc: validation.StringInSlice([]string{"ldap", "active_directory", "client", kmsTrustStore, syslogTrustStore}, false),
+			},
+			"collection_uri": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "URI of the certificate collection of the trust store, for the BMCs exposing it somewhere else than the AccountService. Found from store if not set",
+			},
+			"certificate": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "CA certificate in PEM format. Changing it replaces the certificate on the BMC",
+				ValidateFunc: validatePEMCertificate,
+			},
+			"certificate_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the certificate on the BMC. Empty for the kms and syslog stores, which the BMC does not expose",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Subject of the certificate",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Issuer of the certificate",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the certificate, in RFC3339 format",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the certificate, in hexadecimal",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishCertificateTrustStoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning certificate trust store create")
+	opLog := newOperationLog(m, "redfish_certificate_trust_store")
+	defer opLog.save(d)
+
+	certificate, err := parsePEMCertificate(d.Get("certificate").(string))
+	if err != nil {
+		return diag.Errorf("error parsing certificate: %s", err)
+	}
+	fingerprint := certificateFingerprint(certificate)
+
+	store := d.Get("store").(string)
+	if certificateType, ok := dellTrustStoreCertificateTypes[store]; ok {
+		if vendor := m.(*providerConfig).oem.Vendor(); vendor != "dell" {
+			return diag.Errorf("the %s trust store is not supported on %s BMCs", store, vendor)
+		}
+		err := common.ImportDellCertificate(conn, certificateType, d.Get("certificate").(string))
+		opLog.record("certificate_import", certificateType, "", err)
+		if err != nil {
+			return diag.Errorf("error importing the %s CA certificate: %s", store, err)
+		}
+		d.SetId(certificateType + "#" + fingerprint)
+	} else {
+		collectionURI, err := getCertificateTrustStoreURI(conn, d)
+		if err != nil {
+			return diag.Errorf("error finding the %s trust store: %s", store, err)
+		}
+		uri, err := common.ImportCertificate(conn, collectionURI, d.Get("certificate").(string))
+		opLog.record("certificate_import", collectionURI, "", err)
+		if err != nil {
+			return diag.Errorf("error importing the certificate to the %s trust store: %s", store, err)
+		}
+		if err := d.Set("collection_uri", collectionURI); err != nil {
+			return diag.Errorf("error setting collection_uri: %s", err)
+		}
+		if err := d.Set("certificate_uri", uri); err != nil {
+			return diag.Errorf("error setting certificate_uri: %s", err)
+		}
+		d.SetId(uri)
+	}
+
+	values := map[string]interface{}{
+		"subject":     certificate.Subject.String(),
+		"issuer":      certificate.Issuer.String(),
+		"not_after":   certificate.NotAfter.UTC().Format(time.RFC3339),
+		"fingerprint": fingerprint,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Create finished successfully", d.Id())
+	return resourceRedfishCertificateTrustStoreRead(ctx, d, m)
+}
+
+func resourceRedfishCertificateTrustStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// The certificates imported through the DelliDRACCardService cannot be read back from the iDRAC
+	if _, ok := dellTrustStoreCertificateTypes[d.Get("store").(string)]; ok {
+		return diags
+	}
+
+	certificate, err := common.GetCertificate(conn, d.Id())
+	if err != nil {
+		if common.IsNotFound(err) {
+			log.Printf("[WARN] %s: the certificate was removed from the BMC", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error fetching the certificate: %s", err)
+	}
+	// Some BMCs do not return the certificate, it is only compared when they do
+	if certificate.CertificateString == "" {
+		return diags
+	}
+	current, err := parsePEMCertificate(certificate.CertificateString)
+	if err != nil {
+		return diag.Errorf("error parsing the certificate of the BMC: %s", err)
+	}
+	if certificateFingerprint(current) != d.Get("fingerprint").(string) {
+		log.Printf("[WARN] %s: the certificate was replaced on the BMC", d.Id())
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func resourceRedfishCertificateTrustStoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	opLog := newOperationLog(m, "redfish_certificate_trust_store")
+	defer opLog.save(d)
+
+	// The certificates imported through the DelliDRACCardService are only replaced, by the next one imported
+	if _, ok := dellTrustStoreCertificateTypes[d.Get("store").(string)]; !ok {
+		err := common.DeleteCertificate(conn, d.Id())
+		opLog.record("certificate_delete", d.Id(), "", err)
+		if err != nil {
+			return diag.Errorf("error deleting the certificate: %s", err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// getCertificateTrustStoreURI returns the URI of the certificate collection of the store, collection_uri when it is set
+func getCertificateTrustStoreURI(conn *gofish.APIClient, d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("collection_uri"); ok {
+		return v.(string), nil
+	}
+	accountService, err := conn.Service.AccountService()
+	if err != nil {
+		return "", err
+	}
+	return common.GetLinkURI(conn, accountService.ODataID, certificateTrustStoreLinks[d.Get("store").(string)]...)
+}
+
+// parsePEMCertificate decodes the first certificate of a PEM string
+func parsePEMCertificate(certificate string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the certificate, in hexadecimal
+func certificateFingerprint(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// validatePEMCertificate checks the value is a PEM certificate, so it is rejected at plan time rather than by the BMC
+func validatePEMCertificate(v interface{}, k string) ([]string, []error) {
+	if _, err := parsePEMCertificate(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a PEM certificate: %s", k, err)}
+	}
+	return nil, nil
+}
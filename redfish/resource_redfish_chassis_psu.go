@@ -0,0 +1,90 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// chassisPsuAttributes maps the redfish_chassis_psu variables to the Dell system attributes
+var chassisPsuAttributes = dellAttributeMapping{
+	"redundancy_policy":               "ServerPwr.1.PSRedundancyPolicy",
+	"hot_spare_enabled":               "ServerPwr.1.PSRapidOn",
+	"hot_spare_primary_psu":           "ServerPwr.1.RapidOnPrimaryPSU",
+	"power_factor_correction_enabled": "ServerPwr.1.PSPFCEnabled",
+}
+
+func resourceRedfishChassisPsu() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishChassisPsuUpdate),
+		ReadContext:   resourceRedfishChassisPsuRead,
+		UpdateContext: withLockdownBypass(resourceRedfishChassisPsuUpdate),
+		DeleteContext: resourceRedfishChassisPsuDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"redundancy_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Redundancy policy of the power supplies (i.e. 'Not Redundant' or 'A/B Grid Redundant'). The values available depend on the system",
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"hot_spare_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the secondary power supply is put in standby while the load allows it (hot spare)",
+			},
+			"hot_spare_primary_psu": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Power supply kept active when hot spare is enabled. Applicable values are 'PSU1' and 'PSU2'",
+				ValidateFunc:     validation.StringInSlice([]string{"PSU1", "PSU2"}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"power_factor_correction_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether power factor correction is enabled on the power supplies",
+			},
+		},
+	}
+}
+
+func resourceRedfishChassisPsuUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning PSU configuration update")
+	if err := updateDellAttributes(conn, d, common.DellSystemAttributesURI, chassisPsuAttributes); err != nil {
+		return diag.Errorf("error updating PSU attributes: %s", err)
+	}
+
+	d.SetId(common.DellSystemAttributesURI + "#psu")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishChassisPsuRead(ctx, d, m)
+}
+
+func resourceRedfishChassisPsuRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, chassisPsuAttributes); err != nil {
+		return diag.Errorf("error reading PSU attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishChassisPsuDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,146 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+)
+
+// sledVirtualReseat is the action of redfish_chassis_sled_power that removes and restores the power of the sled
+const sledVirtualReseat = "VirtualReseat"
+
+func resourceRedfishChassisSledPower() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishChassisSledPowerCreate),
+		ReadContext:   resourceRedfishChassisSledPowerRead,
+		DeleteContext: resourceRedfishChassisSledPowerDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"chassis_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Id of the sled or blade chassis, as a member of the enclosure (i.e. the contains of the enclosure in the " +
+					"redfish_chassis data source)",
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Operation performed on the sled. Applicable values are 'On', 'ForceOff', 'GracefulShutdown', 'ForceRestart', " +
+					"'GracefulRestart' and 'PowerCycle', sent as a Chassis.Reset of the sled, and 'VirtualReseat', which removes and " +
+					"restores the power of the sled as if it was pulled out of the enclosure, on the enclosures supporting it",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfish.OnResetType),
+					string(redfish.ForceOffResetType),
+					string(redfish.GracefulShutdownResetType),
+					string(redfish.ForceRestartResetType),
+					string(redfish.GracefulRestartResetType),
+					string(redfish.PowerCycleResetType),
+					sledVirtualReseat,
+				}, false),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that perform the action again when changed",
+			},
+			"power_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Power state of the sled after the action",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishChassisSledPowerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning sled power action")
+	opLog := newOperationLog(m, "redfish_chassis_sled_power")
+	defer opLog.save(d)
+
+	chassis, err := getChassisByID(conn, d.Get("chassis_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	action := d.Get("action").(string)
+	if action == sledVirtualReseat {
+		target, err := common.GetChassisReseatTarget(conn, chassis.ODataID)
+		if err != nil {
+			return diag.Errorf("error fetching the actions of chassis %s: %s", chassis.ID, err)
+		}
+		if target == "" {
+			return diag.Errorf("chassis %s does not support a virtual reseat", chassis.ID)
+		}
+		resp, err := conn.Post(target, map[string]interface{}{})
+		if err == nil {
+			resp.Body.Close()
+		}
+		opLog.record("virtual_reseat", target, "", err)
+		if err != nil {
+			return diag.Errorf("error reseating chassis %s: %s", chassis.ID, err)
+		}
+	} else {
+		err := chassis.Reset(redfish.ResetType(action))
+		opLog.record("reset", chassis.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error resetting chassis %s: %s", chassis.ID, err)
+		}
+	}
+	d.SetId(chassis.ODataID + "#sled_power")
+
+	log.Printf("[DEBUG] %s: Sled power action %s finished successfully", d.Id(), action)
+	return resourceRedfishChassisSledPowerRead(ctx, d, m)
+}
+
+func resourceRedfishChassisSledPowerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// The action has already been performed, only the power state is refreshed
+	chassis, err := getChassisByID(conn, d.Get("chassis_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("power_state", string(chassis.PowerState)); err != nil {
+		return diag.Errorf("error setting power_state: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishChassisSledPowerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// getChassisByID returns the chassis with the given id, among every chassis of the Redfish service
+func getChassisByID(conn *gofish.APIClient, id string) (*redfish.Chassis, error) {
+	chassisList, err := conn.Service.Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching chassis: %s", err)
+	}
+	ids := []string{}
+	for _, chassis := range chassisList {
+		if chassis.ID == id {
+			return chassis, nil
+		}
+		ids = append(ids, chassis.ID)
+	}
+	return nil, fmt.Errorf("chassis %s not found. Available chassis: %v", id, ids)
+}
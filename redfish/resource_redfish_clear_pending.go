@@ -0,0 +1,129 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+func resourceRedfishClearPending() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishClearPendingCreate),
+		ReadContext:   resourceRedfishClearPendingRead,
+		DeleteContext: resourceRedfishClearPendingDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"bios": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      false,
+				Description:  "Whether the pending BIOS settings are cleared",
+				AtLeastOneOf: []string{"bios", "storage_controllers", "uris"},
+			},
+			"storage_controllers": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Ids of the storage controllers whose pending settings are cleared (i.e. RAID.Integrated.1-1)",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"bios", "storage_controllers", "uris"},
+			},
+			"uris": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "URIs of other resources with settings objects (i.e. network device functions), or of the settings objects themselves, whose pending settings are cleared",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"bios", "storage_controllers", "uris"},
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that clear the pending settings again when changed",
+			},
+			"cleared": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the settings objects cleared",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishClearPendingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning clear of pending settings")
+	opLog := newOperationLog(m, "redfish_clear_pending")
+	defer opLog.save(d)
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	uris := []string{}
+	if d.Get("bios").(bool) {
+		uris = append(uris, system.ODataID+"/Bios")
+	}
+	for _, id := range d.Get("storage_controllers").([]interface{}) {
+		uris = append(uris, system.ODataID+"/Storage/"+id.(string))
+	}
+	for _, uri := range d.Get("uris").([]interface{}) {
+		uris = append(uris, uri.(string))
+	}
+
+	cleared := []string{}
+	for _, uri := range uris {
+		settingsURI, err := clearPendingSettings(conn, m.(*providerConfig).oem, opLog, uri)
+		if err != nil {
+			return diag.Errorf("error clearing the pending settings of %s: %s", uri, err)
+		}
+		cleared = append(cleared, settingsURI)
+	}
+	if err := d.Set("cleared", cleared); err != nil {
+		return diag.Errorf("error setting cleared: %s", err)
+	}
+	d.SetId(system.ODataID + "#clear_pending")
+
+	log.Printf("[DEBUG] %s: Clear of pending settings finished successfully", d.Id())
+	return resourceRedfishClearPendingRead(ctx, d, m)
+}
+
+func resourceRedfishClearPendingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings have already been cleared, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishClearPendingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// clearPendingSettings discards the changes pending in the settings object of the resource at uri,
+// returning the URI of the settings object. It is also the clear_pending pre-step of the resources
+// applying settings.
+func clearPendingSettings(conn *gofish.APIClient, oem common.OEMHandler, opLog *operationLog, uri string) (string, error) {
+	settingsURI, err := common.SettingsObjectURI(conn, uri)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[DEBUG] Clearing the pending settings of %s", settingsURI)
+	err = oem.ClearPendingSettings(conn, settingsURI)
+	opLog.record("clear_pending", settingsURI, "", err)
+	return settingsURI, err
+}
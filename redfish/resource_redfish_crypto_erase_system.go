@@ -0,0 +1,317 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultSystemEraseTimeout is the time to wait for the erase, which overwrites whole drives with the overwrite method
+const defaultSystemEraseTimeout = 120 * time.Minute
+
+// Components of redfish_crypto_erase_system
+const (
+	eraseBiosComponent        string = "bios"
+	eraseIdracComponent       string = "idrac"
+	eraseLCDataComponent      string = "lc_data"
+	eraseDiagnosticsComponent string = "diagnostics"
+	eraseDriverPackComponent  string = "driver_pack"
+	eraseDrivesComponent      string = "drives"
+)
+
+// dellSystemEraseComponents maps the components of redfish_crypto_erase_system to the Dell SystemErase components.
+// The drives depend on drive_erase_method.
+var dellSystemEraseComponents = map[string]string{
+	eraseBiosComponent:        "BIOS",
+	eraseIdracComponent:       "IDRAC",
+	eraseLCDataComponent:      "LCData",
+	eraseDiagnosticsComponent: "DIAG",
+	eraseDriverPackComponent:  "DrvPack",
+}
+
+// standardEraseComponents are the components erased through standard Redfish on the vendors without SystemErase
+var standardEraseComponents = []string{eraseBiosComponent, eraseDrivesComponent}
+
+// systemErasePlan are the requests a redfish_crypto_erase_system configuration resolves to
+type systemErasePlan struct {
+	// systemErase are the Dell SystemErase components
+	systemErase []string
+	// resetBios resets the BIOS to its defaults, on the vendors without SystemErase
+	resetBios bool
+	// eraseAllDrives erases every drive of the system with SecureErase, on the vendors without SystemErase
+	eraseAllDrives bool
+	// drives are the drives erased one by one with SecureErase
+	drives []string
+}
+
+// newSystemErasePlan maps the components to erase to the requests of the vendor.
+// drives limits the erase to those drives, with the DMTF SecureErase action, on every vendor.
+func newSystemErasePlan(vendor string, components []string, method string, drives []string) (*systemErasePlan, error) {
+	plan := &systemErasePlan{systemErase: []string{}, drives: drives}
+	for _, component := range components {
+		if component == eraseDrivesComponent && len(drives) > 0 {
+			continue
+		}
+		if vendor == "dell" {
+			if component == eraseDrivesComponent {
+				plan.systemErase = append(plan.systemErase, map[string]string{"cryptographic": "CryptographicErasePD", "overwrite": "OverwritePD"}[method])
+			} else {
+				plan.systemErase = append(plan.systemErase, dellSystemEraseComponents[component])
+			}
+			continue
+		}
+		switch component {
+		case eraseBiosComponent:
+			plan.resetBios = true
+		case eraseDrivesComponent:
+			if method != "cryptographic" {
+				return nil, fmt.Errorf("the %s drive erase method is only supported on Dell servers, SecureErase lets the drive pick the method", method)
+			}
+			plan.eraseAllDrives = true
+		default:
+			return nil, fmt.Errorf("erasing %s is only supported on Dell servers. Applicable components on %s servers are %v", component, vendor, standardEraseComponents)
+		}
+	}
+	if len(drives) > 0 && !common.ContainsFold(components, eraseDrivesComponent) {
+		return nil, fmt.Errorf("drives can only be set when the %s component is erased", eraseDrivesComponent)
+	}
+	sort.Strings(plan.systemErase)
+	return plan, nil
+}
+
+func resourceRedfishCryptoEraseSystem() *schema.Resource {
+	components := []string{eraseDrivesComponent}
+	for component := range dellSystemEraseComponents {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	// The erase is only performed on creation, so every variable replaces the resource
+	preconditions := preconditionsSchema()
+	preconditions.ForceNew = true
+
+	return &schema.Resource{
+		CreateContext: withPreconditions(withLockdownBypass(resourceRedfishCryptoEraseSystemCreate)),
+		ReadContext:   resourceRedfishCryptoEraseSystemRead,
+		DeleteContext: resourceRedfishCryptoEraseSystemDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishCryptoEraseSystemCustomizeDiff, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultSystemEraseTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"components": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Description: "Components to erase. Applicable values are 'bios', 'idrac', 'lc_data', 'diagnostics', 'driver_pack' and 'drives'. " +
+					"Only 'bios' and 'drives' are supported on other vendors than Dell. Their data cannot be recovered",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(components, false),
+				},
+			},
+			"drive_erase_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "cryptographic",
+				Description: "How the drives are erased. 'cryptographic' changes the encryption key of self-encrypting drives, 'overwrite' writes the whole drives (Dell only). " +
+					"Drives not supporting the method are left untouched by the iDRAC",
+				ValidateFunc: validation.StringInSlice([]string{"cryptographic", "overwrite"}, false),
+			},
+			"drives": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ODataIDs of the drives to erase with the DMTF SecureErase action. Not set erases every drive of the system",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"confirm_erase": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Must be true, acknowledging that the components are erased for good",
+			},
+			"confirm_service_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Service tag (or serial number) of the server. When set, the erase is aborted if the BMC belongs to another server, i.e. after an inventory mistake",
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to wait for the erase to finish. Erasing the iDRAC resets it, so the job cannot be tracked until it is back",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the erase again when changed",
+			},
+			"job_uris": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the jobs (or tasks) erasing the components",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			preconditionsAttribute:   preconditions,
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			operationLogAttribute:    operationLogSchema(),
+		},
+	}
+}
+
+// expandSystemErasePlan reads the erase settings of a redfish_crypto_erase_system configuration or plan
+func expandSystemErasePlan(vendor string, d interface{ Get(string) interface{} }) (*systemErasePlan, error) {
+	components := []string{}
+	for _, component := range d.Get("components").(*schema.Set).List() {
+		components = append(components, component.(string))
+	}
+	drives := []string{}
+	for _, drive := range d.Get("drives").([]interface{}) {
+		drives = append(drives, drive.(string))
+	}
+	return newSystemErasePlan(vendor, components, d.Get("drive_erase_method").(string), drives)
+}
+
+func resourceRedfishCryptoEraseSystemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	config := m.(*providerConfig)
+
+	log.Printf("[DEBUG] Beginning system erase")
+	opLog := newOperationLog(m, "redfish_crypto_erase_system")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_crypto_erase_system")
+	defer progress.save(d)
+
+	if !d.Get("confirm_erase").(bool) {
+		return diag.Errorf("confirm_erase must be true to erase the system")
+	}
+	plan, err := expandSystemErasePlan(config.oem.Vendor(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	system, err := common.GetSystem(conn, config.systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the computer system: %s", err)
+	}
+	if serviceTag := d.Get("confirm_service_tag").(string); serviceTag != "" &&
+		!strings.EqualFold(serviceTag, system.SKU) && !strings.EqualFold(serviceTag, system.SerialNumber) {
+		return diag.Errorf("the BMC belongs to the server %s (serial number %s), not to %s. Nothing was erased", system.SKU, system.SerialNumber, serviceTag)
+	}
+
+	drives := plan.drives
+	if plan.eraseAllDrives {
+		storage, err := system.Storage()
+		if err != nil {
+			return diag.Errorf("error fetching the storage of %s: %s", system.ID, err)
+		}
+		for _, s := range storage {
+			storageDrives, err := s.Drives()
+			if err != nil {
+				return diag.Errorf("error fetching the drives of %s: %s", s.ID, err)
+			}
+			for _, drive := range storageDrives {
+				drives = append(drives, drive.ODataID)
+			}
+		}
+	}
+
+	jobURIs := []string{}
+	// The drives are erased first, as erasing the iDRAC resets it
+	for _, drive := range drives {
+		jobURI, err := common.SecureEraseDrive(conn, drive)
+		opLog.record("drive_secure_erase", drive, jobURI, err)
+		if err != nil {
+			return diag.Errorf("error erasing drive %s: %s", drive, err)
+		}
+		if jobURI != "" {
+			jobURIs = append(jobURIs, jobURI)
+		}
+	}
+	if plan.resetBios {
+		bios, err := getBios(conn, config.systemID)
+		if err != nil {
+			return diag.Errorf("error fetching bios resource: %s", err)
+		}
+		err = bios.ResetBios()
+		opLog.record("bios_reset", bios.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error resetting the BIOS: %s", err)
+		}
+	}
+	if len(plan.systemErase) > 0 {
+		jobURI, err := common.SystemErase(conn, plan.systemErase)
+		opLog.record("system_erase", strings.Join(plan.systemErase, ","), jobURI, err)
+		if err != nil {
+			return diag.Errorf("error erasing %v: %s", plan.systemErase, err)
+		}
+		if jobURI != "" {
+			jobURIs = append(jobURIs, jobURI)
+		}
+	}
+
+	d.SetId(system.ODataID + "#erase")
+	if err := d.Set("job_uris", jobURIs); err != nil {
+		return diag.Errorf("error setting job_uris: %s", err)
+	}
+
+	if d.Get("wait").(bool) {
+		for _, jobURI := range jobURIs {
+			err := common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(d.Timeout(schema.TimeoutCreate).Seconds()), progress.reporter(jobURI))
+			opLog.record("job_completion", system.ODataID, jobURI, err)
+			if err != nil {
+				return diag.Errorf("error waiting for job %s to finish: %s", jobURI, err)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Erase of %v started successfully", d.Id(), d.Get("components").(*schema.Set).List())
+	return resourceRedfishCryptoEraseSystemRead(ctx, d, m)
+}
+
+func resourceRedfishCryptoEraseSystemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The erase has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishCryptoEraseSystemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The erased data cannot be restored
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishCryptoEraseSystemCustomizeDiff checks the erase is confirmed and its components are supported by the vendor
+func resourceRedfishCryptoEraseSystemCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	if !d.Get("confirm_erase").(bool) {
+		return fmt.Errorf("confirm_erase must be true to erase the system")
+	}
+	_, err := expandSystemErasePlan(m.(*providerConfig).oem.Vendor(), d)
+	return err
+}
@@ -0,0 +1,47 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSystemErasePlan(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		components []string
+		method     string
+		drives     []string
+		expected   systemErasePlan
+		shouldPass bool
+	}{
+		{1, "dell", []string{"idrac", "bios", "drives", "lc_data"}, "cryptographic", []string{},
+			systemErasePlan{systemErase: []string{"BIOS", "CryptographicErasePD", "IDRAC", "LCData"}, drives: []string{}}, true},
+		{2, "dell", []string{"drives"}, "overwrite", []string{},
+			systemErasePlan{systemErase: []string{"OverwritePD"}, drives: []string{}}, true},
+		// Selected drives are erased one by one with SecureErase
+		{3, "dell", []string{"drives", "bios"}, "cryptographic", []string{"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0"},
+			systemErasePlan{systemErase: []string{"BIOS"}, drives: []string{"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0"}}, true},
+		{4, "hpe", []string{"bios", "drives"}, "cryptographic", []string{},
+			systemErasePlan{systemErase: []string{}, resetBios: true, eraseAllDrives: true, drives: []string{}}, true},
+		{5, "hpe", []string{"idrac"}, "cryptographic", []string{}, systemErasePlan{}, false},
+		{6, "hpe", []string{"drives"}, "overwrite", []string{}, systemErasePlan{}, false},
+		{7, "dell", []string{"bios"}, "cryptographic", []string{"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/Disk.Bay.0"}, systemErasePlan{}, false},
+	}
+	for _, v := range cases {
+		plan, err := newSystemErasePlan(v.vendor, v.components, v.method, v.drives)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(*plan, v.expected) {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, *plan)
+		}
+	}
+}
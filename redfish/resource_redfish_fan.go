@@ -0,0 +1,263 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+)
+
+// fanAttributes maps the redfish_fan variables to the Dell system attributes
+var fanAttributes = dellAttributeMapping{
+	"third_party_pcie_fan_response": "ThermalSettings.1.ThirdPartyPCIFanResponse",
+}
+
+// fanRedundancyTypes maps the RedundancyType of the DMTF ThermalSubsystem to the Mode of the Thermal resource,
+// which redundancy_mode is expressed in
+var fanRedundancyTypes = map[string]string{
+	"NPlusM":       "N+m",
+	"Sparing":      "Sparing",
+	"Failover":     "Failover",
+	"NotRedundant": "NotRedundant",
+}
+
+// fanRedundancy is the fan redundancy of a chassis, as exposed by its ThermalSubsystem or its Thermal resource
+type fanRedundancy struct {
+	mode         string
+	health       string
+	minFans      int
+	thermalURI   string
+	configurable bool
+	fans         []map[string]interface{}
+}
+
+func resourceRedfishFan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishFanUpdate),
+		ReadContext:   resourceRedfishFanRead,
+		UpdateContext: withLockdownBypass(resourceRedfishFanUpdate),
+		DeleteContext: resourceRedfishFanDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"redundancy_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "Redundancy mode of the fans of the chassis. Applicable values are 'N+m', 'Sharing', 'Sparing', 'Failover' and " +
+					"'NotRedundant'. Only BMCs exposing a writable Redundancy in the Thermal resource of the chassis can change it",
+				ValidateFunc: validation.StringInSlice([]string{"N+m", "Sharing", "Sparing", "Failover", "NotRedundant"}, false),
+			},
+			"third_party_pcie_fan_response": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the fans speed up for the PCIe cards the BMC does not know the cooling needs of. " +
+					"Only supported on Dell systems",
+			},
+			"redundancy_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the fan redundancy (i.e. OK, or Warning when a fan failure lost it). Empty when the BMC does not report it",
+			},
+			"min_fans_needed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of fans needed to cool the chassis under the redundancy mode",
+			},
+			"fans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Fans of the chassis",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the fan",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the fan",
+						},
+						"reading": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Speed of the fan, in reading_units",
+						},
+						"reading_units": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Units of reading ('RPM' or 'Percent')",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the fan",
+						},
+					},
+				},
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishFanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	vendor := m.(*providerConfig).oem.Vendor()
+
+	log.Printf("[DEBUG] Beginning fan update")
+	opLog := newOperationLog(m, "redfish_fan")
+	defer opLog.save(d)
+
+	chassis, err := getSystemChassis(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the chassis of the system: %s", err)
+	}
+	d.SetId(chassis.ODataID + "#fan")
+
+	if v, ok := d.GetOk("redundancy_mode"); ok {
+		redundancy, err := getFanRedundancy(conn, chassis)
+		if err != nil {
+			return diag.Errorf("error fetching the fan redundancy: %s", err)
+		}
+		if v.(string) != redundancy.mode {
+			if !redundancy.configurable {
+				return diag.Errorf("the fan redundancy of chassis %s cannot be changed, it is %s", chassis.ID, redundancy.mode)
+			}
+			payload := map[string]interface{}{"Redundancy": []map[string]interface{}{{"Mode": v.(string)}}}
+			err := common.PatchResource(conn, redundancy.thermalURI, payload)
+			opLog.record("redundancy_patch", redundancy.thermalURI, "", err)
+			if err != nil {
+				return diag.Errorf("error updating the fan redundancy: %s", err)
+			}
+		}
+	}
+
+	if vendor == "dell" {
+		err := updateDellAttributes(conn, d, common.DellSystemAttributesURI, fanAttributes)
+		opLog.record("attributes_patch", common.DellSystemAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating fan attributes: %s", err)
+		}
+	} else if _, ok := d.GetOk("third_party_pcie_fan_response"); ok {
+		return diag.Errorf("third_party_pcie_fan_response is not supported on %s BMCs", vendor)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishFanRead(ctx, d, m)
+}
+
+func resourceRedfishFanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	chassis, err := getSystemChassis(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the chassis of the system: %s", err)
+	}
+	redundancy, err := getFanRedundancy(conn, chassis)
+	if err != nil {
+		return diag.Errorf("error fetching the fan redundancy: %s", err)
+	}
+
+	values := map[string]interface{}{
+		"redundancy_mode":   redundancy.mode,
+		"redundancy_status": redundancy.health,
+		"min_fans_needed":   redundancy.minFans,
+		"fans":              redundancy.fans,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, fanAttributes); err != nil {
+			return diag.Errorf("error reading fan attributes: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishFanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The cooling settings are left as configured, as the BMC has no default to restore them to
+	d.SetId("")
+
+	return diags
+}
+
+// getFanRedundancy returns the fan redundancy of the chassis, preferring its ThermalSubsystem over the
+// legacy Thermal resource. Only the Redundancy of the Thermal resource is writable.
+func getFanRedundancy(conn *gofish.APIClient, chassis *redfish.Chassis) (*fanRedundancy, error) {
+	redundancy := &fanRedundancy{fans: []map[string]interface{}{}}
+
+	subsystem, err := common.GetThermalSubsystem(conn, chassis.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	if subsystem != nil {
+		if len(subsystem.FanRedundancy) > 0 {
+			group := subsystem.FanRedundancy[0]
+			redundancy.mode = fanRedundancyTypes[group.RedundancyType]
+			redundancy.health = string(group.Status.Health)
+			redundancy.minFans = group.MinNeededInGroup
+		}
+		for _, fan := range subsystem.Fans {
+			reading, units := 0.0, "Percent"
+			if fan.SpeedPercent.Reading != nil {
+				reading = *fan.SpeedPercent.Reading
+			} else if fan.SpeedPercent.SpeedRPM != nil {
+				reading, units = *fan.SpeedPercent.SpeedRPM, "RPM"
+			}
+			redundancy.fans = append(redundancy.fans, map[string]interface{}{
+				"id":            fan.ID,
+				"name":          fan.Name,
+				"reading":       reading,
+				"reading_units": units,
+				"health":        string(fan.Status.Health),
+			})
+		}
+		// Some BMCs only report the redundancy in the Thermal resource, along with the ThermalSubsystem
+		if len(subsystem.FanRedundancy) > 0 {
+			return redundancy, nil
+		}
+	}
+
+	thermal, err := chassis.Thermal()
+	if err != nil {
+		return nil, err
+	}
+	if thermal == nil {
+		return redundancy, nil
+	}
+	redundancy.thermalURI = thermal.ODataID
+	if len(thermal.Redundancy) > 0 {
+		redundancy.mode = string(thermal.Redundancy[0].Mode)
+		redundancy.health = string(thermal.Redundancy[0].Status.Health)
+		redundancy.minFans = thermal.Redundancy[0].MinNumNeeded
+		redundancy.configurable = true
+	}
+	if subsystem != nil {
+		return redundancy, nil
+	}
+	for _, fan := range thermal.Fans {
+		redundancy.fans = append(redundancy.fans, map[string]interface{}{
+			"id":            fan.MemberID,
+			"name":          fan.Name,
+			"reading":       float64(fan.Reading),
+			"reading_units": string(fan.ReadingUnits),
+			"health":        string(fan.Status.Health),
+		})
+	}
+	return redundancy, nil
+}
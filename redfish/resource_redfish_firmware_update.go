@@ -0,0 +1,816 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+const (
+	// This constants are used to avoid hardcoding the terraform input variables
+	firmwareImageURI         string = "image_uri"
+	firmwareTransferProtocol string = "transfer_protocol"
+	firmwareCatalogURL       string = "catalog_url"
+	firmwareCatalogBaseURI   string = "catalog_base_uri"
+	firmwareSystemModel      string = "system_model"
+	firmwareAppliedPackages  string = "applied_packages"
+	firmwarePendingPackages  string = "pending_packages"
+	firmwareUpdateJobURIs    string = "update_job_uris"
+	firmwareVersions         string = "firmware_versions"
+	firmwareUpdateISOURI     string = "update_iso_uri"
+	firmwareOnDestroy        string = "on_destroy"
+	firmwareUpdated          string = "updated_firmware"
+	firmwareTargets          string = "targets"
+	firmwareTargetBlock      string = "target"
+	firmwarePrevious         string = "previous_versions"
+	firmwareRollbackPackages string = "rollback_packages"
+	firmwareRollback         string = "rollback"
+	firmwareChanges          string = "components_updated"
+)
+
+// firmwareSourceAttributes are the variables selecting what is installed, whose changes push the packages again
+var firmwareSourceAttributes = []string{firmwareImageURI, firmwareCatalogURL, firmwareUpdateISOURI, firmwareTargets, firmwareTargetBlock}
+
+// defaultFirmwareUpdateTimeout is the time to wait for all the update jobs of a resource to finish
+const defaultFirmwareUpdateTimeout = 60 * time.Minute
+
+func resourceRedfishFirmwareUpdate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withFirmwareQueue(withPreconditions(withLockdownBypass(resourceRedfishFirmwareUpdateUpdate))),
+		ReadContext:   resourceRedfishFirmwareUpdateRead,
+		UpdateContext: withFirmwareQueue(withPreconditions(withLockdownBypass(resourceRedfishFirmwareUpdateUpdate))),
+		DeleteContext: withFirmwareQueue(withPreconditions(withLockdownBypass(resourceRedfishFirmwareUpdateDelete))),
+		CustomizeDiff: customdiff.Sequence(resourceRedfishFirmwareUpdateCustomizeDiff, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultFirmwareUpdateTimeout),
+			Update: schema.DefaultTimeout(defaultFirmwareUpdateTimeout),
+			Delete: schema.DefaultTimeout(defaultFirmwareUpdateTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			firmwareImageURI: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URI of a single update package the BMC will pull and apply. I.e: http://10.0.0.1/BIOS_XXXXX_WN64_2.7.7.EXE",
+				ExactlyOneOf: []string{firmwareImageURI, firmwareCatalogURL, firmwareUpdateISOURI},
+			},
+			firmwareTransferProtocol: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Protocol the BMC will use to pull the update packages (i.e. HTTP, HTTPS, NFS, CIFS). If not set, the BMC infers it from the URI",
+				ConflictsWith: []string{shareAttribute},
+			},
+			shareAttribute: shareSchema("Network share the BMC pulls the update packages from, for shares needing credentials. When set, image_uri and update_iso_uri are paths in the share, and the catalog packages are pulled from the share"),
+			firmwareCatalogURL: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of a firmware repository catalog (i.e. Dell Catalog.xml or Catalog.xml.gz). Every package newer than the installed firmware for this system will be applied",
+				ExactlyOneOf: []string{firmwareImageURI, firmwareCatalogURL, firmwareUpdateISOURI},
+			},
+			firmwareCatalogBaseURI: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Base URI of the repository mirror the BMC will pull the catalog packages from. By default the catalog baseLocation is used",
+				ConflictsWith: []string{shareAttribute},
+			},
+			firmwareSystemModel: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "System model used to select the catalog packages (i.e. PowerEdge R740). By default it is read from the system",
+			},
+			firmwareUpdateISOURI: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URI of a bootable vendor update ISO. It is mounted as virtual CD and the system is rebooted into it once. The media stays mounted until the system has reset and its firmware inventory has stopped changing. Use it for components that cannot be updated in-band through Redfish",
+				ExactlyOneOf: []string{firmwareImageURI, firmwareCatalogURL, firmwareUpdateISOURI},
+			},
+			firmwareTargets: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "Devices the updates are restricted to, as firmware inventory URIs or Dell FQDDs (i.e. NIC.Integrated.1-1-1). Use it when a package applies to several identical devices and only some must be updated. If neither targets nor target are set, every applicable device is updated",
+				ConflictsWith: []string{firmwareUpdateISOURI},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwareTargetBlock: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "Devices the updates are restricted to, selected by properties that do not change between BMC firmware revisions and languages, unlike the inventory names. Combined with targets. The first property set selects the devices, in this order: fqdd, software_id and name",
+				ConflictsWith: []string{firmwareUpdateISOURI},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdd": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Dell FQDD of a device (i.e. NIC.Integrated.1-1-1). On other vendors, the Id of its firmware inventory entry",
+						},
+						"software_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SoftwareId of the firmware (i.e. derived from the PCI device id). It selects every identical device",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the firmware inventory entries. Only meant for BMCs reporting no SoftwareId, as names vary between BMC firmware revisions and localizations",
+						},
+					},
+				},
+			},
+			firmwareOnDestroy: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "noop",
+				Description:  "What destroying the resource does. 'noop' only removes it from the state, 'rollback' reinstalls the previous firmware of the components updated by the resource. By default 'noop'",
+				ValidateFunc: validation.StringInSlice([]string{"noop", "rollback"}, false),
+			},
+			firmwareRollbackPackages: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Update packages to roll components back with, indexed by SoftwareId. Used by rollback and on_destroy = 'rollback' for the components the BMC keeps no previous image of, or whose previous image is not the version recorded in previous_versions",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwareRollback: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value that rolls back the components updated by the resource when changed to a non empty value, to recover quickly from a bad update. It cannot change together with the update source. When using catalog_url, remove the bad package from the catalog or the next apply installs it again",
+			},
+			firmwarePrevious: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Version each component updated by the resource was running before its last update, indexed by SoftwareId. Rollbacks restore these versions",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwareUpdated: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Components updated by the resource and the version it installed, indexed by SoftwareId. Used by on_destroy = 'rollback'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwareChanges: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Components whose installed firmware changed during the last run (update or rollback), from the firmware inventory before and after it. Meant for notifications and change records",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the component in the firmware inventory",
+						},
+						"software_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SoftwareId of the component, or its name when the BMC reports none",
+						},
+						"previous_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version installed before the run. Empty for components that were not installed",
+						},
+						"new_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version installed by the run",
+						},
+					},
+				},
+			},
+			firmwareAppliedPackages: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Update packages applied by the last run",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwarePendingPackages: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Catalog packages newer than the installed firmware. If any is found, the next apply will install them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			firmwareUpdateJobURIs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the update jobs created by the last run",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			preconditionsAttribute:   preconditionsSchema(),
+			firmwareVersions: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Installed firmware versions, indexed by firmware inventory name",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceRedfishFirmwareUpdateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning firmware update")
+	opLog := newOperationLog(m, "redfish_firmware_update")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_firmware_update")
+	defer progress.save(d)
+	transferProtocol := d.Get(firmwareTransferProtocol).(string)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+
+	if v := d.Get(firmwareRollback).(string); !d.IsNewResource() && d.HasChange(firmwareRollback) && v != "" {
+		log.Printf("[DEBUG] %s: Rolling back updated firmware", d.Id())
+		before, err := common.GetFirmwareInventory(conn)
+		if err != nil {
+			return diag.Errorf("error fetching firmware inventory: %s", err)
+		}
+		if err := rollbackFirmware(ctx, conn, d, opLog, progress, timeout); err != nil {
+			return diag.Errorf("error rolling back firmware: %s", err)
+		}
+		after, err := common.GetFirmwareInventory(conn)
+		if err != nil {
+			return diag.Errorf("error fetching firmware inventory: %s", err)
+		}
+		if err := d.Set(firmwareChanges, flattenFirmwareChanges(common.FirmwareChanges(before, after))); err != nil {
+			return diag.Errorf("error setting %s: %s", firmwareChanges, err)
+		}
+		log.Printf("[DEBUG] %s: Firmware rollback finished successfully", d.Id())
+		return resourceRedfishFirmwareUpdateRead(ctx, d, m)
+	}
+
+	// Changing only the settings (i.e. on_destroy) does not flash the server again. Catalogs are still resolved,
+	// as the packages found pending by the last read are planned as a change too.
+	if !d.IsNewResource() && !d.HasChanges(firmwareSourceAttributes...) && d.Get(firmwareCatalogURL).(string) == "" {
+		log.Printf("[DEBUG] %s: Update source unchanged, nothing to apply", d.Id())
+		return resourceRedfishFirmwareUpdateRead(ctx, d, m)
+	}
+
+	share, err := expandShare(d)
+	if err != nil {
+		return diag.Errorf("error in share: %s", err)
+	}
+
+	before, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return diag.Errorf("error fetching firmware inventory: %s", err)
+	}
+
+	if v, ok := d.GetOk(firmwareUpdateISOURI); ok {
+		d.SetId(v.(string))
+		if err := applyUpdateISO(ctx, m.(*providerConfig), opLog, v.(string), share, timeout); err != nil {
+			return diag.Errorf("error applying update ISO %s: %s", v.(string), err)
+		}
+		if err := d.Set(firmwareAppliedPackages, []string{v.(string)}); err != nil {
+			return diag.Errorf("error setting applied packages: %s", err)
+		}
+		if err := setUpdatedFirmware(conn, d, before); err != nil {
+			return diag.Errorf("error recording updated firmware: %s", err)
+		}
+		if err := clearShareWriteOnly(d); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] %s: Firmware update finished successfully", d.Id())
+		return resourceRedfishFirmwareUpdateRead(ctx, d, m)
+	}
+
+	targets, err := getFirmwareTargets(m.(*providerConfig).oem, d, before)
+	if err != nil {
+		return diag.Errorf("error resolving targets: %s", err)
+	}
+
+	// packages holds the paths in the share when a share is set
+	var packages, imageURIs []string
+	// packageTargets holds the target URIs of every package, nil when the update is not restricted
+	var packageTargets [][]string
+	// packageComponents holds the names of the components every package updates, to find its jobs
+	var packageComponents [][]string
+	// packageVersions holds the version of every package, when known, to tell its jobs from the ones of other versions
+	var packageVersions []string
+	if v, ok := d.GetOk(firmwareImageURI); ok {
+		packages = []string{v.(string)}
+		imageURIs = []string{v.(string)}
+		if share != nil {
+			imageURIs = []string{share.URI(v.(string))}
+		}
+		packageTargets = [][]string{targetURIs(targets)}
+		packageComponents = [][]string{targetNames(targets)}
+		packageVersions = []string{""}
+		d.SetId(v.(string))
+	} else {
+		catalogURL := d.Get(firmwareCatalogURL).(string)
+		catalog, updates, err := resolveCatalogUpdates(ctx, conn, m.(*providerConfig), d)
+		if err != nil {
+			return diag.Errorf("error resolving updates from catalog %s: %s", catalogURL, err)
+		}
+		baseURI := d.Get(firmwareCatalogBaseURI).(string)
+		if share != nil {
+			baseURI = share.URI("")
+		}
+		for _, update := range updates {
+			packages = append(packages, update.Path)
+			imageURIs = append(imageURIs, catalog.PackageURI(baseURI, update))
+			updateTargets := catalogUpdateTargets(update, targets)
+			packageTargets = append(packageTargets, targetURIs(updateTargets))
+			packageComponents = append(packageComponents, targetNames(updateTargets))
+			packageVersions = append(packageVersions, update.Version())
+		}
+		d.SetId(catalogURL)
+	}
+
+	// A create interrupted after pushing the packages left their jobs running without saving them to the state.
+	// They are adopted instead of pushing the packages again while the first jobs run.
+	inFlight := []*common.Task{}
+	if d.IsNewResource() {
+		if inFlight, err = m.(*providerConfig).oem.Jobs(conn); err != nil {
+			log.Printf("[DEBUG] %s: error fetching the jobs in flight, pushing every package: %s", d.Id(), err)
+			inFlight = []*common.Task{}
+		}
+	}
+
+	jobURIs := []string{}
+	for i, imageURI := range imageURIs {
+		if job := common.FindFirmwareUpdateJob(inFlight, imageURI, packageVersions[i], packageComponents[i]); job != nil {
+			log.Printf("[DEBUG] %s: Update package %s is already being applied by job %s, waiting for it", d.Id(), imageURI, job.ODataID)
+			opLog.record("firmware_push_adopted", imageURI, job.ODataID, nil)
+			jobURIs = append(jobURIs, job.ODataID)
+			inFlight = removeTask(inFlight, job)
+			continue
+		}
+		log.Printf("[DEBUG] %s: Applying update package %s to %v", d.Id(), imageURI, packageTargets[i])
+		var jobURI string
+		if share != nil {
+			jobURI, err = common.SimpleUpdateFromShare(conn, share, packages[i], packageTargets[i])
+		} else {
+			jobURI, err = common.SimpleUpdate(conn, imageURI, transferProtocol, packageTargets[i])
+		}
+		opLog.record("firmware_push", imageURI, jobURI, err)
+		if err != nil {
+			// Keep the jobs of the packages already pushed, so the state shows what was sent before retrying
+			if setErr := d.Set(firmwareUpdateJobURIs, jobURIs); setErr != nil {
+				log.Printf("[DEBUG] %s: error setting update job uris: %s", d.Id(), setErr)
+			}
+			return diag.Errorf("error applying update package %s: %s", imageURI, err)
+		}
+		jobURIs = append(jobURIs, jobURI)
+	}
+	if err := d.Set(firmwareUpdateJobURIs, jobURIs); err != nil {
+		return diag.Errorf("error setting update job uris: %s", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for i, jobURI := range jobURIs {
+		remaining := int(time.Until(deadline).Seconds())
+		if remaining <= 0 {
+			opLog.record("job_completion", imageURIs[i], jobURI, fmt.Errorf("timeout reached"))
+			return diag.Errorf("timeout reached waiting for update job %s to finish", jobURI)
+		}
+		err := common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, remaining, progress.reporter(jobURI))
+		opLog.record("job_completion", imageURIs[i], jobURI, err)
+		if err != nil {
+			return diag.Errorf("error waiting for update job %s to finish: %s", jobURI, err)
+		}
+	}
+
+	if err := d.Set(firmwareAppliedPackages, packages); err != nil {
+		return diag.Errorf("error setting applied packages: %s", err)
+	}
+	if err := setUpdatedFirmware(conn, d, before); err != nil {
+		return diag.Errorf("error recording updated firmware: %s", err)
+	}
+	if err := clearShareWriteOnly(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: Firmware update finished successfully", d.Id())
+	return resourceRedfishFirmwareUpdateRead(ctx, d, m)
+}
+
+func resourceRedfishFirmwareUpdateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] %s: Beginning read", d.Id())
+	inventory, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return diag.Errorf("error fetching firmware inventory: %s", err)
+	}
+	versions := make(map[string]string)
+	for _, entry := range inventory {
+		if entry.Installed() {
+			versions[entry.Name] = entry.Version
+		}
+	}
+	if err := d.Set(firmwareVersions, versions); err != nil {
+		return diag.Errorf("error setting firmware versions: %s", err)
+	}
+
+	pending := []string{}
+	if _, ok := d.GetOk(firmwareCatalogURL); ok {
+		_, updates, err := resolveCatalogUpdates(ctx, conn, m.(*providerConfig), d)
+		if err != nil {
+			return diag.Errorf("error resolving updates from catalog: %s", err)
+		}
+		for _, update := range updates {
+			pending = append(pending, update.Path)
+		}
+	}
+	if err := d.Set(firmwarePendingPackages, pending); err != nil {
+		return diag.Errorf("error setting pending packages: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Read finished successfully", d.Id())
+	return diags
+}
+
+func resourceRedfishFirmwareUpdateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.Get(firmwareOnDestroy).(string) == "rollback" {
+		conn := m.(*providerConfig).clientWithContext(ctx)
+		log.Printf("[DEBUG] %s: Rolling back updated firmware", d.Id())
+		opLog := newOperationLog(m, "redfish_firmware_update")
+		// The state is dropped on destroy, so the last progress of a failed rollback is shown as a warning
+		progress := newJobProgress("redfish_firmware_update")
+		if err := rollbackFirmware(ctx, conn, d, opLog, progress, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return append(diag.Errorf("error rolling back firmware: %s", err), progress.warning()...)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishFirmwareUpdateCustomizeDiff plans a new run when the last read found catalog packages
+// newer than the installed firmware, so "update to latest" converges on every apply.
+// Rollbacks are planned on their own, as they undo the updates instead of applying them.
+func resourceRedfishFirmwareUpdateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange(firmwareRollback) {
+		for _, key := range firmwareSourceAttributes {
+			if d.HasChange(key) {
+				return fmt.Errorf("%s cannot change together with %s", firmwareRollback, key)
+			}
+		}
+		return d.SetNewComputed(firmwareChanges)
+	}
+	if pending, ok := d.Get(firmwarePendingPackages).([]interface{}); ok && len(pending) > 0 {
+		log.Printf("[DEBUG] %s: %d catalog packages pending to be applied", d.Id(), len(pending))
+		if err := d.SetNewComputed(firmwareAppliedPackages); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed(firmwareChanges); err != nil {
+			return err
+		}
+		return d.SetNewComputed(firmwarePendingPackages)
+	}
+	// Any other run reports its own changes
+	for _, key := range firmwareSourceAttributes {
+		if d.HasChange(key) {
+			return d.SetNewComputed(firmwareChanges)
+		}
+	}
+	return nil
+}
+
+// setUpdatedFirmware adds the components updated since the before snapshot to the updated firmware of the resource,
+// recording the version they were running before as their previous version. The changes of the run are
+// also reported in components_updated.
+func setUpdatedFirmware(conn *gofish.APIClient, d *schema.ResourceData, before []*common.FirmwareInventoryEntry) error {
+	after, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return err
+	}
+	updated := make(map[string]interface{})
+	for component, version := range d.Get(firmwareUpdated).(map[string]interface{}) {
+		updated[component] = version
+	}
+	previous := make(map[string]interface{})
+	for component, version := range d.Get(firmwarePrevious).(map[string]interface{}) {
+		previous[component] = version
+	}
+	for component, version := range common.UpdatedFirmware(before, after) {
+		updated[component] = version
+		if installed := common.FindFirmware(before, component, true); len(installed) > 0 {
+			previous[component] = installed[0].Version
+		}
+	}
+	if err := d.Set(firmwarePrevious, previous); err != nil {
+		return err
+	}
+	if err := d.Set(firmwareChanges, flattenFirmwareChanges(common.FirmwareChanges(before, after))); err != nil {
+		return err
+	}
+	return d.Set(firmwareUpdated, updated)
+}
+
+func flattenFirmwareChanges(changes []common.FirmwareChange) []interface{} {
+	flattened := []interface{}{}
+	for _, change := range changes {
+		flattened = append(flattened, map[string]interface{}{
+			"name":             change.Name,
+			"software_id":      change.Component,
+			"previous_version": change.PreviousVersion,
+			"new_version":      change.NewVersion,
+		})
+	}
+	return flattened
+}
+
+// rollbackFirmware reinstalls the previous image of the components updated by the resource.
+// The previous image kept by the BMC is used, unless it is not the version recorded before the update
+// and a rollback package is set for the component.
+// Components no longer running the version the resource installed (i.e. already rolled back,
+// or updated by someone else) are left untouched, so a failed rollback can be safely retried.
+func rollbackFirmware(ctx context.Context, conn *gofish.APIClient, d *schema.ResourceData, opLog *operationLog, progress *jobProgress, timeout time.Duration) error {
+	inventory, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return fmt.Errorf("error fetching firmware inventory: %s", err)
+	}
+	jobURIs := []string{}
+	for component, version := range d.Get(firmwareUpdated).(map[string]interface{}) {
+		installed := common.FindFirmware(inventory, component, true)
+		if len(installed) == 0 || installed[0].Version != version.(string) {
+			log.Printf("[DEBUG] %s: %s is not running %s anymore, skipping rollback", d.Id(), component, version)
+			continue
+		}
+		previous := common.FindFirmware(inventory, component, false)
+		recorded, _ := d.Get(firmwarePrevious).(map[string]interface{})[component].(string)
+		if rollbackPackage, ok := d.Get(firmwareRollbackPackages).(map[string]interface{})[component].(string); ok && (len(previous) == 0 || (recorded != "" && previous[0].Version != recorded)) {
+			log.Printf("[DEBUG] %s: Rolling %s back with %s", d.Id(), installed[0].Name, rollbackPackage)
+			jobURI, err := common.SimpleUpdate(conn, rollbackPackage, d.Get(firmwareTransferProtocol).(string), nil)
+			opLog.record("firmware_rollback", rollbackPackage, jobURI, err)
+			if err != nil {
+				return fmt.Errorf("error rolling %s back: %s", installed[0].Name, err)
+			}
+			jobURIs = append(jobURIs, jobURI)
+			continue
+		}
+		if len(previous) == 0 {
+			return fmt.Errorf("there is no previous firmware to roll %s back to. Set a package for it in %s", installed[0].Name, firmwareRollbackPackages)
+		}
+		for _, entry := range previous {
+			log.Printf("[DEBUG] %s: Rolling %s back to %s", d.Id(), entry.Name, entry.Version)
+			jobURI, err := common.SimpleUpdate(conn, entry.ODataID, "", nil)
+			opLog.record("firmware_rollback", entry.ODataID, jobURI, err)
+			if err != nil {
+				return fmt.Errorf("error rolling %s back: %s", entry.Name, err)
+			}
+			jobURIs = append(jobURIs, jobURI)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for _, jobURI := range jobURIs {
+		remaining := int(time.Until(deadline).Seconds())
+		if remaining <= 0 {
+			opLog.record("job_completion", jobURI, jobURI, fmt.Errorf("timeout reached"))
+			return fmt.Errorf("timeout reached waiting for rollback job %s to finish", jobURI)
+		}
+		err := common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, remaining, progress.reporter(jobURI))
+		opLog.record("job_completion", jobURI, jobURI, err)
+		if err != nil {
+			return fmt.Errorf("error waiting for rollback job %s to finish: %s", jobURI, err)
+		}
+	}
+	return nil
+}
+
+// applyUpdateISO mounts a bootable update ISO as virtual CD, boots the system from it once
+// and waits until the run of the ISO is complete. The media is always ejected and the
+// one-time boot override cleared afterwards, verifying both as some BMCs report success without doing it.
+// When share is set, isoURI is a path in the share.
+func applyUpdateISO(ctx context.Context, config *providerConfig, opLog *operationLog, isoURI string, share *common.Share, timeout time.Duration) (err error) {
+	conn := config.clientWithContext(ctx)
+	before, err := common.InstalledFirmwareVersions(conn)
+	if err != nil {
+		return fmt.Errorf("error fetching firmware inventory: %s", err)
+	}
+
+	virtualMedia, err := common.GetVirtualMediaForType(conn, config.managerID, redfish.CDMediaType)
+	if err != nil {
+		return err
+	}
+	if virtualMedia.Inserted {
+		log.Printf("[DEBUG] Ejecting %s from %s before mounting the update ISO", virtualMedia.Image, virtualMedia.ODataID)
+		err := common.EjectVirtualMediaAndVerify(conn, virtualMedia.ODataID, common.DetachAttempts, common.TimeBetweenAttempts)
+		opLog.record("virtual_media_eject", virtualMedia.Image, "", err)
+		if err != nil {
+			return fmt.Errorf("error ejecting the current media: %s", err)
+		}
+	}
+	if share != nil {
+		isoURI = share.URI(isoURI)
+		err = common.InsertVirtualMedia(conn, virtualMedia.ODataID, isoURI, share)
+	} else {
+		err = virtualMedia.InsertMedia(isoURI, true, true)
+	}
+	opLog.record("virtual_media_insert", isoURI, "", err)
+	if err != nil {
+		return fmt.Errorf("error mounting the update ISO: %s", err)
+	}
+	defer func() {
+		// The original client is used, so the media is ejected even if ctx was cancelled. An ISO left
+		// attached would be booted again by the next reboot
+		ejectErr := common.EjectVirtualMediaAndVerify(config.client, virtualMedia.ODataID, common.DetachAttempts, common.TimeBetweenAttempts)
+		opLog.record("virtual_media_eject", isoURI, "", ejectErr)
+		if ejectErr != nil && err == nil {
+			err = fmt.Errorf("error ejecting the update ISO: %s", ejectErr)
+		}
+	}()
+
+	system, err := common.GetSystem(conn, config.systemID)
+	if err != nil {
+		return err
+	}
+	err = system.SetBoot(redfish.Boot{
+		BootSourceOverrideTarget:  redfish.CdBootSourceOverrideTarget,
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+	})
+	opLog.record("set_boot_once", system.ODataID, "", err)
+	if err != nil {
+		return fmt.Errorf("error setting one-time boot from virtual CD: %s", err)
+	}
+	defer func() {
+		// The override is left set when the system did not boot from the CD (i.e. the update timed out before the reboot)
+		clearErr := common.ClearBootOverrideAndVerify(config.client, system.ODataID, redfish.CdBootSourceOverrideTarget, common.DetachAttempts, common.TimeBetweenAttempts)
+		opLog.record("clear_boot_override", system.ODataID, "", clearErr)
+		if clearErr != nil && err == nil {
+			err = fmt.Errorf("error clearing the one-time boot from virtual CD: %s", clearErr)
+		}
+	}()
+	resetType := redfish.OnResetType
+	if system.PowerState == redfish.OnPowerState {
+		resetType = redfish.ForceRestartResetType
+	}
+	err = system.Reset(resetType)
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return fmt.Errorf("error resetting the system: %s", err)
+	}
+
+	// The media is only ejected once the whole run is over, as the ISO keeps updating components after the first one
+	err = common.WaitForUpdateISOCompletion(ctx, conn, system.ODataID, before, common.UpdateISOStablePolls, common.TimeBetweenAttempts, int(timeout.Seconds()))
+	opLog.record("update_iso_completion", isoURI, "", err)
+	return err
+}
+
+// resolveCatalogUpdates downloads the catalog set on the resource and returns the packages
+// newer than the installed firmware for the system model.
+func resolveCatalogUpdates(ctx context.Context, conn *gofish.APIClient, config *providerConfig, d *schema.ResourceData) (*common.Catalog, []common.CatalogComponent, error) {
+	catalog, model, inventory, err := loadCatalog(ctx, conn, d, config.systemID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// With targets, only the firmware of the targeted devices is compared against the catalog
+	targets, err := getFirmwareTargets(config.oem, d, inventory)
+	if err != nil {
+		return nil, nil, err
+	}
+	if targets != nil {
+		inventory = targets
+	}
+	return catalog, catalog.ResolveUpdates(model, inventory), nil
+}
+
+// getFirmwareTargets resolves the targets and target blocks set on the resource against the firmware inventory.
+// It returns nil when the updates are not restricted to any target.
+func getFirmwareTargets(oem common.OEMHandler, d *schema.ResourceData, inventory []*common.FirmwareInventoryEntry) ([]*common.FirmwareInventoryEntry, error) {
+	rawTargets := d.Get(firmwareTargets).([]interface{})
+	rawBlocks := d.Get(firmwareTargetBlock).([]interface{})
+	if len(rawTargets) == 0 && len(rawBlocks) == 0 {
+		return nil, nil
+	}
+	targets := make([]string, len(rawTargets))
+	for i, raw := range rawTargets {
+		targets[i] = raw.(string)
+	}
+	entries, err := common.ResolveFirmwareTargets(oem, inventory, targets)
+	if err != nil {
+		return nil, err
+	}
+	selectors := []common.FirmwareTarget{}
+	for _, raw := range rawBlocks {
+		block, _ := raw.(map[string]interface{})
+		if block == nil {
+			return nil, fmt.Errorf("%s blocks need fqdd, software_id or name", firmwareTargetBlock)
+		}
+		selectors = append(selectors, common.FirmwareTarget{
+			FQDD:       block["fqdd"].(string),
+			SoftwareID: block["software_id"].(string),
+			Name:       block["name"].(string),
+		})
+	}
+	selected, err := common.SelectFirmwareTargets(oem, inventory, selectors)
+	if err != nil {
+		return nil, err
+	}
+	// Devices set in both are only targeted once
+	for _, entry := range selected {
+		duplicate := false
+		for _, target := range entries {
+			duplicate = duplicate || target.ODataID == entry.ODataID
+		}
+		if !duplicate {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// catalogUpdateTargets returns the targets a catalog package applies to. It returns nil when
+// the updates are not restricted, so the package is applied to every device.
+func catalogUpdateTargets(update common.CatalogComponent, targets []*common.FirmwareInventoryEntry) []*common.FirmwareInventoryEntry {
+	if targets == nil {
+		return nil
+	}
+	updateTargets := []*common.FirmwareInventoryEntry{}
+	for _, target := range targets {
+		if update.AppliesTo(target) {
+			updateTargets = append(updateTargets, target)
+		}
+	}
+	return updateTargets
+}
+
+// targetURIs returns the URIs of the targets, as expected by SimpleUpdate
+func targetURIs(targets []*common.FirmwareInventoryEntry) []string {
+	uris := []string{}
+	for _, target := range targets {
+		uris = append(uris, target.ODataID)
+	}
+	return uris
+}
+
+// targetNames returns the names of the firmware inventory entries
+func targetNames(targets []*common.FirmwareInventoryEntry) []string {
+	names := []string{}
+	for _, target := range targets {
+		names = append(names, target.Name)
+	}
+	return names
+}
+
+// removeTask returns tasks without task
+func removeTask(tasks []*common.Task, task *common.Task) []*common.Task {
+	result := []*common.Task{}
+	for _, t := range tasks {
+		if t != task {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// loadCatalog downloads the catalog set in catalog_url, once per run of the provider, and fetches what is needed to match
+// it against the system: the system model (unless set in system_model) and the firmware inventory.
+func loadCatalog(ctx context.Context, conn *gofish.APIClient, d *schema.ResourceData, systemID string) (*common.Catalog, string, []*common.FirmwareInventoryEntry, error) {
+	catalog, err := fetchFirmwareCatalog(ctx, d.Get(firmwareCatalogURL).(string))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	model := d.Get(firmwareSystemModel).(string)
+	if len(model) == 0 {
+		system, err := common.GetSystem(conn, systemID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		model = system.Model
+		if err := d.Set(firmwareSystemModel, model); err != nil {
+			return nil, "", nil, err
+		}
+	}
+	inventory, err := common.GetFirmwareInventory(conn)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return catalog, model, inventory, nil
+}
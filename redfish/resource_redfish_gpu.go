@@ -0,0 +1,274 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"strconv"
+)
+
+// Profiles of redfish_gpu
+const (
+	// gpuPerformanceProfile is for bare metal GPU servers, including MIG partitioning
+	gpuPerformanceProfile string = "performance"
+	// gpuVirtualizationProfile also enables the processor virtualization, to pass the GPUs (or their vGPUs) to VMs
+	gpuVirtualizationProfile string = "virtualization"
+)
+
+// gpuCoolingOffsets are the cooling offsets of redfish_gpu, from the lowest to the highest fan speed
+var gpuCoolingOffsets = []string{"Off", "Low", "Medium", "High", "Max"}
+
+// gpuPreset are the vendor attributes a redfish_gpu profile resolves to
+type gpuPreset struct {
+	// bios are the BIOS attributes, applied on the next reboot
+	bios map[string]string
+	// system are the Dell system attributes, applied right away
+	system map[string]string
+}
+
+// attributes returns every attribute of the preset, as stored in the attributes variable
+func (p *gpuPreset) attributes() map[string]string {
+	attributes := make(map[string]string)
+	for key, value := range p.bios {
+		attributes[key] = value
+	}
+	for key, value := range p.system {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// newGpuPreset maps the settings of a redfish_gpu profile to the attributes of the vendor.
+// Processor specific attributes (i.e. NUMA nodes per socket on AMD and sub-NUMA clustering on Intel)
+// are all included, the ones missing from the BIOS of the server are skipped when applying the preset.
+func newGpuPreset(vendor string, profile string, numaNodesPerSocket int, coolingOffset string) (*gpuPreset, error) {
+	preset := &gpuPreset{bios: map[string]string{}, system: map[string]string{}}
+	subNumaClustering := "Disabled"
+	if numaNodesPerSocket > 1 {
+		subNumaClustering = "Enabled"
+	}
+	switch vendor {
+	case "dell":
+		preset.bios["SriovGlobalEnable"] = "Enabled"
+		preset.bios["MmioAbove4Gb"] = "Enabled"
+		preset.bios["SysProfile"] = "PerfOptimized"
+		preset.bios["NumaNodesPerSocket"] = strconv.Itoa(numaNodesPerSocket)
+		preset.bios["SubNumaCluster"] = subNumaClustering
+		if profile == gpuVirtualizationProfile {
+			preset.bios["ProcVirtualization"] = "Enabled"
+		}
+		if coolingOffset == "Off" {
+			preset.system["ThermalSettings.1.FanSpeedOffset"] = "Off"
+		} else {
+			preset.system["ThermalSettings.1.FanSpeedOffset"] = coolingOffset + " Fan Speed"
+		}
+	case "hpe":
+		preset.bios["Sriov"] = "Enabled"
+		preset.bios["WorkloadProfile"] = "GraphicProcessing"
+		preset.bios["NumaMemoryDomainsPerSocket"] = map[int]string{1: "OneMemoryDomain", 2: "TwoMemoryDomains", 4: "FourMemoryDomains"}[numaNodesPerSocket]
+		preset.bios["SubNumaClustering"] = subNumaClustering
+		if profile == gpuVirtualizationProfile {
+			preset.bios["WorkloadProfile"] = "Virtualization-MaxPerformance"
+			preset.bios["ProcVirtualization"] = "Enabled"
+		}
+		// HPE has no fan speed offset, only cooling levels
+		preset.bios["ThermalConfig"] = map[string]string{
+			"Off":    "OptimalCooling",
+			"Low":    "OptimalCooling",
+			"Medium": "IncreasedCooling",
+			"High":   "IncreasedCooling",
+			"Max":    "MaximumCooling",
+		}[coolingOffset]
+	default:
+		return nil, fmt.Errorf("there is no GPU preset for %s servers. Use redfish_bios with the attributes recommended by the vendor instead", vendor)
+	}
+	return preset, nil
+}
+
+func resourceRedfishGpu() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishGpuUpdate),
+		ReadContext:   resourceRedfishGpuRead,
+		UpdateContext: withLockdownBypass(resourceRedfishGpuUpdate),
+		DeleteContext: resourceRedfishGpuDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishGpuCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"profile": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      gpuPerformanceProfile,
+				Description:  "Settings bundle to apply. 'performance' enables SR-IOV, MMIO above 4GB and the performance system profile. 'virtualization' also enables the processor virtualization to pass the GPUs to VMs",
+				ValidateFunc: validation.StringInSlice([]string{gpuPerformanceProfile, gpuVirtualizationProfile}, false),
+			},
+			"numa_nodes_per_socket": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "NUMA nodes per processor socket. Applicable values are 1, 2 and 4. More than 1 enables sub-NUMA clustering on Intel processors",
+				ValidateFunc: validation.IntInSlice([]int{1, 2, 4}),
+			},
+			"cooling_offset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "High",
+				Description:  "Additional cooling for the GPUs. Applicable values are 'Off', 'Low', 'Medium', 'High' and 'Max'. Mapped to the fan speed offset on Dell and the thermal configuration on HPE",
+				ValidateFunc: validation.StringInSlice(gpuCoolingOffsets, false),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Vendor attributes the preset manages, with their current values. Pending BIOS changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"skipped_attributes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Attributes of the preset the server does not have, i.e. the AMD specific ones on Intel servers",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishGpuUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning GPU preset update")
+	opLog := newOperationLog(m, "redfish_gpu")
+	defer opLog.save(d)
+
+	preset, err := newGpuPreset(oem.Vendor(), d.Get("profile").(string), d.Get("numa_nodes_per_socket").(int), d.Get("cooling_offset").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	skipped := []string{}
+	if len(preset.system) > 0 {
+		current, err := common.GetDellAttributes(conn, common.DellSystemAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading system attributes: %s", err)
+		}
+		payload := make(map[string]interface{})
+		for key, value := range preset.system {
+			if currentValue, ok := current[key]; !ok {
+				skipped = append(skipped, key)
+			} else if !equivalentValues(currentValue, value) {
+				payload[key] = value
+			}
+		}
+		if len(payload) > 0 {
+			err = common.PatchDellAttributes(conn, common.DellSystemAttributesURI, payload)
+			opLog.record("attributes_patch", common.DellSystemAttributesURI, "", err)
+			if err != nil {
+				return diag.Errorf("error updating system attributes: %s", err)
+			}
+		}
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	biosPayload, missing := biosChanges(bios, preset.bios)
+	skipped = append(skipped, missing...)
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "GPU"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	sort.Strings(skipped)
+	if err := d.Set("skipped_attributes", skipped); err != nil {
+		return diag.Errorf("error setting skipped_attributes: %s", err)
+	}
+	applied := preset.attributes()
+	for _, key := range skipped {
+		delete(applied, key)
+	}
+	if err := setStagedBiosAttributes(d, applied); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bios.ODataID + "#gpu")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishGpuRead(ctx, d, m)
+}
+
+func resourceRedfishGpuRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		current, err := common.GetDellAttributes(conn, common.DellSystemAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading system attributes: %s", err)
+		}
+		for key := range attributes {
+			if value, ok := current[key]; ok {
+				attributes[key] = value
+			}
+		}
+	}
+
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishGpuDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as there is no way to know which ones were set before the preset
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishGpuCustomizeDiff checks the vendor has a preset and plans the attributes of the preset
+func resourceRedfishGpuCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	preset, err := newGpuPreset(m.(*providerConfig).oem.Vendor(), d.Get("profile").(string), d.Get("numa_nodes_per_socket").(int), d.Get("cooling_offset").(string))
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	// Skipped attributes are not in the state
+	current := d.Get("attributes").(map[string]interface{})
+	attributes := make(map[string]string)
+	for key, value := range preset.attributes() {
+		if _, ok := current[key]; ok {
+			attributes[key] = value
+		}
+	}
+	return planStagedAttributes(d, attributes)
+}
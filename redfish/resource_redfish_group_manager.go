@@ -0,0 +1,90 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+// groupManagerAttributes maps the redfish_group_manager variables to the Dell iDRAC attributes
+var groupManagerAttributes = dellAttributeMapping{
+	"enabled": common.GroupManagerAttribute,
+}
+
+func resourceRedfishGroupManager() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishGroupManagerUpdate),
+		ReadContext:   resourceRedfishGroupManagerRead,
+		UpdateContext: withLockdownBypass(resourceRedfishGroupManagerUpdate),
+		DeleteContext: resourceRedfishGroupManagerDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether iDRAC Group Manager is enabled. While enabled, the group can push settings that override the ones managed by terraform, so security baselines usually require it to be disabled",
+			},
+		},
+	}
+}
+
+func resourceRedfishGroupManagerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning group manager update")
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, groupManagerAttributes); err != nil {
+		return diag.Errorf("error updating group manager: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#group_manager")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishGroupManagerRead(ctx, d, m)
+}
+
+func resourceRedfishGroupManagerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, groupManagerAttributes); err != nil {
+		return diag.Errorf("error reading group manager: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishGroupManagerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// withGroupManagerCheck wraps the create/update function of a resource so a warning is reported
+// when Group Manager is enabled, as the group may push settings that override the changes of f.
+// Deleted resources are not checked.
+// Failures checking Group Manager are only logged, as they do not affect the changes.
+func withGroupManagerCheck(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := f(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		enabled, err := common.GroupManagerEnabled(m.(*providerConfig).clientWithContext(ctx))
+		if err != nil {
+			log.Printf("[DEBUG] %s: error checking group manager: %s", d.Id(), err)
+			return diags
+		}
+		if enabled {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "iDRAC Group Manager is enabled",
+				Detail:   "The group can push its settings to this iDRAC, overriding the changes applied. Disable it with redfish_group_manager if the configuration is managed by terraform",
+			})
+		}
+		return diags
+	}
+}
@@ -0,0 +1,233 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+// hostNameDNSAttributes maps the redfish_host_name_dns variables to the Dell network attributes of the function
+var hostNameDNSAttributes = dellAttributeMapping{
+	"boot_protocol":   "LegacyBootProto",
+	"ip_via_dhcp":     "TcpIpViaDHCP",
+	"target_via_dhcp": "IscsiViaDHCP",
+	"primary_dns":     "IscsiInitiatorPrimDns",
+	"secondary_dns":   "IscsiInitiatorSecDns",
+	"initiator_name":  "IscsiInitiatorName",
+	"dhcp_vendor_id":  "DhcpVendId",
+}
+
+// hostNameDNSNoBootProtocol is the boot_protocol of the functions not booting the system. Standard Redfish calls it Disabled
+const hostNameDNSNoBootProtocol = "NONE"
+
+func resourceRedfishHostNameDNS() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishHostNameDNSUpdate),
+		ReadContext:   resourceRedfishHostNameDNSRead,
+		UpdateContext: withLockdownBypass(resourceRedfishHostNameDNSUpdate),
+		DeleteContext: resourceRedfishHostNameDNSDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"network_adapter_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the network adapter (i.e. NIC.Integrated.1 on Dell systems)",
+			},
+			"function_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the network device function the system boots from (i.e. NIC.Integrated.1-1-1 for the first port)",
+			},
+			"boot_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Protocol the function boots the system with. Applicable values are 'PXE', 'iSCSI' and 'NONE'",
+				ValidateFunc: validation.StringInSlice([]string{"PXE", "iSCSI", hostNameDNSNoBootProtocol}, false),
+			},
+			"ip_via_dhcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the boot environment takes its IP address, mask and DNS servers from DHCP",
+			},
+			"target_via_dhcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iSCSI boot target is taken from the DHCP options (root path) instead of the configuration of the NIC",
+			},
+			"primary_dns": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "DNS server the boot environment registers and resolves names with, when ip_via_dhcp is false",
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"secondary_dns": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Secondary DNS server of the boot environment, when ip_via_dhcp is false",
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"initiator_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name (IQN) the host presents to the iSCSI targets and registers with (i.e. iqn.2001-05.com.example:server1)",
+			},
+			"dhcp_vendor_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Vendor class identifier (DHCP option 60) the boot environment sends, so the DHCP server can tell the boot requests apart. Only supported on Dell systems",
+			},
+			"config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the changes on the next reboot, on BMCs with a Dell job queue",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishHostNameDNSUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning host name DNS update")
+	opLog := newOperationLog(m, "redfish_host_name_dns")
+	defer opLog.save(d)
+
+	function, err := getHostNameDNSFunction(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(function.ODataID + "#host_name_dns")
+
+	var settingsURI, jobURI string
+	if oem.Vendor() == "dell" {
+		settingsURI = function.DellNetworkAttributesURI() + "/Settings"
+		err = updateDellAttributes(conn, d, settingsURI, hostNameDNSAttributes)
+		if err == nil {
+			jobURI, err = oem.CreateConfigJob(conn, settingsURI)
+		}
+	} else {
+		if _, ok := d.GetOk("dhcp_vendor_id"); ok {
+			return diag.Errorf("dhcp_vendor_id is not supported on %s BMCs", oem.Vendor())
+		}
+		settingsURI = function.SettingsURI()
+		jobURI, err = common.PatchResourceWithJob(conn, settingsURI, expandHostNameDNSSettings(d))
+	}
+	opLog.record("network_settings_patch", settingsURI, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error updating the boot settings of function %s: %s", function.ID, err)
+	}
+	if err := d.Set("config_job_uri", jobURI); err != nil {
+		return diag.Errorf("error setting config_job_uri: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished, the changes will be applied on the next reboot", d.Id())
+	return resourceRedfishHostNameDNSRead(ctx, d, m)
+}
+
+func resourceRedfishHostNameDNSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// The settings are kept until the reboot applies the pending changes
+	if configJobPending(conn, d, d.Get("config_job_uri").(string)) {
+		return diags
+	}
+
+	function, err := getHostNameDNSFunction(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, function.DellNetworkAttributesURI(), hostNameDNSAttributes); err != nil {
+			return diag.Errorf("error reading the boot settings of function %s: %s", function.ID, err)
+		}
+		return diags
+	}
+
+	bootProtocol := function.BootMode
+	if bootProtocol == "Disabled" {
+		bootProtocol = hostNameDNSNoBootProtocol
+	}
+	values := map[string]interface{}{
+		"boot_protocol":   bootProtocol,
+		"ip_via_dhcp":     function.ISCSIBoot.IPMaskDNSViaDHCP,
+		"target_via_dhcp": function.ISCSIBoot.TargetInfoViaDHCP,
+		"primary_dns":     function.ISCSIBoot.PrimaryDNS,
+		"secondary_dns":   function.ISCSIBoot.SecondaryDNS,
+		"initiator_name":  function.ISCSIBoot.InitiatorName,
+		"dhcp_vendor_id":  "",
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishHostNameDNSDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The boot settings are left as configured, as the network boot of the host depends on them
+	d.SetId("")
+
+	return diags
+}
+
+// getHostNameDNSFunction fetches the network device function of redfish_host_name_dns
+func getHostNameDNSFunction(conn *gofish.APIClient, d *schema.ResourceData) (*common.NetworkDeviceFunction, error) {
+	_, functions, err := getVirtualNetworkFunctions(conn, d.Get("network_adapter_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	function, ok := functions[d.Get("function_id").(string)]
+	if !ok {
+		return nil, fmt.Errorf("function %s not found in network adapter %s", d.Get("function_id").(string), d.Get("network_adapter_id").(string))
+	}
+	return function, nil
+}
+
+// expandHostNameDNSSettings returns the standard Redfish properties of the function for the variables set in the configuration
+func expandHostNameDNSSettings(d *schema.ResourceData) map[string]interface{} {
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOk("boot_protocol"); ok {
+		payload["BootMode"] = v.(string)
+		if v.(string) == hostNameDNSNoBootProtocol {
+			payload["BootMode"] = "Disabled"
+		}
+	}
+	iSCSIBoot := make(map[string]interface{})
+	// GetOkExists is needed so the booleans explicitly set to false are sent too
+	if v, ok := d.GetOkExists("ip_via_dhcp"); ok {
+		iSCSIBoot["IPMaskDNSViaDHCP"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("target_via_dhcp"); ok {
+		iSCSIBoot["TargetInfoViaDHCP"] = v.(bool)
+	}
+	for key, property := range map[string]string{"primary_dns": "PrimaryDNS", "secondary_dns": "SecondaryDNS", "initiator_name": "InitiatorName"} {
+		if v, ok := d.GetOk(key); ok {
+			iSCSIBoot[property] = v.(string)
+		}
+	}
+	if len(iSCSIBoot) > 0 {
+		payload["iSCSIBoot"] = iSCSIBoot
+	}
+	return payload
+}
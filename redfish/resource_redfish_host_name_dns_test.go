@@ -0,0 +1,34 @@
+package redfish
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"testing"
+)
+
+func TestExpandHostNameDNSSettings(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		raw      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{1, map[string]interface{}{"boot_protocol": "iSCSI", "ip_via_dhcp": false, "primary_dns": "10.0.0.53", "initiator_name": "iqn.2001-05.com.example:server1"}, map[string]interface{}{
+			"BootMode": "iSCSI",
+			"iSCSIBoot": map[string]interface{}{
+				"IPMaskDNSViaDHCP": false,
+				"PrimaryDNS":       "10.0.0.53",
+				"InitiatorName":    "iqn.2001-05.com.example:server1",
+			},
+		}},
+		{2, map[string]interface{}{"boot_protocol": "NONE"}, map[string]interface{}{"BootMode": "Disabled"}},
+		{3, map[string]interface{}{"target_via_dhcp": true}, map[string]interface{}{"iSCSIBoot": map[string]interface{}{"TargetInfoViaDHCP": true}}},
+	}
+	for _, v := range cases {
+		v.raw["network_adapter_id"] = "DE07A000"
+		v.raw["function_id"] = "1"
+		d := schema.TestResourceDataRaw(t, resourceRedfishHostNameDNS().Schema, v.raw)
+		if payload := expandHostNameDNSSettings(d); !reflect.DeepEqual(payload, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, payload)
+		}
+	}
+}
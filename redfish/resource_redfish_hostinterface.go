@@ -0,0 +1,173 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+func resourceRedfishHostInterface() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishHostInterfaceUpdate),
+		ReadContext:   resourceRedfishHostInterfaceRead,
+		UpdateContext: withLockdownBypass(resourceRedfishHostInterfaceUpdate),
+		DeleteContext: resourceRedfishHostInterfaceDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"host_interface_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Id of the host interface of the manager (i.e. Host.1). By default the first one is managed",
+			},
+			"host_interface_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the host interface (i.e. NetworkHostInterface)",
+			},
+			"interface_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the host OS can reach the BMC through the host interface",
+			},
+			"credential_bootstrapping_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the host OS can create its own BMC account through the host interface (credential bootstrapping)",
+			},
+			"credential_bootstrapping_enable_after_reset": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether credential bootstrapping is enabled again after a host reset. The BMC disables it once the host OS has used it",
+			},
+			"credential_bootstrapping_role_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Role of the accounts created through credential bootstrapping (i.e. Administrator)",
+			},
+		},
+	}
+}
+
+func resourceRedfishHostInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning host interface update")
+	hostInterface, err := getHostInterface(conn, d, m.(*providerConfig).managerID)
+	if err != nil {
+		return diag.Errorf("error fetching host interface: %s", err)
+	}
+
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOkExists("interface_enabled"); ok && (d.IsNewResource() || d.HasChange("interface_enabled")) {
+		payload["InterfaceEnabled"] = v.(bool)
+	}
+	bootstrapping := make(map[string]interface{})
+	if v, ok := d.GetOkExists("credential_bootstrapping_enabled"); ok && (d.IsNewResource() || d.HasChange("credential_bootstrapping_enabled")) {
+		bootstrapping["Enabled"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("credential_bootstrapping_enable_after_reset"); ok && (d.IsNewResource() || d.HasChange("credential_bootstrapping_enable_after_reset")) {
+		bootstrapping["EnableAfterReset"] = v.(bool)
+	}
+	if v, ok := d.GetOk("credential_bootstrapping_role_id"); ok && (d.IsNewResource() || d.HasChange("credential_bootstrapping_role_id")) {
+		bootstrapping["RoleId"] = v.(string)
+	}
+	if len(bootstrapping) > 0 {
+		if hostInterface.CredentialBootstrapping == nil {
+			return diag.Errorf("host interface %s does not support credential bootstrapping", hostInterface.ID)
+		}
+		payload["CredentialBootstrapping"] = bootstrapping
+	}
+
+	if len(payload) > 0 {
+		if err := common.PatchHostInterface(conn, hostInterface.ODataID, payload); err != nil {
+			return diag.Errorf("error updating host interface %s: %s", hostInterface.ID, err)
+		}
+	}
+
+	d.SetId(hostInterface.ODataID)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishHostInterfaceRead(ctx, d, m)
+}
+
+func resourceRedfishHostInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	hostInterface, err := common.GetHostInterface(conn, d.Id())
+	if err != nil {
+		if common.IsNotFound(err) {
+			log.Printf("[DEBUG] %s: Host interface not found, removing it from the state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error reading host interface: %s", err)
+	}
+
+	if err := d.Set("host_interface_id", hostInterface.ID); err != nil {
+		return diag.Errorf("error setting host_interface_id: %s", err)
+	}
+	if err := d.Set("host_interface_type", hostInterface.HostInterfaceType); err != nil {
+		return diag.Errorf("error setting host_interface_type: %s", err)
+	}
+	if err := d.Set("interface_enabled", hostInterface.InterfaceEnabled); err != nil {
+		return diag.Errorf("error setting interface_enabled: %s", err)
+	}
+	if hostInterface.CredentialBootstrapping != nil {
+		if err := d.Set("credential_bootstrapping_enabled", hostInterface.CredentialBootstrapping.Enabled); err != nil {
+			return diag.Errorf("error setting credential_bootstrapping_enabled: %s", err)
+		}
+		if err := d.Set("credential_bootstrapping_enable_after_reset", hostInterface.CredentialBootstrapping.EnableAfterReset); err != nil {
+			return diag.Errorf("error setting credential_bootstrapping_enable_after_reset: %s", err)
+		}
+		if err := d.Set("credential_bootstrapping_role_id", hostInterface.CredentialBootstrapping.RoleID); err != nil {
+			return diag.Errorf("error setting credential_bootstrapping_role_id: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishHostInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// getHostInterface returns the host interface managed by the resource: the one in its state, the one set
+// in host_interface_id, or the first host interface of the manager with managerID.
+func getHostInterface(conn *gofish.APIClient, d *schema.ResourceData, managerID string) (*common.HostInterface, error) {
+	if len(d.Id()) > 0 {
+		return common.GetHostInterface(conn, d.Id())
+	}
+	manager, err := common.GetManager(conn, managerID)
+	if err != nil {
+		return nil, err
+	}
+	hostInterfaces, err := common.GetHostInterfaces(conn, manager.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	id := d.Get("host_interface_id").(string)
+	for _, hostInterface := range hostInterfaces {
+		if len(id) == 0 || hostInterface.ID == id {
+			return hostInterface, nil
+		}
+	}
+	if len(id) == 0 {
+		return nil, fmt.Errorf("manager %s has no host interfaces", manager.ID)
+	}
+	return nil, fmt.Errorf("host interface %s not found in manager %s", id, manager.ID)
+}
@@ -0,0 +1,105 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// idracAutoConfigAttributes maps the redfish_idrac_auto_config variables to the Dell iDRAC attributes
+var idracAutoConfigAttributes = dellAttributeMapping{
+	"auto_config":   "NIC.1.AutoConfig",
+	"dhcp_enabled":  "IPv4.1.DHCPEnable",
+	"dns_from_dhcp": "IPv4.1.DNSFromDHCP",
+}
+
+// idracAutoConfigLcAttributes maps the redfish_idrac_auto_config variables to the Dell lifecycle controller attributes
+var idracAutoConfigLcAttributes = dellAttributeMapping{
+	"provisioning_server": "LCAttributes.1.ProvisioningServer",
+}
+
+func resourceRedfishIdracAutoConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracAutoConfigUpdate),
+		ReadContext:   resourceRedfishIdracAutoConfigRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracAutoConfigUpdate),
+		DeleteContext: resourceRedfishIdracAutoConfigDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"auto_config": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "iDRAC Auto Config, which imports the Server Configuration Profile announced by the DHCP server. Applicable values are 'Disabled', 'Enable Once' and 'Enable Once After Reset'",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Disabled",
+					"Enable Once",
+					"Enable Once After Reset",
+				}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"dhcp_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC gets its IPv4 address from DHCP, which Auto Config requires",
+			},
+			"dns_from_dhcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC gets its DNS servers from DHCP",
+			},
+			"provisioning_server": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Provisioning server the lifecycle controller announces itself to for zero-touch onboarding. Empty means it is discovered through DHCP or DNS",
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+		},
+	}
+}
+
+func resourceRedfishIdracAutoConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning auto config update")
+	// The provisioning server goes first, as Auto Config may start as soon as it is enabled
+	if err := updateDellAttributes(conn, d, common.DellLifecycleControllerAttributesURI, idracAutoConfigLcAttributes); err != nil {
+		return diag.Errorf("error updating provisioning attributes: %s", err)
+	}
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, idracAutoConfigAttributes); err != nil {
+		return diag.Errorf("error updating auto config attributes: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#auto_config")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracAutoConfigRead(ctx, d, m)
+}
+
+func resourceRedfishIdracAutoConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracAutoConfigAttributes); err != nil {
+		return diag.Errorf("error reading auto config attributes: %s", err)
+	}
+	if err := readDellAttributes(conn, d, common.DellLifecycleControllerAttributesURI, idracAutoConfigLcAttributes); err != nil {
+		return diag.Errorf("error reading provisioning attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracAutoConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,88 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// idracLcdAttributes maps the redfish_idrac_lcd variables to the Dell system attributes
+var idracLcdAttributes = dellAttributeMapping{
+	"configuration":       "LCD.1.Configuration",
+	"user_defined_string": "LCD.1.UserDefinedString",
+}
+
+func resourceRedfishIdracLcd() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracLcdUpdate),
+		ReadContext:   resourceRedfishIdracLcdRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracLcdUpdate),
+		DeleteContext: resourceRedfishIdracLcdDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"configuration": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What the front panel display shows. Applicable values are 'Service Tag', 'User Defined', 'Model Name', 'Asset Tag', 'iDRAC IPv4 Address', 'iDRAC IPv6 Address', 'iDRAC MAC Address', 'OS System Name', 'Ambient Temperature', 'System Watts' and 'None'",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Service Tag",
+					"User Defined",
+					"Model Name",
+					"Asset Tag",
+					"iDRAC IPv4 Address",
+					"iDRAC IPv6 Address",
+					"iDRAC MAC Address",
+					"OS System Name",
+					"Ambient Temperature",
+					"System Watts",
+					"None",
+				}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"user_defined_string": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Text shown on the front panel display when configuration is 'User Defined'",
+				ValidateFunc: validation.StringLenBetween(0, 62),
+			},
+		},
+	}
+}
+
+func resourceRedfishIdracLcdUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning LCD update")
+	if err := updateDellAttributes(conn, d, common.DellSystemAttributesURI, idracLcdAttributes); err != nil {
+		return diag.Errorf("error updating LCD attributes: %s", err)
+	}
+
+	d.SetId(common.DellSystemAttributesURI)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracLcdRead(ctx, d, m)
+}
+
+func resourceRedfishIdracLcdRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, idracLcdAttributes); err != nil {
+		return diag.Errorf("error reading LCD attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracLcdDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,140 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// idracLockoutAttributes maps the redfish_idrac_lockout variables to the Dell iDRAC attributes
+var idracLockoutAttributes = dellAttributeMapping{
+	"ip_range_enabled":     "IPBlocking.1.RangeEnable",
+	"ip_range_address":     "IPBlocking.1.RangeAddr",
+	"ip_range_mask":        "IPBlocking.1.RangeMask",
+	"lockout_enabled":      "IPBlocking.1.BlockEnable",
+	"lockout_fail_count":   "IPBlocking.1.FailCount",
+	"lockout_fail_window":  "IPBlocking.1.FailWindow",
+	"lockout_penalty_time": "IPBlocking.1.PenaltyTime",
+}
+
+func resourceRedfishIdracLockout() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracLockoutUpdate),
+		ReadContext:   resourceRedfishIdracLockoutRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracLockoutUpdate),
+		DeleteContext: resourceRedfishIdracLockoutDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishIdracLockoutCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"ip_range_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC only accepts connections from the IP range of ip_range_address and ip_range_mask. Make sure the range includes the address terraform connects from",
+			},
+			"ip_range_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Base IPv4 address of the range the iDRAC accepts connections from (i.e. 192.168.10.0)",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"ip_range_mask": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Mask of the range the iDRAC accepts connections from (i.e. 255.255.255.0)",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"lockout_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the IP addresses failing to log in lockout_fail_count times within lockout_fail_window are blocked for lockout_penalty_time",
+			},
+			"lockout_fail_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of failed logins from an IP address that blocks it",
+				ValidateFunc: validation.IntBetween(2, 16),
+			},
+			"lockout_fail_window": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds the failed logins are counted over",
+				ValidateFunc: validation.IntBetween(10, 65535),
+			},
+			"lockout_penalty_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Time in seconds a blocked IP address cannot log in",
+				ValidateFunc: validation.IntBetween(2, 65535),
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishIdracLockoutUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning lockout update")
+	opLog := newOperationLog(m, "redfish_idrac_lockout")
+	defer opLog.save(d)
+
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor != "dell" {
+		return diag.Errorf("IP blocking is not supported on %s BMCs", vendor)
+	}
+	err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, idracLockoutAttributes)
+	opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating IP blocking attributes: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#ipblocking")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracLockoutRead(ctx, d, m)
+}
+
+func resourceRedfishIdracLockoutRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracLockoutAttributes); err != nil {
+		return diag.Errorf("error reading IP blocking attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracLockoutDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as disabling the filtering on destroy would open the iDRAC to every network
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishIdracLockoutCustomizeDiff rejects enabling the IP range filtering without a range,
+// which would lock everyone out of the iDRAC
+func resourceRedfishIdracLockoutCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if enabled, ok := d.GetOk("ip_range_enabled"); !ok || !enabled.(bool) {
+		return nil
+	}
+	// The iDRAC reports the range it has not been given as 0.0.0.0
+	for _, key := range []string{"ip_range_address", "ip_range_mask"} {
+		if v, ok := d.GetOk(key); !ok || v.(string) == "0.0.0.0" {
+			return fmt.Errorf("%s must be set when ip_range_enabled is true", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// idracQuickSyncAttributes maps the redfish_idrac_quick_sync variables to the Dell iDRAC attributes
+var idracQuickSyncAttributes = dellAttributeMapping{
+	"access":                   "QuickSync.1.Access",
+	"read_authentication":      "QuickSync.1.ReadAuthentication",
+	"wifi_enabled":             "QuickSync.1.WifiEnable",
+	"inactivity_timer_enabled": "QuickSync.1.InactivityTimerEnable",
+	"inactivity_timeout":       "QuickSync.1.InactivityTimeout",
+}
+
+// idracQuickSyncPresenceAttributes maps the read-only redfish_idrac_quick_sync variables to the Dell iDRAC
+// attributes. They are kept apart, so they are never sent to the BMC.
+var idracQuickSyncPresenceAttributes = dellAttributeMapping{
+	"presence": "QuickSync.1.Presence",
+}
+
+func resourceRedfishIdracQuickSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracQuickSyncUpdate),
+		ReadContext:   resourceRedfishIdracQuickSyncRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracQuickSyncUpdate),
+		DeleteContext: resourceRedfishIdracQuickSyncDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"access": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What the OpenManage Mobile app can do through Quick Sync 2 (Bluetooth Low Energy and NFC). Applicable values are 'Disabled', 'Read-only' and 'Read-write'. 'Disabled' turns the wireless interface off",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Disabled",
+					"Read-only",
+					"Read-write",
+				}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"read_authentication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether iDRAC credentials are required to read the server inventory through Quick Sync",
+			},
+			"wifi_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the Quick Sync Wi-Fi connection, used by the mobile app to reach the iDRAC, is enabled",
+			},
+			"inactivity_timer_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether Quick Sync is turned off after inactivity_timeout seconds without use",
+			},
+			"inactivity_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Seconds without use before Quick Sync is turned off, when inactivity_timer_enabled is set",
+				ValidateFunc: validation.IntBetween(120, 3600),
+			},
+			"presence": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the Quick Sync 2 module is fitted in the left control panel (i.e. 'Present' or 'Absent')",
+			},
+		},
+	}
+}
+
+func resourceRedfishIdracQuickSyncUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning Quick Sync update")
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, idracQuickSyncAttributes); err != nil {
+		return diag.Errorf("error updating Quick Sync attributes: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#quicksync")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracQuickSyncRead(ctx, d, m)
+}
+
+func resourceRedfishIdracQuickSyncRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracQuickSyncAttributes); err != nil {
+		return diag.Errorf("error reading Quick Sync attributes: %s", err)
+	}
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracQuickSyncPresenceAttributes); err != nil {
+		return diag.Errorf("error reading Quick Sync attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracQuickSyncDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,176 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// remoteSyslogAttributes maps the redfish_idrac_remote_syslog variables to the Dell iDRAC attributes
+var remoteSyslogAttributes = dellAttributeMapping{
+	"enabled":     "SysLog.1.SysLogEnable",
+	"port":        "SysLog.1.Port",
+	"tls_enabled": "SysLog.1.SecureSysLogEnable",
+	"tls_port":    "SysLog.1.SecurePort",
+}
+
+// remoteSyslogServerAttribute returns the Dell iDRAC attribute of the remote syslog server at index (i.e. SysLog.1.Server1)
+func remoteSyslogServerAttribute(index int) string {
+	return fmt.Sprintf("SysLog.1.Server%d", index+1)
+}
+
+// remoteSyslogMaxServers is the number of remote syslog servers of the iDRAC
+const remoteSyslogMaxServers = 3
+
+func resourceRedfishIdracRemoteSyslog() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracRemoteSyslogUpdate),
+		ReadContext:   resourceRedfishIdracRemoteSyslogRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracRemoteSyslogUpdate),
+		DeleteContext: resourceRedfishIdracRemoteSyslogDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishIdracRemoteSyslogCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC sends its logs (the Lifecycle and System Event logs) to the remote syslog servers",
+			},
+			"servers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    remoteSyslogMaxServers,
+				Description: "Addresses of the remote syslog servers, up to 3. The servers not listed are cleared",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "UDP port of the remote syslog servers, when tls_enabled is false",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"tls_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the logs are sent over TLS (RFC 5425) instead of UDP. The servers are validated with ca_certificate",
+			},
+			"tls_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "TCP port of the remote syslog servers, when tls_enabled is true (i.e. 6514)",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"ca_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "CA certificate (PEM) the iDRAC validates the TLS syslog servers with. It is imported again whenever it changes. " +
+					"Leave it empty when the CA is managed by a redfish_certificate_trust_store with the syslog store",
+				ValidateFunc: validatePEMCertificate,
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishIdracRemoteSyslogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning remote syslog update")
+	opLog := newOperationLog(m, "redfish_idrac_remote_syslog")
+	defer opLog.save(d)
+
+	// The CA is imported before TLS is enabled, so the iDRAC never fails to validate the servers
+	if v, ok := d.GetOk("ca_certificate"); ok && (d.IsNewResource() || d.HasChange("ca_certificate")) {
+		err := common.ImportDellCertificate(conn, common.SyslogCACertificateType, v.(string))
+		opLog.record("certificate_import", common.SyslogCACertificateType, "", err)
+		if err != nil {
+			return diag.Errorf("error importing ca_certificate: %s", err)
+		}
+	}
+
+	if v, ok := d.GetOk("servers"); ok && (d.IsNewResource() || d.HasChange("servers")) {
+		err := common.PatchDellAttributes(conn, common.DellIdracAttributesURI, remoteSyslogServersPayload(v.([]interface{})))
+		opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating remote syslog servers: %s", err)
+		}
+	}
+
+	err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, remoteSyslogAttributes)
+	opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating remote syslog attributes: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#remote_syslog")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracRemoteSyslogRead(ctx, d, m)
+}
+
+func resourceRedfishIdracRemoteSyslogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, remoteSyslogAttributes); err != nil {
+		return diag.Errorf("error reading remote syslog attributes: %s", err)
+	}
+
+	attributes, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+	if err != nil {
+		return diag.Errorf("error reading remote syslog servers: %s", err)
+	}
+	servers := []string{}
+	for i := 0; i < remoteSyslogMaxServers; i++ {
+		if server := attributes[remoteSyslogServerAttribute(i)]; server != "" {
+			servers = append(servers, server)
+		}
+	}
+	if err := d.Set("servers", servers); err != nil {
+		return diag.Errorf("error setting servers: %s", err)
+	}
+
+	// The CA certificate cannot be read back from the iDRAC
+
+	return diags
+}
+
+func resourceRedfishIdracRemoteSyslogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as disabling the log shipping on destroy would leave gaps in the audit trail
+	d.SetId("")
+
+	return diags
+}
+
+func resourceRedfishIdracRemoteSyslogCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor != "dell" {
+		return fmt.Errorf("remote syslog is not supported on %s BMCs, only on Dell iDRAC", vendor)
+	}
+	return nil
+}
+
+// remoteSyslogServersPayload returns the iDRAC attributes of servers, clearing the servers not listed
+func remoteSyslogServersPayload(servers []interface{}) map[string]interface{} {
+	payload := make(map[string]interface{})
+	for i := 0; i < remoteSyslogMaxServers; i++ {
+		payload[remoteSyslogServerAttribute(i)] = ""
+		if i < len(servers) {
+			payload[remoteSyslogServerAttribute(i)] = servers[i].(string)
+		}
+	}
+	return payload
+}
@@ -0,0 +1,26 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemoteSyslogServersPayload(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		servers  []interface{}
+		expected map[string]interface{}
+	}{
+		{1, []interface{}{"syslog1.example.com", "syslog2.example.com"}, map[string]interface{}{
+			"SysLog.1.Server1": "syslog1.example.com",
+			"SysLog.1.Server2": "syslog2.example.com",
+			"SysLog.1.Server3": "",
+		}},
+		{2, []interface{}{}, map[string]interface{}{"SysLog.1.Server1": "", "SysLog.1.Server2": "", "SysLog.1.Server3": ""}},
+	}
+	for _, v := range cases {
+		if payload := remoteSyslogServersPayload(v.servers); !reflect.DeepEqual(payload, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, payload)
+		}
+	}
+}
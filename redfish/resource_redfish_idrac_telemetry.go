@@ -0,0 +1,271 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"regexp"
+	"strconv"
+)
+
+// idracTelemetryAttributes maps the redfish_idrac_telemetry variables to the Dell iDRAC attributes
+var idracTelemetryAttributes = dellAttributeMapping{
+	"enabled":                  "Telemetry.1.EnableTelemetry",
+	"rsyslog_enabled":          "Telemetry.1.RsyslogTarget",
+	"rsyslog_server":           "Telemetry.1.RsyslogServer1",
+	"rsyslog_port":             "Telemetry.1.RsyslogServer1Port",
+	"rsyslog_secondary_server": "Telemetry.1.RsyslogServer2",
+	"rsyslog_secondary_port":   "Telemetry.1.RsyslogServer2Port",
+}
+
+// idracTelemetrySubscriptionContext identifies the metric report subscriptions created by redfish_idrac_telemetry
+const idracTelemetrySubscriptionContext string = common.SubscriptionContextPrefix + "telemetry"
+
+// telemetryReportAttribute returns the Dell iDRAC attribute of a setting of a telemetry report (i.e. TelemetryCPUSensor.1.ReportInterval)
+func telemetryReportAttribute(report string, setting string) string {
+	return fmt.Sprintf("Telemetry%s.1.%s", report, setting)
+}
+
+func resourceRedfishIdracTelemetry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracTelemetryUpdate),
+		ReadContext:   resourceRedfishIdracTelemetryRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracTelemetryUpdate),
+		DeleteContext: withLockdownBypass(resourceRedfishIdracTelemetryDelete),
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC generates telemetry reports. Requires the Datacenter license",
+			},
+			"rsyslog_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the reports are streamed to the rsyslog servers",
+			},
+			"rsyslog_server": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address of the rsyslog server the reports are streamed to",
+			},
+			"rsyslog_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port of the rsyslog server",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"rsyslog_secondary_server": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address of a second rsyslog server the reports are streamed to",
+			},
+			"rsyslog_secondary_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port of the second rsyslog server",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"report": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Telemetry reports to configure. Reports not listed are left untouched",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the report, as in its iDRAC attributes (i.e. 'CPUSensor', 'PowerStatistics' or 'GPUMetrics' for TelemetryGPUMetrics.1)",
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]+$`), "must be the name of the report, without the Telemetry prefix"),
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the report is generated",
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "Seconds between reports. 0 keeps the interval set in the iDRAC",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"http_destinations": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "URLs the metric reports are POSTed to (i.e. an HTTP ingest endpoint), through Redfish event subscriptions. The subscriptions are deleted with the resource",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+			},
+			"subscription_uris": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the event subscriptions of http_destinations",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishIdracTelemetryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning telemetry update")
+	opLog := newOperationLog(m, "redfish_idrac_telemetry")
+	defer opLog.save(d)
+
+	// The reports are configured before telemetry is enabled, so the reports not wanted are never streamed
+	reportsPayload := make(map[string]interface{})
+	for _, raw := range d.Get("report").(*schema.Set).List() {
+		report := raw.(map[string]interface{})
+		name := report["name"].(string)
+		enabled := "Disabled"
+		if report["enabled"].(bool) {
+			enabled = "Enabled"
+		}
+		reportsPayload[telemetryReportAttribute(name, "EnableTelemetry")] = enabled
+		if interval := report["interval"].(int); interval > 0 {
+			reportsPayload[telemetryReportAttribute(name, "ReportInterval")] = interval
+		}
+	}
+	if len(reportsPayload) > 0 {
+		err := common.PatchDellAttributes(conn, common.DellIdracAttributesURI, reportsPayload)
+		opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating telemetry reports: %s", err)
+		}
+	}
+
+	err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, idracTelemetryAttributes)
+	opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating telemetry attributes: %s", err)
+	}
+
+	// The subscriptions cannot be modified, so they are created again whenever the destinations change
+	if d.IsNewResource() || d.HasChange("http_destinations") {
+		if diags := deleteTelemetrySubscriptions(d, conn, opLog); diags.HasError() {
+			return diags
+		}
+		subscriptions := []string{}
+		for _, destination := range d.Get("http_destinations").([]interface{}) {
+			uri, err := common.CreateMetricReportSubscription(conn, destination.(string), idracTelemetrySubscriptionContext)
+			opLog.record("subscription_create", destination.(string), uri, err)
+			if err != nil {
+				d.Set("subscription_uris", subscriptions)
+				return diag.Errorf("error subscribing %s to the metric reports: %s", destination, err)
+			}
+			subscriptions = append(subscriptions, uri)
+		}
+		if err := d.Set("subscription_uris", subscriptions); err != nil {
+			return diag.Errorf("error setting subscription_uris: %s", err)
+		}
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#telemetry")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracTelemetryRead(ctx, d, m)
+}
+
+func resourceRedfishIdracTelemetryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracTelemetryAttributes); err != nil {
+		return diag.Errorf("error reading telemetry attributes: %s", err)
+	}
+
+	attributes, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+	if err != nil {
+		return diag.Errorf("error reading telemetry reports: %s", err)
+	}
+	reports := []interface{}{}
+	for _, raw := range d.Get("report").(*schema.Set).List() {
+		report := raw.(map[string]interface{})
+		name := report["name"].(string)
+		if value, ok := attributes[telemetryReportAttribute(name, "EnableTelemetry")]; ok {
+			report["enabled"] = value == "Enabled"
+		}
+		// Reports keeping the interval of the iDRAC do not track it
+		if report["interval"].(int) > 0 {
+			if interval, err := strconv.Atoi(attributes[telemetryReportAttribute(name, "ReportInterval")]); err == nil {
+				report["interval"] = interval
+			}
+		}
+		reports = append(reports, report)
+	}
+	if err := d.Set("report", reports); err != nil {
+		return diag.Errorf("error setting report: %s", err)
+	}
+
+	// Subscriptions deleted out of band (i.e. by the BMC, after failing to deliver) are created again on the next apply
+	destinations := d.Get("http_destinations").([]interface{})
+	subscriptions := d.Get("subscription_uris").([]interface{})
+	for i, uri := range subscriptions {
+		exists, err := common.EventSubscriptionExists(conn, uri.(string))
+		if err != nil {
+			return diag.Errorf("error reading subscription %s: %s", uri, err)
+		}
+		if !exists && i < len(destinations) {
+			log.Printf("[DEBUG] %s: Subscription %s of %s not found", d.Id(), uri, destinations[i])
+			if err := d.Set("http_destinations", destinations[:i]); err != nil {
+				return diag.Errorf("error setting http_destinations: %s", err)
+			}
+			break
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracTelemetryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_idrac_telemetry")
+
+	// The telemetry settings are kept, only the subscriptions created by the resource are removed
+	diags := deleteTelemetrySubscriptions(d, conn, opLog)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// deleteTelemetrySubscriptions removes the metric report subscriptions in subscription_uris
+func deleteTelemetrySubscriptions(d *schema.ResourceData, conn *gofish.APIClient, opLog *operationLog) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, uri := range d.Get("subscription_uris").([]interface{}) {
+		err := common.DeleteEventSubscription(conn, uri.(string))
+		opLog.record("subscription_delete", uri.(string), "", err)
+		if err != nil {
+			return diag.Errorf("error deleting subscription %s: %s", uri, err)
+		}
+	}
+	if err := d.Set("subscription_uris", []string{}); err != nil {
+		return diag.Errorf("error setting subscription_uris: %s", err)
+	}
+	return diags
+}
@@ -0,0 +1,130 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// idracVncAttributes maps the redfish_idrac_vnc variables to the Dell iDRAC attributes.
+// The password is not included, as it cannot be read back.
+var idracVncAttributes = dellAttributeMapping{
+	"enabled":                 "VNCServer.1.Enable",
+	"port":                    "VNCServer.1.Port",
+	"timeout":                 "VNCServer.1.Timeout",
+	"ssl_encryption":          "VNCServer.1.SSLEncryptionBitLength",
+	"virtual_console_enabled": "VirtualConsole.1.Enable",
+	"virtual_console_port":    "VirtualConsole.1.Port",
+}
+
+// idracVncPasswordAttribute is the Dell iDRAC attribute holding the VNC password
+const idracVncPasswordAttribute string = "VNCServer.1.Password"
+
+func resourceRedfishIdracVnc() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishIdracVncUpdate),
+		ReadContext:   resourceRedfishIdracVncRead,
+		UpdateContext: withLockdownBypass(resourceRedfishIdracVncUpdate),
+		DeleteContext: resourceRedfishIdracVncDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC VNC server is enabled",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port the VNC server listens on",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				StateFunc:    hashPassword,
+				Description:  "Password of the VNC server. Only its SHA-256 hash is stored in the state",
+				ValidateFunc: validation.StringLenBetween(1, 8),
+			},
+			"password" + writeOnlySuffix:        writeOnlyPasswordSchema("password", "password"+writeOnlyVersionSuffix, "Password of the VNC server"),
+			"password" + writeOnlyVersionSuffix: writeOnlyVersionSchema("password" + writeOnlySuffix),
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds a VNC session can stay idle before it is closed",
+			},
+			"ssl_encryption": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "SSL encryption of the VNC sessions (i.e. 'Disabled' or '256-Bit or higher')",
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"virtual_console_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the iDRAC virtual console (remote presence) is enabled",
+			},
+			"virtual_console_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port of the iDRAC virtual console (remote presence)",
+				ValidateFunc: validation.IsPortNumber,
+			},
+		},
+	}
+}
+
+func resourceRedfishIdracVncUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning VNC update")
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, idracVncAttributes); err != nil {
+		return diag.Errorf("error updating VNC attributes: %s", err)
+	}
+
+	// The password is only sent when it changes, as the current one cannot be compared
+	if password, ok := passwordToSend(d, "password", "password"+writeOnlySuffix); ok {
+		log.Printf("[DEBUG] Updating VNC password")
+		err := common.PatchDellAttributes(conn, common.DellIdracAttributesURI, map[string]interface{}{idracVncPasswordAttribute: password})
+		if err != nil {
+			return diag.Errorf("error updating VNC password: %s", err)
+		}
+		if err := clearWriteOnly(d, "password"+writeOnlySuffix); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#vnc")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishIdracVncRead(ctx, d, m)
+}
+
+func resourceRedfishIdracVncRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, idracVncAttributes); err != nil {
+		return diag.Errorf("error reading VNC attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishIdracVncDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
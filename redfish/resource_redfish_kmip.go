@@ -0,0 +1,296 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"log"
+	"strings"
+)
+
+// Key management modes of redfish_kmip
+const (
+	kmipSEKMMode     string = "SEKM"
+	kmipILKMMode     string = "iLKM"
+	kmipDisabledMode string = "Disabled"
+)
+
+// Dell iDRAC attributes enabling each key management mode
+const (
+	sekmStatusAttribute string = "SEKM.1.SEKMStatus"
+	ilkmStatusAttribute string = "SEKM.1.iLKMStatus"
+)
+
+// kmipAttributes maps the redfish_kmip variables to the Dell iDRAC attributes.
+// The password is not included, as it cannot be read back.
+var kmipAttributes = dellAttributeMapping{
+	"primary_server_address":   "KMS.1.PrimaryServerAddress",
+	"redundant_server_address": "KMS.1.RedundantServerAddress1",
+	"port":                     "KMS.1.KMIPPortNumber",
+	"timeout":                  "KMS.1.Timeout",
+	"username":                 "KMS.1.iDRACUserName",
+	"common_name":              "SEKMCert.1.CommonName",
+	"organization":             "SEKMCert.1.OrganizationName",
+	"organization_unit":        "SEKMCert.1.OrganizationUnit",
+	"locality":                 "SEKMCert.1.LocalityName",
+	"state":                    "SEKMCert.1.StateName",
+	"country":                  "SEKMCert.1.CountryCode",
+}
+
+// kmipPasswordAttribute is the Dell iDRAC attribute holding the password of the key management server user
+const kmipPasswordAttribute string = "KMS.1.iDRACPassword"
+
+// kmipCertificates maps the redfish_kmip certificate variables to the types they are imported as
+var kmipCertificates = map[string]string{
+	"kms_ca_certificate": common.KMSCACertificateType,
+	"sekm_certificate":   common.SEKMSSLCertificateType,
+}
+
+func resourceRedfishKmip() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishKmipUpdate),
+		ReadContext:   resourceRedfishKmipRead,
+		UpdateContext: withLockdownBypass(resourceRedfishKmipUpdate),
+		DeleteContext: resourceRedfishKmipDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishKmipCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "Key management mode of the self-encrypting drives. Applicable values are 'SEKM' (keys stored in the KMIP server), 'iLKM' (keys stored in the iDRAC) and 'Disabled'. " +
+					"Switching between 'SEKM' and 'iLKM' requires the drives to be rekeyed by the iDRAC; when the iDRAC does not support the switch, the current mode is kept and it has to be disabled first. " +
+					"'Disabled' requires confirm_disable",
+				ValidateFunc: validation.StringInSlice([]string{
+					kmipSEKMMode,
+					kmipILKMMode,
+					kmipDisabledMode,
+				}, false),
+			},
+			"primary_server_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address of the primary KMIP server",
+			},
+			"redundant_server_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address of the KMIP server used when the primary one is not reachable",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port the KMIP servers listen on",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Seconds to wait for the KMIP server to answer",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "User the iDRAC authenticates with in the KMIP server",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashPassword,
+				Description: "Password of the KMIP server user. Only its SHA-256 hash is stored in the state",
+			},
+			"password" + writeOnlySuffix:        writeOnlyPasswordSchema("password", "password"+writeOnlyVersionSuffix, "Password of the KMIP server user"),
+			"password" + writeOnlyVersionSuffix: writeOnlyVersionSchema("password" + writeOnlySuffix),
+			"common_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Common name of the certificate signing request the iDRAC generates for the KMIP server. Usually the KMIP user name",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Organization of the certificate signing request",
+			},
+			"organization_unit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Organization unit of the certificate signing request",
+			},
+			"locality": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Locality of the certificate signing request",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "State of the certificate signing request",
+			},
+			"country": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Two letter country code of the certificate signing request",
+				ValidateFunc: validation.StringLenBetween(2, 2),
+			},
+			"kms_ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CA certificate (PEM) the iDRAC validates the KMIP server with. It is imported again whenever it changes",
+			},
+			"sekm_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Certificate (PEM) the iDRAC presents to the KMIP server, signed from the iDRAC certificate signing request. It is imported again whenever it changes",
+			},
+			"confirm_disable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Must be true to set mode to 'Disabled', acknowledging that the drives locked with the current keys might not be unlocked anymore",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishKmipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning KMIP update")
+	opLog := newOperationLog(m, "redfish_kmip")
+	defer opLog.save(d)
+
+	// The certificates are only imported when they change, as the current ones cannot be compared
+	for key, certificateType := range kmipCertificates {
+		if v, ok := d.GetOk(key); ok && (d.IsNewResource() || d.HasChange(key)) {
+			err := common.ImportDellCertificate(conn, certificateType, v.(string))
+			opLog.record("certificate_import", certificateType, "", err)
+			if err != nil {
+				return diag.Errorf("error importing %s: %s", key, err)
+			}
+		}
+	}
+
+	err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, kmipAttributes)
+	opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating KMIP attributes: %s", err)
+	}
+	if password, ok := passwordToSend(d, "password", "password"+writeOnlySuffix); ok {
+		log.Printf("[DEBUG] Updating KMIP password")
+		err := common.PatchDellAttributes(conn, common.DellIdracAttributesURI, map[string]interface{}{kmipPasswordAttribute: password})
+		opLog.record("password_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating KMIP password: %s", err)
+		}
+		if err := clearWriteOnly(d, "password"+writeOnlySuffix); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The mode is changed last, as enabling SEKM requires the KMIP server to be already configured
+	if v, ok := d.GetOk("mode"); ok {
+		err := setKmipMode(conn, v.(string), d.Get("confirm_disable").(bool))
+		opLog.record("mode_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error setting the key management mode: %s", err)
+		}
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#kmip")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishKmipRead(ctx, d, m)
+}
+
+func resourceRedfishKmipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, kmipAttributes); err != nil {
+		return diag.Errorf("error reading KMIP attributes: %s", err)
+	}
+	attributes, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+	if err != nil {
+		return diag.Errorf("error reading the key management mode: %s", err)
+	}
+	if err := d.Set("mode", kmipMode(attributes)); err != nil {
+		return diag.Errorf("error setting mode: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishKmipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The key management configuration is kept, as disabling it could leave the drives locked
+	d.SetId("")
+
+	return diags
+}
+
+// kmipMode returns the key management mode enabled in the iDRAC attributes
+func kmipMode(attributes map[string]string) string {
+	if strings.EqualFold(attributes[sekmStatusAttribute], "Enabled") {
+		return kmipSEKMMode
+	}
+	if strings.EqualFold(attributes[ilkmStatusAttribute], "Enabled") {
+		return kmipILKMMode
+	}
+	return kmipDisabledMode
+}
+
+// setKmipMode enables a key management mode. The new mode is enabled without disabling the current one,
+// so the iDRAC switches the keys of the drives itself, or rejects the switch leaving the current mode in place.
+// The current mode is only disabled when mode is Disabled, which requires confirmDisable.
+func setKmipMode(conn redfishcommon.Client, mode string, confirmDisable bool) error {
+	attributes, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+	if err != nil {
+		return err
+	}
+	current := kmipMode(attributes)
+	if current == mode {
+		return nil
+	}
+	statusAttributes := map[string]string{
+		kmipSEKMMode: sekmStatusAttribute,
+		kmipILKMMode: ilkmStatusAttribute,
+	}
+	if mode == kmipDisabledMode {
+		if !confirmDisable {
+			return fmt.Errorf("confirm_disable must be true to disable %s", current)
+		}
+		return common.PatchDellAttributes(conn, common.DellIdracAttributesURI, map[string]interface{}{statusAttributes[current]: "Disabled"})
+	}
+	err = common.PatchDellAttributes(conn, common.DellIdracAttributesURI, map[string]interface{}{statusAttributes[mode]: "Enabled"})
+	if err != nil && current != kmipDisabledMode {
+		return fmt.Errorf("the iDRAC did not switch from %s to %s, which stays enabled. Set mode to %s with confirm_disable first to switch: %s", current, mode, kmipDisabledMode, err)
+	}
+	return err
+}
+
+// resourceRedfishKmipCustomizeDiff requires confirm_disable to plan disabling the key management
+func resourceRedfishKmipCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("mode") && d.Get("mode").(string) == kmipDisabledMode && !d.Get("confirm_disable").(bool) {
+		return fmt.Errorf("confirm_disable must be true to set mode to %s", kmipDisabledMode)
+	}
+	return nil
+}
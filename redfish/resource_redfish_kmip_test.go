@@ -0,0 +1,56 @@
+package redfish
+
+import (
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetKmipMode(t *testing.T) {
+	cases := []struct {
+		noTest         int
+		current        string
+		mode           string
+		confirmDisable bool
+		patchStatus    int
+		expectedPatch  string
+		shouldPass     bool
+	}{
+		{1, `{"SEKM.1.SEKMStatus":"Disabled","SEKM.1.iLKMStatus":"Enabled"}`, kmipSEKMMode, false, http.StatusOK, "map[Attributes:map[SEKM.1.SEKMStatus:Enabled]]", true},
+		{2, `{"SEKM.1.SEKMStatus":"Enabled","SEKM.1.iLKMStatus":"Disabled"}`, kmipILKMMode, false, http.StatusBadRequest, "map[Attributes:map[SEKM.1.iLKMStatus:Enabled]]", false},
+		{3, `{"SEKM.1.SEKMStatus":"Enabled","SEKM.1.iLKMStatus":"Disabled"}`, kmipDisabledMode, false, http.StatusOK, "", false},
+		{4, `{"SEKM.1.SEKMStatus":"Enabled","SEKM.1.iLKMStatus":"Disabled"}`, kmipDisabledMode, true, http.StatusOK, "map[Attributes:map[SEKM.1.SEKMStatus:Disabled]]", true},
+		{5, `{"SEKM.1.SEKMStatus":"Enabled","SEKM.1.iLKMStatus":"Disabled"}`, kmipSEKMMode, false, http.StatusOK, "", true},
+	}
+	for _, v := range cases {
+		testClient := &redfishcommon.TestClient{}
+		testClient.CustomReturnForActions = map[string][]interface{}{
+			http.MethodGet: {&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"Attributes":` + v.current + `}`)),
+			}},
+			http.MethodPatch: {&http.Response{
+				StatusCode: v.patchStatus,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}},
+		}
+		err := setKmipMode(testClient, v.mode, v.confirmDisable)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+		patches := []string{}
+		for _, call := range testClient.CapturedCalls() {
+			if call.Action == http.MethodPatch {
+				patches = append(patches, call.Payload)
+			}
+		}
+		if v.expectedPatch == "" && len(patches) > 0 || v.expectedPatch != "" && (len(patches) != 1 || patches[0] != v.expectedPatch) {
+			t.Errorf("Test number %v: expected patch %q, got %v", v.noTest, v.expectedPatch, patches)
+		}
+	}
+}
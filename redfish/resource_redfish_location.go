@@ -0,0 +1,256 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+)
+
+// locationAttributes maps the redfish_location variables to the Dell system attributes.
+// iDRAC exposes them in the Location of the chassis too, but only as read-only properties.
+var locationAttributes = dellAttributeMapping{
+	"data_center": "System.Location.DataCenter",
+	"room":        "System.Location.RoomName",
+	"aisle":       "System.Location.Aisle",
+	"rack":        "System.Location.Rack.Name",
+	"rack_slot":   "System.Location.Rack.Slot",
+}
+
+// locationSizeAttributes maps the read-only redfish_location variables to the Dell system attributes.
+// They are kept apart, so they are never sent to the BMC.
+var locationSizeAttributes = dellAttributeMapping{
+	"size_u": "System.Location.DeviceSize",
+}
+
+// locationContactVariables are the redfish_location variables only managed through the Redfish Location of the chassis
+var locationContactVariables = []string{"contact_name", "contact_email", "contact_phone"}
+
+func resourceRedfishLocation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishLocationUpdate),
+		ReadContext:   resourceRedfishLocationRead,
+		UpdateContext: withLockdownBypass(resourceRedfishLocationUpdate),
+		DeleteContext: resourceRedfishLocationDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"data_center": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Data center the server is installed in. It is the building of the postal address of the chassis on BMCs other than Dell iDRAC",
+			},
+			"room": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Room of the data center the server is installed in",
+			},
+			"aisle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Aisle of the room the rack is in. It is the row of the placement of the chassis on BMCs other than Dell iDRAC",
+			},
+			"rack": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the rack the server is mounted in",
+			},
+			"rack_slot": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Lowest rack unit (U) the server occupies in the rack",
+				ValidateFunc: validation.IntBetween(1, 255),
+			},
+			"contact_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Person or team responsible for the server. Not supported on Dell iDRAC",
+			},
+			"contact_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Email address of the contact. Not supported on Dell iDRAC",
+			},
+			"contact_phone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Phone number of the contact. Not supported on Dell iDRAC",
+			},
+			"size_u": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Rack units the server occupies, as reported by Dell iDRAC. 0 on other BMCs",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishLocationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning location update")
+	opLog := newOperationLog(m, "redfish_location")
+	defer opLog.save(d)
+
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor == "dell" {
+		for _, key := range locationContactVariables {
+			if _, ok := d.GetOk(key); ok {
+				return diag.Errorf("%s is not supported on %s BMCs", key, vendor)
+			}
+		}
+		err := updateDellAttributes(conn, d, common.DellSystemAttributesURI, locationAttributes)
+		opLog.record("attributes_patch", common.DellSystemAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating location attributes: %s", err)
+		}
+		d.SetId(common.DellSystemAttributesURI + "#location")
+		log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+		return resourceRedfishLocationRead(ctx, d, m)
+	}
+
+	chassis, err := getSystemChassis(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the chassis of the system: %s", err)
+	}
+	// Only the properties set and different from the current ones are sent, as some BMCs reject the ones they do not support
+	postalAddress := make(map[string]interface{})
+	if v, ok := d.GetOk("data_center"); ok && v.(string) != chassis.Location.PostalAddress.Building {
+		postalAddress["Building"] = v.(string)
+	}
+	if v, ok := d.GetOk("room"); ok && v.(string) != chassis.Location.PostalAddress.Room {
+		postalAddress["Room"] = v.(string)
+	}
+	placement := make(map[string]interface{})
+	if v, ok := d.GetOk("aisle"); ok && v.(string) != chassis.Location.Placement.Row {
+		placement["Row"] = v.(string)
+	}
+	if v, ok := d.GetOk("rack"); ok && v.(string) != chassis.Location.Placement.Rack {
+		placement["Rack"] = v.(string)
+	}
+	if v, ok := d.GetOk("rack_slot"); ok && v.(int) != chassis.Location.Placement.RackOffset {
+		placement["RackOffset"] = v.(int)
+		placement["RackOffsetUnits"] = "EIA_310"
+	}
+	location := make(map[string]interface{})
+	if len(postalAddress) > 0 {
+		location["PostalAddress"] = postalAddress
+	}
+	if len(placement) > 0 {
+		location["Placement"] = placement
+	}
+	if contact, changed := expandLocationContact(d, chassis.Location.Contacts); changed {
+		// Contacts is an array, so it is sent whole
+		location["Contacts"] = []interface{}{contact}
+	}
+	if len(location) > 0 {
+		err := common.PatchResource(conn, chassis.ODataID, map[string]interface{}{"Location": location})
+		opLog.record("location_patch", chassis.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the location of the chassis: %s", err)
+		}
+	} else {
+		log.Printf("[DEBUG] The location of the chassis is already set")
+	}
+
+	d.SetId(chassis.ODataID + "#location")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishLocationRead(ctx, d, m)
+}
+
+func resourceRedfishLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, locationAttributes); err != nil {
+			return diag.Errorf("error reading location attributes: %s", err)
+		}
+		if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, locationSizeAttributes); err != nil {
+			return diag.Errorf("error reading location attributes: %s", err)
+		}
+		return diags
+	}
+
+	chassis, err := getSystemChassis(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the chassis of the system: %s", err)
+	}
+	values := map[string]interface{}{
+		"data_center": chassis.Location.PostalAddress.Building,
+		"room":        chassis.Location.PostalAddress.Room,
+		"aisle":       chassis.Location.Placement.Row,
+		"rack":        chassis.Location.Placement.Rack,
+		"rack_slot":   chassis.Location.Placement.RackOffset,
+		"size_u":      0,
+	}
+	if len(chassis.Location.Contacts) > 0 {
+		values["contact_name"] = chassis.Location.Contacts[0].ContactName
+		values["contact_email"] = chassis.Location.Contacts[0].EmailAddress
+		values["contact_phone"] = chassis.Location.Contacts[0].PhoneNumber
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishLocationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The location is kept, as there is no meaningful value to restore
+	d.SetId("")
+
+	return diags
+}
+
+// getSystemChassis fetches the chassis holding the computer system the provider manages
+func getSystemChassis(conn *gofish.APIClient, systemID string) (*redfish.Chassis, error) {
+	system, err := common.GetSystem(conn, systemID)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := common.GetSystemChassisURI(conn, system.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	return redfish.GetChassis(conn, uri)
+}
+
+// expandLocationContact returns the contact of the configuration, on top of the first one of current,
+// and whether it differs from it
+func expandLocationContact(d *schema.ResourceData, current []redfishcommon.ContactInfo) (map[string]interface{}, bool) {
+	existing := redfishcommon.ContactInfo{}
+	if len(current) > 0 {
+		existing = current[0]
+	}
+	contact := map[string]interface{}{
+		"ContactName":  existing.ContactName,
+		"EmailAddress": existing.EmailAddress,
+		"PhoneNumber":  existing.PhoneNumber,
+	}
+	changed := false
+	for key, property := range map[string]string{"contact_name": "ContactName", "contact_email": "EmailAddress", "contact_phone": "PhoneNumber"} {
+		if v, ok := d.GetOk(key); ok && v.(string) != contact[property] {
+			contact[property] = v.(string)
+			changed = true
+		}
+	}
+	return contact, changed
+}
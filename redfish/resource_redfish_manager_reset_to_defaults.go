@@ -0,0 +1,120 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+)
+
+func resourceRedfishManagerResetToDefaults() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishManagerResetToDefaultsCreate),
+		ReadContext:   resourceRedfishManagerResetToDefaultsRead,
+		DeleteContext: resourceRedfishManagerResetToDefaultsDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishManagerResetToDefaultsCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"reset_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(redfish.PreserveNetworkAndUsersResetToDefaultsType),
+				Description: "Settings of the BMC the reset keeps. Applicable values are 'PreserveNetworkAndUsers', 'PreserveNetwork' and 'ResetAll', " +
+					"which also resets the network settings and the accounts, so terraform cannot reach the BMC with the provider settings afterwards. " +
+					"BMCs predating Manager.ResetToDefaults only support some of them (Dell iDRAC 'ResetAll' and 'PreserveNetworkAndUsers', HPE iLO 'ResetAll')",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfish.PreserveNetworkAndUsersResetToDefaultsType),
+					string(redfish.PreserveNetworkResetToDefaultsType),
+					string(redfish.ResetAllResetToDefaultsType),
+				}, false),
+			},
+			"confirm_reset": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+				Description: "Must be true for the reset types other than 'PreserveNetworkAndUsers', acknowledging that the accounts " +
+					"(and with 'ResetAll' the network settings) of the BMC are reset, so terraform cannot reach it afterwards",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that reset the BMC to its defaults again when changed (i.e. the ticket of the RMA)",
+			},
+			"action_target": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Target of the action the BMC was reset with, the standard one or the OEM one of the vendor",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishManagerResetToDefaultsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning manager reset to defaults")
+	opLog := newOperationLog(m, "redfish_manager_reset_to_defaults")
+	defer opLog.save(d)
+
+	if err := checkManagerResetConfirmed(d.Get("reset_type").(string), d.Get("confirm_reset").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	manager, err := common.GetManager(conn, m.(*providerConfig).managerID)
+	if err != nil {
+		return diag.Errorf("error fetching manager: %s", err)
+	}
+	target, err := common.ResetManagerToDefaults(conn, manager.ODataID, d.Get("reset_type").(string))
+	opLog.record("reset_to_defaults", target, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the manager to its defaults: %s", err)
+	}
+	if err := d.Set("action_target", target); err != nil {
+		return diag.Errorf("error setting action_target: %s", err)
+	}
+	d.SetId(manager.ODataID + "#reset_to_defaults")
+
+	log.Printf("[DEBUG] %s: Manager reset to defaults finished successfully, the BMC is restarting", d.Id())
+	return resourceRedfishManagerResetToDefaultsRead(ctx, d, m)
+}
+
+func resourceRedfishManagerResetToDefaultsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The reset has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+func resourceRedfishManagerResetToDefaultsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The previous BMC settings cannot be restored
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishManagerResetToDefaultsCustomizeDiff checks the resets leaving the BMC unreachable are confirmed
+func resourceRedfishManagerResetToDefaultsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	return checkManagerResetConfirmed(d.Get("reset_type").(string), d.Get("confirm_reset").(bool))
+}
+
+// checkManagerResetConfirmed requires confirm_reset for the reset types resetting the accounts of the BMC
+func checkManagerResetConfirmed(resetType string, confirmed bool) error {
+	if resetType != string(redfish.PreserveNetworkAndUsersResetToDefaultsType) && !confirmed {
+		return fmt.Errorf("confirm_reset must be true to reset the BMC with %s, which resets its accounts", resetType)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestCheckManagerResetConfirmed(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		resetType  string
+		confirmed  bool
+		shouldPass bool
+	}{
+		{1, "PreserveNetworkAndUsers", false, true},
+		{2, "PreserveNetwork", false, false},
+		{3, "PreserveNetwork", true, true},
+		{4, "ResetAll", false, false},
+		{5, "ResetAll", true, true},
+	}
+	for _, v := range cases {
+		err := checkManagerResetConfirmed(v.resetType, v.confirmed)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+// defaultMemorySettingsTimeout is the time to wait for each of the reboots applying the memory settings,
+// which includes the memory training of large configurations
+const defaultMemorySettingsTimeout = 60 * time.Minute
+
+// memoryOperatingModes are the operating modes of redfish_memory_settings, mapped to the BIOS values of each vendor
+var memoryOperatingModes = map[string]map[string]string{
+	"dell": {
+		"Optimizer": "OptimizerMode",
+		"Mirror":    "MirrorMode",
+		"Spare":     "SpareMode",
+	},
+	"hpe": {
+		"Optimizer": "AdvancedEcc",
+		"Mirror":    "MirroredAdvancedEcc",
+		"Spare":     "OnlineSpareAdvancedEcc",
+	},
+}
+
+// memorySettingsBiosAttributes are the BIOS attributes of the operating mode and the node interleaving of each vendor
+var memorySettingsBiosAttributes = map[string]struct {
+	operatingMode    string
+	nodeInterleaving string
+}{
+	"dell": {"MemOpMode", "NodeInterleave"},
+	"hpe":  {"AdvancedMemProtection", "NodeInterleaving"},
+}
+
+// persistentMemoryGoal is a persistent_memory_goal block of redfish_memory_settings
+type persistentMemoryGoal struct {
+	memoryDomain string
+	sizeMiB      int
+}
+
+// newMemorySettingsAttributes maps the settings of redfish_memory_settings to the BIOS attributes of the vendor.
+// An empty operating mode and a nil node interleaving leave them untouched.
+func newMemorySettingsAttributes(vendor string, operatingMode string, nodeInterleaving *bool) (map[string]string, error) {
+	names, ok := memorySettingsBiosAttributes[vendor]
+	if !ok {
+		return nil, fmt.Errorf("memory settings are not supported on %s servers. Use redfish_bios with the memory attributes of the vendor instead", vendor)
+	}
+	attributes := make(map[string]string)
+	if operatingMode != "" {
+		attributes[names.operatingMode] = memoryOperatingModes[vendor][operatingMode]
+	}
+	if nodeInterleaving != nil {
+		attributes[names.nodeInterleaving] = "Disabled"
+		if *nodeInterleaving {
+			attributes[names.nodeInterleaving] = "Enabled"
+		}
+	}
+	return attributes, nil
+}
+
+// expandMemorySettingsAttributes reads the settings of a redfish_memory_settings configuration or plan
+func expandMemorySettingsAttributes(vendor string, d interface {
+	Get(string) interface{}
+	GetOkExists(string) (interface{}, bool)
+}) (map[string]string, error) {
+	var nodeInterleaving *bool
+	if v, ok := d.GetOkExists("node_interleaving"); ok {
+		enabled := v.(bool)
+		nodeInterleaving = &enabled
+	}
+	return newMemorySettingsAttributes(vendor, d.Get("operating_mode").(string), nodeInterleaving)
+}
+
+// expandPersistentMemoryGoals reads the persistent_memory_goal blocks, rejecting the domains with several goals
+func expandPersistentMemoryGoals(raw []interface{}) ([]persistentMemoryGoal, error) {
+	goals := []persistentMemoryGoal{}
+	seen := make(map[string]bool)
+	for _, v := range raw {
+		block := v.(map[string]interface{})
+		goal := persistentMemoryGoal{
+			memoryDomain: block["memory_domain"].(string),
+			sizeMiB:      block["size_mib"].(int),
+		}
+		if seen[goal.memoryDomain] {
+			return nil, fmt.Errorf("memory domain %s has more than one persistent memory goal", goal.memoryDomain)
+		}
+		seen[goal.memoryDomain] = true
+		goals = append(goals, goal)
+	}
+	return goals, nil
+}
+
+// resourceRedfishMemorySettings manages the memory operating mode, the node interleaving and the persistent memory goals.
+// Persistent memory namespaces are out of its scope: neither Redfish nor the OEM services of Dell and HPE expose them,
+// so they are created from the operating system once the BIOS has provisioned the regions.
+func resourceRedfishMemorySettings() *schema.Resource {
+	operatingModes := []string{}
+	for mode := range memoryOperatingModes["dell"] {
+		operatingModes = append(operatingModes, mode)
+	}
+
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishMemorySettingsUpdate),
+		ReadContext:   resourceRedfishMemorySettingsRead,
+		UpdateContext: withLockdownBypass(resourceRedfishMemorySettingsUpdate),
+		DeleteContext: resourceRedfishMemorySettingsDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishMemorySettingsCustomizeDiff, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultMemorySettingsTimeout),
+			Update: schema.DefaultTimeout(defaultMemorySettingsTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"operating_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Memory operating mode. 'Optimizer' uses the whole capacity, 'Mirror' mirrors the memory across channels (half the capacity) " +
+					"and 'Spare' keeps a rank of each channel as a spare. Mapped to MemOpMode on Dell and AdvancedMemProtection on HPE. Not set leaves it untouched",
+				ValidateFunc: validation.StringInSlice(operatingModes, false),
+			},
+			"node_interleaving": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the memory is interleaved across the processors, which presents a single NUMA node to the operating system. Not set leaves it untouched",
+			},
+			"persistent_memory_goal": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Persistent memory (i.e. Intel Optane PMem) regions to provision in App Direct mode, as memory chunks of the memory domains. " +
+					"They are provisioned by the BIOS on the next reboot. Changing them deletes the regions created before, along with the data they hold. " +
+					"Namespaces are created from the operating system (i.e. with ndctl), as the BMCs do not expose them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"memory_domain": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Id of the memory domain the region is created in, interleaved across its modules",
+						},
+						"size_mib": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "Size of the region in MiB. 0 uses the whole persistent capacity of the domain",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"reset_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "None",
+				Description: "How the system is rebooted to apply the changes. Applicable values are 'None' (applied on the next reboot), 'GracefulRestart', 'ForceRestart' and 'PowerCycle'. " +
+					"When both the operating mode and the persistent memory goals change, the system is rebooted twice, as the goals depend on the capacity left by the mode. Systems powered off are powered on",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None",
+					string(redfish.GracefulRestartResetType),
+					string(redfish.ForceRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "BIOS attributes the settings manage, with their current values. Pending changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"memory_chunk_uris": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the memory chunks created for the persistent memory goals",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishMemorySettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning memory settings update")
+	opLog := newOperationLog(m, "redfish_memory_settings")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_memory_settings")
+	defer progress.save(d)
+
+	attributes, err := expandMemorySettingsAttributes(oem.Vendor(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	goals, err := expandPersistentMemoryGoals(d.Get("persistent_memory_goal").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	goalsChanged := d.IsNewResource() || d.HasChange("persistent_memory_goal")
+	resetType := d.Get("reset_type").(string)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	d.SetId(bios.ODataID + "#memory")
+
+	biosPayload, missing := biosChanges(bios, attributes)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, the setting is not supported by this system", missing[0])
+	}
+	jobURI := ""
+	if len(biosPayload) > 0 {
+		if jobURI, err = stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "memory"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := setStagedBiosAttributes(d, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+	pendingReboot := len(biosPayload) > 0
+
+	if goalsChanged {
+		// The goals depend on the capacity left by the operating mode, so it is applied first
+		if pendingReboot && len(goals) > 0 && resetType != "None" {
+			if err := rebootForMemorySettings(ctx, d, m, opLog, progress, jobURI, timeout); err != nil {
+				return diag.FromErr(err)
+			}
+			pendingReboot = false
+			jobURI = ""
+		}
+		chunkURIs, err := replacePersistentMemoryGoals(conn, d, m.(*providerConfig).systemID, opLog, goals)
+		if err := d.Set("memory_chunk_uris", chunkURIs); err != nil {
+			return diag.Errorf("error setting memory_chunk_uris: %s", err)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		pendingReboot = pendingReboot || len(goals) > 0
+	}
+
+	if pendingReboot && resetType != "None" {
+		if err := rebootForMemorySettings(ctx, d, m, opLog, progress, jobURI, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishMemorySettingsRead(ctx, d, m)
+}
+
+// replacePersistentMemoryGoals deletes the memory chunks created before by the resource and creates the ones of goals.
+// It returns the URIs of the chunks that exist, even when it fails halfway.
+func replacePersistentMemoryGoals(conn *gofish.APIClient, d *schema.ResourceData, systemID string, opLog *operationLog, goals []persistentMemoryGoal) ([]string, error) {
+	old, _ := d.GetChange("memory_chunk_uris")
+	remaining := []string{}
+	for _, v := range old.([]interface{}) {
+		remaining = append(remaining, v.(string))
+	}
+	for len(remaining) > 0 {
+		err := common.DeleteMemoryChunk(conn, remaining[0])
+		opLog.record("memory_chunk_delete", remaining[0], "", err)
+		if err != nil {
+			return remaining, fmt.Errorf("error deleting memory chunk %s: %s", remaining[0], err)
+		}
+		remaining = remaining[1:]
+	}
+	if len(goals) == 0 {
+		return remaining, nil
+	}
+
+	system, err := common.GetSystem(conn, systemID)
+	if err != nil {
+		return remaining, fmt.Errorf("error fetching the computer system: %s", err)
+	}
+	domains, err := common.GetMemoryDomains(conn, system.ODataID)
+	if err != nil {
+		return remaining, fmt.Errorf("error fetching the memory domains: %s", err)
+	}
+	chunkURIs := []string{}
+	for _, goal := range goals {
+		var domain *common.MemoryDomain
+		ids := []string{}
+		for _, candidate := range domains {
+			ids = append(ids, candidate.ID)
+			if candidate.ID == goal.memoryDomain {
+				domain = candidate
+			}
+		}
+		if domain == nil {
+			return chunkURIs, fmt.Errorf("memory domain %s not found. Available memory domains: %v", goal.memoryDomain, ids)
+		}
+		chunkURI, err := common.CreatePersistentMemoryChunk(conn, domain, goal.sizeMiB)
+		opLog.record("memory_chunk_create", domain.ODataID, chunkURI, err)
+		if err != nil {
+			return chunkURIs, fmt.Errorf("error creating the persistent memory goal of %s: %s", goal.memoryDomain, err)
+		}
+		chunkURIs = append(chunkURIs, chunkURI)
+	}
+	return chunkURIs, nil
+}
+
+// rebootForMemorySettings reboots the system and waits for the BIOS to apply the pending memory settings:
+// until the configuration job finishes, or the POST does on BMCs without jobs
+func rebootForMemorySettings(ctx context.Context, d *schema.ResourceData, m interface{}, opLog *operationLog, progress *jobProgress, jobURI string, timeout time.Duration) error {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return fmt.Errorf("error fetching the computer system: %s", err)
+	}
+	before, err := common.GetSystemBootProgress(conn, system.ODataID)
+	if err != nil {
+		return fmt.Errorf("error reading the boot progress of the system: %s", err)
+	}
+	resetType := redfish.ResetType(d.Get("reset_type").(string))
+	if system.PowerState != redfish.OnPowerState {
+		resetType = redfish.OnResetType
+	}
+	err = system.Reset(resetType)
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return fmt.Errorf("error resetting the system: %s", err)
+	}
+
+	if jobURI != "" {
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(timeout.Seconds()), progress.reporter(jobURI))
+		opLog.record("job_completion", system.ODataID, jobURI, err)
+		if err != nil {
+			return fmt.Errorf("error waiting for BIOS configuration job %s to finish: %s", jobURI, err)
+		}
+	}
+	_, err = common.WaitForPOST(ctx, conn, system.ODataID, before, common.TimeBetweenAttempts, int(timeout.Seconds()))
+	opLog.record("boot_progress", system.ODataID, "", err)
+	if err != nil {
+		return fmt.Errorf("error waiting for the system to apply the memory settings: %s", err)
+	}
+	return nil
+}
+
+func resourceRedfishMemorySettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishMemorySettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings and the persistent memory regions are kept, as the regions might hold data
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishMemorySettingsCustomizeDiff checks the settings are supported by the vendor and plans their attributes
+func resourceRedfishMemorySettingsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	attributes, err := expandMemorySettingsAttributes(m.(*providerConfig).oem.Vendor(), d)
+	if err != nil {
+		return err
+	}
+	if _, err := expandPersistentMemoryGoals(d.Get("persistent_memory_goal").([]interface{})); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, attributes)
+}
@@ -0,0 +1,75 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMemorySettingsAttributes(t *testing.T) {
+	enabled, disabled := true, false
+	cases := []struct {
+		noTest           int
+		vendor           string
+		operatingMode    string
+		nodeInterleaving *bool
+		expected         map[string]string
+		shouldPass       bool
+	}{
+		{1, "dell", "Mirror", &disabled, map[string]string{"MemOpMode": "MirrorMode", "NodeInterleave": "Disabled"}, true},
+		{2, "dell", "", nil, map[string]string{}, true},
+		{3, "hpe", "Spare", &enabled, map[string]string{"AdvancedMemProtection": "OnlineSpareAdvancedEcc", "NodeInterleaving": "Enabled"}, true},
+		{4, "hpe", "Optimizer", nil, map[string]string{"AdvancedMemProtection": "AdvancedEcc"}, true},
+		{5, "generic", "Optimizer", nil, nil, false},
+	}
+	for _, v := range cases {
+		attributes, err := newMemorySettingsAttributes(v.vendor, v.operatingMode, v.nodeInterleaving)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(attributes, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, attributes)
+		}
+	}
+}
+
+func TestExpandPersistentMemoryGoals(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		raw        []interface{}
+		expected   []persistentMemoryGoal
+		shouldPass bool
+	}{
+		{1, []interface{}{
+			map[string]interface{}{"memory_domain": "PMem.Socket.1", "size_mib": 0},
+			map[string]interface{}{"memory_domain": "PMem.Socket.2", "size_mib": 262144},
+		}, []persistentMemoryGoal{{"PMem.Socket.1", 0}, {"PMem.Socket.2", 262144}}, true},
+		{2, []interface{}{}, []persistentMemoryGoal{}, true},
+		{3, []interface{}{
+			map[string]interface{}{"memory_domain": "PMem.Socket.1", "size_mib": 0},
+			map[string]interface{}{"memory_domain": "PMem.Socket.1", "size_mib": 1024},
+		}, nil, false},
+	}
+	for _, v := range cases {
+		goals, err := expandPersistentMemoryGoals(v.raw)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(goals, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, goals)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"time"
+)
+
+// defaultOSDeploymentTimeout is the time to wait for the jobs exposing the ISO image and the drivers
+const defaultOSDeploymentTimeout = 30 * time.Minute
+
+func resourceRedfishOSDeployment() *schema.Resource {
+	share := shareSchema("Network share holding the ISO image. Only 'NFS' and 'CIFS' shares are supported")
+	share.Optional = false
+	share.Required = true
+
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishOSDeploymentUpdate),
+		ReadContext:   resourceRedfishOSDeploymentRead,
+		UpdateContext: withLockdownBypass(resourceRedfishOSDeploymentUpdate),
+		DeleteContext: withLockdownBypass(resourceRedfishOSDeploymentDelete),
+		CustomizeDiff: customdiff.Sequence(resumeInterruptedApply, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOSDeploymentTimeout),
+			Update: schema.DefaultTimeout(defaultOSDeploymentTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			shareAttribute: share,
+			"image_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Path of the ISO image in the share. The host boots from it once it is attached",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"driver_pack_os": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Operating system whose drivers are exposed to the host from the driver pack of the Lifecycle Controller (i.e. 'Microsoft Windows Server 2019'). See available_driver_packs. Empty exposes no drivers",
+			},
+			"expose_duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minutes the ISO image and the drivers stay attached. 0 keeps the default of the iDRAC (18 hours)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that boot the host from the ISO image again when changed",
+			},
+			"job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the job booting the host from the ISO image",
+			},
+			"iso_attach_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the ISO image is attached to the host ('Attached' or 'NotAttached'). It is detached once expose_duration elapses",
+			},
+			"drivers_attach_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the drivers are attached to the host ('Attached' or 'NotAttached')",
+			},
+			"available_driver_packs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Operating systems the driver pack of the Lifecycle Controller has drivers for",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			checkpointAttribute:      checkpointSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			operationLogAttribute:    operationLogSchema(),
+		},
+	}
+}
+
+// resourceRedfishOSDeploymentUpdate attaches the drivers, then boots the host from the ISO image. Both steps are recorded
+// in the checkpoint, so an interrupted apply waits for the boot job it started instead of booting the host again.
+func resourceRedfishOSDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning OS deployment")
+	opLog := newOperationLog(m, "redfish_os_deployment")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_os_deployment")
+	defer progress.save(d)
+	cp := newCheckpoint(d, shareAttribute, "image_name", "driver_pack_os", "expose_duration", "triggers")
+	// The Id is set first, so the checkpoint is stored when the creation is interrupted
+	d.SetId(common.DellOSDeploymentServiceURI)
+
+	share, err := expandShare(d)
+	if err != nil {
+		return diag.Errorf("error in share: %s", err)
+	}
+	exposeDuration := ""
+	if minutes := d.Get("expose_duration").(int); minutes > 0 {
+		exposeDuration = fmt.Sprintf("PT%dM", minutes)
+	}
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	deadline := time.Now().Add(timeout)
+
+	// Whatever is attached from a previous run is detached first, as the service exposes a single image.
+	// The images attached by the interrupted apply are kept.
+	if !cp.done("drivers") {
+		if diags := detachOSDeployment(conn, opLog, m.(*providerConfig).systemID); diags.HasError() {
+			return diags
+		}
+	}
+
+	if osName := d.Get("driver_pack_os").(string); osName != "" && !cp.done("drivers") {
+		info, err := common.GetDriverPackInfo(conn)
+		if err != nil {
+			return diag.Errorf("error fetching the driver pack information: %s", err)
+		}
+		found := false
+		for _, name := range info.OSList {
+			found = found || name == osName
+		}
+		if !found {
+			return diag.Errorf("the driver pack %s has no drivers for %s. Available operating systems: %v", info.Version, osName, info.OSList)
+		}
+		jobURI, err := common.UnpackAndAttachDrivers(conn, osName, exposeDuration)
+		opLog.record("drivers_attach", common.DellOSDeploymentServiceURI, jobURI, err)
+		if err != nil {
+			return diag.Errorf("error attaching the drivers for %s: %s", osName, err)
+		}
+		if jobURI != "" {
+			remaining := int(time.Until(deadline).Seconds())
+			if remaining <= 0 {
+				opLog.record("job_completion", common.DellOSDeploymentServiceURI, jobURI, fmt.Errorf("timeout reached"))
+				return diag.Errorf("timeout reached waiting for the drivers job %s to finish", jobURI)
+			}
+			err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, remaining, progress.reporter(jobURI))
+			opLog.record("job_completion", common.DellOSDeploymentServiceURI, jobURI, err)
+			if err != nil {
+				return diag.Errorf("error waiting for the drivers job %s to finish: %s", jobURI, err)
+			}
+		}
+	}
+	cp.complete(d, "drivers")
+
+	imageName := d.Get("image_name").(string)
+	if !cp.done("boot") {
+		jobURI, err := common.BootToNetworkISO(conn, share, imageName, exposeDuration)
+		opLog.record("boot_to_network_iso", share.URI(imageName), jobURI, err)
+		if err != nil {
+			return diag.Errorf("error booting from %s: %s", share.URI(imageName), err)
+		}
+		if err := d.Set("job_uri", jobURI); err != nil {
+			return diag.Errorf("error setting job_uri: %s", err)
+		}
+		cp.complete(d, "boot")
+	}
+	if jobURI := d.Get("job_uri").(string); jobURI != "" {
+		// A resumed apply may have no time left once the drivers are attached
+		remaining := int(time.Until(deadline).Seconds())
+		if remaining <= 0 {
+			opLog.record("job_completion", common.DellOSDeploymentServiceURI, jobURI, fmt.Errorf("timeout reached"))
+			return diag.Errorf("timeout reached waiting for the boot job %s to finish", jobURI)
+		}
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, remaining, progress.reporter(jobURI))
+		opLog.record("job_completion", common.DellOSDeploymentServiceURI, jobURI, err)
+		if err != nil {
+			// A failed job is not waited for again, the next apply boots the host from the image again
+			if task, taskErr := common.GetTask(conn, jobURI); taskErr == nil && task.Finished() {
+				cp.finish(d)
+			}
+			return diag.Errorf("error waiting for the boot job %s to finish: %s", jobURI, err)
+		}
+	}
+
+	cp.finish(d)
+
+	if err := clearShareWriteOnly(d); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] %s: OS deployment finished successfully", d.Id())
+	return resourceRedfishOSDeploymentRead(ctx, d, m)
+}
+
+func resourceRedfishOSDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// The image is not booted again when it gets detached, as the OS is usually installed by then
+	status, err := common.GetOSDeploymentAttachStatus(conn)
+	if err != nil {
+		return diag.Errorf("error fetching the attach status: %s", err)
+	}
+	if err := d.Set("iso_attach_status", status.ISOAttachStatus); err != nil {
+		return diag.Errorf("error setting iso_attach_status: %s", err)
+	}
+	if err := d.Set("drivers_attach_status", status.DriversAttachStatus); err != nil {
+		return diag.Errorf("error setting drivers_attach_status: %s", err)
+	}
+
+	info, err := common.GetDriverPackInfo(conn)
+	if err != nil {
+		return diag.Errorf("error fetching the driver pack information: %s", err)
+	}
+	if err := d.Set("available_driver_packs", info.OSList); err != nil {
+		return diag.Errorf("error setting available_driver_packs: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishOSDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_os_deployment")
+
+	diags := detachOSDeployment(conn, opLog, m.(*providerConfig).systemID)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// detachOSDeployment detaches the ISO image and the drivers exposed to the host, if any, and clears the one-time boot
+// from the ISO. The attach status is read back after detaching, and the detach retried, as the iDRAC intermittently
+// reports success while leaving the image attached, which the next reboot would boot again.
+func detachOSDeployment(conn *gofish.APIClient, opLog *operationLog, systemID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for attempt := 0; ; attempt++ {
+		status, err := common.GetOSDeploymentAttachStatus(conn)
+		if err != nil {
+			return diag.Errorf("error fetching the attach status: %s", err)
+		}
+		if status.ISOAttachStatus != common.AttachedStatus && status.DriversAttachStatus != common.AttachedStatus {
+			break
+		}
+		if attempt == common.DetachAttempts {
+			return diag.Errorf("still attached after %d detach attempts (ISO image: %s, drivers: %s)", attempt, status.ISOAttachStatus, status.DriversAttachStatus)
+		}
+		if status.ISOAttachStatus == common.AttachedStatus {
+			err := common.DetachISOImage(conn)
+			opLog.record("iso_detach", common.DellOSDeploymentServiceURI, "", err)
+			if err != nil {
+				return diag.Errorf("error detaching the ISO image: %s", err)
+			}
+		}
+		if status.DriversAttachStatus == common.AttachedStatus {
+			err := common.DetachDrivers(conn)
+			opLog.record("drivers_detach", common.DellOSDeploymentServiceURI, "", err)
+			if err != nil {
+				return diag.Errorf("error detaching the drivers: %s", err)
+			}
+		}
+		time.Sleep(time.Duration(common.TimeBetweenAttempts) * time.Second)
+	}
+
+	system, err := common.GetSystem(conn, systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = common.ClearBootOverrideAndVerify(conn, system.ODataID, redfish.CdBootSourceOverrideTarget, common.DetachAttempts, common.TimeBetweenAttempts)
+	opLog.record("clear_boot_override", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error clearing the one-time boot from the ISO image: %s", err)
+	}
+	return diags
+}
@@ -0,0 +1,272 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// pciSlotBifurcationLanes are the bifurcations of redfish_pci_slot, with the lanes of the slot they need.
+// 'auto' lets the BIOS pick the bifurcation of the riser, so it fits any slot.
+var pciSlotBifurcationLanes = map[string]int{
+	"auto":     0,
+	"x16":      16,
+	"x8x8":     16,
+	"x4x4x4x4": 16,
+	"x4x4":     8,
+}
+
+// pciSlot is a slot block of redfish_pci_slot
+type pciSlot struct {
+	number      int
+	enabled     bool
+	bifurcation string
+}
+
+// expandPciSlots reads the slot blocks, rejecting the slots configured twice
+func expandPciSlots(raw []interface{}) ([]pciSlot, error) {
+	slots := []pciSlot{}
+	seen := make(map[int]bool)
+	for _, v := range raw {
+		block := v.(map[string]interface{})
+		slot := pciSlot{
+			number:      block["number"].(int),
+			enabled:     block["enabled"].(bool),
+			bifurcation: block["bifurcation"].(string),
+		}
+		if seen[slot.number] {
+			return nil, fmt.Errorf("slot %d is configured more than once", slot.number)
+		}
+		seen[slot.number] = true
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// newPciSlotAttributes maps the slot blocks of redfish_pci_slot to the BIOS attributes of the vendor
+func newPciSlotAttributes(vendor string, slots []pciSlot) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, slot := range slots {
+		switch vendor {
+		case "dell":
+			attributes[fmt.Sprintf("Slot%d", slot.number)] = "Disabled"
+			if slot.enabled {
+				attributes[fmt.Sprintf("Slot%d", slot.number)] = "Enabled"
+			}
+			if slot.bifurcation != "" {
+				attributes[fmt.Sprintf("Slot%dBif", slot.number)] = map[string]string{
+					"auto":     "DefaultBifurcation",
+					"x16":      "x16",
+					"x8x8":     "x8x8",
+					"x4x4x4x4": "x4x4x4x4",
+					"x4x4":     "x4x4",
+				}[slot.bifurcation]
+			}
+		case "hpe":
+			attributes[fmt.Sprintf("PciSlot%dEnable", slot.number)] = "Disabled"
+			if slot.enabled {
+				attributes[fmt.Sprintf("PciSlot%dEnable", slot.number)] = "Auto"
+			}
+			if slot.bifurcation != "" {
+				bifurcation, ok := map[string]string{
+					"auto":     "Auto",
+					"x8x8":     "DualX8",
+					"x4x4x4x4": "QuadX4",
+				}[slot.bifurcation]
+				if !ok {
+					return nil, fmt.Errorf("bifurcation %s of slot %d is not supported on HPE servers. Applicable values are 'auto', 'x8x8' and 'x4x4x4x4'", slot.bifurcation, slot.number)
+				}
+				attributes[fmt.Sprintf("PciSlot%dBifurcation", slot.number)] = bifurcation
+			}
+		default:
+			return nil, fmt.Errorf("PCIe slot settings are not supported on %s servers. Use redfish_bios with the slot attributes of the vendor instead", vendor)
+		}
+	}
+	return attributes, nil
+}
+
+// checkPciSlots verifies the slots are present in the inventory of the chassis and are wide enough for their bifurcation.
+// Inventories without slots (i.e. older firmware) are not checked, the BIOS attributes are checked instead.
+func checkPciSlots(slots []pciSlot, inventory []*common.PCIeSlot) error {
+	if len(inventory) == 0 {
+		return nil
+	}
+	lanes := make(map[int]int)
+	numbers := []int{}
+	for _, slot := range inventory {
+		lanes[slot.Number] = slot.Lanes
+		numbers = append(numbers, slot.Number)
+	}
+	for _, slot := range slots {
+		slotLanes, ok := lanes[slot.number]
+		if !ok {
+			return fmt.Errorf("slot %d not found. Available slots: %v", slot.number, numbers)
+		}
+		if needed := pciSlotBifurcationLanes[slot.bifurcation]; slotLanes > 0 && needed > slotLanes {
+			return fmt.Errorf("bifurcation %s needs %d lanes, slot %d only has %d", slot.bifurcation, needed, slot.number, slotLanes)
+		}
+	}
+	return nil
+}
+
+func resourceRedfishPciSlot() *schema.Resource {
+	bifurcations := []string{}
+	for bifurcation := range pciSlotBifurcationLanes {
+		bifurcations = append(bifurcations, bifurcation)
+	}
+
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishPciSlotUpdate),
+		ReadContext:   resourceRedfishPciSlotRead,
+		UpdateContext: withLockdownBypass(resourceRedfishPciSlotUpdate),
+		DeleteContext: resourceRedfishPciSlotDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishPciSlotCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"slot": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "PCIe slots to configure. Slots not listed are left untouched",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"number": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Number of the slot, as printed on the chassis or riser (i.e. 3 for Slot 3)",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the slot is enabled. Disabled slots hide their card from the operating system",
+						},
+						"bifurcation": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "How the lanes of the slot are split between devices (i.e. 'x4x4x4x4' for a quad NVMe adapter). " +
+								"Applicable values are 'auto', 'x16', 'x8x8', 'x4x4x4x4' and 'x4x4'. HPE only supports 'auto', 'x8x8' and 'x4x4x4x4'. Not set leaves it untouched",
+							ValidateFunc: validation.StringInSlice(bifurcations, false),
+						},
+					},
+				},
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "BIOS attributes the slots manage, with their current values. Pending changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishPciSlotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning PCIe slot update")
+	opLog := newOperationLog(m, "redfish_pci_slot")
+	defer opLog.save(d)
+
+	slots, err := expandPciSlots(d.Get("slot").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attributes, err := newPciSlotAttributes(oem.Vendor(), slots)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching the computer system: %s", err)
+	}
+	inventory, err := common.GetPCIeSlots(conn, system.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching the PCIe slots: %s", err)
+	}
+	if err := checkPciSlots(slots, inventory); err != nil {
+		return diag.FromErr(err)
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	biosPayload, missing := biosChanges(bios, attributes)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, the slot is not present or its setting is not supported by this system", missing[0])
+	}
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "PCIe slot"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setStagedBiosAttributes(d, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bios.ODataID + "#pci_slot")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishPciSlotRead(ctx, d, m)
+}
+
+func resourceRedfishPciSlotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishPciSlotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The slots are left as configured, as the cards might depend on their bifurcation
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishPciSlotCustomizeDiff checks the slots are supported by the vendor and plans their attributes
+func resourceRedfishPciSlotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	slots, err := expandPciSlots(d.Get("slot").([]interface{}))
+	if err != nil {
+		return err
+	}
+	attributes, err := newPciSlotAttributes(m.(*providerConfig).oem.Vendor(), slots)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, attributes)
+}
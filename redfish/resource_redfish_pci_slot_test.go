@@ -0,0 +1,78 @@
+package redfish
+
+import (
+	"github.com/dell/terraform-provider-redfish/common"
+	"reflect"
+	"testing"
+)
+
+func TestNewPciSlotAttributes(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		slots      []pciSlot
+		expected   map[string]string
+		shouldPass bool
+	}{
+		{1, "dell", []pciSlot{{3, true, "x4x4x4x4"}, {4, false, ""}}, map[string]string{
+			"Slot3":    "Enabled",
+			"Slot3Bif": "x4x4x4x4",
+			"Slot4":    "Disabled",
+		}, true},
+		{2, "dell", []pciSlot{{1, true, "auto"}}, map[string]string{
+			"Slot1":    "Enabled",
+			"Slot1Bif": "DefaultBifurcation",
+		}, true},
+		{3, "hpe", []pciSlot{{1, true, "x8x8"}, {2, false, ""}}, map[string]string{
+			"PciSlot1Enable":      "Auto",
+			"PciSlot1Bifurcation": "DualX8",
+			"PciSlot2Enable":      "Disabled",
+		}, true},
+		{4, "hpe", []pciSlot{{1, true, "x16"}}, nil, false},
+		{5, "generic", []pciSlot{{1, true, ""}}, nil, false},
+	}
+	for _, v := range cases {
+		attributes, err := newPciSlotAttributes(v.vendor, v.slots)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(attributes, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, attributes)
+		}
+	}
+}
+
+func TestCheckPciSlots(t *testing.T) {
+	inventory := []*common.PCIeSlot{
+		{Number: 1, Lanes: 16},
+		{Number: 2, Lanes: 8},
+	}
+	cases := []struct {
+		noTest     int
+		slots      []pciSlot
+		inventory  []*common.PCIeSlot
+		shouldPass bool
+	}{
+		{1, []pciSlot{{1, true, "x4x4x4x4"}, {2, true, "x4x4"}}, inventory, true},
+		{2, []pciSlot{{2, true, "x8x8"}}, inventory, false},
+		{3, []pciSlot{{3, true, ""}}, inventory, false},
+		// Without slots in the inventory, the BIOS attributes are checked instead
+		{4, []pciSlot{{3, true, "x16"}}, []*common.PCIeSlot{}, true},
+	}
+	for _, v := range cases {
+		err := checkPciSlots(v.slots, v.inventory)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+// powerMetricsSubscriptionContext identifies the alert subscriptions created by redfish_power_metrics
+const powerMetricsSubscriptionContext string = common.SubscriptionContextPrefix + "power-alerts"
+
+// powerSensorContexts are the physical contexts of the sensors measuring the power of the whole chassis, by preference
+var powerSensorContexts = []string{"Chassis", "SystemBoard", "PowerSupply"}
+
+func resourceRedfishPowerMetrics() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishPowerMetricsUpdate),
+		ReadContext:   resourceRedfishPowerMetricsRead,
+		UpdateContext: withLockdownBypass(resourceRedfishPowerMetricsUpdate),
+		DeleteContext: resourceRedfishPowerMetricsDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishPowerMetricsCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"chassis_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the chassis whose power consumption is monitored (i.e. System.Embedded.1 on Dell systems)",
+			},
+			"sensor_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "Id of the power sensor the thresholds are set on. Defaults to the sensor measuring the power of the whole chassis " +
+					"(i.e. SystemBoardPwrConsumption on Dell systems)",
+			},
+			"warning_watts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Power consumption in watts above which the BMC raises a warning (UpperCaution threshold of the sensor)",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"critical_watts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Power consumption in watts above which the BMC raises a critical alert (UpperCritical threshold of the sensor)",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"alert_destination": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "URL the alerts of the sensor are POSTed to (i.e. the webhook of the monitoring system), through a Redfish event " +
+					"subscription. The subscription is deleted with the resource",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"subscription_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the event subscription of alert_destination",
+			},
+			"power_consumed_watts": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Power consumption in watts read by the sensor, to compare the thresholds with",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishPowerMetricsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning power metrics update")
+	opLog := newOperationLog(m, "redfish_power_metrics")
+	defer opLog.save(d)
+
+	sensor, err := getPowerMetricsSensor(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(sensor.ODataID)
+	if err := d.Set("sensor_id", sensor.ID); err != nil {
+		return diag.Errorf("error setting sensor_id: %s", err)
+	}
+
+	thresholds := make(map[string]interface{})
+	for key, threshold := range map[string]string{"warning_watts": "UpperCaution", "critical_watts": "UpperCritical"} {
+		if v, ok := d.GetOk(key); ok {
+			thresholds[threshold] = map[string]interface{}{"Reading": v.(int)}
+		}
+	}
+	if len(thresholds) > 0 {
+		err := common.PatchResource(conn, sensor.ODataID, map[string]interface{}{"Thresholds": thresholds})
+		opLog.record("thresholds_patch", sensor.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the thresholds of sensor %s: %s", sensor.ID, err)
+		}
+	}
+
+	// The subscriptions cannot be modified, so it is created again whenever the destination changes
+	if d.IsNewResource() || d.HasChange("alert_destination") {
+		if diags := deletePowerMetricsSubscription(d, conn, opLog); diags.HasError() {
+			return diags
+		}
+		if destination := d.Get("alert_destination").(string); destination != "" {
+			uri, err := common.CreateAlertSubscription(conn, destination, powerMetricsSubscriptionContext, []string{sensor.ODataID})
+			opLog.record("subscription_create", destination, uri, err)
+			if err != nil {
+				return diag.Errorf("error subscribing %s to the alerts of sensor %s: %s", destination, sensor.ID, err)
+			}
+			if err := d.Set("subscription_uri", uri); err != nil {
+				return diag.Errorf("error setting subscription_uri: %s", err)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishPowerMetricsRead(ctx, d, m)
+}
+
+func resourceRedfishPowerMetricsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	sensor, err := getPowerMetricsSensor(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	readings := sensor.Thresholds.Readings()
+	values := map[string]interface{}{
+		"warning_watts":        int(readings["upper_caution"]),
+		"critical_watts":       int(readings["upper_critical"]),
+		"power_consumed_watts": 0.0,
+	}
+	if sensor.Reading != nil {
+		values["power_consumed_watts"] = *sensor.Reading
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	// A subscription deleted out of band (i.e. by the BMC, after failing to deliver) is created again on the next apply
+	if uri := d.Get("subscription_uri").(string); uri != "" {
+		exists, err := common.EventSubscriptionExists(conn, uri)
+		if err != nil {
+			return diag.Errorf("error reading subscription %s: %s", uri, err)
+		}
+		if !exists {
+			log.Printf("[DEBUG] %s: Subscription %s not found", d.Id(), uri)
+			for key, value := range map[string]interface{}{"alert_destination": "", "subscription_uri": ""} {
+				if err := d.Set(key, value); err != nil {
+					return diag.Errorf("error setting %s: %s", key, err)
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishPowerMetricsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_power_metrics")
+
+	// The thresholds are kept, as the sensor has no defaults to restore. Only the subscription is removed
+	diags := deletePowerMetricsSubscription(d, conn, opLog)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceRedfishPowerMetricsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	warning, warningOk := d.GetOk("warning_watts")
+	critical, criticalOk := d.GetOk("critical_watts")
+	if warningOk && criticalOk && warning.(int) >= critical.(int) {
+		return fmt.Errorf("warning_watts (%d) must be lower than critical_watts (%d)", warning.(int), critical.(int))
+	}
+	return nil
+}
+
+// deletePowerMetricsSubscription removes the alert subscription in subscription_uri, if any
+func deletePowerMetricsSubscription(d *schema.ResourceData, conn *gofish.APIClient, opLog *operationLog) diag.Diagnostics {
+	var diags diag.Diagnostics
+	uri := d.Get("subscription_uri").(string)
+	if uri == "" {
+		return diags
+	}
+	err := common.DeleteEventSubscription(conn, uri)
+	opLog.record("subscription_delete", uri, "", err)
+	if err != nil {
+		return diag.Errorf("error deleting subscription %s: %s", uri, err)
+	}
+	if err := d.Set("subscription_uri", ""); err != nil {
+		return diag.Errorf("error setting subscription_uri: %s", err)
+	}
+	return diags
+}
+
+// getPowerMetricsSensor fetches the power sensor of redfish_power_metrics
+func getPowerMetricsSensor(conn *gofish.APIClient, d *schema.ResourceData) (*common.Sensor, error) {
+	chassis, err := getChassisByID(conn, d.Get("chassis_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	sensors, err := common.GetChassisSensors(conn, chassis.ODataID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the sensors of chassis %s: %s", chassis.ID, err)
+	}
+	sensor := findPowerSensor(sensors, d.Get("sensor_id").(string))
+	if sensor == nil {
+		return nil, fmt.Errorf("no power sensor %s found in chassis %s", d.Get("sensor_id").(string), chassis.ID)
+	}
+	return sensor, nil
+}
+
+// findPowerSensor returns the sensor with id, or the power sensor of the whole chassis when id is empty.
+// It returns nil if there is none.
+func findPowerSensor(sensors []*common.Sensor, id string) *common.Sensor {
+	if id != "" {
+		for _, sensor := range sensors {
+			if sensor.ID == id {
+				return sensor
+			}
+		}
+		return nil
+	}
+	for _, physicalContext := range powerSensorContexts {
+		for _, sensor := range sensors {
+			if sensor.ReadingType == "Power" && sensor.PhysicalContext == physicalContext {
+				return sensor
+			}
+		}
+	}
+	for _, sensor := range sensors {
+		if sensor.ReadingType == "Power" {
+			return sensor
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package redfish
+
+import (
+	"github.com/dell/terraform-provider-redfish/common"
+	"testing"
+)
+
+func TestFindPowerSensor(t *testing.T) {
+	sensors := []*common.Sensor{
+		{ID: "InletTemp", ReadingType: "Temperature", PhysicalContext: "Intake"},
+		{ID: "PS1InputPower", ReadingType: "Power", PhysicalContext: "PowerSupply"},
+		{ID: "SystemBoardPwrConsumption", ReadingType: "Power", PhysicalContext: "SystemBoard"},
+	}
+	cases := []struct {
+		noTest   int
+		sensors  []*common.Sensor
+		id       string
+		expected string
+	}{
+		{1, sensors, "", "SystemBoardPwrConsumption"},
+		{2, sensors, "PS1InputPower", "PS1InputPower"},
+		{3, sensors, "Unknown", ""},
+		{4, sensors[:2], "", "PS1InputPower"},
+		{5, sensors[:1], "", ""},
+	}
+	for _, v := range cases {
+		sensor := findPowerSensor(v.sensors, v.id)
+		id := ""
+		if sensor != nil {
+			id = sensor.ID
+		}
+		if id != v.expected {
+			t.Errorf("Test number %v: expected %q, got %q", v.noTest, v.expected, id)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	redfishcommon "github.com/stmcginnis/gofish/common"
+	"hash/fnv"
+	"log"
+	"strconv"
+)
+
+// Dell BIOS attributes that control what the server does when AC power is restored
+const (
+	acPowerRecoveryAttribute          string = "AcPwrRcvry"
+	acPowerRecoveryDelayAttribute     string = "AcPwrRcvryDelay"
+	acPowerRecoveryUserDelayAttribute string = "AcPwrRcvryUserDelay"
+)
+
+func resourceRedfishPowerOnDelay() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishPowerOnDelayUpdate),
+		ReadContext:   resourceRedfishPowerOnDelayRead,
+		UpdateContext: withLockdownBypass(resourceRedfishPowerOnDelayUpdate),
+		DeleteContext: resourceRedfishPowerOnDelayDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishPowerOnDelayCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"ac_power_recovery": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "What the server does when AC power is restored. Applicable values are 'Last', 'On' and 'Off'",
+				ValidateFunc: validation.StringInSlice([]string{"Last", "On", "Off"}, false),
+			},
+			"delay_mode": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "How the power on is delayed when AC power is restored. Applicable values are 'Immediate', 'Random' and 'User'",
+				ValidateFunc:  validation.StringInSlice([]string{"Immediate", "Random", "User"}, false),
+				ConflictsWith: []string{"stagger_key"},
+			},
+			"delay_seconds": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Power on delay in seconds when delay_mode is 'User'",
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"stagger_key"},
+			},
+			"stagger_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Unique key of this server in the fleet (i.e. each.key). When set, delay_seconds is derived from it so the servers of a fleet power on staggered within the stagger window",
+			},
+			"stagger_min_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "Minimum delay assigned by the stagger. By default 60 seconds",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"stagger_window_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      180,
+				Description:  "Width of the window the staggered delays are spread over, starting at stagger_min_seconds. By default 180 seconds",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"settings_apply_time": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The time when the BIOS settings can be applied. Applicable values are 'OnReset', 'Immediate', 'AtMaintenanceWindowStart' and 'InMaintenanceWindowStart'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfishcommon.ImmediateApplyTime),
+					string(redfishcommon.OnResetApplyTime),
+					string(redfishcommon.AtMaintenanceWindowStartApplyTime),
+					string(redfishcommon.InMaintenanceWindowOnResetApplyTime),
+				}, false),
+			},
+			operationLogAttribute: operationLogSchema(),
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Description: "BIOS configuration job uri",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRedfishPowerOnDelayUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning power on delay update")
+	opLog := newOperationLog(m, "redfish_power_on_delay")
+	defer opLog.save(d)
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+
+	desired := make(map[string]interface{})
+	if v, ok := d.GetOk("ac_power_recovery"); ok {
+		desired[acPowerRecoveryAttribute] = v.(string)
+	}
+	if v, ok := d.GetOk("stagger_key"); ok {
+		desired[acPowerRecoveryDelayAttribute] = "User"
+		desired[acPowerRecoveryUserDelayAttribute] = staggeredDelay(v.(string), d.Get("stagger_min_seconds").(int), d.Get("stagger_window_seconds").(int))
+	} else {
+		if v, ok := d.GetOk("delay_mode"); ok {
+			desired[acPowerRecoveryDelayAttribute] = v.(string)
+		}
+		if v, ok := d.GetOkExists("delay_seconds"); ok {
+			desired[acPowerRecoveryUserDelayAttribute] = v.(int)
+		}
+	}
+
+	// Only send the attributes that are different from the current ones
+	attrsPayload := make(map[string]interface{})
+	for key, value := range desired {
+		current, ok := bios.Attributes[key]
+		if !ok {
+			return diag.Errorf("BIOS attribute %s not found", key)
+		}
+		if fmt.Sprintf("%v", current) != fmt.Sprintf("%v", value) {
+			attrsPayload[key] = value
+		}
+	}
+
+	if len(attrsPayload) != 0 {
+		err = updateBiosAttributes(d, bios, attrsPayload)
+		opLog.record("bios_settings_patch", bios.ODataID+"/Settings", d.Get("bios_config_job_uri").(string), err)
+		if err != nil {
+			return diag.Errorf("error updating power on delay attributes: %s", err)
+		}
+	} else {
+		log.Printf("[DEBUG] Power on delay attributes are already set")
+	}
+
+	d.SetId(bios.ODataID)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishPowerOnDelayRead(ctx, d, m)
+}
+
+func resourceRedfishPowerOnDelayRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching BIOS resource: %s", err)
+	}
+
+	attributes := make(map[string]string)
+	if err = copyBiosAttributes(bios, attributes); err != nil {
+		return diag.Errorf("error fetching BIOS attributes: %s", err)
+	}
+
+	if err := d.Set("ac_power_recovery", attributes[acPowerRecoveryAttribute]); err != nil {
+		return diag.Errorf("error setting ac_power_recovery: %s", err)
+	}
+	if err := d.Set("delay_mode", attributes[acPowerRecoveryDelayAttribute]); err != nil {
+		return diag.Errorf("error setting delay_mode: %s", err)
+	}
+	if delay, err := strconv.Atoi(attributes[acPowerRecoveryUserDelayAttribute]); err == nil {
+		if err := d.Set("delay_seconds", delay); err != nil {
+			return diag.Errorf("error setting delay_seconds: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishPowerOnDelayDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishPowerOnDelayCustomizeDiff plans the staggered delay, so a server whose delay
+// does not match its stagger key (i.e. it was changed out of band) gets updated.
+func resourceRedfishPowerOnDelayCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	key, ok := d.GetOk("stagger_key")
+	if !ok {
+		return nil
+	}
+	delay := staggeredDelay(key.(string), d.Get("stagger_min_seconds").(int), d.Get("stagger_window_seconds").(int))
+	if d.Get("delay_seconds").(int) != delay {
+		if err := d.SetNew("delay_seconds", delay); err != nil {
+			return err
+		}
+	}
+	if d.Get("delay_mode").(string) != "User" {
+		return d.SetNew("delay_mode", "User")
+	}
+	return nil
+}
+
+// staggeredDelay spreads the servers of a fleet over [min, min+window] seconds.
+// The offset is derived from a hash of the key, so it is stable across runs and
+// needs no coordination between the resources of the fleet.
+func staggeredDelay(key string, min int, window int) int {
+	if window <= 0 {
+		return min
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return min + int(h.Sum32()%uint32(window+1))
+}
@@ -0,0 +1,35 @@
+package redfish
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStaggeredDelay(t *testing.T) {
+	cases := []struct {
+		noTest int
+		min    int
+		window int
+	}{
+		{1, 60, 180},
+		{2, 0, 30},
+		{3, 120, 0},
+	}
+	for _, v := range cases {
+		seen := make(map[int]bool)
+		for i := 0; i < 50; i++ {
+			key := fmt.Sprintf("server-%02d", i)
+			delay := staggeredDelay(key, v.min, v.window)
+			if delay < v.min || delay > v.min+v.window {
+				t.Errorf("Test number %v failed. Delay %v for %v is out of [%v, %v]", v.noTest, delay, key, v.min, v.min+v.window)
+			}
+			if delay != staggeredDelay(key, v.min, v.window) {
+				t.Errorf("Test number %v failed. Delay for %v is not stable", v.noTest, key)
+			}
+			seen[delay] = true
+		}
+		if v.window > 0 && len(seen) < 2 {
+			t.Errorf("Test number %v failed. Delays were not spread over the window", v.noTest)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"regexp"
+)
+
+// nicMacAddressAttributes maps the redfish_sb_nic_mac_address variables to the Dell virtual address attributes of the function
+var nicMacAddressAttributes = dellAttributeMapping{
+	"mac_address": "VirtMacAddr",
+	"wwnn":        "VirtWWN",
+	"wwpn":        "VirtWWPN",
+}
+
+var (
+	macAddressRegexp = regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`)
+	wwnRegexp        = regexp.MustCompile(`^([0-9A-Fa-f]{2}:){7}[0-9A-Fa-f]{2}$`)
+)
+
+func resourceRedfishSbNicMacAddress() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSbNicMacAddressUpdate),
+		ReadContext:   resourceRedfishSbNicMacAddressRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSbNicMacAddressUpdate),
+		DeleteContext: resourceRedfishSbNicMacAddressDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"network_adapter_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the network adapter (i.e. NIC.Integrated.1 on Dell systems)",
+			},
+			"function_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the network device function, a port or a NIC partition (i.e. NIC.Integrated.1-1-1)",
+			},
+			"mac_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Virtual MAC address the function presents instead of its permanent one (i.e. 02:00:0A:00:01:10). 00:00:00:00:00:00 when it is not overridden on Dell systems",
+				ValidateFunc:     validation.StringMatch(macAddressRegexp, "must be a MAC address like 02:00:0A:00:01:10"),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"wwnn": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Virtual World Wide Node Name of the FC or FCoE function (i.e. 20:00:00:0E:1E:C2:00:10)",
+				ValidateFunc:     validation.StringMatch(wwnRegexp, "must be a WWN like 20:00:00:0E:1E:C2:00:10"),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"wwpn": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Virtual World Wide Port Name of the FC or FCoE function (i.e. 20:01:00:0E:1E:C2:00:10)",
+				ValidateFunc:     validation.StringMatch(wwnRegexp, "must be a WWN like 20:01:00:0E:1E:C2:00:10"),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"permanent_mac_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "MAC address burned into the function",
+			},
+			"permanent_wwnn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "World Wide Node Name burned into the function. Empty for the Ethernet functions",
+			},
+			"permanent_wwpn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "World Wide Port Name burned into the function. Empty for the Ethernet functions",
+			},
+			"config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the changes on the next reboot, on BMCs with a Dell job queue",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishSbNicMacAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning NIC MAC address update")
+	opLog := newOperationLog(m, "redfish_sb_nic_mac_address")
+	defer opLog.save(d)
+
+	function, err := getNicMacAddressFunction(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(function.ODataID + "#mac_address")
+
+	var settingsURI, jobURI string
+	if oem.Vendor() == "dell" {
+		settingsURI = function.DellNetworkAttributesURI() + "/Settings"
+		err = updateDellAttributes(conn, d, settingsURI, nicMacAddressAttributes)
+		if err == nil {
+			jobURI, err = oem.CreateConfigJob(conn, settingsURI)
+		}
+	} else {
+		settingsURI = function.SettingsURI()
+		jobURI, err = common.PatchResourceWithJob(conn, settingsURI, expandNicMacAddressSettings(d))
+	}
+	opLog.record("network_settings_patch", settingsURI, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error updating the addresses of function %s: %s", function.ID, err)
+	}
+	if err := d.Set("config_job_uri", jobURI); err != nil {
+		return diag.Errorf("error setting config_job_uri: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished, the changes will be applied on the next reboot", d.Id())
+	return resourceRedfishSbNicMacAddressRead(ctx, d, m)
+}
+
+func resourceRedfishSbNicMacAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	function, err := getNicMacAddressFunction(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	values := map[string]interface{}{
+		"permanent_mac_address": function.Ethernet.PermanentMACAddress,
+		"permanent_wwnn":        function.FibreChannel.PermanentWWNN,
+		"permanent_wwpn":        function.FibreChannel.PermanentWWPN,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	// The addresses are kept until the reboot applies the pending changes
+	if configJobPending(conn, d, d.Get("config_job_uri").(string)) {
+		return diags
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, function.DellNetworkAttributesURI(), nicMacAddressAttributes); err != nil {
+			return diag.Errorf("error reading the addresses of function %s: %s", function.ID, err)
+		}
+		return diags
+	}
+
+	values = map[string]interface{}{
+		"mac_address": function.Ethernet.MACAddress,
+		"wwnn":        function.FibreChannel.WWNN,
+		"wwpn":        function.FibreChannel.WWPN,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishSbNicMacAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The addresses are left as configured, as the DHCP reservations and SAN zoning of the host depend on them
+	d.SetId("")
+
+	return diags
+}
+
+// getNicMacAddressFunction fetches the network device function of redfish_sb_nic_mac_address
+func getNicMacAddressFunction(conn *gofish.APIClient, d *schema.ResourceData) (*common.NetworkDeviceFunction, error) {
+	_, functions, err := getVirtualNetworkFunctions(conn, d.Get("network_adapter_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	function, ok := functions[d.Get("function_id").(string)]
+	if !ok {
+		return nil, fmt.Errorf("function %s not found in network adapter %s", d.Get("function_id").(string), d.Get("network_adapter_id").(string))
+	}
+	return function, nil
+}
+
+// expandNicMacAddressSettings returns the standard Redfish properties of the function for the addresses set in the configuration.
+// The WWNs are only used instead of the permanent ones when their source is ConfiguredLocally.
+func expandNicMacAddressSettings(d *schema.ResourceData) map[string]interface{} {
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOk("mac_address"); ok {
+		payload["Ethernet"] = map[string]interface{}{"MACAddress": v.(string)}
+	}
+	fibreChannel := make(map[string]interface{})
+	for key, property := range map[string]string{"wwnn": "WWNN", "wwpn": "WWPN"} {
+		if v, ok := d.GetOk(key); ok {
+			fibreChannel[property] = v.(string)
+		}
+	}
+	if len(fibreChannel) > 0 {
+		fibreChannel["WWNSource"] = "ConfiguredLocally"
+		payload["FibreChannel"] = fibreChannel
+	}
+	return payload
+}
@@ -0,0 +1,33 @@
+package redfish
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"testing"
+)
+
+func TestExpandNicMacAddressSettings(t *testing.T) {
+	cases := []struct {
+		noTest   int
+		raw      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{1, map[string]interface{}{"mac_address": "02:00:0A:00:01:10"}, map[string]interface{}{"Ethernet": map[string]interface{}{"MACAddress": "02:00:0A:00:01:10"}}},
+		{2, map[string]interface{}{"wwnn": "20:00:00:0E:1E:C2:00:10", "wwpn": "20:01:00:0E:1E:C2:00:10"}, map[string]interface{}{
+			"FibreChannel": map[string]interface{}{
+				"WWNN":      "20:00:00:0E:1E:C2:00:10",
+				"WWPN":      "20:01:00:0E:1E:C2:00:10",
+				"WWNSource": "ConfiguredLocally",
+			},
+		}},
+		{3, map[string]interface{}{}, map[string]interface{}{}},
+	}
+	for _, v := range cases {
+		v.raw["network_adapter_id"] = "DE07A000"
+		v.raw["function_id"] = "1"
+		d := schema.TestResourceDataRaw(t, resourceRedfishSbNicMacAddress().Schema, v.raw)
+		if payload := expandNicMacAddressSettings(d); !reflect.DeepEqual(payload, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, payload)
+		}
+	}
+}
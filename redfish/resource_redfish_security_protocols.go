@@ -0,0 +1,147 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+// securityProtocolsAttributes maps the redfish_security_protocols variables to the Dell iDRAC attributes.
+// The network services are managed through the ManagerNetworkProtocol resource instead.
+var securityProtocolsAttributes = dellAttributeMapping{
+	"tls_protocol":        "WebServer.1.TLSProtocol",
+	"web_session_timeout": "WebServer.1.Timeout",
+	"ssh_ciphers":         "SSHCrypto.1.Ciphers",
+}
+
+func resourceRedfishSecurityProtocols() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSecurityProtocolsUpdate),
+		ReadContext:   resourceRedfishSecurityProtocolsRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSecurityProtocolsUpdate),
+		DeleteContext: resourceRedfishSecurityProtocolsDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"tls_protocol": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "TLS versions accepted by the web server (i.e. 'TLS 1.1 and Higher', 'TLS 1.2 Only' or 'TLS 1.3 Only'). The values available depend on the iDRAC firmware",
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"web_session_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Seconds a web session can stay idle before it is closed",
+				ValidateFunc: validation.IntBetween(60, 10800),
+			},
+			"ssh_ciphers": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Comma separated list of the ciphers the SSH server accepts, so the weak ones can be left out",
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"https_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether plain HTTP is disabled, so the BMC can only be reached through HTTPS",
+			},
+			"telnet_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the telnet service is enabled",
+			},
+			"ipmi_over_lan_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether IPMI over LAN is enabled",
+			},
+		},
+	}
+}
+
+func resourceRedfishSecurityProtocolsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning security protocols update")
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, securityProtocolsAttributes); err != nil {
+		return diag.Errorf("error updating security attributes: %s", err)
+	}
+
+	protocol, err := getManagerNetworkProtocol(conn, m.(*providerConfig).managerID)
+	if err != nil {
+		return diag.Errorf("error fetching the BMC network protocol: %s", err)
+	}
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOkExists("https_only"); ok && (d.IsNewResource() || d.HasChange("https_only")) {
+		payload["HTTP"] = map[string]interface{}{"ProtocolEnabled": !v.(bool)}
+	}
+	if v, ok := d.GetOkExists("telnet_enabled"); ok && (d.IsNewResource() || d.HasChange("telnet_enabled")) {
+		payload["Telnet"] = map[string]interface{}{"ProtocolEnabled": v.(bool)}
+	}
+	if v, ok := d.GetOkExists("ipmi_over_lan_enabled"); ok && (d.IsNewResource() || d.HasChange("ipmi_over_lan_enabled")) {
+		payload["IPMI"] = map[string]interface{}{"ProtocolEnabled": v.(bool)}
+	}
+	if len(payload) > 0 {
+		log.Printf("[DEBUG] %s: Updating network protocols %v", protocol.ODataID, payload)
+		if err := common.PatchResource(conn, protocol.ODataID, payload); err != nil {
+			return diag.Errorf("error updating the BMC network protocols: %s", err)
+		}
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#security_protocols")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishSecurityProtocolsRead(ctx, d, m)
+}
+
+func resourceRedfishSecurityProtocolsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, securityProtocolsAttributes); err != nil {
+		return diag.Errorf("error reading security attributes: %s", err)
+	}
+
+	protocol, err := getManagerNetworkProtocol(conn, m.(*providerConfig).managerID)
+	if err != nil {
+		return diag.Errorf("error fetching the BMC network protocol: %s", err)
+	}
+	if err := d.Set("https_only", !protocol.HTTP.ProtocolEnabled); err != nil {
+		return diag.Errorf("error setting https_only: %s", err)
+	}
+	if err := d.Set("telnet_enabled", protocol.Telnet.ProtocolEnabled); err != nil {
+		return diag.Errorf("error setting telnet_enabled: %s", err)
+	}
+	if err := d.Set("ipmi_over_lan_enabled", protocol.IPMI.ProtocolEnabled); err != nil {
+		return diag.Errorf("error setting ipmi_over_lan_enabled: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishSecurityProtocolsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// getManagerNetworkProtocol returns the network services of the manager with managerID
+func getManagerNetworkProtocol(conn *gofish.APIClient, managerID string) (*common.ManagerNetworkProtocol, error) {
+	manager, err := common.GetManager(conn, managerID)
+	if err != nil {
+		return nil, err
+	}
+	return common.GetManagerNetworkProtocol(conn, manager.ODataID)
+}
@@ -0,0 +1,222 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// serialOverLanSettings are the vendor attributes a redfish_serial_over_lan configuration resolves to
+type serialOverLanSettings struct {
+	// bios are the BIOS console redirection attributes, applied on the next reboot
+	bios map[string]string
+	// manager are the Dell iDRAC attributes of the Serial-over-LAN service, applied right away
+	manager map[string]string
+}
+
+// attributes returns every attribute of the settings, as stored in the attributes variable
+func (s *serialOverLanSettings) attributes() map[string]string {
+	attributes := make(map[string]string)
+	for key, value := range s.bios {
+		attributes[key] = value
+	}
+	for key, value := range s.manager {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// newSerialOverLanSettings maps the settings of redfish_serial_over_lan to the attributes of the vendor.
+// port is the serial port the BIOS redirects the console to ('COM1' or 'COM2').
+func newSerialOverLanSettings(vendor string, enabled bool, baudRate int, port string) (*serialOverLanSettings, error) {
+	settings := &serialOverLanSettings{bios: map[string]string{}, manager: map[string]string{}}
+	switch vendor {
+	case "dell":
+		settings.bios["SerialComm"] = "OnNoConRedir"
+		settings.bios["RedirAfterBoot"] = "Disabled"
+		settings.manager["IPMISOL.1.Enable"] = "Disabled"
+		if enabled {
+			settings.bios["SerialComm"] = map[string]string{"COM1": "OnConRedirCom1", "COM2": "OnConRedirCom2"}[port]
+			settings.bios["RedirAfterBoot"] = "Enabled"
+			settings.manager["IPMISOL.1.Enable"] = "Enabled"
+		}
+		settings.bios["FailSafeBaud"] = strconv.Itoa(baudRate)
+		settings.manager["IPMISOL.1.BaudRate"] = strconv.Itoa(baudRate)
+	case "hpe":
+		// The virtual serial port of iLO follows the BIOS, so there is nothing to set on the manager
+		settings.bios["SerialConsolePort"] = "Disabled"
+		settings.bios["VirtualSerialPort"] = "Disabled"
+		if enabled {
+			settings.bios["SerialConsolePort"] = "Virtual"
+			settings.bios["VirtualSerialPort"] = map[string]string{"COM1": "Com1Irq4", "COM2": "Com2Irq3"}[port]
+		}
+		settings.bios["SerialConsoleBaudRate"] = fmt.Sprintf("BaudRate%d", baudRate)
+	default:
+		return nil, fmt.Errorf("Serial-over-LAN is not supported on %s servers. Use redfish_bios with the console redirection attributes of the vendor instead", vendor)
+	}
+	return settings, nil
+}
+
+func resourceRedfishSerialOverLan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSerialOverLanUpdate),
+		ReadContext:   resourceRedfishSerialOverLanRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSerialOverLanUpdate),
+		DeleteContext: resourceRedfishSerialOverLanDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishSerialOverLanCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the BIOS redirects the console to the serial port and the BMC exposes it (IPMI Serial-over-LAN on Dell, virtual serial port on HPE)",
+			},
+			"baud_rate": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      115200,
+				Description:  "Baud rate of the serial console, set on both the BIOS and the BMC. Applicable values are 9600, 19200, 57600 and 115200",
+				ValidateFunc: validation.IntInSlice([]int{9600, 19200, 57600, 115200}),
+			},
+			"port": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "COM2",
+				Description:  "Serial port the BIOS redirects the console to. Applicable values are 'COM1' and 'COM2'. The operating system console (i.e. console=ttyS1) should use the same one",
+				ValidateFunc: validation.StringInSlice([]string{"COM1", "COM2"}, false),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Vendor attributes the settings manage, with their current values. Pending BIOS changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishSerialOverLanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning Serial-over-LAN update")
+	opLog := newOperationLog(m, "redfish_serial_over_lan")
+	defer opLog.save(d)
+
+	settings, err := newSerialOverLanSettings(oem.Vendor(), d.Get("enabled").(bool), d.Get("baud_rate").(int), d.Get("port").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(settings.manager) > 0 {
+		current, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading iDRAC attributes: %s", err)
+		}
+		payload := make(map[string]interface{})
+		for key, value := range settings.manager {
+			if currentValue, ok := current[key]; !ok {
+				return diag.Errorf("iDRAC attribute %s not found, Serial-over-LAN is not supported by this BMC", key)
+			} else if !equivalentValues(currentValue, value) {
+				payload[key] = value
+			}
+		}
+		if len(payload) > 0 {
+			err = common.PatchDellAttributes(conn, common.DellIdracAttributesURI, payload)
+			opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+			if err != nil {
+				return diag.Errorf("error updating Serial-over-LAN attributes: %s", err)
+			}
+		}
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	biosPayload, missing := biosChanges(bios, settings.bios)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, console redirection is not supported by this system", missing[0])
+	}
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "console redirection"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setStagedBiosAttributes(d, settings.attributes()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bios.ODataID + "#serial_over_lan")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishSerialOverLanRead(ctx, d, m)
+}
+
+func resourceRedfishSerialOverLanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		current, err := common.GetDellAttributes(conn, common.DellIdracAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading iDRAC attributes: %s", err)
+		}
+		for key := range attributes {
+			if value, ok := current[key]; ok {
+				attributes[key] = value
+			}
+		}
+	}
+
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishSerialOverLanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as the console is usually still needed once terraform stops managing it
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishSerialOverLanCustomizeDiff checks the vendor is supported and plans the attributes of the settings
+func resourceRedfishSerialOverLanCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	settings, err := newSerialOverLanSettings(m.(*providerConfig).oem.Vendor(), d.Get("enabled").(bool), d.Get("baud_rate").(int), d.Get("port").(string))
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, settings.attributes())
+}
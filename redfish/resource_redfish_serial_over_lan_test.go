@@ -0,0 +1,59 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestNewSerialOverLanSettings(t *testing.T) {
+	cases := []struct {
+		noTest     int
+		vendor     string
+		enabled    bool
+		baudRate   int
+		port       string
+		expected   map[string]string
+		shouldPass bool
+	}{
+		{1, "dell", true, 115200, "COM2", map[string]string{
+			"SerialComm":         "OnConRedirCom2",
+			"RedirAfterBoot":     "Enabled",
+			"FailSafeBaud":       "115200",
+			"IPMISOL.1.Enable":   "Enabled",
+			"IPMISOL.1.BaudRate": "115200",
+		}, true},
+		{2, "dell", false, 9600, "COM1", map[string]string{
+			"SerialComm":         "OnNoConRedir",
+			"IPMISOL.1.Enable":   "Disabled",
+			"IPMISOL.1.BaudRate": "9600",
+		}, true},
+		{3, "hpe", true, 57600, "COM1", map[string]string{
+			"SerialConsolePort":     "Virtual",
+			"VirtualSerialPort":     "Com1Irq4",
+			"SerialConsoleBaudRate": "BaudRate57600",
+		}, true},
+		{4, "hpe", false, 115200, "COM2", map[string]string{
+			"SerialConsolePort": "Disabled",
+			"VirtualSerialPort": "Disabled",
+		}, true},
+		{5, "generic", true, 115200, "COM2", nil, false},
+	}
+	for _, v := range cases {
+		settings, err := newSerialOverLanSettings(v.vendor, v.enabled, v.baudRate, v.port)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		attributes := settings.attributes()
+		for key, value := range v.expected {
+			if attributes[key] != value {
+				t.Errorf("Test number %v: expected %s to be %q, got %q", v.noTest, key, value, attributes[key])
+			}
+		}
+	}
+}
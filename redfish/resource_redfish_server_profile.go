@@ -0,0 +1,575 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"strconv"
+	"time"
+)
+
+// defaultServerProfileTimeout is the time to wait for the reboot applying a server profile
+const defaultServerProfileTimeout = 60 * time.Minute
+
+func resourceRedfishServerProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishServerProfileUpdate),
+		ReadContext:   resourceRedfishServerProfileRead,
+		UpdateContext: withLockdownBypass(resourceRedfishServerProfileUpdate),
+		DeleteContext: resourceRedfishServerProfileDelete,
+		CustomizeDiff: customdiff.Sequence(resumeInterruptedApply, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultServerProfileTimeout),
+			Update: schema.DefaultTimeout(defaultServerProfileTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"bios_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "BIOS attributes of the profile. Only the attributes set here are managed",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"boot_order": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Boot order of the system, as boot option references (i.e. Boot0001)",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "BMC accounts of the profile. Accounts removed from the profile are deleted from the BMC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "User name of the account",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							StateFunc:   hashPassword,
+							Description: "Password of the account. It is only sent when the account is created or the password changes. Only its SHA-256 hash is stored in the state",
+						},
+						"role_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "ReadOnly",
+							Description: "Role of the account (i.e. Administrator, Operator or ReadOnly)",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the account is enabled",
+						},
+					},
+				},
+			},
+			"ntp": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "NTP settings of the BMC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the BMC synchronizes its clock with NTP",
+						},
+						"servers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "NTP servers",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"network": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Network settings of the BMC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Host name of the BMC",
+						},
+						"name_servers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Static DNS servers of the first network interface of the BMC",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"reset_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     string(redfish.GracefulRestartResetType),
+				Description: "Reset used to apply the BIOS and boot order changes, all of them with a single reboot. 'None' leaves them pending until the next reboot",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None",
+					string(redfish.GracefulRestartResetType),
+					string(redfish.ForceRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes of the last apply",
+			},
+			checkpointAttribute:      checkpointSchema(),
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+// resourceRedfishServerProfileUpdate applies the settings that take effect immediately first (users, NTP
+// and network), then stages the BIOS and boot order changes, and finally reboots the system once for all of them.
+// Every step is recorded in the checkpoint, so an interrupted apply resumes without staging the changes nor rebooting again.
+func resourceRedfishServerProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning server profile update")
+	opLog := newOperationLog(m, "redfish_server_profile")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_server_profile")
+	defer progress.save(d)
+	cp := newCheckpoint(d, "bios_attributes", "boot_order", "user", "ntp", "network", "reset_type")
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(system.ODataID)
+
+	// The interrupted apply left the configuration in the state, so the steps it did not complete have to run whatever changed
+	if (d.IsNewResource() || d.HasChange("user") || cp.resuming) && !cp.done("users") {
+		if err := applyProfileUsers(conn, d, opLog); err != nil {
+			return diag.Errorf("error applying users: %s", err)
+		}
+	}
+	cp.complete(d, "users")
+
+	if (d.IsNewResource() || d.HasChange("ntp") || d.HasChange("network") || cp.resuming) && !cp.done("network") {
+		if err := applyProfileNetwork(conn, d, m.(*providerConfig).managerID, opLog); err != nil {
+			return diag.Errorf("error applying BMC network settings: %s", err)
+		}
+	}
+	cp.complete(d, "network")
+
+	// The staged changes are pending until the reboot, so they are not staged again when resuming
+	biosStaged := cp.done("bios_settings")
+	bootOrderStaged := cp.done("boot_order")
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	biosPayload, err := serverProfileBiosPayload(bios.Attributes, d.Get("bios_attributes").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(biosPayload) > 0 && !biosStaged {
+		if _, err := stageBiosAttributes(conn, m.(*providerConfig).oem, d, opLog, bios, biosPayload, "server profile"); err != nil {
+			return diag.FromErr(err)
+		}
+		biosStaged = true
+		cp.complete(d, "bios_settings")
+	}
+
+	bootOrder := []string{}
+	for _, v := range d.Get("boot_order").([]interface{}) {
+		bootOrder = append(bootOrder, v.(string))
+	}
+	if len(bootOrder) > 0 && !sameStrings(system.Boot.BootOrder, bootOrder) && !bootOrderStaged {
+		payload := map[string]interface{}{"Boot": map[string]interface{}{"BootOrder": bootOrder}}
+		err := common.PatchResource(conn, system.ODataID, payload)
+		opLog.record("boot_order_patch", system.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the boot order: %s", err)
+		}
+		bootOrderStaged = true
+		cp.complete(d, "boot_order")
+	}
+
+	if !biosStaged && !bootOrderStaged {
+		log.Printf("[DEBUG] %s: Update finished successfully, no reboot required", d.Id())
+		cp.finish(d)
+		return resourceRedfishServerProfileRead(ctx, d, m)
+	}
+
+	resetType := d.Get("reset_type").(string)
+	if resetType == "None" {
+		log.Printf("[DEBUG] %s: The BIOS and boot order changes will be applied on the next reboot", d.Id())
+		cp.finish(d)
+		return resourceRedfishServerProfileRead(ctx, d, m)
+	}
+	if !cp.done("reset") {
+		if system.PowerState != redfish.OnPowerState {
+			log.Printf("[DEBUG] %s: The system is %s, the changes will be applied on the next power on", d.Id(), system.PowerState)
+			cp.finish(d)
+			return resourceRedfishServerProfileRead(ctx, d, m)
+		}
+		err = system.Reset(redfish.ResetType(resetType))
+		opLog.record("reset", system.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error resetting the system: %s", err)
+		}
+		cp.complete(d, "reset")
+	}
+
+	if jobURI := d.Get("bios_config_job_uri").(string); biosStaged && jobURI != "" {
+		timeout := d.Timeout(schema.TimeoutCreate)
+		if !d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutUpdate)
+		}
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(timeout.Seconds()), progress.reporter(jobURI))
+		opLog.record("job_completion", bios.ODataID+"/Settings", jobURI, err)
+		if err != nil {
+			// A failed job is not waited for again, the next apply stages the changes again
+			if task, taskErr := common.GetTask(conn, jobURI); taskErr == nil && task.Finished() {
+				cp.finish(d)
+			}
+			return diag.Errorf("error waiting for BIOS configuration job %s to finish: %s", jobURI, err)
+		}
+	}
+
+	cp.finish(d)
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishServerProfileRead(ctx, d, m)
+}
+
+func resourceRedfishServerProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// Only the settings in the profile are read back, as the BMC holds many more
+	if attrs := d.Get("bios_attributes").(map[string]interface{}); len(attrs) > 0 {
+		bios, err := getBios(conn, m.(*providerConfig).systemID)
+		if err != nil {
+			return diag.Errorf("error fetching BIOS resource: %s", err)
+		}
+		attributes := make(map[string]string)
+		if err = copyBiosAttributes(bios, attributes); err != nil {
+			return diag.Errorf("error fetching BIOS attributes: %s", err)
+		}
+		current := make(map[string]string)
+		for key := range attrs {
+			current[key] = attributes[key]
+		}
+		if err := d.Set("bios_attributes", current); err != nil {
+			return diag.Errorf("error setting bios_attributes: %s", err)
+		}
+	}
+
+	if len(d.Get("boot_order").([]interface{})) > 0 {
+		system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("boot_order", system.Boot.BootOrder); err != nil {
+			return diag.Errorf("error setting boot_order: %s", err)
+		}
+	}
+
+	if users := d.Get("user").([]interface{}); len(users) > 0 {
+		accounts, err := getAccountList(conn)
+		if err != nil {
+			return diag.Errorf("error fetching accounts: %s", err)
+		}
+		current := []interface{}{}
+		for i, raw := range users {
+			user := raw.(map[string]interface{})
+			// d.Set skips the StateFunc, so the passwords planned in this apply are hashed here
+			if d.HasChange(fmt.Sprintf("user.%d.password", i)) {
+				user["password"] = hashPassword(user["password"])
+			}
+			// Accounts deleted out of band are dropped, so they are created again
+			if account := findAccount(accounts, user["username"].(string)); account != nil {
+				user["role_id"] = account.RoleID
+				user["enabled"] = account.Enabled
+				current = append(current, user)
+			}
+		}
+		if err := d.Set("user", current); err != nil {
+			return diag.Errorf("error setting user: %s", err)
+		}
+	}
+
+	_, ntpSet := d.GetOk("ntp")
+	_, networkSet := d.GetOk("network")
+	if ntpSet || networkSet {
+		manager, err := common.GetManager(conn, m.(*providerConfig).managerID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		protocol, err := common.GetManagerNetworkProtocol(conn, manager.ODataID)
+		if err != nil {
+			return diag.Errorf("error fetching the BMC network protocol: %s", err)
+		}
+		if ntpSet {
+			ntp := []interface{}{map[string]interface{}{
+				"enabled": protocol.NTP.ProtocolEnabled,
+				"servers": nonEmptyStrings(protocol.NTP.NTPServers),
+			}}
+			if err := d.Set("ntp", ntp); err != nil {
+				return diag.Errorf("error setting ntp: %s", err)
+			}
+		}
+		if networkSet {
+			network := d.Get("network").([]interface{})[0].(map[string]interface{})
+			if len(network["hostname"].(string)) > 0 {
+				network["hostname"] = protocol.HostName
+			}
+			if len(network["name_servers"].([]interface{})) > 0 {
+				interfaces, err := manager.EthernetInterfaces()
+				if err != nil {
+					return diag.Errorf("error fetching BMC network interfaces: %s", err)
+				}
+				if len(interfaces) > 0 {
+					network["name_servers"] = nonEmptyStrings(interfaces[0].StaticNameServers)
+				}
+			}
+			if err := d.Set("network", []interface{}{network}); err != nil {
+				return diag.Errorf("error setting network: %s", err)
+			}
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishServerProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are left as they are on the server
+	d.SetId("")
+
+	return diags
+}
+
+// applyProfileUsers creates or updates the accounts of the profile, and deletes the ones removed from it.
+// The state holds the hashes of the passwords, so a password is sent when the hash of the one planned
+// differs from the one in the state.
+func applyProfileUsers(conn *gofish.APIClient, d *schema.ResourceData, opLog *operationLog) error {
+	oldRaw, newRaw := d.GetChange("user")
+	// oldPasswords are the hashes of the passwords in the state, by user name
+	oldPasswords := make(map[string]string)
+	for _, raw := range oldRaw.([]interface{}) {
+		user := raw.(map[string]interface{})
+		oldPasswords[user["username"].(string)] = user["password"].(string)
+	}
+
+	accounts, err := getAccountList(conn)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool)
+	for i, raw := range newRaw.([]interface{}) {
+		user := raw.(map[string]interface{})
+		username := user["username"].(string)
+		wanted[username] = true
+		payload := map[string]interface{}{
+			"RoleId":  user["role_id"].(string),
+			"Enabled": user["enabled"].(bool),
+		}
+		account := findAccount(accounts, username)
+		created := account == nil
+		if account == nil {
+			// ID 1 is reserved
+			for _, a := range accounts {
+				if len(a.UserName) == 0 && a.ID != "1" {
+					account = a
+					break
+				}
+			}
+			if account == nil {
+				return fmt.Errorf("there is no room for user %s", username)
+			}
+			payload["UserName"] = username
+			// The slot is taken, so it is not picked again for the next user
+			account.UserName = username
+		}
+		// Only the passwords planned in this apply are known in clear, the others are the hashes of the state
+		if d.HasChange(fmt.Sprintf("user.%d.password", i)) {
+			password := user["password"].(string)
+			if oldPassword, ok := oldPasswords[username]; created || !ok || oldPassword != hashPassword(password) {
+				payload["Password"] = password
+			}
+		} else if created {
+			return fmt.Errorf("the password of user %s is not planned in this apply, as the state only holds its hash. Change it to create the account", username)
+		}
+		err := common.PatchResource(conn, account.ODataID, payload)
+		opLog.record("account_update", account.ODataID, "", err)
+		if err != nil {
+			return fmt.Errorf("error updating user %s: %s", username, err)
+		}
+	}
+
+	for username := range oldPasswords {
+		if wanted[username] {
+			continue
+		}
+		account := findAccount(accounts, username)
+		if account == nil {
+			continue
+		}
+		err := common.PatchResource(conn, account.ODataID, map[string]interface{}{"UserName": ""})
+		opLog.record("account_delete", account.ODataID, "", err)
+		if err != nil {
+			return fmt.Errorf("error deleting user %s: %s", username, err)
+		}
+	}
+	return nil
+}
+
+// applyProfileNetwork updates the NTP and network settings of the manager with managerID
+func applyProfileNetwork(conn *gofish.APIClient, d *schema.ResourceData, managerID string, opLog *operationLog) error {
+	manager, err := common.GetManager(conn, managerID)
+	if err != nil {
+		return err
+	}
+	protocol, err := common.GetManagerNetworkProtocol(conn, manager.ODataID)
+	if err != nil {
+		return err
+	}
+
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOk("ntp"); ok {
+		ntp := v.([]interface{})[0].(map[string]interface{})
+		servers := []string{}
+		for _, s := range ntp["servers"].([]interface{}) {
+			servers = append(servers, s.(string))
+		}
+		ntpPayload := map[string]interface{}{"ProtocolEnabled": ntp["enabled"].(bool)}
+		if len(servers) > 0 {
+			ntpPayload["NTPServers"] = servers
+		}
+		payload["NTP"] = ntpPayload
+	}
+
+	nameServers := []string{}
+	if v, ok := d.GetOk("network"); ok {
+		network := v.([]interface{})[0].(map[string]interface{})
+		if hostname := network["hostname"].(string); len(hostname) > 0 && hostname != protocol.HostName {
+			payload["HostName"] = hostname
+		}
+		for _, s := range network["name_servers"].([]interface{}) {
+			nameServers = append(nameServers, s.(string))
+		}
+	}
+
+	if len(payload) > 0 {
+		err := common.PatchResource(conn, protocol.ODataID, payload)
+		opLog.record("network_protocol_patch", protocol.ODataID, "", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(nameServers) > 0 {
+		interfaces, err := manager.EthernetInterfaces()
+		if err != nil {
+			return err
+		}
+		if len(interfaces) == 0 {
+			return fmt.Errorf("manager %s has no network interfaces", manager.ID)
+		}
+		if !sameStrings(nonEmptyStrings(interfaces[0].StaticNameServers), nameServers) {
+			err := common.PatchResource(conn, interfaces[0].ODataID, map[string]interface{}{"StaticNameServers": nameServers})
+			opLog.record("name_servers_patch", interfaces[0].ODataID, "", err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// serverProfileBiosPayload returns the desired BIOS attributes that differ from the current ones.
+// Values are strings in the configuration, so they are converted back to integers for integer attributes.
+func serverProfileBiosPayload(current map[string]interface{}, desired map[string]interface{}) (map[string]interface{}, error) {
+	payload := make(map[string]interface{})
+	for key, value := range desired {
+		currentValue, ok := current[key]
+		if !ok {
+			return nil, fmt.Errorf("BIOS attribute %s not found", key)
+		}
+		if _, isNumber := currentValue.(float64); isNumber {
+			intValue, err := strconv.Atoi(value.(string))
+			if err != nil {
+				return nil, fmt.Errorf("BIOS attribute %s must be an integer", key)
+			}
+			if float64(intValue) != currentValue.(float64) {
+				payload[key] = intValue
+			}
+			continue
+		}
+		if fmt.Sprintf("%v", currentValue) != value.(string) {
+			payload[key] = value.(string)
+		}
+	}
+	return payload, nil
+}
+
+// findAccount returns the account with the given user name, or nil when there is none
+func findAccount(accounts []*redfish.ManagerAccount, username string) *redfish.ManagerAccount {
+	for _, account := range accounts {
+		if account.UserName == username {
+			return account
+		}
+	}
+	return nil
+}
+
+// nonEmptyStrings drops the empty entries BMCs use to pad fixed size lists (i.e. NTP and DNS servers)
+func nonEmptyStrings(list []string) []string {
+	result := []string{}
+	for _, s := range list {
+		if len(s) > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// sameStrings reports whether two lists hold the same strings in the same order
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestServerProfileBiosPayload(t *testing.T) {
+	current := map[string]interface{}{
+		"LogicalProc":         "Enabled",
+		"SysProfile":          "PerfOptimized",
+		"AcPwrRcvryUserDelay": float64(60),
+	}
+	cases := []struct {
+		noTest     int
+		desired    map[string]interface{}
+		expected   map[string]interface{}
+		shouldPass bool
+	}{
+		{1, map[string]interface{}{"LogicalProc": "Disabled", "SysProfile": "PerfOptimized"}, map[string]interface{}{"LogicalProc": "Disabled"}, true},
+		{2, map[string]interface{}{"AcPwrRcvryUserDelay": "120"}, map[string]interface{}{"AcPwrRcvryUserDelay": 120}, true},
+		{3, map[string]interface{}{"AcPwrRcvryUserDelay": "60"}, map[string]interface{}{}, true},
+		{4, map[string]interface{}{"AcPwrRcvryUserDelay": "soon"}, nil, false},
+		{5, map[string]interface{}{"Unknown": "Enabled"}, nil, false},
+	}
+	for _, v := range cases {
+		payload, err := serverProfileBiosPayload(current, v.desired)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if len(payload) != len(v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, payload)
+			continue
+		}
+		for key, value := range v.expected {
+			if payload[key] != value {
+				t.Errorf("Test number %v: expected %v=%v, got %v", v.noTest, key, value, payload[key])
+			}
+		}
+	}
+}
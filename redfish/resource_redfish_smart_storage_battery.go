@@ -0,0 +1,252 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"log"
+	"strings"
+)
+
+// smartStorageDataGuard protects the data of the logical drives from the changes of redfish_smart_storage_battery,
+// which are all non-destructive
+const smartStorageDataGuard = "Strict"
+
+func resourceRedfishSmartStorageBattery() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSmartStorageBatteryUpdate),
+		ReadContext:   resourceRedfishSmartStorageBatteryRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSmartStorageBatteryUpdate),
+		DeleteContext: resourceRedfishSmartStorageBatteryDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishSmartStorageBatteryCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"location": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Location of the Smart Array controller (i.e. Slot 0 for the embedded one). Defaults to the first controller",
+			},
+			"read_cache_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Percentage of the cache module used to cache reads, the rest caches writes",
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"write_cache_without_battery": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the controller caches writes while the battery is missing, failed or charging. " +
+					"The writes still in the cache are lost on a power failure",
+			},
+			"write_cache_bypass_threshold_kib": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Size in KiB above which the writes bypass the cache, from 16 to 1040 in multiples of 16",
+				ValidateFunc: validation.All(validation.IntBetween(16, 1040), validation.IntDivisibleBy(16)),
+			},
+			"drive_write_cache": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the physical drives cache the writes themselves. Applicable values are 'enabled' and 'disabled'. " +
+					"Unlike the cache module, the cache of the drives is not backed by the battery",
+				ValidateFunc:     validation.StringInSlice([]string{"enabled", "disabled"}, true),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"battery_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the Smart Storage battery (i.e. OK). Empty when the chassis has no battery, in which case the writes are only cached with write_cache_without_battery",
+			},
+			"battery_charge_percent": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Charge of the Smart Storage battery, in percent",
+			},
+			"settings_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the settings object holding the changes until the next reboot applies them",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishSmartStorageBatteryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning Smart Storage battery update")
+	opLog := newOperationLog(m, "redfish_smart_storage_battery")
+	defer opLog.save(d)
+
+	config, err := getSmartStorageConfig(conn, m.(*providerConfig).systemID, d.Get("location").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(config.ODataID)
+
+	settingsURI, err := common.SettingsObjectURI(conn, config.ODataID)
+	if err != nil {
+		return diag.Errorf("error fetching the settings object of %s: %s", config.Location, err)
+	}
+	if err := d.Set("settings_uri", settingsURI); err != nil {
+		return diag.Errorf("error setting settings_uri: %s", err)
+	}
+
+	payload := expandSmartStorageSettings(d)
+	if len(payload) > 0 {
+		payload["DataGuard"] = smartStorageDataGuard
+		err = common.PatchResource(conn, settingsURI, payload)
+		opLog.record("smart_storage_settings_patch", settingsURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the cache settings of controller %s: %s", config.Location, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished, the changes will be applied on the next reboot", d.Id())
+	return resourceRedfishSmartStorageBatteryRead(ctx, d, m)
+}
+
+func resourceRedfishSmartStorageBatteryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	config, err := common.GetSmartStorageConfig(conn, d.Id())
+	if err != nil {
+		return diag.Errorf("error fetching %s: %s", d.Id(), err)
+	}
+	// The pending changes are shown instead of the current values until the reboot applies them
+	if settingsURI := d.Get("settings_uri").(string); settingsURI != "" {
+		pending, err := common.GetSmartStorageConfig(conn, settingsURI)
+		if err != nil {
+			return diag.Errorf("error fetching %s: %s", settingsURI, err)
+		}
+		mergeSmartStorageSettings(config, pending)
+	}
+	values := map[string]interface{}{
+		"location":          config.Location,
+		"drive_write_cache": strings.ToLower(config.DriveWriteCache),
+	}
+	if config.ReadCachePercent != nil {
+		values["read_cache_percent"] = *config.ReadCachePercent
+	}
+	if config.WriteCacheBypassThresholdKiB != nil {
+		values["write_cache_bypass_threshold_kib"] = *config.WriteCacheBypassThresholdKiB
+	}
+	if config.WriteCacheWithoutBackupPowerEnabled != nil {
+		values["write_cache_without_battery"] = *config.WriteCacheWithoutBackupPowerEnabled
+	}
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	chassisURI, err := common.GetSystemChassisURI(conn, system.ODataID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	batteries, err := common.GetSmartStorageBatteries(conn, chassisURI)
+	if err != nil {
+		return diag.Errorf("error fetching the Smart Storage batteries: %s", err)
+	}
+	values["battery_status"] = ""
+	values["battery_charge_percent"] = 0
+	if len(batteries) > 0 {
+		values["battery_status"] = string(batteries[0].Status.Health)
+		values["battery_charge_percent"] = batteries[0].ChargeLevelPercent
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishSmartStorageBatteryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The cache settings are kept, as the controller has no defaults to restore
+	d.SetId("")
+
+	return diags
+}
+
+func resourceRedfishSmartStorageBatteryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor != "hpe" {
+		return fmt.Errorf("the Smart Storage settings are not supported on %s BMCs, only on HPE iLO", vendor)
+	}
+	return nil
+}
+
+// getSmartStorageConfig fetches the configuration of the Smart Array controller at location, or of the first one when location is empty
+func getSmartStorageConfig(conn *gofish.APIClient, systemID string, location string) (*common.SmartStorageConfig, error) {
+	system, err := common.GetSystem(conn, systemID)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := common.GetSmartStorageConfigs(conn, system.ODataID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the Smart Array controllers: %s", err)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no Smart Array controller found in system %s", system.ID)
+	}
+	if location == "" {
+		return configs[0], nil
+	}
+	locations := []string{}
+	for _, config := range configs {
+		if config.Location == location {
+			return config, nil
+		}
+		locations = append(locations, config.Location)
+	}
+	return nil, fmt.Errorf("no Smart Array controller found at %s. Available locations: %v", location, locations)
+}
+
+// expandSmartStorageSettings returns the HpeSmartStorageConfig properties of the settings set in the configuration
+func expandSmartStorageSettings(d *schema.ResourceData) map[string]interface{} {
+	payload := make(map[string]interface{})
+	if v, ok := d.GetOkExists("read_cache_percent"); ok {
+		payload["ReadCachePercent"] = v.(int)
+	}
+	if v, ok := d.GetOk("write_cache_bypass_threshold_kib"); ok {
+		payload["WriteCacheBypassThresholdKiB"] = v.(int)
+	}
+	if v, ok := d.GetOkExists("write_cache_without_battery"); ok {
+		payload["WriteCacheWithoutBackupPowerEnabled"] = v.(bool)
+	}
+	if v, ok := d.GetOk("drive_write_cache"); ok {
+		payload["DriveWriteCache"] = map[string]string{"enabled": "Enabled", "disabled": "Disabled"}[strings.ToLower(v.(string))]
+	}
+	return payload
+}
+
+// mergeSmartStorageSettings overrides the settings of config with the ones pending in the settings object
+func mergeSmartStorageSettings(config *common.SmartStorageConfig, pending *common.SmartStorageConfig) {
+	if pending.ReadCachePercent != nil {
+		config.ReadCachePercent = pending.ReadCachePercent
+	}
+	if pending.WriteCacheBypassThresholdKiB != nil {
+		config.WriteCacheBypassThresholdKiB = pending.WriteCacheBypassThresholdKiB
+	}
+	if pending.WriteCacheWithoutBackupPowerEnabled != nil {
+		config.WriteCacheWithoutBackupPowerEnabled = pending.WriteCacheWithoutBackupPowerEnabled
+	}
+	if pending.DriveWriteCache != "" {
+		config.DriveWriteCache = pending.DriveWriteCache
+	}
+}
@@ -0,0 +1,27 @@
+package redfish
+
+import (
+	"github.com/dell/terraform-provider-redfish/common"
+	"testing"
+)
+
+func TestMergeSmartStorageSettings(t *testing.T) {
+	readCache, pendingReadCache := 10, 50
+	withoutBattery := true
+	cases := []struct {
+		noTest   int
+		pending  common.SmartStorageConfig
+		expected common.SmartStorageConfig
+	}{
+		{1, common.SmartStorageConfig{}, common.SmartStorageConfig{Location: "Slot 0", ReadCachePercent: &readCache, DriveWriteCache: "Disabled"}},
+		{2, common.SmartStorageConfig{ReadCachePercent: &pendingReadCache, WriteCacheWithoutBackupPowerEnabled: &withoutBattery, DriveWriteCache: "Enabled"},
+			common.SmartStorageConfig{Location: "Slot 0", ReadCachePercent: &pendingReadCache, WriteCacheWithoutBackupPowerEnabled: &withoutBattery, DriveWriteCache: "Enabled"}},
+	}
+	for _, v := range cases {
+		config := common.SmartStorageConfig{Location: "Slot 0", ReadCachePercent: &readCache, DriveWriteCache: "Disabled"}
+		mergeSmartStorageSettings(&config, &v.pending)
+		if config != v.expected {
+			t.Errorf("Test number %v: expected %+v, got %+v", v.noTest, v.expected, config)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stmcginnis/gofish"
+	"log"
+)
+
+func resourceRedfishSpdm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSpdmUpdate),
+		ReadContext:   resourceRedfishSpdmRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSpdmUpdate),
+		DeleteContext: resourceRedfishSpdmDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"component_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Ids of the ComponentIntegrity members whose attestation is managed (i.e. the devices of the redfish_component_integrity data source). Every component when empty",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the BMC attests the components over SPDM, authenticating their identity and collecting their measurements",
+			},
+			"components": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Components managed by the resource",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Id of the ComponentIntegrity member",
+						},
+						"target_component_uri": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URI of the device the attestation applies to",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the attestation of the component is enabled",
+						},
+					},
+				},
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishSpdmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning SPDM update")
+	opLog := newOperationLog(m, "redfish_spdm")
+	defer opLog.save(d)
+
+	components, err := getSpdmComponents(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(conn.Service.ODataID + "#spdm")
+
+	enabled := d.Get("enabled").(bool)
+	for _, component := range components {
+		if component.ComponentIntegrityEnabled == enabled {
+			continue
+		}
+		err := common.SetComponentIntegrityEnabled(conn, component.ODataID, enabled)
+		opLog.record("component_integrity_patch", component.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the attestation of component %s: %s", component.ID, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishSpdmRead(ctx, d, m)
+}
+
+func resourceRedfishSpdmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	components, err := getSpdmComponents(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	componentList := []map[string]interface{}{}
+	for _, component := range components {
+		// A component attested differently than configured plans the change back
+		if component.ComponentIntegrityEnabled != d.Get("enabled").(bool) {
+			if err := d.Set("enabled", component.ComponentIntegrityEnabled); err != nil {
+				return diag.Errorf("error setting enabled: %s", err)
+			}
+		}
+		componentList = append(componentList, map[string]interface{}{
+			"id":                   component.ID,
+			"target_component_uri": component.TargetComponentURI,
+			"enabled":              component.ComponentIntegrityEnabled,
+		})
+	}
+	if err := d.Set("components", componentList); err != nil {
+		return diag.Errorf("error setting components: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishSpdmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The attestation is left as configured, as disabling it on destroy would weaken the security of the server
+	d.SetId("")
+
+	return diags
+}
+
+// getSpdmComponents returns the ComponentIntegrity members of component_ids, every member when it is empty
+func getSpdmComponents(conn *gofish.APIClient, d *schema.ResourceData) ([]*common.ComponentIntegrity, error) {
+	components, err := common.GetComponentIntegrity(conn, conn.Service.ODataID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching component integrity: %s", err)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("the BMC does not support the attestation of components")
+	}
+	ids := d.Get("component_ids").(*schema.Set)
+	if ids.Len() == 0 {
+		return components, nil
+	}
+	selected := []*common.ComponentIntegrity{}
+	available := []string{}
+	for _, component := range components {
+		available = append(available, component.ID)
+		if ids.Contains(component.ID) {
+			selected = append(selected, component)
+		}
+	}
+	if len(selected) != ids.Len() {
+		return nil, fmt.Errorf("components %v not all found. Available components: %v", ids.List(), available)
+	}
+	return selected, nil
+}
@@ -10,6 +10,7 @@ import (
 	redfishcommon "github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -20,14 +21,17 @@ const (
 	volumeDisks         string = "volume_disks"
 	settingsApplyTime   string = "settings_apply_time"
 	biosConfigJobURI    string = "bios_config_job_uri"
+	checkJobQueue       string = "check_job_queue"
+	clearStaleJobs      string = "clear_stale_jobs"
 )
 
 func resourceRedfishStorageVolume() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceStorageVolumeCreate,
+		CreateContext: withPreconditions(withLockdownBypass(resourceStorageVolumeCreate)),
 		ReadContext:   resourceStorageVolumeRead,
-		UpdateContext: resourceStorageVolumeUpdate,
-		DeleteContext: resourceStorageVolumeDelete,
+		UpdateContext: withPreconditions(withLockdownBypass(resourceStorageVolumeUpdate)),
+		DeleteContext: withPreconditions(withLockdownBypass(resourceStorageVolumeDelete)),
+		CustomizeDiff: checkSystemLockdown,
 		Schema: map[string]*schema.Schema{
 			storageControllerID: &schema.Schema{
 				Type:        schema.TypeString,
@@ -61,6 +65,21 @@ func resourceRedfishStorageVolume() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			checkJobQueue: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify there are no pending RAID configuration jobs in the job queue before creating the volume",
+			},
+			clearStaleJobs: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When check_job_queue is set, delete the pending RAID configuration jobs that are not running instead of failing",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+			preconditionsAttribute:   preconditionsSchema(),
 			/*TODO
 			Implement validate function with redfish.GetOperationApplyTimeValues()*/
 		},
@@ -69,8 +88,11 @@ func resourceRedfishStorageVolume() *schema.Resource {
 
 func resourceStorageVolumeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	conn := m.(*gofish.APIClient)
-	service := conn.Service
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_storage_volume")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_storage_volume")
+	defer progress.save(d)
 	//Get user config
 	storageID := d.Get(storageControllerID).(string)
 	volumeType := d.Get(volumeType).(string)
@@ -88,7 +110,7 @@ func resourceStorageVolumeCreate(ctx context.Context, d *schema.ResourceData, m
 		driveNames[i] = raw.(string)
 	}
 	//Get storage
-	storage, err := getStorageController(service, storageID)
+	storage, err := getStorageController(conn, m.(*providerConfig).systemID, storageID)
 	if err != nil {
 		return diag.Errorf("Issue when getting the storage struct: %s", err)
 	}
@@ -97,22 +119,35 @@ func resourceStorageVolumeCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.Errorf("Issue when getting the drives: %s", err)
 	}
+	//Check there are no RAID jobs pending in the job queue
+	if d.Get(checkJobQueue).(bool) {
+		err = m.(*providerConfig).oem.CheckJobQueue(conn, []string{common.RAIDConfigurationJobType}, d.Get(clearStaleJobs).(bool))
+		if err != nil {
+			return diag.Errorf("Issue when checking the job queue: %s", err)
+		}
+	}
 	//Need to figure out how to proceed with settingsApplyTime (Immediate or OnReset)
 	//func createVolume(service *gofish.Service, storageLink string, volumeType string, volumeName string, drives []*redfish.Drive, applyTime string) (jobID string, err error) {
 	//Get redfish.drives
 
 	jobID, err := createVolume(conn, storage.ODataID, volumeType, volumeName, drives, applyTime.(string))
+	opLog.record("volume_create", storage.ODataID, jobID, err)
 	if err != nil {
 		return diag.Errorf("Error when creating the virtual disk on disk controller %s - %s", storageID, err)
 	}
+	//Keep the job until the volume ID is known, so if anything below fails the resource is tainted
+	//and the next apply deletes the job (or the volume it created) before trying again
+	d.Set(biosConfigJobURI, jobID)
+	d.SetId(jobID)
 	if applyTime.(string) == "Immediate" {
-		err = common.WaitForJobToFinish(conn, jobID, common.TimeBetweenAttempts, common.Timeout)
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobID, common.TimeBetweenAttempts, common.Timeout, progress.reporter(jobID))
+		opLog.record("job_completion", storage.ODataID, jobID, err)
 		if err != nil {
-			return diag.Errorf("Error. Job %s wasn't able to complete", jobID)
+			return diag.Errorf("Error. Job %s wasn't able to complete: %s", jobID, err)
 		}
 		// Get new volumeID
 		//getVolumeID(storage *redfish.Storage, volumeName string) (volumeLink string, err error)
-		storage, err := getStorageController(service, storageID)
+		storage, err := getStorageController(conn, m.(*providerConfig).systemID, storageID)
 		if err != nil {
 			return diag.Errorf("Issue when getting the storage struct: %s", err)
 		}
@@ -122,11 +157,8 @@ func resourceStorageVolumeCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 		d.Set(biosConfigJobURI, "")
 		d.SetId(volumeID)
-	} else {
-		//TODO - Implement for not Immediate scenarios
-		d.Set(biosConfigJobURI, jobID)
-		d.SetId(jobID)
 	}
+	//TODO - Implement for not Immediate scenarios
 
 	//resourceStorageVolumeRead(ctx, d, m)
 	return diags
@@ -144,8 +176,8 @@ func resourceStorageVolumeUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	conn := m.(*gofish.APIClient)
-	service := conn.Service
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	opLog := newOperationLog(m, "redfish_storage_volume")
 	//Get user config
 	//If applyTime has been set to Immediate, the volumeID of the resource will be the ODataID of the volume just created.
 	//If applyTime is OnReset, the volumeID will be the JobID
@@ -156,19 +188,29 @@ func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, m
 		applyTime = "Immediate"
 	}
 	//DELETE VOLUME
-	if applyTime.(string) == "Immediate" {
+	//The ID is a job as well when an Immediate creation failed before the volume ID was known
+	if applyTime.(string) == "Immediate" && !isTaskURI(volumeID) {
 		jobID, err := deleteVolume(conn, volumeID)
+		opLog.record("volume_delete", volumeID, jobID, err)
 		if err != nil {
 			return diag.Errorf("Error. There was an error when deleting volume %s", volumeID)
 		}
 		//WAIT FOR VOLUME TO DELETE
-		err = common.WaitForJobToFinish(conn, jobID, common.TimeBetweenAttempts, common.Timeout)
+		// The state is dropped on destroy, so the last progress of a failed job is shown as a warning
+		progress := newJobProgress("redfish_storage_volume")
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobID, common.TimeBetweenAttempts, common.Timeout, progress.reporter(jobID))
+		opLog.record("job_completion", volumeID, jobID, err)
 		if err != nil {
-			panic(err)
+			return append(diag.Errorf("Error. Job %s deleting volume %s wasn't able to complete: %s", jobID, volumeID, err), progress.warning()...)
 		}
 	} else {
 		//Check if the job has been completed or not. If not, kill the job. If so, kill the volume
 		task, err := redfish.GetTask(conn, volumeID)
+		if common.IsNotFound(err) {
+			//The BMC already removed the job, so there is nothing left to delete
+			d.SetId("")
+			return diags
+		}
 		if err != nil {
 			return diag.Errorf("Issue when retrieving the tasks: %s", err)
 		}
@@ -177,7 +219,7 @@ func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, m
 			storageID := d.Get(storageControllerID).(string)
 			volumeName := d.Get(volumeName).(string)
 			//getStorageController
-			storage, err := getStorageController(service, storageID)
+			storage, err := getStorageController(conn, m.(*providerConfig).systemID, storageID)
 			if err != nil {
 				return diag.Errorf("Issue when getting the storage struct: %s", err)
 			}
@@ -187,12 +229,14 @@ func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, m
 				return diag.Errorf("Issue when getting the actual volumeID: %s", err)
 			}
 			//MAYBE WE NEED TO SET A JOB INSTEAD OF DELETING IT RIGHTAWAY
-			_, err = deleteVolume(conn, actualVolumeID)
+			jobID, err := deleteVolume(conn, actualVolumeID)
+			opLog.record("volume_delete", actualVolumeID, jobID, err)
 			d.SetId("")
 		} else {
 			//Get rid of the Job that will create the volume
-			//IMPORTART LIMITATION. TO DELETE A TASK IN DELL EMC REDFISH IMPLEMENTATION, NEEDS TO BE DONE THROUGH ITS MANAGER/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/%s
-			err := common.DeleteDellJob(conn, task.ID)
+			//Some vendors (i.e. Dell) only delete jobs through their job queue, which the OEM handler knows about
+			err := m.(*providerConfig).oem.DeleteJob(conn, task.ODataID)
+			opLog.record("job_delete", volumeID, volumeID, err)
 			if err != nil {
 				return diag.Errorf("Issue when deleting the task: %s", err)
 			}
@@ -202,14 +246,20 @@ func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, m
 	return diags
 }
 
-func getStorageController(service *gofish.Service, diskControllerID string) (*redfish.Storage, error) {
-	systems, err := service.Systems()
+// isTaskURI reports if id is the URI of a task or an iDRAC job, instead of a volume
+func isTaskURI(id string) bool {
+	return strings.Contains(id, "/TaskService/Tasks/") || strings.Contains(id, "/Jobs/")
+}
+
+// getStorageController returns the storage controller diskControllerID of the computer system systemID (the first one when empty)
+func getStorageController(conn *gofish.APIClient, systemID string, diskControllerID string) (*redfish.Storage, error) {
+	system, err := common.GetSystem(conn, systemID)
 	if err != nil {
-		return nil, fmt.Errorf("Error when retreiving the Systems from the Redfish API")
+		return nil, err
 	}
-	sg, err := systems[0].Storage()
+	sg, err := system.Storage()
 	if err != nil {
-		return nil, fmt.Errorf("Error when retreiving the Storage from %v from the Redfish API", systems[0].Name)
+		return nil, fmt.Errorf("Error when retreiving the Storage from %v from the Redfish API", system.Name)
 	}
 	for _, storage := range sg {
 		if storage.Entity.ID == diskControllerID {
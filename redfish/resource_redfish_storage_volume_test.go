@@ -28,7 +28,7 @@ func TestGetStorageController(t *testing.T) {
 		if err != nil {
 			t.Errorf("There was an error with the mocked client")
 		}
-		_, err = getStorageController(service.(*gofish.Service), v.storageID)
+		_, err = getStorageController(&gofish.APIClient{Service: service.(*gofish.Service)}, "", v.storageID)
 		if v.shouldPass {
 			if err != nil {
 				t.Errorf("Test number %v failed %v", v.noTest, err)
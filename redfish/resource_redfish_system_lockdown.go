@@ -0,0 +1,122 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+// systemLockdownAttributes maps the redfish_system_lockdown variables to the Dell iDRAC attributes
+var systemLockdownAttributes = dellAttributeMapping{
+	"enabled": common.SystemLockdownAttribute,
+}
+
+func resourceRedfishSystemLockdown() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRedfishSystemLockdownUpdate,
+		ReadContext:   resourceRedfishSystemLockdownRead,
+		UpdateContext: resourceRedfishSystemLockdownUpdate,
+		DeleteContext: resourceRedfishSystemLockdownDelete,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether iDRAC System Lockdown is enabled. While enabled, configuration and firmware changes are rejected by the iDRAC",
+			},
+		},
+	}
+}
+
+func resourceRedfishSystemLockdownUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning system lockdown update")
+	if err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, systemLockdownAttributes); err != nil {
+		return diag.Errorf("error updating system lockdown: %s", err)
+	}
+
+	d.SetId(common.DellIdracAttributesURI)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishSystemLockdownRead(ctx, d, m)
+}
+
+func resourceRedfishSystemLockdownRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, systemLockdownAttributes); err != nil {
+		return diag.Errorf("error reading system lockdown: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishSystemLockdownDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
+
+// checkSystemLockdown is a CustomizeDiff function for the resources that change the server configuration.
+// It fails the plan when System Lockdown is enabled, as the iDRAC would reject the changes at apply time,
+// unless lockdown_bypass is set in the provider.
+func checkSystemLockdown(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := m.(*providerConfig)
+	// In read_only mode the changes are refused anyway (see withReadOnly)
+	if config.lockdownBypass || config.readOnly {
+		return nil
+	}
+	// Resources without changes do not need to write to the server
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	enabled, err := common.SystemLockdownEnabled(config.clientWithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("error checking system lockdown: %s", err)
+	}
+	if enabled {
+		return fmt.Errorf("iDRAC System Lockdown is enabled, so the changes would be rejected. Disable it with redfish_system_lockdown or set lockdown_bypass in the provider")
+	}
+	return nil
+}
+
+// withLockdownBypass wraps the create/update/delete function of a resource so, when lockdown_bypass
+// is set in the provider and System Lockdown is enabled, lockdown is disabled while f runs
+// and enabled again once it finishes. As every configuration change goes through it, f also
+// warns when Group Manager is enabled (see withGroupManagerCheck).
+func withLockdownBypass(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	f = withGroupManagerCheck(f)
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		config := m.(*providerConfig)
+		if !config.lockdownBypass {
+			return f(ctx, d, m)
+		}
+		conn := config.clientWithContext(ctx)
+		enabled, err := common.SystemLockdownEnabled(conn)
+		if err != nil {
+			return diag.Errorf("error checking system lockdown: %s", err)
+		}
+		if !enabled {
+			return f(ctx, d, m)
+		}
+
+		log.Printf("[DEBUG] Disabling system lockdown")
+		if err := common.SetSystemLockdown(conn, false); err != nil {
+			return diag.Errorf("error disabling system lockdown: %s", err)
+		}
+		defer func() {
+			// The original client is used, so lockdown is enabled again even if ctx was cancelled
+			log.Printf("[DEBUG] Enabling system lockdown")
+			if err := common.SetSystemLockdown(config.client, true); err != nil {
+				diags = append(diags, diag.Errorf("error enabling system lockdown again, it was left disabled: %s", err)...)
+			}
+		}()
+		return f(ctx, d, m)
+	}
+}
@@ -0,0 +1,196 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// Workload profiles of redfish_system_profile
+const (
+	// systemPerformanceProfile runs the processors at their highest frequency, with the power management disabled
+	systemPerformanceProfile string = "performance"
+	// systemPerformancePerWattProfile lets the BMC scale the processor frequency with the load
+	systemPerformancePerWattProfile string = "performance_per_watt"
+	// systemCustomProfile applies the C-states, turbo boost and uncore frequency set on the resource
+	systemCustomProfile string = "custom"
+)
+
+// newSystemProfileSettings maps the settings of redfish_system_profile to the BIOS attributes of the vendor.
+// Empty cStates, turbo and uncore are left untouched.
+func newSystemProfileSettings(vendor string, profile string, cStates string, turbo string, uncore string) (map[string]string, error) {
+	if profile != systemCustomProfile && (cStates != "" || turbo != "" || uncore != "") {
+		return nil, fmt.Errorf("c_states, turbo_boost and uncore_frequency can only be set with the %s profile, the other profiles set them", systemCustomProfile)
+	}
+	enabled := map[string]string{"enabled": "Enabled", "disabled": "Disabled"}
+	attributes := make(map[string]string)
+	switch vendor {
+	case "dell":
+		attributes["SysProfile"] = map[string]string{
+			systemPerformanceProfile:        "PerfOptimized",
+			systemPerformancePerWattProfile: "PerfPerWattOptimizedDapc",
+			systemCustomProfile:             "Custom",
+		}[profile]
+		if cStates != "" {
+			attributes["ProcCStates"] = enabled[cStates]
+		}
+		if turbo != "" {
+			attributes["ProcTurboMode"] = enabled[turbo]
+		}
+		if uncore != "" {
+			attributes["UncoreFrequency"] = map[string]string{"dynamic": "DynamicUncore", "maximum": "MaxUncore"}[uncore]
+		}
+	case "hpe":
+		attributes["WorkloadProfile"] = map[string]string{
+			systemPerformanceProfile:        "GeneralPeakFrequencyCompute",
+			systemPerformancePerWattProfile: "GeneralPowerEfficientCompute",
+			systemCustomProfile:             "Custom",
+		}[profile]
+		// HPE sets the deepest C-state allowed instead of enabling them
+		if cStates != "" {
+			attributes["MinProcIdlePower"] = map[string]string{"enabled": "C6", "disabled": "NoCStates"}[cStates]
+		}
+		if turbo != "" {
+			attributes["ProcTurbo"] = enabled[turbo]
+		}
+		if uncore != "" {
+			attributes["UncoreFreqScaling"] = map[string]string{"dynamic": "Auto", "maximum": "Maximum"}[uncore]
+		}
+	default:
+		return nil, fmt.Errorf("system profiles are not supported on %s servers. Use redfish_bios with the attributes of the vendor instead", vendor)
+	}
+	return attributes, nil
+}
+
+func resourceRedfishSystemProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishSystemProfileUpdate),
+		ReadContext:   resourceRedfishSystemProfileRead,
+		UpdateContext: withLockdownBypass(resourceRedfishSystemProfileUpdate),
+		DeleteContext: resourceRedfishSystemProfileDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishSystemProfileCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"profile": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "Workload profile of the system. Applicable values are 'performance', 'performance_per_watt' and 'custom'. " +
+					"Mapped to the system profile on Dell (PerfOptimized, PerfPerWattOptimizedDapc or Custom) and the workload profile on HPE " +
+					"(GeneralPeakFrequencyCompute, GeneralPowerEfficientCompute or Custom)",
+				ValidateFunc: validation.StringInSlice([]string{systemPerformanceProfile, systemPerformancePerWattProfile, systemCustomProfile}, false),
+			},
+			"c_states": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Whether the processors enter the idle power states, with the custom profile only. Applicable values are 'enabled' and 'disabled'. " +
+					"Mapped to the minimum processor idle power state on HPE (C6 or NoCStates)",
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+			},
+			"turbo_boost": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Whether the processors run above their base frequency when the power and thermal limits allow, with the custom profile only. Applicable values are 'enabled' and 'disabled'",
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+			},
+			"uncore_frequency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Frequency of the processor parts outside the cores (i.e. the memory controller and the caches), with the custom profile only. Applicable values are 'dynamic' and 'maximum'",
+				ValidateFunc: validation.StringInSlice([]string{"dynamic", "maximum"}, false),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "BIOS attributes the profile manages, with their current values. Pending changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishSystemProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning system profile update")
+	opLog := newOperationLog(m, "redfish_system_profile")
+	defer opLog.save(d)
+
+	attributes, err := newSystemProfileSettings(oem.Vendor(), d.Get("profile").(string), d.Get("c_states").(string), d.Get("turbo_boost").(string), d.Get("uncore_frequency").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	biosPayload, missing := biosChanges(bios, attributes)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, the %s setting is not supported by this system", missing[0], d.Get("profile").(string))
+	}
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "system profile"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setStagedBiosAttributes(d, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bios.ODataID + "#system_profile")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishSystemProfileRead(ctx, d, m)
+}
+
+func resourceRedfishSystemProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+	if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishSystemProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The profile is kept, as there is no way to know which one was set before
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishSystemProfileCustomizeDiff checks the settings are supported by the vendor and plans their attributes
+func resourceRedfishSystemProfileCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	attributes, err := newSystemProfileSettings(m.(*providerConfig).oem.Vendor(), d.Get("profile").(string), d.Get("c_states").(string), d.Get("turbo_boost").(string), d.Get("uncore_frequency").(string))
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, attributes)
+}
@@ -0,0 +1,268 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// Thermal profiles of redfish_thermal_profile
+const (
+	// thermalDefaultProfile lets the BMC balance the fan speed and the power usage
+	thermalDefaultProfile string = "default"
+	// thermalMaximumPerformanceProfile keeps the components cool, at the cost of fan power and noise
+	thermalMaximumPerformanceProfile string = "maximum_performance"
+	// thermalMinimumPowerProfile runs the fans as slow as the components allow
+	thermalMinimumPowerProfile string = "minimum_power"
+	// thermalSoundCapProfile caps the fan noise, lowering the processor power if needed (Dell only)
+	thermalSoundCapProfile string = "sound_cap"
+)
+
+// thermalMinimumFanSpeedAttribute is the Dell system attribute of the minimum fan speed
+const thermalMinimumFanSpeedAttribute = "ThermalSettings.1.MinimumFanSpeed"
+
+// thermalSettings are the vendor attributes a redfish_thermal_profile configuration resolves to
+type thermalSettings struct {
+	// bios are the BIOS attributes, applied on the next reboot
+	bios map[string]string
+	// system are the Dell system attributes, applied right away
+	system map[string]string
+}
+
+// attributes returns every attribute of the settings, as stored in the attributes variable
+func (s *thermalSettings) attributes() map[string]string {
+	attributes := make(map[string]string)
+	for key, value := range s.bios {
+		attributes[key] = value
+	}
+	for key, value := range s.system {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// newThermalSettings maps the settings of redfish_thermal_profile to the attributes of the vendor.
+// Empty profile and fanSpeedOffset, and a negative minimumFanSpeed, are left untouched.
+func newThermalSettings(vendor string, profile string, fanSpeedOffset string, minimumFanSpeed int) (*thermalSettings, error) {
+	settings := &thermalSettings{bios: map[string]string{}, system: map[string]string{}}
+	switch vendor {
+	case "dell":
+		if profile != "" {
+			settings.system["ThermalSettings.1.ThermalProfile"] = map[string]string{
+				thermalDefaultProfile:            "Default Thermal Profile Settings",
+				thermalMaximumPerformanceProfile: "Maximum Performance",
+				thermalMinimumPowerProfile:       "Minimum Power",
+				thermalSoundCapProfile:           "Sound Cap",
+			}[profile]
+		}
+		if fanSpeedOffset == "Off" {
+			settings.system["ThermalSettings.1.FanSpeedOffset"] = "Off"
+		} else if fanSpeedOffset != "" {
+			settings.system["ThermalSettings.1.FanSpeedOffset"] = fanSpeedOffset + " Fan Speed"
+		}
+		if minimumFanSpeed >= 0 {
+			settings.system[thermalMinimumFanSpeedAttribute] = strconv.Itoa(minimumFanSpeed)
+		}
+	case "hpe":
+		// HPE has a single thermal configuration in the BIOS, which both the profile and the fan speed offset map to
+		if profile != "" && fanSpeedOffset != "" {
+			return nil, fmt.Errorf("thermal_profile and fan_speed_offset both set the thermal configuration on HPE servers, set only one of them")
+		}
+		if minimumFanSpeed >= 0 {
+			return nil, fmt.Errorf("minimum_fan_speed is not supported on HPE servers. Use fan_speed_offset to increase the cooling instead")
+		}
+		switch {
+		case profile == thermalSoundCapProfile:
+			return nil, fmt.Errorf("the %s thermal profile is only supported on Dell servers", profile)
+		case profile != "":
+			settings.bios["ThermalConfig"] = map[string]string{
+				thermalDefaultProfile:            "OptimalCooling",
+				thermalMaximumPerformanceProfile: "MaximumCooling",
+				thermalMinimumPowerProfile:       "OptimalCooling",
+			}[profile]
+		case fanSpeedOffset != "":
+			settings.bios["ThermalConfig"] = map[string]string{
+				"Off":    "OptimalCooling",
+				"Low":    "OptimalCooling",
+				"Medium": "IncreasedCooling",
+				"High":   "IncreasedCooling",
+				"Max":    "MaximumCooling",
+			}[fanSpeedOffset]
+		}
+	default:
+		return nil, fmt.Errorf("thermal settings are not supported on %s servers", vendor)
+	}
+	return settings, nil
+}
+
+func resourceRedfishThermalProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishThermalProfileUpdate),
+		ReadContext:   resourceRedfishThermalProfileRead,
+		UpdateContext: withLockdownBypass(resourceRedfishThermalProfileUpdate),
+		DeleteContext: resourceRedfishThermalProfileDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishThermalProfileCustomizeDiff, checkSystemLockdown),
+		Schema: map[string]*schema.Schema{
+			"thermal_profile": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Thermal profile of the system. Applicable values are 'default', 'maximum_performance', 'minimum_power' and 'sound_cap' (Dell only). " +
+					"Mapped to the thermal profile on Dell and the thermal configuration of the BIOS on HPE, where 'minimum_power' is the same as 'default'",
+				ValidateFunc: validation.StringInSlice([]string{thermalDefaultProfile, thermalMaximumPerformanceProfile, thermalMinimumPowerProfile, thermalSoundCapProfile}, false),
+			},
+			"fan_speed_offset": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Additional fan speed for the cards cooled by the system fans (i.e. GPUs). Applicable values are 'Off', 'Low', 'Medium', 'High' and 'Max'. " +
+					"Mapped to the thermal configuration of the BIOS on HPE, so it cannot be set together with thermal_profile there",
+				ValidateFunc: validation.StringInSlice(gpuCoolingOffsets, false),
+			},
+			"minimum_fan_speed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				Description:  "Minimum fan speed, in percent of the maximum (Dell only). The lowest value accepted depends on the system. -1 leaves it untouched",
+				ValidateFunc: validation.IntBetween(-1, 100),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Vendor attributes the settings manage, with their current values. Pending BIOS changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the BIOS changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishThermalProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning thermal settings update")
+	opLog := newOperationLog(m, "redfish_thermal_profile")
+	defer opLog.save(d)
+
+	settings, err := newThermalSettings(oem.Vendor(), d.Get("thermal_profile").(string), d.Get("fan_speed_offset").(string), d.Get("minimum_fan_speed").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	minimumFanSpeed := d.Get("minimum_fan_speed").(int)
+	id := common.DellSystemAttributesURI + "#thermal"
+	if len(settings.system) > 0 {
+		current, err := common.GetDellAttributes(conn, common.DellSystemAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading system attributes: %s", err)
+		}
+		payload := make(map[string]interface{})
+		for key, value := range settings.system {
+			if currentValue, ok := current[key]; !ok {
+				return diag.Errorf("system attribute %s not found, it is not supported by this system", key)
+			} else if !equivalentValues(currentValue, value) {
+				payload[key] = value
+				// The minimum fan speed is an integer attribute
+				if key == thermalMinimumFanSpeedAttribute {
+					payload[key] = minimumFanSpeed
+				}
+			}
+		}
+		if len(payload) > 0 {
+			err = common.PatchDellAttributes(conn, common.DellSystemAttributesURI, payload)
+			opLog.record("attributes_patch", common.DellSystemAttributesURI, "", err)
+			if err != nil {
+				return diag.Errorf("error updating thermal attributes: %s", err)
+			}
+		}
+	}
+
+	if len(settings.bios) > 0 {
+		bios, err := getBios(conn, m.(*providerConfig).systemID)
+		if err != nil {
+			return diag.Errorf("error fetching bios resource: %s", err)
+		}
+		id = bios.ODataID + "#thermal"
+		biosPayload, missing := biosChanges(bios, settings.bios)
+		if len(missing) > 0 {
+			return diag.Errorf("BIOS attribute %s not found, the thermal configuration is not supported by this system", missing[0])
+		}
+		if len(biosPayload) > 0 {
+			if _, err := stageBiosAttributes(conn, oem, d, opLog, bios, biosPayload, "thermal"); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if err := setStagedBiosAttributes(d, settings.attributes()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishThermalProfileRead(ctx, d, m)
+}
+
+func resourceRedfishThermalProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		current, err := common.GetDellAttributes(conn, common.DellSystemAttributesURI)
+		if err != nil {
+			return diag.Errorf("error reading system attributes: %s", err)
+		}
+		for key := range attributes {
+			if value, ok := current[key]; ok {
+				attributes[key] = value
+			}
+		}
+	} else if err := readStagedBiosAttributes(conn, d, m.(*providerConfig).systemID, attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishThermalProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The settings are kept, as lowering the cooling of a running server is not safe to do implicitly
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishThermalProfileCustomizeDiff checks the settings are supported by the vendor and plans their attributes
+func resourceRedfishThermalProfileCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	settings, err := newThermalSettings(m.(*providerConfig).oem.Vendor(), d.Get("thermal_profile").(string), d.Get("fan_speed_offset").(string), d.Get("minimum_fan_speed").(int))
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, settings.attributes())
+}
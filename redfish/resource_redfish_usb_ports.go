@@ -0,0 +1,174 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// usbPortsIdracAttributes maps the redfish_usb_ports variables to the Dell iDRAC attributes
+var usbPortsIdracAttributes = dellAttributeMapping{
+	"front_usb_enabled": "USBFront.1.Enable",
+	"idrac_direct_mode": "USB.1.ManagementPortMode",
+}
+
+// usbPortsSystemAttributes maps the redfish_usb_ports variables to the Dell system attributes
+var usbPortsSystemAttributes = dellAttributeMapping{
+	"front_panel_access": "LCD.1.FrontPanelLocking",
+}
+
+// usbPortsBiosAttributes maps the redfish_usb_ports variables to the BIOS attributes, which are applied on the next reboot
+var usbPortsBiosAttributes = map[string]string{
+	"power_button_enabled": "PwrButton",
+	"nmi_button_enabled":   "NmiButton",
+}
+
+func resourceRedfishUsbPorts() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishUsbPortsUpdate),
+		ReadContext:   resourceRedfishUsbPortsRead,
+		UpdateContext: withLockdownBypass(resourceRedfishUsbPortsUpdate),
+		DeleteContext: resourceRedfishUsbPortsDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"front_usb_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the front panel USB ports are enabled",
+			},
+			"idrac_direct_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Use of the front panel iDRAC Direct USB port. Applicable values are 'Automatic', 'Standard OS Use' and 'iDRAC Direct Only'",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Automatic",
+					"Standard OS Use",
+					"iDRAC Direct Only",
+				}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"front_panel_access": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Access allowed from the front panel LCD. Applicable values are 'Full-Access', 'View-Only' and 'Disabled'",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Full-Access",
+					"View-Only",
+					"Disabled",
+				}, false),
+				DiffSuppressFunc: suppressEquivalentValue,
+			},
+			"power_button_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the front panel power button is enabled. Applied on the next reboot",
+			},
+			"nmi_button_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the NMI button is enabled. Applied on the next reboot",
+			},
+			"bios_config_job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the configuration job applying the button changes on the next reboot",
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishUsbPortsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning USB ports update")
+	opLog := newOperationLog(m, "redfish_usb_ports")
+	defer opLog.save(d)
+
+	err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, usbPortsIdracAttributes)
+	opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating USB attributes: %s", err)
+	}
+	err = updateDellAttributes(conn, d, common.DellSystemAttributesURI, usbPortsSystemAttributes)
+	opLog.record("attributes_patch", common.DellSystemAttributesURI, "", err)
+	if err != nil {
+		return diag.Errorf("error updating front panel attributes: %s", err)
+	}
+
+	bios, err := getBios(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.Errorf("error fetching bios resource: %s", err)
+	}
+	attributes := make(map[string]string)
+	for key, attribute := range usbPortsBiosAttributes {
+		v, ok := d.GetOkExists(key)
+		if !ok {
+			continue
+		}
+		attributes[attribute] = "Disabled"
+		if v.(bool) {
+			attributes[attribute] = "Enabled"
+		}
+	}
+	biosPayload, missing := biosChanges(bios, attributes)
+	if len(missing) > 0 {
+		return diag.Errorf("BIOS attribute %s not found, the button lockout is not supported by this system", missing[0])
+	}
+	if len(biosPayload) > 0 {
+		if _, err := stageBiosAttributes(conn, m.(*providerConfig).oem, d, opLog, bios, biosPayload, "button"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(common.DellIdracAttributesURI + "#usb_ports")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishUsbPortsRead(ctx, d, m)
+}
+
+func resourceRedfishUsbPortsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, usbPortsIdracAttributes); err != nil {
+		return diag.Errorf("error reading USB attributes: %s", err)
+	}
+	if err := readDellAttributes(conn, d, common.DellSystemAttributesURI, usbPortsSystemAttributes); err != nil {
+		return diag.Errorf("error reading front panel attributes: %s", err)
+	}
+
+	// Pending button changes are kept in the state until the reboot applies them,
+	// so they are not sent again on every apply
+	if !configJobPending(conn, d, d.Get(biosConfigJobAttribute).(string)) {
+		bios, err := getBios(conn, m.(*providerConfig).systemID)
+		if err != nil {
+			return diag.Errorf("error fetching bios resource: %s", err)
+		}
+		for key, attribute := range usbPortsBiosAttributes {
+			if value, ok := bios.Attributes[attribute]; ok {
+				if err := d.Set(key, value == "Enabled"); err != nil {
+					return diag.Errorf("error setting %s: %s", key, err)
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishUsbPortsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
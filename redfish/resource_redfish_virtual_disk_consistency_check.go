@@ -0,0 +1,74 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+func resourceRedfishVirtualDiskConsistencyCheck() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishVirtualDiskConsistencyCheckCreate),
+		ReadContext:   resourceRedfishVolumeActionRead,
+		DeleteContext: resourceRedfishVirtualDiskConsistencyCheckDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultVolumeActionTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ODataID of the redundant volume to check (i.e. the id of a redfish_storage_volume)",
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the consistency check to finish",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the consistency check again when changed",
+			},
+			"job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the job checking the volume",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishVirtualDiskConsistencyCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	volumeID := d.Get("volume_id").(string)
+
+	log.Printf("[DEBUG] %s: Beginning volume consistency check", volumeID)
+	opLog := newOperationLog(m, "redfish_virtual_disk_consistency_check")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_virtual_disk_consistency_check")
+	defer progress.save(d)
+
+	jobURI, err := common.CheckVolumeConsistency(conn, volumeID)
+	opLog.record("volume_check_consistency", volumeID, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error starting the consistency check of volume %s: %s", volumeID, err)
+	}
+	d.SetId(volumeID + "#consistency_check")
+
+	return waitForVolumeAction(ctx, d, m, opLog, progress, jobURI)
+}
+
+func resourceRedfishVirtualDiskConsistencyCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return cancelVolumeAction(ctx, d, m, common.VolumeConsistencyCheck)
+}
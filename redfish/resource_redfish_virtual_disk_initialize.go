@@ -0,0 +1,143 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"time"
+)
+
+// defaultVolumeActionTimeout is the time to wait for a background operation of a volume
+const defaultVolumeActionTimeout = 60 * time.Minute
+
+func resourceRedfishVirtualDiskInitialize() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishVirtualDiskInitializeCreate),
+		ReadContext:   resourceRedfishVolumeActionRead,
+		DeleteContext: resourceRedfishVirtualDiskInitializeDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultVolumeActionTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ODataID of the volume to initialize (i.e. the id of a redfish_storage_volume). All its data is erased",
+			},
+			"initialize_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     common.FastInitializeType,
+				Description: "How the volume is initialized. 'Fast' only clears the metadata, 'Slow' writes the whole volume",
+				ValidateFunc: validation.StringInSlice([]string{
+					common.FastInitializeType,
+					common.SlowInitializeType,
+				}, false),
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the initialization to finish",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the initialization again when changed",
+			},
+			"job_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI of the job initializing the volume",
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishVirtualDiskInitializeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	volumeID := d.Get("volume_id").(string)
+
+	log.Printf("[DEBUG] %s: Beginning volume initialization", volumeID)
+	opLog := newOperationLog(m, "redfish_virtual_disk_initialize")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_virtual_disk_initialize")
+	defer progress.save(d)
+
+	jobURI, err := common.InitializeVolume(conn, volumeID, d.Get("initialize_type").(string))
+	opLog.record("volume_initialize", volumeID, jobURI, err)
+	if err != nil {
+		return diag.Errorf("error initializing volume %s: %s", volumeID, err)
+	}
+	d.SetId(volumeID + "#initialize")
+
+	return waitForVolumeAction(ctx, d, m, opLog, progress, jobURI)
+}
+
+func resourceRedfishVirtualDiskInitializeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return cancelVolumeAction(ctx, d, m, common.VolumeInitialization)
+}
+
+// waitForVolumeAction stores the job of a volume action and waits for it to finish when requested
+func waitForVolumeAction(ctx context.Context, d *schema.ResourceData, m interface{}, opLog *operationLog, progress *jobProgress, jobURI string) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if err := d.Set("job_uri", jobURI); err != nil {
+		return diag.Errorf("error setting job_uri: %s", err)
+	}
+	if jobURI == "" || !d.Get("wait").(bool) {
+		log.Printf("[DEBUG] %s: Not waiting for the volume operation to finish", d.Id())
+		return resourceRedfishVolumeActionRead(ctx, d, m)
+	}
+
+	err := common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, int(d.Timeout(schema.TimeoutCreate).Seconds()), progress.reporter(jobURI))
+	opLog.record("job_completion", d.Get("volume_id").(string), jobURI, err)
+	if err != nil {
+		return diag.Errorf("error waiting for volume job %s to finish: %s", jobURI, err)
+	}
+
+	log.Printf("[DEBUG] %s: Volume operation finished successfully", d.Id())
+	return resourceRedfishVolumeActionRead(ctx, d, m)
+}
+
+func resourceRedfishVolumeActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The action has already been performed, so there is nothing to refresh
+
+	return diags
+}
+
+// cancelVolumeAction cancels the background operation of a volume if its job has not finished yet
+func cancelVolumeAction(ctx context.Context, d *schema.ResourceData, m interface{}, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	if jobURI := d.Get("job_uri").(string); jobURI != "" {
+		progress, err := common.GetJobProgress(conn, jobURI)
+		if err != nil {
+			log.Printf("[DEBUG] %s: error fetching job %s, it is not cancelled: %s", d.Id(), jobURI, err)
+		} else if !common.StateFinished(progress.State) {
+			volumeID := d.Get("volume_id").(string)
+			log.Printf("[DEBUG] %s: Cancelling %s of %s", d.Id(), operation, volumeID)
+			if err := common.CancelVolumeOperation(conn, volumeID, operation); err != nil {
+				return diag.Errorf("error cancelling %s of volume %s: %s", operation, volumeID, err)
+			}
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
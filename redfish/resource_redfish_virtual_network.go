@@ -0,0 +1,467 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultVirtualNetworkTimeout is the time to wait for the reboot applying the NIC changes
+const defaultVirtualNetworkTimeout = 30 * time.Minute
+
+// virtualNetworkIntegerAttributes are the Dell network attributes sent as integers
+var virtualNetworkIntegerAttributes = []string{"VLanId", "MinBandwidth", "MaxBandwidth"}
+
+// virtualNetworkPartition is a partition block of redfish_virtual_network. -1 leaves a setting untouched
+type virtualNetworkPartition struct {
+	functionID   string
+	vlanID       int
+	minBandwidth int
+	maxBandwidth int
+}
+
+// expandVirtualNetworkPartitions reads the partition blocks, rejecting the functions configured twice
+func expandVirtualNetworkPartitions(raw []interface{}) ([]virtualNetworkPartition, error) {
+	partitions := []virtualNetworkPartition{}
+	seen := make(map[string]bool)
+	for _, v := range raw {
+		block := v.(map[string]interface{})
+		partition := virtualNetworkPartition{
+			functionID:   block["function_id"].(string),
+			vlanID:       block["vlan_id"].(int),
+			minBandwidth: block["min_bandwidth_percent"].(int),
+			maxBandwidth: block["max_bandwidth_percent"].(int),
+		}
+		if seen[partition.functionID] {
+			return nil, fmt.Errorf("function %s is configured more than once", partition.functionID)
+		}
+		seen[partition.functionID] = true
+		if partition.minBandwidth >= 0 && partition.maxBandwidth >= 0 && partition.minBandwidth > partition.maxBandwidth {
+			return nil, fmt.Errorf("min_bandwidth_percent of function %s is higher than max_bandwidth_percent", partition.functionID)
+		}
+		partitions = append(partitions, partition)
+	}
+	return partitions, nil
+}
+
+// newVirtualNetworkAttributes maps the settings of redfish_virtual_network to the attributes of the vendor,
+// keyed by function Id and attribute name (i.e. NIC.Integrated.1-1-1/VLanId).
+// Dell iDRAC exposes NIC partitioning through the DellNetworkAttributes of every function,
+// the rest only the VLAN of the function through standard Redfish.
+func newVirtualNetworkAttributes(vendor string, partitioning string, partitions []virtualNetworkPartition) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, partition := range partitions {
+		key := partition.functionID + "/"
+		switch vendor {
+		case "dell":
+			if partitioning != "" {
+				attributes[key+"NicPartitioning"] = partitioning
+			}
+			if partition.vlanID == 0 {
+				attributes[key+"VLanMode"] = "Disabled"
+			} else if partition.vlanID > 0 {
+				attributes[key+"VLanMode"] = "Enabled"
+				attributes[key+"VLanId"] = strconv.Itoa(partition.vlanID)
+			}
+			if partition.minBandwidth >= 0 {
+				attributes[key+"MinBandwidth"] = strconv.Itoa(partition.minBandwidth)
+			}
+			if partition.maxBandwidth >= 0 {
+				attributes[key+"MaxBandwidth"] = strconv.Itoa(partition.maxBandwidth)
+			}
+		default:
+			if partitioning != "" || partition.minBandwidth >= 0 || partition.maxBandwidth >= 0 {
+				return nil, fmt.Errorf("NIC partitioning and bandwidth are not supported on %s BMCs, only the VLAN of the functions", vendor)
+			}
+			if partition.vlanID == 0 {
+				attributes[key+"VLANEnable"] = "false"
+			} else if partition.vlanID > 0 {
+				attributes[key+"VLANEnable"] = "true"
+				attributes[key+"VLANId"] = strconv.Itoa(partition.vlanID)
+			}
+		}
+	}
+	return attributes, nil
+}
+
+// splitVirtualNetworkAttributes groups the attributes of redfish_virtual_network by function Id
+func splitVirtualNetworkAttributes(attributes map[string]string) map[string]map[string]string {
+	functions := make(map[string]map[string]string)
+	for key, value := range attributes {
+		i := strings.LastIndex(key, "/")
+		functionID, attribute := key[:i], key[i+1:]
+		if functions[functionID] == nil {
+			functions[functionID] = make(map[string]string)
+		}
+		functions[functionID][attribute] = value
+	}
+	return functions
+}
+
+func resourceRedfishVirtualNetwork() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishVirtualNetworkUpdate),
+		ReadContext:   resourceRedfishVirtualNetworkRead,
+		UpdateContext: withLockdownBypass(resourceRedfishVirtualNetworkUpdate),
+		DeleteContext: resourceRedfishVirtualNetworkDelete,
+		CustomizeDiff: customdiff.Sequence(resourceRedfishVirtualNetworkCustomizeDiff, checkSystemLockdown),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultVirtualNetworkTimeout),
+			Update: schema.DefaultTimeout(defaultVirtualNetworkTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"network_adapter_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the network adapter (i.e. NIC.Integrated.1 on Dell systems)",
+			},
+			"partitioning": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Whether NIC partitioning (NPAR) splits the ports of the adapter into partitions. Applicable values are 'Enabled' and 'Disabled'. " +
+					"Not set leaves it untouched. Only supported on Dell systems",
+				ValidateFunc: validation.StringInSlice([]string{"Enabled", "Disabled"}, false),
+			},
+			"partition": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Network device functions (partitions) to configure. Functions not listed are left untouched",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"function_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Id of the network device function (i.e. NIC.Integrated.1-1-1 for the first partition of the first port)",
+						},
+						"vlan_id": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							Description:  "VLAN the function tags its traffic with. 0 disables the VLAN tagging, -1 (the default) leaves it untouched",
+							ValidateFunc: validation.IntBetween(-1, 4094),
+						},
+						"min_bandwidth_percent": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							Description:  "Bandwidth of the port guaranteed to the partition, in percent. -1 (the default) leaves it untouched. Only supported on Dell systems",
+							ValidateFunc: validation.IntBetween(-1, 100),
+						},
+						"max_bandwidth_percent": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							Description:  "Bandwidth of the port the partition can use at most, in percent. -1 (the default) leaves it untouched. Only supported on Dell systems",
+							ValidateFunc: validation.IntBetween(-1, 100),
+						},
+					},
+				},
+			},
+			"reset_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "None",
+				Description: "How the system is rebooted to apply the changes. Applicable values are 'None' (applied on the next reboot), 'GracefulRestart', 'ForceRestart' and 'PowerCycle'. Systems powered off are not powered on",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None",
+					string(redfish.GracefulRestartResetType),
+					string(redfish.ForceRestartResetType),
+					string(redfish.PowerCycleResetType),
+				}, false),
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Attributes the partitions manage, keyed by function Id and attribute name (i.e. NIC.Integrated.1-1-1/VLanId), with their current values. Pending changes are shown once the reboot applies them",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"config_job_uris": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URIs of the configuration jobs applying the changes of every function on the next reboot, on BMCs with a Dell job queue",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			operationLogAttribute:    operationLogSchema(),
+			lastTaskMessageAttribute: lastTaskMessageSchema(),
+		},
+	}
+}
+
+func resourceRedfishVirtualNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+	oem := m.(*providerConfig).oem
+
+	log.Printf("[DEBUG] Beginning virtual network update")
+	opLog := newOperationLog(m, "redfish_virtual_network")
+	defer opLog.save(d)
+	progress := newJobProgress("redfish_virtual_network")
+	defer progress.save(d)
+
+	partitions, err := expandVirtualNetworkPartitions(d.Get("partition").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attributes, err := newVirtualNetworkAttributes(oem.Vendor(), d.Get("partitioning").(string), partitions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	adapterURI, functions, err := getVirtualNetworkFunctions(conn, d.Get("network_adapter_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(adapterURI + "#virtual_network")
+
+	jobURIs := []string{}
+	changed := false
+	byFunction := splitVirtualNetworkAttributes(attributes)
+	functionIDs := []string{}
+	for functionID := range byFunction {
+		functionIDs = append(functionIDs, functionID)
+	}
+	sort.Strings(functionIDs)
+	for _, functionID := range functionIDs {
+		function, ok := functions[functionID]
+		if !ok {
+			return diag.Errorf("function %s not found in network adapter %s", functionID, d.Get("network_adapter_id").(string))
+		}
+		current, err := getVirtualNetworkAttributes(conn, oem.Vendor(), function)
+		if err != nil {
+			return diag.Errorf("error fetching the settings of function %s: %s", functionID, err)
+		}
+		changes := make(map[string]string)
+		for attribute, value := range byFunction[functionID] {
+			if currentValue, ok := current[attribute]; !ok {
+				return diag.Errorf("attribute %s of function %s not found, it is not supported by this network adapter", attribute, functionID)
+			} else if !equivalentValues(currentValue, value) {
+				changes[attribute] = value
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		settingsURI, jobURI, err := setVirtualNetworkAttributes(conn, oem, function, changes)
+		opLog.record("network_settings_patch", settingsURI, jobURI, err)
+		if err != nil {
+			// Keep the jobs of the functions already changed, so the state shows what is pending
+			if setErr := d.Set("config_job_uris", jobURIs); setErr != nil {
+				log.Printf("[DEBUG] %s: error setting config_job_uris: %s", d.Id(), setErr)
+			}
+			return diag.Errorf("error updating the settings of function %s: %s", functionID, err)
+		}
+		changed = true
+		if jobURI != "" {
+			jobURIs = append(jobURIs, jobURI)
+		}
+		log.Printf("[DEBUG] %s: Function %s changes %v will be applied on the next reboot", d.Id(), functionID, changes)
+	}
+	if err := d.Set("config_job_uris", jobURIs); err != nil {
+		return diag.Errorf("error setting config_job_uris: %s", err)
+	}
+	// The pending changes are stored as applied, so they are not sent again on every apply
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	resetType := d.Get("reset_type").(string)
+	if resetType == "None" || !changed {
+		log.Printf("[DEBUG] %s: Update finished, the changes will be applied on the next reboot", d.Id())
+		return resourceRedfishVirtualNetworkRead(ctx, d, m)
+	}
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if system.PowerState != redfish.OnPowerState {
+		log.Printf("[DEBUG] %s: The system is %s, the changes will be applied on the next power on", d.Id(), system.PowerState)
+		return resourceRedfishVirtualNetworkRead(ctx, d, m)
+	}
+	err = system.Reset(redfish.ResetType(resetType))
+	opLog.record("reset", system.ODataID, "", err)
+	if err != nil {
+		return diag.Errorf("error resetting the system: %s", err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	deadline := time.Now().Add(timeout)
+	for _, jobURI := range jobURIs {
+		remaining := int(time.Until(deadline).Seconds())
+		if remaining <= 0 {
+			opLog.record("job_completion", adapterURI, jobURI, fmt.Errorf("timeout reached"))
+			return diag.Errorf("timeout reached waiting for configuration job %s to finish", jobURI)
+		}
+		err = common.WaitForJobToFinishWithProgress(ctx, conn, jobURI, common.TimeBetweenAttempts, remaining, progress.reporter(jobURI))
+		opLog.record("job_completion", adapterURI, jobURI, err)
+		if err != nil {
+			return diag.Errorf("error waiting for configuration job %s to finish: %s", jobURI, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishVirtualNetworkRead(ctx, d, m)
+}
+
+func resourceRedfishVirtualNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	// The attributes are kept until the reboot applies the pending changes
+	jobURIs := []string{}
+	for _, jobURI := range d.Get("config_job_uris").([]interface{}) {
+		jobURIs = append(jobURIs, jobURI.(string))
+	}
+	if configJobPending(conn, d, jobURIs...) {
+		return diags
+	}
+
+	_, functions, err := getVirtualNetworkFunctions(conn, d.Get("network_adapter_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+	for functionID, functionAttributes := range splitVirtualNetworkAttributes(attributes) {
+		function, ok := functions[functionID]
+		if !ok {
+			log.Printf("[DEBUG] %s: Function %s not found", d.Id(), functionID)
+			continue
+		}
+		current, err := getVirtualNetworkAttributes(conn, m.(*providerConfig).oem.Vendor(), function)
+		if err != nil {
+			return diag.Errorf("error fetching the settings of function %s: %s", functionID, err)
+		}
+		for attribute := range functionAttributes {
+			if value, ok := current[attribute]; ok {
+				attributes[functionID+"/"+attribute] = value
+			}
+		}
+	}
+
+	if err := d.Set("attributes", attributes); err != nil {
+		return diag.Errorf("error setting attributes: %s", err)
+	}
+
+	return diags
+}
+
+func resourceRedfishVirtualNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The partitions are left as configured, as the host networking depends on them
+	d.SetId("")
+
+	return diags
+}
+
+// resourceRedfishVirtualNetworkCustomizeDiff checks the settings are supported by the vendor and plans their attributes
+func resourceRedfishVirtualNetworkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	partitions, err := expandVirtualNetworkPartitions(d.Get("partition").([]interface{}))
+	if err != nil {
+		return err
+	}
+	attributes, err := newVirtualNetworkAttributes(m.(*providerConfig).oem.Vendor(), d.Get("partitioning").(string), partitions)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return planStagedAttributes(d, attributes)
+}
+
+// getVirtualNetworkFunctions returns the URI of the network adapter adapterID and its functions, indexed by Id
+func getVirtualNetworkFunctions(conn *gofish.APIClient, adapterID string) (string, map[string]*common.NetworkDeviceFunction, error) {
+	adapters, err := getNetworkAdapters(conn.Service)
+	if err != nil {
+		return "", nil, fmt.Errorf("error fetching network adapters: %s", err)
+	}
+	ids := []string{}
+	for _, adapter := range adapters {
+		if adapter.ID != adapterID {
+			ids = append(ids, adapter.ID)
+			continue
+		}
+		functions, err := common.GetNetworkDeviceFunctions(conn, adapter.ODataID)
+		if err != nil {
+			return "", nil, fmt.Errorf("error fetching network device functions of %s: %s", adapterID, err)
+		}
+		byID := make(map[string]*common.NetworkDeviceFunction)
+		for _, function := range functions {
+			byID[function.ID] = function
+		}
+		return adapter.ODataID, byID, nil
+	}
+	return "", nil, fmt.Errorf("network adapter %s not found. Available network adapters: %v", adapterID, ids)
+}
+
+// getVirtualNetworkAttributes returns the current values of the attributes redfish_virtual_network manages on a function
+func getVirtualNetworkAttributes(conn *gofish.APIClient, vendor string, function *common.NetworkDeviceFunction) (map[string]string, error) {
+	if vendor == "dell" {
+		return common.GetDellAttributes(conn, function.DellNetworkAttributesURI())
+	}
+	return map[string]string{
+		"VLANEnable": strconv.FormatBool(function.Ethernet.VLAN.VLANEnable),
+		"VLANId":     strconv.Itoa(function.Ethernet.VLAN.VLANID),
+	}, nil
+}
+
+// setVirtualNetworkAttributes sends the changes of a function to its settings object.
+// Returns the settings object and the job applying the changes on the next reboot, if any.
+func setVirtualNetworkAttributes(conn *gofish.APIClient, oem common.OEMHandler, function *common.NetworkDeviceFunction, changes map[string]string) (string, string, error) {
+	if oem.Vendor() == "dell" {
+		settingsURI := function.DellNetworkAttributesURI() + "/Settings"
+		payload := make(map[string]interface{})
+		for attribute, value := range changes {
+			payload[attribute] = value
+			if common.ContainsFold(virtualNetworkIntegerAttributes, attribute) {
+				intValue, err := strconv.Atoi(value)
+				if err != nil {
+					return settingsURI, "", fmt.Errorf("attribute %s is not an integer: %s", attribute, value)
+				}
+				payload[attribute] = intValue
+			}
+		}
+		if err := common.PatchDellAttributes(conn, settingsURI, payload); err != nil {
+			return settingsURI, "", err
+		}
+		jobURI, err := oem.CreateConfigJob(conn, settingsURI)
+		return settingsURI, jobURI, err
+	}
+
+	vlan := map[string]interface{}{}
+	if value, ok := changes["VLANEnable"]; ok {
+		vlan["VLANEnable"] = value == "true"
+	}
+	if value, ok := changes["VLANId"]; ok {
+		vlanID, err := strconv.Atoi(value)
+		if err != nil {
+			return function.SettingsURI(), "", fmt.Errorf("VLANId is not an integer: %s", value)
+		}
+		vlan["VLANId"] = vlanID
+	}
+	payload := map[string]interface{}{"Ethernet": map[string]interface{}{"VLAN": vlan}}
+	jobURI, err := common.PatchResourceWithJob(conn, function.SettingsURI(), payload)
+	return function.SettingsURI(), jobURI, err
+}
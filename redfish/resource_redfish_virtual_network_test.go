@@ -0,0 +1,84 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewVirtualNetworkAttributes(t *testing.T) {
+	cases := []struct {
+		noTest       int
+		vendor       string
+		partitioning string
+		partitions   []virtualNetworkPartition
+		expected     map[string]string
+		shouldPass   bool
+	}{
+		{1, "dell", "Enabled", []virtualNetworkPartition{{"NIC.Integrated.1-1-1", 100, 25, 100}, {"NIC.Integrated.1-1-2", 0, -1, -1}}, map[string]string{
+			"NIC.Integrated.1-1-1/NicPartitioning": "Enabled",
+			"NIC.Integrated.1-1-1/VLanMode":        "Enabled",
+			"NIC.Integrated.1-1-1/VLanId":          "100",
+			"NIC.Integrated.1-1-1/MinBandwidth":    "25",
+			"NIC.Integrated.1-1-1/MaxBandwidth":    "100",
+			"NIC.Integrated.1-1-2/NicPartitioning": "Enabled",
+			"NIC.Integrated.1-1-2/VLanMode":        "Disabled",
+		}, true},
+		{2, "dell", "", []virtualNetworkPartition{{"NIC.Integrated.1-1-1", -1, -1, -1}}, map[string]string{}, true},
+		{3, "hpe", "", []virtualNetworkPartition{{"1", 200, -1, -1}, {"2", 0, -1, -1}}, map[string]string{
+			"1/VLANEnable": "true",
+			"1/VLANId":     "200",
+			"2/VLANEnable": "false",
+		}, true},
+		{4, "hpe", "Enabled", []virtualNetworkPartition{{"1", 200, -1, -1}}, nil, false},
+		{5, "generic", "", []virtualNetworkPartition{{"1", -1, 10, -1}}, nil, false},
+	}
+	for _, v := range cases {
+		attributes, err := newVirtualNetworkAttributes(v.vendor, v.partitioning, v.partitions)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if !v.shouldPass {
+			if err == nil {
+				t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(attributes, v.expected) {
+			t.Errorf("Test number %v: expected %v, got %v", v.noTest, v.expected, attributes)
+		}
+		// The attributes must be split back to the functions they came from
+		functions := splitVirtualNetworkAttributes(attributes)
+		for _, partition := range v.partitions {
+			for attribute, value := range functions[partition.functionID] {
+				if attributes[partition.functionID+"/"+attribute] != value {
+					t.Errorf("Test number %v: %s of %s was split wrong", v.noTest, attribute, partition.functionID)
+				}
+			}
+		}
+	}
+}
+
+func TestExpandVirtualNetworkPartitions(t *testing.T) {
+	partition := func(functionID string, minBandwidth int, maxBandwidth int) interface{} {
+		return map[string]interface{}{"function_id": functionID, "vlan_id": -1, "min_bandwidth_percent": minBandwidth, "max_bandwidth_percent": maxBandwidth}
+	}
+	cases := []struct {
+		noTest     int
+		raw        []interface{}
+		shouldPass bool
+	}{
+		{1, []interface{}{partition("NIC.Integrated.1-1-1", 25, 100), partition("NIC.Integrated.1-1-2", 75, -1)}, true},
+		{2, []interface{}{partition("NIC.Integrated.1-1-1", -1, -1), partition("NIC.Integrated.1-1-1", -1, -1)}, false},
+		{3, []interface{}{partition("NIC.Integrated.1-1-1", 50, 25)}, false},
+	}
+	for _, v := range cases {
+		_, err := expandVirtualNetworkPartitions(v.raw)
+		if v.shouldPass && err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		}
+		if !v.shouldPass && err == nil {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
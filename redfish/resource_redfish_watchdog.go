@@ -0,0 +1,150 @@
+package redfish
+
+import (
+	"context"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/stmcginnis/gofish/redfish"
+	"log"
+)
+
+// watchdogAttributes maps the redfish_watchdog variables to the Dell iDRAC attributes.
+// The timeout is not part of the Redfish HostWatchdogTimer, so it is only managed on Dell iDRAC.
+var watchdogAttributes = dellAttributeMapping{
+	"timeout": "ServiceModule.1.WatchdogResetTime",
+}
+
+func resourceRedfishWatchdog() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withLockdownBypass(resourceRedfishWatchdogUpdate),
+		ReadContext:   resourceRedfishWatchdogRead,
+		UpdateContext: withLockdownBypass(resourceRedfishWatchdogUpdate),
+		DeleteContext: resourceRedfishWatchdogDelete,
+		CustomizeDiff: checkSystemLockdown,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the host watchdog timer is enabled. The host OS (i.e. through the iDRAC Service Module) keeps it from expiring while it is responsive",
+			},
+			"timeout_action": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What the BMC does when the timer expires. Applicable values are 'None', 'ResetSystem', 'PowerCycle' and 'PowerDown'",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfish.NoneWatchdogTimeoutActions),
+					string(redfish.ResetSystemWatchdogTimeoutActions),
+					string(redfish.PowerCycleWatchdogTimeoutActions),
+					string(redfish.PowerDownWatchdogTimeoutActions),
+				}, false),
+			},
+			"warning_action": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What the BMC does shortly before the timer expires. Applicable values are 'None', 'DiagnosticInterrupt', 'SMI', 'MessagingInterrupt' and 'SCI'. Not every BMC supports it",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(redfish.NoneWatchdogWarningActions),
+					string(redfish.DiagnosticInterruptWatchdogWarningActions),
+					string(redfish.SMIWatchdogWarningActions),
+					string(redfish.MessagingInterruptWatchdogWarningActions),
+					string(redfish.SCIWatchdogWarningActions),
+				}, false),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Seconds the timer waits before taking the timeout action. Only supported on Dell iDRAC, where it ranges from 60 to 720",
+				ValidateFunc: validation.IntBetween(60, 720),
+			},
+			operationLogAttribute: operationLogSchema(),
+		},
+	}
+}
+
+func resourceRedfishWatchdogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	log.Printf("[DEBUG] Beginning watchdog update")
+	opLog := newOperationLog(m, "redfish_watchdog")
+	defer opLog.save(d)
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if vendor := m.(*providerConfig).oem.Vendor(); vendor == "dell" {
+		err := updateDellAttributes(conn, d, common.DellIdracAttributesURI, watchdogAttributes)
+		opLog.record("attributes_patch", common.DellIdracAttributesURI, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the watchdog timeout: %s", err)
+		}
+	} else if _, ok := d.GetOk("timeout"); ok {
+		return diag.Errorf("timeout is not supported on %s BMCs", vendor)
+	}
+
+	// Only the properties set are sent, as some BMCs reject the ones they do not support
+	watchdog := make(map[string]interface{})
+	if v, ok := d.GetOkExists("enabled"); ok && v.(bool) != system.HostWatchdogTimer.FunctionEnabled {
+		watchdog["FunctionEnabled"] = v.(bool)
+	}
+	if v, ok := d.GetOk("timeout_action"); ok && v.(string) != string(system.HostWatchdogTimer.TimeoutAction) {
+		watchdog["TimeoutAction"] = v.(string)
+	}
+	if v, ok := d.GetOk("warning_action"); ok && v.(string) != string(system.HostWatchdogTimer.WarningAction) {
+		watchdog["WarningAction"] = v.(string)
+	}
+	if len(watchdog) > 0 {
+		err := common.PatchResource(conn, system.ODataID, map[string]interface{}{"HostWatchdogTimer": watchdog})
+		opLog.record("watchdog_patch", system.ODataID, "", err)
+		if err != nil {
+			return diag.Errorf("error updating the host watchdog timer: %s", err)
+		}
+	}
+
+	d.SetId(system.ODataID + "#watchdog")
+
+	log.Printf("[DEBUG] %s: Update finished successfully", d.Id())
+	return resourceRedfishWatchdogRead(ctx, d, m)
+}
+
+func resourceRedfishWatchdogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := m.(*providerConfig).clientWithContext(ctx)
+
+	system, err := common.GetSystem(conn, m.(*providerConfig).systemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("enabled", system.HostWatchdogTimer.FunctionEnabled); err != nil {
+		return diag.Errorf("error setting enabled: %s", err)
+	}
+	if err := d.Set("timeout_action", string(system.HostWatchdogTimer.TimeoutAction)); err != nil {
+		return diag.Errorf("error setting timeout_action: %s", err)
+	}
+	if err := d.Set("warning_action", string(system.HostWatchdogTimer.WarningAction)); err != nil {
+		return diag.Errorf("error setting warning_action: %s", err)
+	}
+
+	if m.(*providerConfig).oem.Vendor() == "dell" {
+		if err := readDellAttributes(conn, d, common.DellIdracAttributesURI, watchdogAttributes); err != nil {
+			return diag.Errorf("error reading the watchdog timeout: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceRedfishWatchdogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId("")
+
+	return diags
+}
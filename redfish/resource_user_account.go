@@ -1,7 +1,8 @@
 package redfish
 
 import (
-	"fmt"
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stmcginnis/gofish"
 	"github.com/stmcginnis/gofish/redfish"
@@ -9,10 +10,16 @@ import (
 
 func resourceUserAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserAccountCreate,
-		Read:   resourceUserAccountRead,
-		Update: resourceUserAccountUpdate,
-		Delete: resourceUserAccountDelete,
+		CreateContext: withLockdownBypass(resourceUserAccountCreate),
+		ReadContext:   resourceUserAccountRead,
+		UpdateContext: withLockdownBypass(resourceUserAccountUpdate),
+		DeleteContext: withLockdownBypass(resourceUserAccountDelete),
+		CustomizeDiff: checkSystemLockdown,
+		// Accounts are imported by Id (i.e. 3), as listed by the redfish_accounts data source.
+		// The password cannot be read, so it is sent again on the first apply after the import.
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"username": &schema.Schema{
@@ -20,10 +27,13 @@ func resourceUserAccount() *schema.Resource {
 				Required: true,
 			},
 			"password": &schema.Schema{
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password" + writeOnlySuffix},
 			},
+			"password" + writeOnlySuffix:        writeOnlyPasswordSchema("password", "password"+writeOnlyVersionSuffix, "Password of the user account"),
+			"password" + writeOnlyVersionSuffix: writeOnlyVersionSchema("password" + writeOnlySuffix),
 			"enabled": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -36,17 +46,17 @@ func resourceUserAccount() *schema.Resource {
 	}
 }
 
-func resourceUserAccountCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*gofish.APIClient)
+func resourceUserAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerConfig).clientWithContext(ctx)
 	accountList, err := getAccountList(c)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	payload := make(map[string]interface{})
 	for _, account := range accountList {
 		if len(account.UserName) == 0 && account.ID != "1" { //ID 1 is reserved
 			payload["UserName"] = d.Get("username").(string)
-			payload["Password"] = d.Get("password").(string)
+			payload["Password"], _ = passwordToSend(d, "password", "password"+writeOnlySuffix)
 			if value, set := d.GetOk("enabled"); set {
 				payload["Enabled"] = value
 			} else {
@@ -59,24 +69,27 @@ func resourceUserAccountCreate(d *schema.ResourceData, m interface{}) error {
 			}
 			res, err := c.Patch(account.ODataID, payload)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if res.StatusCode != 200 {
-				return fmt.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
+				return diag.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
 			}
 			d.SetId(account.ID)
-			return resourceUserAccountRead(d, m)
+			if err := clearWriteOnly(d, "password"+writeOnlySuffix); err != nil {
+				return diag.FromErr(err)
+			}
+			return resourceUserAccountRead(ctx, d, m)
 		}
 	}
 	//No room for new users
-	return fmt.Errorf("There are no room for new users")
+	return diag.Errorf("There are no room for new users")
 }
 
-func resourceUserAccountRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(*gofish.APIClient)
+func resourceUserAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerConfig).clientWithContext(ctx)
 	account, err := getAccount(c, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if account == nil {
 		d.SetId("")
@@ -89,48 +102,53 @@ func resourceUserAccountRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceUserAccountUpdate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*gofish.APIClient)
+func resourceUserAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerConfig).clientWithContext(ctx)
 	account, err := getAccount(c, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	payload := make(map[string]interface{})
 	payload["UserName"] = d.Get("username")
-	payload["Password"] = d.Get("password")
+	// Write-only passwords are only known when they change
+	if password, ok := passwordToSend(d, "password", "password"+writeOnlySuffix); ok {
+		payload["Password"] = password
+	}
 	payload["Enabled"] = d.Get("enabled")
 	payload["RoleId"] = d.Get("role_id")
 	res, err := c.Patch(account.ODataID, payload)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if res.StatusCode != 200 {
-		return fmt.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
+		return diag.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
 	}
-	return resourceUserAccountRead(d, m)
+	if err := clearWriteOnly(d, "password"+writeOnlySuffix); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceUserAccountRead(ctx, d, m)
 }
 
-func resourceUserAccountDelete(d *schema.ResourceData, m interface{}) error {
-	c := m.(*gofish.APIClient)
+func resourceUserAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerConfig).clientWithContext(ctx)
 	account, err := getAccount(c, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if account == nil {
-		return fmt.Errorf("The user account does not exist")
+		return diag.Errorf("The user account does not exist")
 	}
 	payload := make(map[string]interface{})
 	payload["UserName"] = ""
 	res, err := c.Patch(account.ODataID, payload)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if res.StatusCode != 200 {
-		return fmt.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
+		return diag.Errorf("There was an issue with the APIClient. HTTP error code %d", res.StatusCode)
 	}
 	d.SetId("")
 	return nil
-
 }
 
 func getAccountList(c *gofish.APIClient) ([]*redfish.ManagerAccount, error) {
@@ -0,0 +1,123 @@
+package redfish
+
+import (
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// shareAttribute is the name of the network share block of the resources pulling files from a share
+const shareAttribute string = "share"
+
+// Keys of the password of the share block and of its write-only variant, the block holding a single share
+const (
+	sharePasswordKey          string = shareAttribute + ".0.password"
+	shareWriteOnlyPasswordKey string = sharePasswordKey + writeOnlySuffix
+	shareWriteOnlyVersionKey  string = sharePasswordKey + writeOnlyVersionSuffix
+)
+
+// shareSchema is the schema of the network share block, shared by every resource the BMC pulls files
+// from a share for. The paths set on the resource are relative to the share.
+func shareSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ip": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "IP address or hostname of the share server",
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"share_type": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Type of the share. Applicable values are 'NFS', 'CIFS', 'HTTP' and 'HTTPS'",
+					ValidateFunc: validation.StringInSlice([]string{
+						common.NFSShareType,
+						common.CIFSShareType,
+						common.HTTPShareType,
+						common.HTTPSShareType,
+					}, false),
+				},
+				"share_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the share (i.e. the NFS export or the CIFS share name), or base path for HTTP shares",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "User name to access the share. Required for CIFS shares",
+				},
+				"password": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{shareWriteOnlyPasswordKey},
+					Description:   "Password to access the share. Required for CIFS shares. It is stored in the state, as every operation on the share needs it",
+				},
+				"password" + writeOnlySuffix: writeOnlyPasswordSchema(sharePasswordKey, shareWriteOnlyVersionKey,
+					"Password to access the share. As it is only known to the provider when "+shareWriteOnlyVersionKey+
+						" changes, that version must change in every apply running an operation on the share (i.e. pushing a package or booting an image)"),
+				"password" + writeOnlyVersionSuffix: writeOnlyVersionSchema(shareWriteOnlyPasswordKey),
+				"ignore_cert": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Do not validate the certificate of HTTPS shares",
+				},
+			},
+		},
+	}
+}
+
+// expandShare returns the share set in the share block of the resource, or nil if it is not set.
+// The share is validated, so the same rules apply to every resource.
+func expandShare(d *schema.ResourceData) (*common.Share, error) {
+	raw := d.Get(shareAttribute).([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+	block := raw[0].(map[string]interface{})
+	share := &common.Share{
+		IPAddress:  block["ip"].(string),
+		ShareType:  block["share_type"].(string),
+		ShareName:  block["share_name"].(string),
+		Username:   block["username"].(string),
+		IgnoreCert: block["ignore_cert"].(bool),
+	}
+	// The write-only password is only known in the applies changing its version, the state holds nothing at all
+	if password := block["password"].(string); password != "" {
+		share.Password = password
+	} else if password, ok := passwordToSend(d, shareWriteOnlyPasswordKey); ok {
+		share.Password = password
+	} else if block["password"+writeOnlyVersionSuffix].(string) != "" {
+		return nil, fmt.Errorf("password%s of the share is only known to the provider when it changes. Change password%s to send it again", writeOnlySuffix, writeOnlyVersionSuffix)
+	}
+	if err := share.Validate(); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// clearShareWriteOnly removes the write-only password of the share from the state once sent
+func clearShareWriteOnly(d *schema.ResourceData) error {
+	raw := d.Get(shareAttribute).([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	block := make(map[string]interface{})
+	for key, value := range raw[0].(map[string]interface{}) {
+		block[key] = value
+	}
+	block["password"+writeOnlySuffix] = ""
+	if err := d.Set(shareAttribute, []interface{}{block}); err != nil {
+		return fmt.Errorf("error clearing %s: %s", shareWriteOnlyPasswordKey, err)
+	}
+	return nil
+}
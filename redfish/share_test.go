@@ -0,0 +1,80 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+func TestSharePassword(t *testing.T) {
+	r := Provider().ResourcesMap["redfish_os_deployment"]
+	cases := []struct {
+		noTest          int
+		passwordKey     string
+		expectedPlanned string
+		expectedStored  string
+		shouldPassLater bool
+	}{
+		{1, "password", "calvin", "calvin", true},
+		{2, "password" + writeOnlySuffix, hashPassword("calvin"), "", false},
+	}
+	for _, v := range cases {
+		block := map[string]interface{}{"ip": "192.168.0.10", "share_type": "CIFS", "share_name": "isos", "username": "admin", v.passwordKey: "calvin"}
+		if v.passwordKey != "password" {
+			block["password"+writeOnlyVersionSuffix] = "1"
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"image_name": "os.iso", shareAttribute: []interface{}{block}})
+		diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), nil, config, nil, nil, true)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if attr := diff.Attributes[shareAttribute+".0."+v.passwordKey]; attr == nil || attr.New != v.expectedPlanned {
+			t.Errorf("Test number %v: the share password is not planned as expected", v.noTest)
+		}
+		d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		share, err := expandShare(d)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		if share.Password != "calvin" {
+			t.Errorf("Test number %v: expected the password planned to be sent, got %q", v.noTest, share.Password)
+		}
+		if err := clearShareWriteOnly(d); err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		d.SetId("/redfish/v1/Dell/Systems/System.Embedded.1/DellOSDeploymentService")
+		state := d.State()
+		if state.Attributes[sharePasswordKey] != v.expectedStored || state.Attributes[shareWriteOnlyPasswordKey] != "" {
+			t.Errorf("Test number %v: unexpected passwords in the state %q %q", v.noTest, state.Attributes[sharePasswordKey], state.Attributes[shareWriteOnlyPasswordKey])
+		}
+
+		// The next image is booted without changing the password
+		config = terraform.NewResourceConfigRaw(map[string]interface{}{"image_name": "other.iso", shareAttribute: []interface{}{block}})
+		diff, err = schema.InternalMap(r.Schema).Diff(context.Background(), state, config, nil, nil, true)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		d, err = schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		share, err = expandShare(d)
+		if err != nil && v.shouldPassLater {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+		} else if err == nil && !v.shouldPassLater {
+			t.Errorf("Test number %v: expected an error sending an unknown write-only password", v.noTest)
+		} else if err == nil && share.Password != "calvin" {
+			t.Errorf("Test number %v: expected the stored password to be sent, got %q", v.noTest, share.Password)
+		}
+	}
+}
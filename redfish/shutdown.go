@@ -0,0 +1,31 @@
+package redfish
+
+import (
+	"sync"
+)
+
+var (
+	// shutdownHooks give back what the provider holds on the BMCs while it runs, in the reverse order of registration
+	shutdownHooks []func()
+	// shutdownHooksLock protects shutdownHooks
+	shutdownHooksLock sync.Mutex
+)
+
+// onShutdown registers f to run when the provider stops
+func onShutdown(f func()) {
+	shutdownHooksLock.Lock()
+	defer shutdownHooksLock.Unlock()
+	shutdownHooks = append(shutdownHooks, f)
+}
+
+// Shutdown gives back what the provider held on the BMCs during the run of terraform, such as the locks
+// of lock_path. It is called once the provider stops serving terraform, and runs every hook only once.
+func Shutdown() {
+	shutdownHooksLock.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownHooksLock.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
@@ -0,0 +1,17 @@
+package redfish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShutdown(t *testing.T) {
+	calls := []int{}
+	onShutdown(func() { calls = append(calls, 1) })
+	onShutdown(func() { calls = append(calls, 2) })
+	Shutdown()
+	Shutdown()
+	if !reflect.DeepEqual(calls, []int{2, 1}) {
+		t.Errorf("Unexpected shutdown hook calls %v", calls)
+	}
+}
@@ -0,0 +1,36 @@
+package redfish
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// stateUpgrade migrates the state of a resource from one schema version to the next.
+type stateUpgrade struct {
+	// priorResource returns the resource as it was in the version being upgraded from.
+	// Its schema is needed to decode states stored in the legacy flatmap format.
+	priorResource func() *schema.Resource
+	upgrade       schema.StateUpgradeFunc
+}
+
+// stateUpgrades holds, for each resource type, the upgrades from every past schema version, oldest first.
+// A resource with n upgrades is at schema version n. When the schema of a resource changes in a way
+// existing states cannot be read with, its current definition is copied as the prior resource of a new
+// upgrade, which is appended here; terraform then migrates the states automatically on the next refresh.
+var stateUpgrades = map[string][]stateUpgrade{}
+
+// withStateUpgrades sets the schema version and the state upgraders of the resources from stateUpgrades.
+func withStateUpgrades(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, resource := range resources {
+		upgrades := stateUpgrades[name]
+		resource.SchemaVersion = len(upgrades)
+		resource.StateUpgraders = nil
+		for version, upgrade := range upgrades {
+			resource.StateUpgraders = append(resource.StateUpgraders, schema.StateUpgrader{
+				Version: version,
+				Type:    upgrade.priorResource().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgrade.upgrade,
+			})
+		}
+	}
+	return resources
+}
@@ -0,0 +1,22 @@
+package redfish
+
+import (
+	"testing"
+)
+
+func TestProviderStateUpgraders(t *testing.T) {
+	provider := Provider()
+	if err := provider.InternalValidate(); err != nil {
+		t.Fatalf("Provider is not valid: %s", err)
+	}
+	for name, resource := range provider.ResourcesMap {
+		if resource.SchemaVersion != len(stateUpgrades[name]) {
+			t.Errorf("%s: expected schema version %v, got %v", name, len(stateUpgrades[name]), resource.SchemaVersion)
+		}
+	}
+	for name := range stateUpgrades {
+		if _, ok := provider.ResourcesMap[name]; !ok {
+			t.Errorf("State upgrades registered for unknown resource %s", name)
+		}
+	}
+}
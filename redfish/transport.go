@@ -0,0 +1,587 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dell/terraform-provider-redfish/common"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetryAfterAttempts bounds how many times a request is replayed because of a Retry-After header
+	maxRetryAfterAttempts = 5
+	// maxRetryAfterWait bounds a single wait, so a misbehaving service cannot stall the provider
+	maxRetryAfterWait = 60 * time.Second
+	// defaultRetryAfterWait is used when a 503 comes without a usable Retry-After header
+	defaultRetryAfterWait = 5 * time.Second
+	// sessionDeleteTimeout bounds the deletion of the session when the provider stops
+	sessionDeleteTimeout = 10 * time.Second
+)
+
+// retryAfterTransport is an http.RoundTripper honoring the Retry-After header. Requests answered with
+// 503 Service Unavailable or 429 Too Many Requests are replayed once the service asks to. Responses
+// 202 Accepted (i.e. of task monitors) are returned as is, as the job pollers wait on their own schedule.
+type retryAfterTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if err != nil || attempt > maxRetryAfterAttempts {
+			return resp, err
+		}
+		wait, retry := retryAfter(resp)
+		if !retry {
+			return resp, nil
+		}
+		// The body cannot be replayed, so the response is returned as is
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+		log.Printf("[DEBUG] %s %s returned %d, retrying in %s", req.Method, req.URL.Path, resp.StatusCode, wait)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter returns whether the request has to be replayed, and how long to wait before doing it
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	switch resp.StatusCode {
+	case http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		if !ok {
+			wait = defaultRetryAfterWait
+		}
+	default:
+		return 0, false
+	}
+	if wait > maxRetryAfterWait {
+		wait = maxRetryAfterWait
+	}
+	return wait, true
+}
+
+// parseRetryAfter parses a Retry-After header, which holds either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := date.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// etagTransport is an http.RoundTripper adding the If-Match header to PATCH requests, using the ETag
+// of the resource being patched. Strict implementations reject PATCHes without it, or with a stale one,
+// with 412 Precondition Failed; in that case the ETag is fetched again and the PATCH retried once.
+type etagTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodPatch || len(req.Header.Get("If-Match")) > 0 {
+		return base.RoundTrip(req)
+	}
+	patch, err := t.withETag(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := base.RoundTrip(patch)
+	if err != nil || resp.StatusCode != http.StatusPreconditionFailed || req.GetBody == nil {
+		return resp, err
+	}
+	resp.Body.Close()
+	log.Printf("[DEBUG] PATCH %s failed with a stale ETag, retrying", req.URL.Path)
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	if patch, err = t.withETag(retry); err != nil {
+		return nil, err
+	}
+	return base.RoundTrip(patch)
+}
+
+// withETag returns a copy of the request with If-Match set to the current ETag of the resource.
+// The request is returned unchanged when the resource has no ETag.
+func (t *etagTransport) withETag(req *http.Request) (*http.Request, error) {
+	etag, err := t.fetchETag(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(etag) == 0 {
+		return req, nil
+	}
+	patch := req.Clone(req.Context())
+	patch.Header.Set("If-Match", etag)
+	return patch, nil
+}
+
+// fetchETag GETs the resource targeted by the request, with the same credentials, and returns its ETag.
+// The ETag header is preferred, falling back to the @odata.etag property of the body.
+func (t *etagTransport) fetchETag(req *http.Request) (string, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	get, err := http.NewRequestWithContext(req.Context(), http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	for _, header := range []string{"Authorization", "X-Auth-Token", "User-Agent", "Accept"} {
+		if v := req.Header.Get(header); len(v) > 0 {
+			get.Header.Set(header, v)
+		}
+	}
+	get.Close = req.Close
+	resp, err := base.RoundTrip(get)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	// The PATCH itself will report whatever made the GET fail
+	if resp.StatusCode != http.StatusOK {
+		return "", nil
+	}
+	if etag := resp.Header.Get("ETag"); len(etag) > 0 {
+		return etag, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var resource struct {
+		ETag string `json:"@odata.etag"`
+	}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return "", nil
+	}
+	return resource.ETag, nil
+}
+
+// sessionsURI is the collection of the sessions, at the same URI on every Redfish service
+const sessionsURI string = "/redfish/v1/SessionService/Sessions"
+
+// sessionTransport is an http.RoundTripper authenticating the requests with a Redfish session (X-Auth-Token).
+// The session is created on the first request and, as BMCs expire idle sessions (often after 30 minutes)
+// even while long operations are being polled, created again whenever a request is answered with
+// 401 Unauthorized, replaying the request with the new token.
+// Sessions rejected with password are created with previousPasswords, in order, so the credentials can be rotated.
+// Replaced sessions are deleted, and so is the last one when the provider stops, as BMCs only allow a few of them.
+type sessionTransport struct {
+	base              http.RoundTripper
+	username          string
+	password          string
+	previousPasswords []string
+	// lock protects token and location, which requests running in parallel share
+	lock  sync.Mutex
+	token string
+	// location is the URL of the session of token, to delete it
+	location string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	token, err := t.sessionToken(req, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := base.RoundTrip(withSessionToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// The body cannot be replayed, so the response is returned as is
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+	log.Printf("[DEBUG] %s %s returned 401, creating a new session", req.Method, req.URL.Path)
+	if token, err = t.sessionToken(req, token); err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return base.RoundTrip(withSessionToken(retry, token))
+}
+
+// sessionToken returns the token of the current session, creating a session if there is none or the
+// current token is expired. Requests failing at the same time create a single new session.
+func (t *sessionTransport) sessionToken(req *http.Request, expired string) (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token != "" && t.token != expired {
+		return t.token, nil
+	}
+	token, location, err := t.createSession(req)
+	if err != nil {
+		return "", err
+	}
+	// The BMC might not have expired the session yet, in which case it would count against its limit
+	if t.token != "" {
+		t.deleteSession(req.Context(), t.token, t.location)
+	}
+	t.token, t.location = token, location
+	return token, nil
+}
+
+// close deletes the current session. Requests sent afterwards create a new one.
+func (t *sessionTransport) close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sessionDeleteTimeout)
+	defer cancel()
+	t.deleteSession(ctx, t.token, t.location)
+	t.token, t.location = "", ""
+}
+
+// deleteSession logs the session at location out. Failures are only logged, as the BMC expires the session anyway.
+func (t *sessionTransport) deleteSession(ctx context.Context, token string, location string) {
+	if location == "" {
+		return
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, location, nil)
+	if err != nil {
+		log.Printf("[DEBUG] error deleting the Redfish session %s: %s", location, err)
+		return
+	}
+	resp, err := base.RoundTrip(withSessionToken(req, token))
+	if err != nil {
+		log.Printf("[DEBUG] error deleting the Redfish session %s: %s", location, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusNotFound {
+		log.Printf("[DEBUG] error deleting the Redfish session %s, status code was %d", location, resp.StatusCode)
+	}
+}
+
+// createSession creates a session in the service req is sent to, with the credentials of the provider,
+// returning its token and URL. The previous passwords are tried when the service rejects the current one.
+func (t *sessionTransport) createSession(req *http.Request) (string, string, error) {
+	var err error
+	for i, password := range append([]string{t.password}, t.previousPasswords...) {
+		var token, location string
+		token, location, err = t.createSessionWithPassword(req, password)
+		if err == nil {
+			if i > 0 {
+				log.Printf("[DEBUG] %s rejected the password of %s, the session was created with a previous one", req.URL.Host, t.username)
+			}
+			return token, location, nil
+		}
+		if !errors.Is(err, common.ErrUnauthorized) {
+			return "", "", err
+		}
+	}
+	return "", "", err
+}
+
+// createSessionWithPassword creates a session in the service req is sent to, authenticating with password.
+// The URL of the session is empty when the service does not report it.
+func (t *sessionTransport) createSessionWithPassword(req *http.Request, password string) (string, string, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	body, err := json.Marshal(map[string]string{"UserName": t.username, "Password": password})
+	if err != nil {
+		return "", "", err
+	}
+	sessionURL := *req.URL
+	sessionURL.Path = sessionsURI
+	sessionURL.RawQuery = ""
+	create, err := http.NewRequestWithContext(req.Context(), http.MethodPost, sessionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	create.Header.Set("Content-Type", "application/json")
+	create.Header.Set("Accept", "application/json")
+	create.Close = req.Close
+	resp, err := base.RoundTrip(create)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("error creating a Redfish session: %w", &common.HTTPError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+	token := resp.Header.Get("X-Auth-Token")
+	if len(token) == 0 {
+		return "", "", fmt.Errorf("error creating a Redfish session, the service returned no X-Auth-Token")
+	}
+	location := ""
+	if u, err := resp.Location(); err == nil {
+		location = u.String()
+	}
+	return token, location, nil
+}
+
+// withSessionToken returns a copy of the request authenticated with the session token instead of basic authentication
+func withSessionToken(req *http.Request, token string) *http.Request {
+	authenticated := req.Clone(req.Context())
+	authenticated.Header.Del("Authorization")
+	authenticated.Header.Set("X-Auth-Token", token)
+	return authenticated
+}
+
+// basicAuthTransport is an http.RoundTripper authenticating the requests with basic authentication while the
+// credentials are being rotated. Requests rejected with 401 Unauthorized are replayed with the other passwords,
+// the current one first, and the password accepted is kept for the next requests. This way the provider connects
+// with the previous password until the user resource changes it, and with the new one afterwards.
+type basicAuthTransport struct {
+	base      http.RoundTripper
+	username  string
+	passwords []string
+	// lock protects current, which requests running in parallel share
+	lock    sync.Mutex
+	current int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t.lock.Lock()
+	current := t.current
+	t.lock.Unlock()
+	resp, err := base.RoundTrip(withBasicAuth(req, t.username, t.passwords[current]))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// The body cannot be replayed, so the response is returned as is
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	for i, password := range t.passwords {
+		if i == current {
+			continue
+		}
+		retry := withBasicAuth(req, t.username, password)
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+		}
+		next, err := base.RoundTrip(retry)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if next.StatusCode == http.StatusUnauthorized {
+			next.Body.Close()
+			continue
+		}
+		resp.Body.Close()
+		log.Printf("[DEBUG] %s %s returned 401, authenticating %s with another password of the provider from now on", req.Method, req.URL.Path, t.username)
+		t.lock.Lock()
+		t.current = i
+		t.lock.Unlock()
+		return next, nil
+	}
+	return resp, nil
+}
+
+// withBasicAuth returns a copy of the request authenticated with username and password
+func withBasicAuth(req *http.Request, username string, password string) *http.Request {
+	authenticated := req.Clone(req.Context())
+	authenticated.SetBasicAuth(username, password)
+	return authenticated
+}
+
+// cacheTransport is an http.RoundTripper caching the GETs of the resources that do not change while terraform runs:
+// the service root, and the services and collections right below it (i.e. /redfish/v1/Systems or /redfish/v1/UpdateService),
+// which every resource walks through on each operation. Responses are kept for ttl, and dropped as soon as any other
+// request is sent, as it may have changed them. A GET answered while another request was sent may hold the state from
+// before the change, so it is not cached: generation changes when any other request starts or ends.
+type cacheTransport struct {
+	base http.RoundTripper
+	ttl  time.Duration
+	// lock protects entries and generation, which requests running in parallel share
+	lock       sync.Mutex
+	entries    map[string]*cacheEntry
+	generation uint64
+}
+
+// cacheEntry is a response of cacheTransport
+type cacheEntry struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		t.invalidate()
+		defer t.invalidate()
+		return base.RoundTrip(req)
+	}
+	if req.Method != http.MethodGet || !isStaticURI(req.URL.Path) {
+		return base.RoundTrip(req)
+	}
+	key := req.URL.RequestURI()
+	t.lock.Lock()
+	entry, ok := t.entries[key]
+	generation := t.generation
+	t.lock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		log.Printf("[DEBUG] GET %s answered from the cache", req.URL.Path)
+		return entry.response(req), nil
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry = &cacheEntry{statusCode: resp.StatusCode, header: resp.Header.Clone(), body: body, expires: time.Now().Add(t.ttl)}
+	t.lock.Lock()
+	if t.entries == nil {
+		t.entries = make(map[string]*cacheEntry)
+	}
+	if t.generation == generation {
+		t.entries[key] = entry
+	} else {
+		log.Printf("[DEBUG] GET %s not cached, as a change was sent to the BMC meanwhile", req.URL.Path)
+	}
+	t.lock.Unlock()
+	return entry.response(req), nil
+}
+
+// invalidate drops the cached responses and keeps the GETs in flight from being cached
+func (t *cacheTransport) invalidate() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.entries = nil
+	t.generation++
+}
+
+// response returns a copy of the cached response, as an answer to req
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.statusCode, http.StatusText(e.statusCode)),
+		StatusCode:    e.statusCode,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// isStaticURI reports whether path is the service root or a service or collection right below it
+func isStaticURI(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	if path == "/redfish/v1" {
+		return true
+	}
+	name := strings.TrimPrefix(path, "/redfish/v1/")
+	return name != path && name != "" && !strings.Contains(name, "/")
+}
+
+// headerTransport is an http.RoundTripper setting headers on every request (i.e. the User-Agent, or the
+// headers a proxy in front of the BMC requires), replacing the ones the request already has
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	tagged := req.Clone(req.Context())
+	for name, values := range t.headers {
+		tagged.Header[name] = values
+	}
+	return base.RoundTrip(tagged)
+}
+
+// authenticationHeaders are the headers http_headers cannot set, as they authenticate to the BMC
+var authenticationHeaders = []string{"Authorization", "X-Auth-Token"}
+
+// validateHTTPHeaders rejects http_headers overriding the authentication to the BMC
+func validateHTTPHeaders(v interface{}, k string) ([]string, []error) {
+	for name := range v.(map[string]interface{}) {
+		if common.ContainsFold(authenticationHeaders, name) {
+			return nil, []error{fmt.Errorf("%s cannot set %s, which authenticates to the BMC", k, name)}
+		}
+	}
+	return nil, nil
+}
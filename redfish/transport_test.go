@@ -0,0 +1,489 @@
+package redfish
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/dell/terraform-provider-redfish/common"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		noTest   int
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{1, "", 0, false},
+		{2, "10", 10 * time.Second, true},
+		{3, "-1", 0, false},
+		{4, "Thu, 01 Oct 2020 12:00:30 GMT", 30 * time.Second, true},
+		{5, "Thu, 01 Oct 2020 11:00:00 GMT", 0, true},
+		{6, "soon", 0, false},
+	}
+	for _, v := range cases {
+		wait, ok := parseRetryAfter(v.value, now)
+		if wait != v.expected || ok != v.ok {
+			t.Errorf("Test number %v: expected %v %v, got %v %v", v.noTest, v.expected, v.ok, wait, ok)
+		}
+	}
+}
+
+func TestRetryAfterTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == "/busy" && calls == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/throttled" && calls < 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/task":
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/action":
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		noTest         int
+		method         string
+		path           string
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{1, http.MethodPost, "/busy", http.StatusOK, 2},
+		{2, http.MethodGet, "/throttled", http.StatusOK, 3},
+		// The job pollers get the 202 of the task monitors, to poll on their own schedule
+		{3, http.MethodGet, "/task", http.StatusAccepted, 1},
+		{4, http.MethodPost, "/action", http.StatusAccepted, 1},
+	}
+	client := &http.Client{Transport: &retryAfterTransport{base: http.DefaultTransport}}
+	for _, v := range cases {
+		calls = 0
+		req, _ := http.NewRequest(v.method, server.URL+v.path, bytes.NewReader([]byte("{}")))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != v.expectedStatus || calls != v.expectedCalls {
+			t.Errorf("Test number %v: expected %v after %v calls, got %v after %v calls", v.noTest, v.expectedStatus, v.expectedCalls, resp.StatusCode, calls)
+		}
+	}
+}
+
+func TestETagTransport(t *testing.T) {
+	etag := `W/"1"`
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/body" {
+				w.Write([]byte(`{"@odata.etag": ` + strconv.Quote(etag) + `}`))
+				return
+			}
+			w.Header().Set("ETag", etag)
+		case http.MethodPatch:
+			ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+			}
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		noTest          int
+		path            string
+		staleETag       bool
+		expectedStatus  int
+		expectedIfMatch int
+	}{
+		{1, "/header", false, http.StatusOK, 1},
+		{2, "/body", false, http.StatusOK, 1},
+		{3, "/header", true, http.StatusOK, 2},
+	}
+	client := &http.Client{Transport: &etagTransport{base: http.DefaultTransport}}
+	for _, v := range cases {
+		ifMatch = nil
+		etag = `W/"1"`
+		base := client.Transport
+		if v.staleETag {
+			// The ETag changes between the GET and the first PATCH
+			client.Transport = &etagTransport{base: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if r.Method == http.MethodPatch && len(ifMatch) == 0 {
+					etag = `W/"2"`
+				}
+				return http.DefaultTransport.RoundTrip(r)
+			})}
+		}
+		req, _ := http.NewRequest(http.MethodPatch, server.URL+v.path, bytes.NewReader([]byte("{}")))
+		resp, err := client.Do(req)
+		client.Transport = base
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != v.expectedStatus || len(ifMatch) != v.expectedIfMatch {
+			t.Errorf("Test number %v: expected %v after %v PATCHes, got %v after %v PATCHes %v", v.noTest, v.expectedStatus, v.expectedIfMatch, resp.StatusCode, len(ifMatch), ifMatch)
+		}
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSessionTransport(t *testing.T) {
+	sessions := 0
+	token := ""
+	var replayedBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == sessionsURI {
+			sessions++
+			token = "token-" + strconv.Itoa(sessions)
+			w.Header().Set("X-Auth-Token", token)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if r.Header.Get("X-Auth-Token") != token || r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPost {
+			body, _ := ioutil.ReadAll(r.Body)
+			replayedBodies = append(replayedBodies, string(body))
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		noTest           int
+		method           string
+		expireSession    bool
+		expectedStatus   int
+		expectedSessions int
+	}{
+		{1, http.MethodGet, false, http.StatusOK, 1},
+		{2, http.MethodGet, false, http.StatusOK, 1},
+		{3, http.MethodGet, true, http.StatusOK, 2},
+		{4, http.MethodPost, true, http.StatusOK, 3},
+	}
+	client := &http.Client{Transport: &sessionTransport{base: http.DefaultTransport, username: "root", password: "calvin"}}
+	for _, v := range cases {
+		if v.expireSession {
+			token = "expired"
+		}
+		req, _ := http.NewRequest(v.method, server.URL+"/redfish/v1/Systems", bytes.NewReader([]byte(`{"a":1}`)))
+		req.SetBasicAuth("root", "calvin")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != v.expectedStatus || sessions != v.expectedSessions {
+			t.Errorf("Test number %v: expected %v after %v sessions, got %v after %v sessions", v.noTest, v.expectedStatus, v.expectedSessions, resp.StatusCode, sessions)
+		}
+	}
+	if len(replayedBodies) != 1 || replayedBodies[0] != `{"a":1}` {
+		t.Errorf("the body of the replayed request was not sent again: %v", replayedBodies)
+	}
+}
+
+func TestSessionTransportDeletesSessions(t *testing.T) {
+	sessions := 0
+	live := make(map[string]bool)
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == sessionsURI {
+			sessions++
+			token := "token-" + strconv.Itoa(sessions)
+			live[token] = true
+			w.Header().Set("X-Auth-Token", token)
+			w.Header().Set("Location", sessionsURI+"/"+strconv.Itoa(sessions))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if !live[r.Header.Get("X-Auth-Token")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			delete(live, r.Header.Get("X-Auth-Token"))
+		}
+	}))
+	defer server.Close()
+
+	transport := &sessionTransport{base: http.DefaultTransport, username: "root", password: "calvin"}
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL + "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("error sending the first request: %s", err)
+	}
+	resp.Body.Close()
+
+	// The session is replaced while the BMC still holds it
+	if _, err := transport.sessionToken(httptest.NewRequest(http.MethodGet, server.URL+"/redfish/v1/Systems", nil), transport.token); err != nil {
+		t.Fatalf("error replacing the session: %s", err)
+	}
+	transport.close()
+	expected := []string{sessionsURI + "/1", sessionsURI + "/2"}
+	if !reflect.DeepEqual(deleted, expected) || len(live) != 0 {
+		t.Errorf("expected sessions %v to be deleted, got %v with %v left", expected, deleted, live)
+	}
+}
+
+func TestSessionTransportRejectedCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"Base.1.8.NoValidSession"}}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &sessionTransport{base: http.DefaultTransport, username: "root", password: "wrong"}}
+	_, err := client.Get(server.URL + "/redfish/v1/Systems")
+	if !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestBasicAuthTransport(t *testing.T) {
+	password := "old"
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, sent, ok := r.BasicAuth()
+		if !ok || username != "root" || sent != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			password = "new"
+		}
+	}))
+	defer server.Close()
+
+	transport := &basicAuthTransport{base: http.DefaultTransport, username: "root", passwords: []string{"new", "old"}}
+	client := &http.Client{Transport: transport}
+	cases := []struct {
+		noTest          int
+		method          string
+		expectedStatus  int
+		expectedCurrent int
+	}{
+		{1, http.MethodGet, http.StatusOK, 1},
+		{2, http.MethodGet, http.StatusOK, 1},
+		{3, http.MethodPatch, http.StatusOK, 1},
+		{4, http.MethodGet, http.StatusOK, 0},
+	}
+	for _, v := range cases {
+		req, _ := http.NewRequest(v.method, server.URL+"/redfish/v1/AccountService/Accounts/2", bytes.NewReader([]byte(`{"Password":"new"}`)))
+		req.SetBasicAuth("root", "new")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != v.expectedStatus || transport.current != v.expectedCurrent {
+			t.Errorf("Test number %v: expected %v with password %v, got %v with password %v", v.noTest, v.expectedStatus, v.expectedCurrent, resp.StatusCode, transport.current)
+		}
+	}
+	if len(bodies) != 1 || bodies[0] != `{"Password":"new"}` {
+		t.Errorf("the body of the replayed request was not sent again: %v", bodies)
+	}
+
+	password = "unknown"
+	resp, err := client.Get(server.URL + "/redfish/v1/Systems")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the service to reject every password, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestSessionTransportPreviousPasswords(t *testing.T) {
+	var passwords []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == sessionsURI {
+			var credentials map[string]string
+			json.NewDecoder(r.Body).Decode(&credentials)
+			passwords = append(passwords, credentials["Password"])
+			if credentials["Password"] != "old" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-Auth-Token", "token")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if r.Header.Get("X-Auth-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &sessionTransport{base: http.DefaultTransport, username: "root", password: "new", previousPasswords: []string{"older", "old"}}}
+	resp, err := client.Get(server.URL + "/redfish/v1/Systems")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the session to be created with a previous password, got %v", err)
+	}
+	resp.Body.Close()
+	if len(passwords) != 3 || passwords[0] != "new" || passwords[2] != "old" {
+		t.Errorf("unexpected passwords tried: %v", passwords)
+	}
+}
+
+func TestCacheTransport(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		w.Write([]byte(`{"@odata.id": "` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &cacheTransport{base: http.DefaultTransport, ttl: time.Minute}}
+	cases := []struct {
+		noTest           int
+		method           string
+		uri              string
+		expectedRequests int
+	}{
+		{1, http.MethodGet, "/redfish/v1/", 1},
+		{2, http.MethodGet, "/redfish/v1/", 1},
+		{3, http.MethodGet, "/redfish/v1/Systems", 1},
+		{4, http.MethodGet, "/redfish/v1/Systems", 1},
+		{5, http.MethodGet, "/redfish/v1/Systems/System.Embedded.1", 1},
+		{6, http.MethodGet, "/redfish/v1/Systems/System.Embedded.1", 2},
+		{7, http.MethodPatch, "/redfish/v1/Systems/System.Embedded.1", 1},
+		{8, http.MethodGet, "/redfish/v1/Systems", 2},
+		{9, http.MethodGet, "/redfish/v1/Systems", 2},
+	}
+	for _, v := range cases {
+		req, _ := http.NewRequest(v.method, server.URL+v.uri, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), v.uri) {
+			t.Errorf("Test number %v returned %v %s", v.noTest, resp.StatusCode, body)
+		}
+		if requests[v.method+" "+v.uri] != v.expectedRequests {
+			t.Errorf("Test number %v: expected %v requests to the service, got %v", v.noTest, v.expectedRequests, requests[v.method+" "+v.uri])
+		}
+	}
+
+	client.Transport = &cacheTransport{base: http.DefaultTransport, ttl: time.Nanosecond}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/redfish/v1/Managers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		time.Sleep(time.Millisecond)
+	}
+	if requests["GET /redfish/v1/Managers"] != 2 {
+		t.Errorf("expected the expired responses to be read again, got %v requests", requests["GET /redfish/v1/Managers"])
+	}
+}
+
+func TestCacheTransportSkipsConcurrentChanges(t *testing.T) {
+	var client *http.Client
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+			// A change is sent while the first GET is answered, which may then hold the state from before it
+			if gets == 1 {
+				req, _ := http.NewRequest(http.MethodPatch, "http://"+r.Host+"/redfish/v1/Chassis/1", nil)
+				if resp, err := client.Do(req); err == nil {
+					resp.Body.Close()
+				}
+			}
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client = &http.Client{Transport: &cacheTransport{base: http.DefaultTransport, ttl: time.Minute}}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL + "/redfish/v1/Chassis")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if gets != 2 {
+		t.Errorf("expected the GET answered during the change not to be cached, got %v GETs", gets)
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("User-Agent", "terraform-ci")
+	headers.Set("X-Audit-Tag", "change-1234")
+	client := &http.Client{Transport: &headerTransport{base: http.DefaultTransport, headers: headers}}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/redfish/v1/", nil)
+	req.Header.Set("User-Agent", "gofish/1.0")
+	req.SetBasicAuth("root", "calvin")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if received.Get("User-Agent") != "terraform-ci" || received.Get("X-Audit-Tag") != "change-1234" || received.Get("Authorization") == "" {
+		t.Errorf("unexpected headers %v", received)
+	}
+	if req.Header.Get("User-Agent") != "gofish/1.0" {
+		t.Errorf("the headers of the original request were changed")
+	}
+
+	cases := []struct {
+		noTest     int
+		headers    map[string]interface{}
+		shouldPass bool
+	}{
+		{1, map[string]interface{}{"X-Audit-Tag": "change-1234", "Proxy-Authorization": "Basic cHJveHk6cHJveHk="}, true},
+		{2, map[string]interface{}{"authorization": "Basic cm9vdDpjYWx2aW4="}, false},
+		{3, map[string]interface{}{"X-Auth-Token": "token"}, false},
+	}
+	for _, v := range cases {
+		_, errs := validateHTTPHeaders(v.headers, "http_headers")
+		if v.shouldPass && len(errs) > 0 {
+			t.Errorf("Test number %v failed %v", v.noTest, errs)
+		}
+		if !v.shouldPass && len(errs) == 0 {
+			t.Errorf("Test number %v passed when it was supposed to fail", v.noTest)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package redfish
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Write-only passwords are never stored in the state, not even hashed. Terraform write-only attributes
+// need a newer plugin SDK, so they are emulated, following the same naming (<name>_wo and <name>_wo_version):
+//   - The password is sent on creation and whenever its version attribute changes. Changing only the
+//     password is not detected, as there is nothing in the state to compare it with.
+//   - Its diff is suppressed otherwise, and it is cleared from the state once sent (see clearWriteOnly).
+//   - Its hash is planned instead of the password (see hashPassword), so plan files do not hold it either.
+
+// writeOnlySuffix and writeOnlyVersionSuffix are appended to the name of a password to get the names of its
+// write-only variant and of the version attribute of the latter
+const (
+	writeOnlySuffix        string = "_wo"
+	writeOnlyVersionSuffix string = "_wo_version"
+)
+
+// writeOnlyPasswordSchema returns the schema of the write-only variant of the password attribute name.
+// versionKey is the version attribute whose changes send it. Passwords sent together (i.e. a new and an old
+// password) share the same version attribute.
+func writeOnlyPasswordSchema(name string, versionKey string, description string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		StateFunc:     hashPassword,
+		ConflictsWith: []string{name},
+		Description:   fmt.Sprintf("%s. Write-only: it is never stored in the state, and only sent on creation and when %s changes. Alternative to %s", description, versionKey, name),
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return d.Id() != "" && !d.HasChange(versionKey)
+		},
+	}
+}
+
+// writeOnlyVersionSchema returns the schema of the version attribute of a write-only password
+func writeOnlyVersionSchema(passwordKey string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  fmt.Sprintf("Arbitrary value that sends %s again when changed (i.e. a rotation date or a secret version)", passwordKey),
+		RequiredWith: []string{passwordKey},
+	}
+}
+
+// passwordToSend returns the password to send to the BMC from the first of keys set, usually a password
+// and its write-only variant. ok is false when none is set, or set and neither new nor changed.
+func passwordToSend(d *schema.ResourceData, keys ...string) (password string, ok bool) {
+	for _, key := range keys {
+		// StateFunc only applies to the state, so the configuration holds the passwords in clear
+		if v, set := d.GetOk(key); set && (d.IsNewResource() || d.HasChange(key)) {
+			return v.(string), true
+		}
+	}
+	return "", false
+}
+
+// clearWriteOnly removes the write-only passwords sent from the state
+func clearWriteOnly(d *schema.ResourceData, keys ...string) error {
+	for _, key := range keys {
+		if err := d.Set(key, ""); err != nil {
+			return fmt.Errorf("error clearing %s: %s", key, err)
+		}
+	}
+	return nil
+}
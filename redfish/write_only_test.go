@@ -0,0 +1,51 @@
+package redfish
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+func TestWriteOnlyPlan(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "3",
+		Attributes: map[string]string{
+			"id":                  "3",
+			"username":            "terraform",
+			"password_wo":         "",
+			"password_wo_version": "1",
+		},
+	}
+	cases := []struct {
+		noTest   int
+		state    *terraform.InstanceState
+		version  string
+		shouldBe bool
+	}{
+		{1, nil, "1", true},
+		{2, state, "1", false},
+		{3, state, "2", true},
+	}
+	for _, v := range cases {
+		r := Provider().ResourcesMap["redfish_user_account"]
+		config := map[string]interface{}{"username": "terraform", "password_wo": "calvin", "password_wo_version": v.version}
+		// lockdown_bypass skips the System Lockdown check, which reads the BMC
+		diff, err := r.Diff(context.Background(), v.state, terraform.NewResourceConfigRaw(config), &providerConfig{lockdownBypass: true})
+		if err != nil {
+			t.Errorf("Test number %v failed %v", v.noTest, err)
+			continue
+		}
+		// No diff at all is planned when the password is suppressed
+		var attr *terraform.ResourceAttrDiff
+		planned := false
+		if diff != nil {
+			attr, planned = diff.Attributes["password_wo"]
+		}
+		if planned != v.shouldBe {
+			t.Errorf("Test number %v: expected password_wo planned to be %v, got %v", v.noTest, v.shouldBe, planned)
+		}
+		if planned && attr.New != hashPassword("calvin") {
+			t.Errorf("Test number %v: the password is planned in clear", v.noTest)
+		}
+	}
+}